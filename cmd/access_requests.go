@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var olderThan time.Duration
+
+var accessRequestsCmd = &cobra.Command{
+	Use:   "access-requests",
+	Short: "Fetches and displays access requests awaiting approval",
+	Long: `Fetches and displays pending requests to join GitLab groups and projects, longest waiting first.
+If a group ID is provided, it will fetch requests to that group, its subgroups, and their projects.
+If no group ID is provided, it will fetch requests from all accessible groups.
+Listing access requests requires at least the Maintainer role on each group or project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runAccessRequests,
+}
+
+func init() {
+	accessRequestsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	accessRequestsCmd.Flags().DurationVar(&olderThan, "older-than", 0,
+		"List only requests pending for at least this long, e.g. 168h for a week")
+
+	supportInstances(accessRequestsCmd)
+	RootCmd.AddCommand(accessRequestsCmd)
+}
+
+func runAccessRequests(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.AccessRequestWithSource, error) {
+			requests, err := client.GetAccessRequestsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return pendingAccessRequests(requests, olderThan, time.Now()), nil
+		},
+		func(formatter output.Formatter, data []*glclient.AccessRequestWithSource) error {
+			return formatter.FormatAccessRequests(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching access requests...",
+	)
+}
+
+// pendingAccessRequests returns the requests pending for at least olderThan, longest waiting first.
+func pendingAccessRequests(
+	requests []*glclient.AccessRequestWithSource,
+	olderThan time.Duration,
+	now time.Time,
+) []*glclient.AccessRequestWithSource {
+	pending := make([]*glclient.AccessRequestWithSource, 0, len(requests))
+
+	for _, request := range requests {
+		if request.PendingFor(now) >= olderThan {
+			pending = append(pending, request)
+		}
+	}
+
+	slices.SortStableFunc(pending, func(a, b *glclient.AccessRequestWithSource) int {
+		return cmp.Or(
+			cmp.Compare(b.PendingFor(now), a.PendingFor(now)),
+			cmp.Compare(a.SourcePath, b.SourcePath),
+			cmp.Compare(a.Username, b.Username),
+		)
+	})
+
+	return pending
+}
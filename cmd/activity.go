@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var staleFor time.Duration
+
+var activityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Fetches and displays when projects were last active",
+	Long: `Fetches and displays the last activity of GitLab projects, least recently active first.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runActivity,
+}
+
+func init() {
+	activityCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	activityCmd.Flags().DurationVar(&staleFor, "stale-for", 0,
+		"List only projects inactive for at least this long, e.g. 4380h for six months")
+
+	supportInstances(activityCmd)
+	RootCmd.AddCommand(activityCmd)
+}
+
+func runActivity(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectActivity, error) {
+			activity, err := client.GetProjectActivityRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return staleProjects(activity, staleFor, time.Now()), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectActivity) error {
+			return formatter.FormatProjectActivity(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching projects...",
+	)
+}
+
+// staleProjects returns the projects inactive for at least staleFor, least recently active first.
+func staleProjects(
+	activity []*glclient.ProjectActivity,
+	staleFor time.Duration,
+	now time.Time,
+) []*glclient.ProjectActivity {
+	stale := make([]*glclient.ProjectActivity, 0, len(activity))
+
+	for _, project := range activity {
+		if project.InactiveFor(now) >= staleFor {
+			stale = append(stale, project)
+		}
+	}
+
+	slices.SortStableFunc(stale, func(a, b *glclient.ProjectActivity) int {
+		return cmp.Or(
+			cmp.Compare(b.InactiveFor(now), a.InactiveFor(now)),
+			cmp.Compare(a.ProjectPath, b.ProjectPath),
+		)
+	})
+
+	return stale
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/gitremote"
+	"github.com/spf13/cobra"
+)
+
+var autoDetect bool
+
+var ErrAutoDetectFailed = errors.New(
+	"cannot detect the GitLab project from the current directory; " +
+		"run inside a clone of a GitLab project or pass --project-id")
+
+const autoDetectUsage = "Detect the project (and the GitLab URL, unless --gitlab-url is set) " +
+	"from the origin remote of the git repository in the current directory"
+
+// applyAutoDetect sets projectID and gitlabURL from the origin remote when --auto-detect is given.
+func applyAutoDetect(command *cobra.Command) error {
+	// conflicting --project-id or --group-id flags are reported by cobra after the pre-run hooks
+	if !autoDetect || command.Flags().Changed("project-id") || command.Flags().Changed("group-id") {
+		return nil
+	}
+
+	remote, err := gitremote.Detect(command.Context(), "", gitremote.DefaultRemote)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAutoDetectFailed, err)
+	}
+
+	projectID = remote.ProjectPath
+
+	if !command.Flags().Changed("gitlab-url") {
+		gitlabURL = remote.BaseURL
+	}
+
+	if debug {
+		fmt.Printf("DEBUG: detected project %s on %s\n", projectID, gitlabURL)
+	}
+
+	return nil
+}
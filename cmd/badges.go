@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var brokenBadgesOnly bool
+
+var badgesCmd = &cobra.Command{
+	Use:   "badges",
+	Short: "Fetches and displays group and project badges",
+	Long: `Fetches and displays badges configured on GitLab groups and projects. If a group ID is provided,
+it will fetch badges from that group, its subgroups, and their projects.
+If no group ID is provided, it will fetch badges from all accessible groups.
+Use --broken-only to check every badge image and list only those not responding with HTTP 200.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runBadges,
+}
+
+func init() {
+	badgesCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	badgesCmd.Flags().BoolVar(&brokenBadgesOnly, "broken-only", false,
+		"Check badge images with a HEAD request and list only badges not responding with HTTP 200")
+
+	supportInstances(badgesCmd)
+	RootCmd.AddCommand(badgesCmd)
+}
+
+func runBadges(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.BadgeWithSource, error) {
+			badges, err := client.GetBadgesRecursively(ctx, groupID)
+			if err != nil || !brokenBadgesOnly {
+				return badges, err
+			}
+
+			client.CheckBadgeImages(ctx, badges)
+
+			return brokenBadges(badges), nil
+		},
+		func(formatter output.Formatter, data []*glclient.BadgeWithSource) error {
+			return formatter.FormatBadges(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching badges...",
+	)
+}
+
+func brokenBadges(badges []*glclient.BadgeWithSource) []*glclient.BadgeWithSource {
+	broken := make([]*glclient.BadgeWithSource, 0, len(badges))
+
+	for _, badge := range badges {
+		if badge.IsBroken() {
+			broken = append(broken, badge)
+		}
+	}
+
+	return broken
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+)
+
+var baselineFile string
+
+const baselineUsage = "JSON report saved by an earlier run of the same command. " +
+	"Only items added, removed, or changed since then are printed"
+
+// checkBaseline rejects output formats that cannot show changes before anything is fetched.
+func checkBaseline() error {
+	if baselineFile != "" && output.Format(format) == output.FormatDotenv {
+		return output.ErrDotenvChanges
+	}
+
+	if baselineFile != "" && limit > 0 {
+		return ErrLimitWithBaseline
+	}
+
+	if baselineFile != "" && rollupBy != "" {
+		return ErrRollupWithBaseline
+	}
+
+	return nil
+}
+
+// formatOrDiff prints the first --limit items with formatItems, or their --rollup-by counts, or only
+// their changes since the --baseline report, and checks all items against the --policy rules.
+func formatOrDiff[T any](
+	formatter output.Formatter,
+	items []T,
+	cmp report.Comparison[T],
+	formatItems func([]T) error,
+) error {
+	violations, err := checkPolicy(items)
+	if err != nil {
+		return err
+	}
+
+	if baselineFile == "" {
+		if err := formatReport(formatter, items, formatItems); err != nil {
+			return err
+		}
+
+		return reportViolations(violations)
+	}
+
+	baseline, err := report.LoadBaseline[T](baselineFile)
+	if err != nil {
+		return fmt.Errorf("failed to load baseline: %w", err)
+	}
+
+	changes, err := report.Diff(baseline, items, cmp)
+	if err != nil {
+		return fmt.Errorf("failed to compare with baseline: %w", err)
+	}
+
+	if err := formatter.FormatChanges(changes); err != nil {
+		return fmt.Errorf("failed to format changes: %w", err)
+	}
+
+	return reportViolations(violations)
+}
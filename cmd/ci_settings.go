@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	publicPipelinesOnly      bool
+	unrestrictedJobTokenOnly bool
+)
+
+var ciSettingsCmd = &cobra.Command{
+	Use:   "ci-settings",
+	Short: "Fetches and displays the general CI/CD settings of projects",
+	Long: `Fetches and displays the general CI/CD settings of GitLab projects: whether CI/CD and public
+pipelines are enabled, the git strategy and clone depth, the job timeout, and the job token settings.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Reading CI/CD settings requires at least the Maintainer role on each project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runCISettings,
+}
+
+func init() {
+	ciSettingsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	ciSettingsCmd.Flags().BoolVar(&publicPipelinesOnly, "public-pipelines-only", false,
+		"List only projects whose job logs and artifacts are visible to non-members")
+	ciSettingsCmd.Flags().BoolVar(&unrestrictedJobTokenOnly, "unrestricted-job-token-only", false,
+		"List only projects accepting CI/CD job tokens from any project, without an allowlist")
+
+	supportInstances(ciSettingsCmd)
+	RootCmd.AddCommand(ciSettingsCmd)
+}
+
+func runCISettings(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectCISettings, error) {
+			settings, err := client.GetCISettingsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return filterCISettings(settings), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectCISettings) error {
+			return formatter.FormatCISettings(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching CI/CD settings...",
+	)
+}
+
+// filterCISettings keeps the projects matching every risky configuration filter that is set.
+// Projects with CI/CD disabled never match, as their settings have no effect.
+func filterCISettings(settings []*glclient.ProjectCISettings) []*glclient.ProjectCISettings {
+	if !publicPipelinesOnly && !unrestrictedJobTokenOnly {
+		return settings
+	}
+
+	filtered := make([]*glclient.ProjectCISettings, 0, len(settings))
+
+	for _, project := range settings {
+		switch {
+		case !project.CIEnabled:
+		case publicPipelinesOnly && !project.PublicPipelines:
+		case unrestrictedJobTokenOnly && project.JobTokenAllowlistEnabled:
+		default:
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var disconnectedAgentsOnly bool
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "Fetches and displays the Kubernetes agents registered in projects",
+	Long: `Fetches and displays the GitLab agents for Kubernetes registered in GitLab projects: the agent
+name, the project holding its configuration, and whether it is connected. An agent counts as
+connected when one of its tokens was used within the last eight minutes.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Listing the agents of a project requires at least the Developer role on it.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runAgents,
+}
+
+func init() {
+	agentsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	agentsCmd.Flags().BoolVar(&disconnectedAgentsOnly, "disconnected-only", false,
+		"List only agents that are not connected, including those that never connected")
+
+	filterAfterFetch(agentsCmd, "disconnected-only")
+	supportInstances(agentsCmd)
+	RootCmd.AddCommand(agentsCmd)
+}
+
+func runAgents(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectClusterAgent, error) {
+			agents, err := client.GetClusterAgentsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if disconnectedAgentsOnly {
+				return report.FilterDisconnectedAgents(agents), nil
+			}
+
+			return agents, nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectClusterAgent) error {
+			return formatter.FormatClusterAgents(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching cluster agents...",
+	)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var unframedOnly bool
+
+var complianceCmd = &cobra.Command{
+	Use:   "compliance",
+	Short: "Fetches and displays the compliance frameworks applied to projects",
+	Long: `Fetches and displays the compliance frameworks applied to GitLab projects. Compliance frameworks
+require GitLab Premium; where they cannot be read, projects are still listed without frameworks.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+With --unframed-only, only projects without a compliance framework are listed.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runCompliance,
+}
+
+func init() {
+	complianceCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	complianceCmd.Flags().BoolVar(&unframedOnly, "unframed-only", false,
+		"List only projects without a compliance framework")
+
+	filterAfterFetch(complianceCmd, "unframed-only")
+	supportInstances(complianceCmd)
+	RootCmd.AddCommand(complianceCmd)
+}
+
+func runCompliance(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectComplianceFrameworks, error) {
+			frameworks, err := client.GetComplianceFrameworksRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !unframedOnly {
+				return frameworks, nil
+			}
+
+			if len(frameworks) > 0 && !slices.ContainsFunc(frameworks, frameworksAvailable) {
+				fmt.Fprintln(os.Stderr, "Warning: GitLab does not report compliance frameworks, "+
+					"no project matches --unframed-only")
+			}
+
+			return report.FilterUnframed(frameworks), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectComplianceFrameworks) error {
+			return formatter.FormatComplianceFrameworks(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching compliance frameworks...",
+	)
+}
+
+func frameworksAvailable(project *glclient.ProjectComplianceFrameworks) bool {
+	return project.FrameworksAvailable
+}
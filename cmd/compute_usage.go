@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var overComputeLimitOnly bool
+
+var computeUsageCmd = &cobra.Command{
+	Use:   "compute-usage",
+	Short: "Fetches and displays the CI/CD compute minutes used by top-level groups",
+	Long: `Fetches and displays the CI/CD compute minutes top-level groups used on shared runners this month,
+against their monthly quota. Compute usage is tracked for top-level groups only, so a subgroup is
+reported through its top-level group.
+If a group ID is provided, it will report the top-level group of that group.
+If no group ID is provided, it will report the top-level groups of all accessible groups.
+Compute usage requires GitLab Premium or Ultimate, or gitlab.com, and the Owner role on the group.
+Quotas are only returned to administrators; groups without a known quota are never over the limit.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runComputeUsage,
+}
+
+func init() {
+	computeUsageCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches all accessible groups if not provided)")
+	computeUsageCmd.Flags().BoolVar(&overComputeLimitOnly, "over-limit-only", false,
+		"List only groups that used more compute minutes than their quota")
+
+	supportInstances(computeUsageCmd)
+	RootCmd.AddCommand(computeUsageCmd)
+}
+
+func runComputeUsage(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.GroupComputeUsage, error) {
+			usage, err := client.GetComputeUsageRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if overComputeLimitOnly {
+				usage = report.OverComputeLimit(usage)
+			}
+
+			return usage, nil
+		},
+		func(formatter output.Formatter, data []*glclient.GroupComputeUsage) error {
+			return formatter.FormatComputeUsage(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching compute usage...",
+	)
+}
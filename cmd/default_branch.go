@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	expectedBranch       string
+	failOnBranchMismatch bool
+)
+
+var (
+	ErrDefaultBranchMismatch  = errors.New("default branch does not match")
+	ErrExpectedBranchRequired = errors.New("--fail-on-mismatch requires --expected")
+)
+
+var defaultBranchCmd = &cobra.Command{
+	Use:   "default-branch",
+	Short: "Fetches and displays the default branch of projects",
+	Long: `Fetches and displays the default branch of GitLab projects.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Projects without a repository or commits have no default branch.`,
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		groupID = strings.Trim(groupID, "/")
+
+		if failOnBranchMismatch && expectedBranch == "" {
+			return ErrExpectedBranchRequired
+		}
+
+		return nil
+	},
+	RunE: runDefaultBranch,
+}
+
+func init() {
+	defaultBranchCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	defaultBranchCmd.Flags().StringVar(&expectedBranch, "expected", "",
+		"List only projects whose default branch is not this one, including projects without one")
+	defaultBranchCmd.Flags().BoolVar(&failOnBranchMismatch, "fail-on-mismatch", false,
+		"Exit with an error if any project's default branch is not the --expected one")
+
+	supportInstances(defaultBranchCmd)
+	RootCmd.AddCommand(defaultBranchCmd)
+}
+
+func runDefaultBranch(command *cobra.Command, _ []string) error {
+	var mismatches int
+
+	err := runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectDefaultBranch, error) {
+			branches, err := client.GetDefaultBranchesRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if expectedBranch == "" {
+				return branches, nil
+			}
+
+			unexpected := report.DefaultBranchMismatches(branches, expectedBranch)
+			mismatches = len(unexpected)
+
+			return unexpected, nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectDefaultBranch) error {
+			return formatter.FormatDefaultBranches(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching projects...",
+	)
+	if err != nil {
+		return err
+	}
+
+	if failOnBranchMismatch && mismatches > 0 {
+		return fmt.Errorf("%w %q in %d projects", ErrDefaultBranchMismatch, expectedBranch, mismatches)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var ErrInvalidEpicState = errors.New("invalid --state, use open or closed")
+
+var epicState string
+
+var epicsCmd = &cobra.Command{
+	Use:   "epics",
+	Short: "Fetches and displays the epics of groups",
+	Long: `Fetches and displays the epics of GitLab groups, a GitLab Premium and Ultimate feature.
+If a group ID is provided, it will fetch epics from that group and its subgroups.
+If no group ID is provided, it will fetch epics from all accessible groups.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runEpics,
+}
+
+func init() {
+	epicsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	epicsCmd.Flags().StringVar(&epicState, "state", "",
+		"List only epics in this state: open or closed (default all)")
+
+	supportInstances(epicsCmd)
+	RootCmd.AddCommand(epicsCmd)
+}
+
+func runEpics(command *cobra.Command, _ []string) error {
+	state, err := epicStateFilter()
+	if err != nil {
+		return err
+	}
+
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.GroupEpic, error) {
+			return client.GetEpicsRecursively(ctx, groupID, state)
+		},
+		func(formatter output.Formatter, data []*glclient.GroupEpic) error {
+			return formatter.FormatEpics(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching epics...",
+	)
+}
+
+// epicStateFilter returns the epic state selected by --state, or no state when it is not given.
+func epicStateFilter() (string, error) {
+	switch strings.ToLower(epicState) {
+	case "":
+		return "", nil
+	case "open", glclient.EpicStateOpened:
+		return glclient.EpicStateOpened, nil
+	case glclient.EpicStateClosed:
+		return glclient.EpicStateClosed, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidEpicState, epicState)
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/picker"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// errorCodes are the stable codes reported with --error-format json, checked in order. Errors
+// without an entry are reported as output.CodeUnknown.
+var errorCodes = []output.ErrorCode{
+	{Err: ErrGitLabTokenRequired, Code: "token_required"},
+	{Err: glclient.ErrGroupNotFound, Code: "group_not_found"},
+	{Err: glclient.ErrGroupIsProject, Code: "group_is_project"},
+	{Err: glclient.ErrProjectNotFound, Code: "project_not_found"},
+	{Err: glclient.ErrAccessDenied, Code: "access_denied"},
+	{Err: glclient.ErrAdminRequired, Code: "admin_required"},
+	{Err: glclient.ErrEpicsUnavailable, Code: "epics_unavailable"},
+	{Err: glclient.ErrPushRulesUnavailable, Code: "push_rules_unavailable"},
+	{Err: glclient.ErrComputeUsageUnavailable, Code: "compute_usage_unavailable"},
+	{Err: glclient.ErrRateLimitUnavailable, Code: "rate_limit_unavailable"},
+	{Err: ErrIncompleteReport, Code: "incomplete_report"},
+	{Err: ErrDefaultBranchMismatch, Code: "default_branch_mismatch"},
+	{Err: ErrTwoFactorViolation, Code: "two_factor_violation"},
+	{Err: ErrPolicyViolation, Code: "policy_violation"},
+	{Err: ErrAutoDetectFailed, Code: "auto_detect_failed"},
+	{Err: picker.ErrNoGroups, Code: "no_groups"},
+	{Err: picker.ErrNoSelection, Code: "no_selection"},
+	{Err: picker.ErrNotConfirmed, Code: "not_confirmed"},
+	{Err: report.ErrInvalidBaseline, Code: "invalid_baseline"},
+	{Err: report.ErrInvalidInstances, Code: "invalid_instances"},
+	{Err: report.ErrInvalidPolicy, Code: "invalid_policy"},
+	{Err: context.DeadlineExceeded, Code: "deadline_exceeded"},
+	{Err: context.Canceled, Code: "canceled"},
+	{Err: output.ErrUnsupportedFormat, Code: "unsupported_format"},
+	{Err: ErrBothGroupIDAndProjectIDProvided, Code: "invalid_flags"},
+	{Err: ErrPartialRequiresDeadline, Code: "invalid_flags"},
+	{Err: ErrGzipRequiresOutput, Code: "invalid_flags"},
+	{Err: ErrAppendRequiresOutput, Code: "invalid_flags"},
+	{Err: ErrXLSXRequiresOutput, Code: "invalid_flags"},
+	{Err: ErrXLSXAppend, Code: "invalid_flags"},
+	{Err: ErrSQLiteOutput, Code: "invalid_flags"},
+	{Err: ErrETagCacheRequiresDir, Code: "invalid_flags"},
+	{Err: ErrVerifyURLsFormat, Code: "invalid_flags"},
+	{Err: ErrCountSharedRequiresShared, Code: "invalid_flags"},
+	{Err: ErrNegativeColumnWidth, Code: "invalid_flags"},
+	{Err: ErrTruncateMiddleRequiresWidth, Code: "invalid_flags"},
+	{Err: ErrInstancesUnsupported, Code: "invalid_flags"},
+	{Err: ErrInstancesWithBaseline, Code: "invalid_flags"},
+	{Err: ErrInstancesWithParents, Code: "invalid_flags"},
+	{Err: ErrExpectedBranchRequired, Code: "invalid_flags"},
+	{Err: ErrValueFilterRequiresValues, Code: "invalid_flags"},
+	{Err: ErrWithParentsRequiresProject, Code: "invalid_flags"},
+	{Err: ErrNegativeLimit, Code: "invalid_flags"},
+	{Err: ErrLimitWithBaseline, Code: "invalid_flags"},
+	{Err: ErrRollupWithBaseline, Code: "invalid_flags"},
+	{Err: ErrDiffProjectsRequired, Code: "invalid_flags"},
+	{Err: ErrDiffBaseline, Code: "invalid_flags"},
+	{Err: ErrGrepPatternRequired, Code: "invalid_flags"},
+	{Err: ErrGrepRequiresValues, Code: "invalid_flags"},
+	{Err: ErrGrepBaseline, Code: "invalid_flags"},
+	{Err: output.ErrTemplateRequired, Code: "invalid_flags"},
+	{Err: ErrInvalidEpicState, Code: "invalid_argument"},
+	{Err: ErrInvalidTokenState, Code: "invalid_argument"},
+	{Err: ErrInvalidCountSharedAs, Code: "invalid_argument"},
+	{Err: ErrInvalidRollupBy, Code: "invalid_argument"},
+	{Err: output.ErrInvalidIDFormat, Code: "invalid_argument"},
+	{Err: output.ErrInvalidErrorFormat, Code: "invalid_argument"},
+	{Err: output.ErrUnknownLinkTarget, Code: "invalid_argument"},
+	{Err: output.ErrUnknownTableStyle, Code: "invalid_argument"},
+	{Err: output.ErrInvalidTimezone, Code: "invalid_argument"},
+	{Err: output.ErrInvalidTimeFormat, Code: "invalid_argument"},
+	{Err: report.ErrInvalidSize, Code: "invalid_argument"},
+	{Err: report.ErrInvalidAccessLevel, Code: "invalid_argument"},
+	{Err: report.ErrInvalidSortField, Code: "invalid_argument"},
+	{Err: report.ErrInvalidSortOrder, Code: "invalid_argument"},
+	{Err: report.ErrInvalidValueRegex, Code: "invalid_argument"},
+	{Err: report.ErrInvalidNameRegex, Code: "invalid_argument"},
+	{Err: report.ErrInvalidCIDR, Code: "invalid_argument"},
+	{Err: glclient.ErrUnknownProfile, Code: "invalid_argument"},
+	{Err: glclient.ErrInvalidProfile, Code: "invalid_argument"},
+}
+
+// reportError prints err on standard error in the --error-format selected, with the command that
+// failed and the group or project it was run for as context.
+func reportError(command *cobra.Command, err error) {
+	// an invalid --error-format is itself reported as text
+	format, formatErr := output.ParseErrorFormat(errorFormat)
+	if formatErr != nil {
+		format = output.ErrorFormatText
+	}
+
+	context := map[string]string{
+		"group_id":   groupID,
+		"project_id": projectID,
+	}
+
+	if command != nil {
+		context["command"] = command.CommandPath()
+	}
+
+	_ = output.WriteError(os.Stderr, format, err, output.ErrorCodeOf(err, errorCodes), context)
+}
+
+// silenceForJSONErrors keeps cobra from printing the error and usage of a failed command in text
+// when the error is reported as JSON. It runs once the flags are parsed, before the arguments are
+// validated, and when parsing the flags fails.
+func silenceForJSONErrors() {
+	if errorFormat == string(output.ErrorFormatJSON) {
+		RootCmd.SilenceErrors = true
+		RootCmd.SilenceUsage = true
+	}
+}
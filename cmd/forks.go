@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var externalForksOnly bool
+
+var forksCmd = &cobra.Command{
+	Use:   "forks",
+	Short: "Fetches and displays the forks of projects",
+	Long: `Fetches and displays the forks of GitLab projects with the namespace that owns each fork,
+whether it lives outside the top-level group of its source project, and how many commits its
+default branch is ahead of and behind the default branch of the source project.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runForks,
+}
+
+func init() {
+	forksCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	forksCmd.Flags().BoolVar(&externalForksOnly, "external-forks-only", false,
+		"List only forks outside the top-level group of their source project")
+
+	filterAfterFetch(forksCmd, "external-forks-only")
+	supportInstances(forksCmd)
+	RootCmd.AddCommand(forksCmd)
+}
+
+func runForks(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectFork, error) {
+			forks, err := client.GetProjectForksRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if externalForksOnly {
+				forks = report.FilterExternalForks(forks)
+			}
+
+			return forks, nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectFork) error {
+			return formatter.FormatProjectForks(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching project forks...",
+	)
+}
@@ -1,14 +1,27 @@
 package cmd
 
 import (
+	"context"
 	"strings"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/spf13/cobra"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
+const defaultDescriptionWidth = 60
+
+var (
+	includeDescription bool
+	descriptionWidth   int
+	onlyWithProjects   bool
+	directOnly         bool
+	topLevelOnly       bool
+	subgroupsOnly      bool
+)
+
 var groupsCmd = &cobra.Command{
 	Use:   "groups",
 	Short: "Fetches and displays information about groups",
@@ -26,14 +39,48 @@ func init() {
 		"The ID or path of the top-level GitLab group to start the search from. "+
 			"Can be a numeric ID or a path with namespace (org/subgroup). "+
 			"(optional, fetches all accessible groups if not provided)")
+	addDescriptionFlags(groupsCmd)
+	groupsCmd.Flags().BoolVar(&onlyWithProjects, "only-with-projects", false,
+		"List only groups that directly contain at least one project (one extra API call per group)")
+	groupsCmd.Flags().BoolVar(&directOnly, "direct-only", false,
+		"List only the group given with --group-id and its direct subgroups")
+	groupsCmd.Flags().BoolVar(&topLevelOnly, "top-level-only", false,
+		"List only top-level groups, which have no parent group")
+	groupsCmd.Flags().BoolVar(&subgroupsOnly, "subgroups-only", false,
+		"List only subgroups, which are nested in a parent group")
+	groupsCmd.MarkFlagsMutuallyExclusive("top-level-only", "subgroups-only")
 
+	supportInstances(groupsCmd)
 	RootCmd.AddCommand(groupsCmd)
 }
 
-func runGroups(_ *cobra.Command, _ []string) error {
+func runGroups(command *cobra.Command, _ []string) error {
 	return runReportCommand(
-		func(client *glclient.Client, groupID string) ([]*gitlab.Group, error) {
-			return client.GetGroupsRecursively(groupID)
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*gitlab.Group, error) {
+			fetch := client.GetGroupsRecursively
+			if directOnly {
+				fetch = client.GetDirectSubgroups
+			}
+
+			groups, err := fetch(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			// the kind filters come first, so that --only-with-projects looks up fewer groups
+			switch {
+			case topLevelOnly:
+				groups = report.FilterTopLevelGroups(groups)
+			case subgroupsOnly:
+				groups = report.FilterSubgroups(groups)
+			}
+
+			if !onlyWithProjects {
+				return groups, nil
+			}
+
+			return client.FilterGroupsWithProjects(ctx, groups)
 		},
 		func(formatter output.Formatter, data []*gitlab.Group) error {
 			return formatter.FormatGroups(data)
@@ -42,3 +89,11 @@ func runGroups(_ *cobra.Command, _ []string) error {
 		"Fetching groups...",
 	)
 }
+
+// addDescriptionFlags registers the flags adding descriptions to the group and project tables.
+func addDescriptionFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&includeDescription, "include-description", false,
+		"Include the description in the table output (other formats always include it)")
+	command.Flags().IntVar(&descriptionWidth, "description-width", defaultDescriptionWidth,
+		"Truncate table descriptions longer than this many characters, 0 to disable")
+}
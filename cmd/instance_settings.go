@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+var instanceSettingsCmd = &cobra.Command{
+	Use:   "instance-settings",
+	Short: "Shows the instance-wide settings governing access tokens",
+	Long: `Shows the instance-wide settings governing access tokens, such as the maximum lifetime of access
+tokens, whether they must expire, and whether personal access tokens are disabled. The settings are
+read with a single request to the application settings API, which requires an administrator token.`,
+	RunE: runInstanceSettings,
+}
+
+func init() {
+	RootCmd.AddCommand(instanceSettingsCmd)
+}
+
+func runInstanceSettings(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " Fetching instance settings..."
+	s.Start()
+
+	settings, err := client.GetInstanceTokenSettings(ctx)
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch instance settings: %w", err)
+	}
+
+	violations, err := checkPolicy([]*glclient.InstanceTokenSettings{settings})
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.FormatInstanceTokenSettings(settings); err != nil {
+		return fmt.Errorf("failed to format instance settings: %w", err)
+	}
+
+	return reportViolations(violations)
+}
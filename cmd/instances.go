@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+// instancesAnnotation marks the commands that can run against every instance of --instances.
+const instancesAnnotation = "instances"
+
+var instancesFile string
+
+var (
+	ErrInstancesUnsupported  = errors.New("--instances is not supported by this command")
+	ErrInstancesWithBaseline = errors.New("--instances cannot be combined with --baseline")
+	ErrInstancesWithParents  = errors.New("--instances cannot be combined with --with-parents")
+)
+
+// supportInstances marks command as able to run against every instance of --instances, which
+// requires report items with an instance field to tag.
+func supportInstances(command *cobra.Command) {
+	if command.Annotations == nil {
+		command.Annotations = make(map[string]string)
+	}
+
+	command.Annotations[instancesAnnotation] = "true"
+}
+
+// checkInstances rejects --instances for commands that cannot run against several instances, and
+// together with the flags that compare with or look up a single one.
+func checkInstances(command *cobra.Command) error {
+	if instancesFile == "" {
+		return nil
+	}
+
+	switch {
+	case command.Annotations[instancesAnnotation] == "":
+		return fmt.Errorf("%w: %s", ErrInstancesUnsupported, command.CommandPath())
+	case baselineFile != "":
+		return ErrInstancesWithBaseline
+	case withParents:
+		return ErrInstancesWithParents
+	}
+
+	return nil
+}
+
+// instanceLabels records the instance each report item was fetched from, for the formats to label
+// the items without an instance field, such as groups and projects. Items are pointers, told apart
+// by identity.
+type instanceLabels struct {
+	mu     sync.Mutex
+	labels map[any]string
+}
+
+// recordInstance records label as the instance of each of items.
+func recordInstance[T any](l *instanceLabels, items []T, label string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, item := range items {
+		l.labels[item] = label
+	}
+}
+
+// label returns the instance item was fetched from.
+func (l *instanceLabels) label(item any) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.labels[item]
+}
+
+// runInstancesReport runs a report against every GitLab instance listed in the --instances file
+// concurrently and formats the items of all instances as a single report, tagged with the label
+// of their instance. Instances without a token use the --token flag or GITLAB_TOKEN. A non-nil
+// sortFunc orders the merged items of all instances before --limit applies.
+func runInstancesReport[T any](
+	ctx context.Context,
+	fetchFunc func(ctx context.Context, client *glclient.Client, groupID string) ([]T, error),
+	sortFunc func(data []T),
+	formatFunc func(formatter output.Formatter, data []T) error,
+	tokenErr error,
+	spinnerSuffix string,
+) error {
+	instances, err := report.LoadInstances(instancesFile)
+	if err != nil {
+		return err
+	}
+
+	clients := make(map[string]*glclient.Client, len(instances))
+
+	for _, instance := range instances {
+		token := instance.Token
+		if token == "" {
+			token = getToken()
+		}
+
+		if token == "" {
+			return fmt.Errorf("instance %s: %w", instance.Label, tokenErr)
+		}
+
+		client, err := newClient(ctx, token, glclient.WithBaseURL(instance.URL))
+		if err != nil {
+			return fmt.Errorf("failed to create GitLab client for instance %s: %w", instance.Label, err)
+		}
+
+		clients[instance.Label] = client
+	}
+
+	labels := &instanceLabels{labels: make(map[any]string)}
+	opts := append(formatterOptions(ctx, clients[instances[0].Label]),
+		output.WithInstanceColumn(), output.WithInstanceLabels(labels.label))
+
+	formatter, err := output.NewFormatter(output.Format(format), opts...)
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " " + spinnerSuffix
+	s.Start()
+
+	data, err := report.FetchInstances(ctx, instances,
+		func(ctx context.Context, instance report.Instance) ([]T, error) {
+			client := clients[instance.Label]
+
+			fetchCtx, stopFetch := fetchContext(ctx, client)
+			defer stopFetch()
+
+			items, err := fetchFunc(fetchCtx, client, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			recordInstance(labels, items, instance.Label)
+
+			return items, nil
+		})
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch data: %w", err)
+	}
+
+	for _, instance := range instances {
+		if err := reportIncomplete(ctx, clients[instance.Label]); err != nil {
+			return fmt.Errorf("instance %s: %w", instance.Label, err)
+		}
+	}
+
+	if sortFunc != nil {
+		sortFunc(data)
+	}
+
+	violations, err := checkPolicy(data)
+	if err != nil {
+		return err
+	}
+
+	err = formatReport(formatter, data, func(items []T) error { return formatFunc(formatter, items) })
+	if err != nil {
+		return fmt.Errorf("failed to format data: %w", err)
+	}
+
+	return reportViolations(violations)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var activeIntegrationsOnly bool
+
+var integrationsCmd = &cobra.Command{
+	Use:   "integrations",
+	Short: "Fetches and displays the integrations and webhooks of projects",
+	Long: `Fetches and displays the integrations, such as Slack or Jira, and the webhooks of GitLab projects,
+with the events they receive and the host of the endpoint they call. Endpoint paths and query strings
+often hold secrets, so they are never shown.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Reading integrations and webhooks requires at least the Maintainer role on each project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runIntegrations,
+}
+
+func init() {
+	integrationsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	integrationsCmd.Flags().BoolVar(&activeIntegrationsOnly, "active-only", false,
+		"List only active integrations and webhooks, leaving out webhooks GitLab disabled after failures")
+
+	supportInstances(integrationsCmd)
+	RootCmd.AddCommand(integrationsCmd)
+}
+
+func runIntegrations(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectIntegration, error) {
+			integrations, err := client.GetIntegrationsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return filterIntegrations(integrations), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectIntegration) error {
+			return formatter.FormatIntegrations(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching integrations...",
+	)
+}
+
+// filterIntegrations keeps the active integrations and webhooks when --active-only is set.
+func filterIntegrations(integrations []*glclient.ProjectIntegration) []*glclient.ProjectIntegration {
+	if !activeIntegrationsOnly {
+		return integrations
+	}
+
+	filtered := make([]*glclient.ProjectIntegration, 0, len(integrations))
+
+	for _, integration := range integrations {
+		if integration.Active {
+			filtered = append(filtered, integration)
+		}
+	}
+
+	return filtered
+}
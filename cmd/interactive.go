@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/picker"
+	"golang.org/x/term"
+)
+
+// selectRootGroup lets the user pick the top-level group to start from when --interactive is given
+// without --group-id or --project-id. It does nothing unless both stdin and stdout are terminals,
+// so scripts and redirected output keep scanning all accessible groups.
+func selectRootGroup(ctx context.Context, client *glclient.Client) error {
+	if !interactive || groupID != "" || projectID != "" {
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil
+	}
+
+	selected, err := picker.SelectGroup(ctx, client, picker.NewLinePrompt(os.Stdin, os.Stdout))
+	if err != nil {
+		return fmt.Errorf("failed to select a group: %w", err)
+	}
+
+	groupID = selected
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var allowlistDisabledOnly bool
+
+var jobTokenScopeCmd = &cobra.Command{
+	Use:   "job-token-scope",
+	Short: "Fetches and displays the CI/CD job token allowlists of projects",
+	Long: `Fetches and displays the CI/CD job token allowlist of GitLab projects: whether it is enforced,
+and which projects and groups may access each project with their job tokens.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Reading the allowlist requires at least the Maintainer role on each project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runJobTokenScope,
+}
+
+func init() {
+	jobTokenScopeCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	jobTokenScopeCmd.Flags().BoolVar(&allowlistDisabledOnly, "disabled-only", false,
+		"List only projects accepting CI/CD job tokens from any project, without an allowlist")
+
+	supportInstances(jobTokenScopeCmd)
+	RootCmd.AddCommand(jobTokenScopeCmd)
+}
+
+func runJobTokenScope(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectJobTokenScope, error) {
+			scopes, err := client.GetJobTokenScopesRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return filterJobTokenScopes(scopes), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectJobTokenScope) error {
+			return formatter.FormatJobTokenScopes(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching job token allowlists...",
+	)
+}
+
+// filterJobTokenScopes keeps the projects without an enforced allowlist when --disabled-only is set.
+func filterJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) []*glclient.ProjectJobTokenScope {
+	if !allowlistDisabledOnly {
+		return scopes
+	}
+
+	filtered := make([]*glclient.ProjectJobTokenScope, 0, len(scopes))
+
+	for _, scope := range scopes {
+		if !scope.AllowlistEnabled {
+			filtered = append(filtered, scope)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/spf13/cobra"
+)
+
+var limit int
+
+// fetchAllItems is set by checkLimit when the command filters or sorts its report after fetching,
+// which keeps --limit from stopping the fetches early.
+var fetchAllItems bool
+
+const (
+	filterAfterFetchAnnotation = "glreporter_filter_after_fetch"
+	sortAfterFetchAnnotation   = "glreporter_sort_after_fetch"
+)
+
+var (
+	ErrNegativeLimit     = errors.New("--limit must not be negative")
+	ErrLimitWithBaseline = errors.New("--limit cannot be combined with --baseline, which needs the full report")
+)
+
+// filterAfterFetch marks the named flags of command as filters applied to the report after its
+// items were fetched. While one of them is set, the fetches do not stop at --limit, since the first
+// items fetched may not be the ones the filter keeps.
+func filterAfterFetch(command *cobra.Command, names ...string) {
+	if command.Annotations == nil {
+		command.Annotations = make(map[string]string)
+	}
+
+	filters := strings.Fields(command.Annotations[filterAfterFetchAnnotation])
+	command.Annotations[filterAfterFetchAnnotation] = strings.Join(append(filters, names...), " ")
+}
+
+// sortAfterFetch marks command as sorting its report after the items were fetched, so that the
+// fetches never stop at --limit: the first items fetched are not the first of the sorted report.
+func sortAfterFetch(command *cobra.Command) {
+	if command.Annotations == nil {
+		command.Annotations = make(map[string]string)
+	}
+
+	command.Annotations[sortAfterFetchAnnotation] = "true"
+}
+
+// checkLimit validates --limit and decides whether the fetches of command may stop once they
+// collected the --limit items.
+func checkLimit(command *cobra.Command) error {
+	if limit < 0 {
+		return ErrNegativeLimit
+	}
+
+	fetchAllItems = command.Annotations[sortAfterFetchAnnotation] != "" ||
+		slices.ContainsFunc(strings.Fields(command.Annotations[filterAfterFetchAnnotation]), command.Flags().Changed)
+
+	return nil
+}
+
+// fetchContext returns the context to fetch a report with, which stops the fetches of client once
+// they collected the --limit items. With --rollup-by, --limit caps the rows of counts instead, which
+// need every item, as do the reports filtered or sorted after fetching.
+func fetchContext(ctx context.Context, client *glclient.Client) (context.Context, context.CancelFunc) {
+	if limit <= 0 || rollupBy != "" || fetchAllItems {
+		return ctx, func() {}
+	}
+
+	return client.LimitItems(ctx, limit)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseCommand finds the command of args and parses its flags, which are reset when the test ends.
+func parseCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+
+	command, flags, err := RootCmd.Find(args)
+	require.NoError(t, err)
+	require.NoError(t, command.ParseFlags(flags))
+
+	t.Cleanup(func() {
+		command.Flags().Visit(func(flag *pflag.Flag) {
+			assert.NoError(t, flag.Value.Set(flag.DefValue))
+			flag.Changed = false
+		})
+
+		fetchAllItems = false
+	})
+
+	return command
+}
+
+func TestFetchContext_limit(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stopsEarly bool
+	}{
+		{
+			name:       "without a filter",
+			args:       []string{"variables", "project", "--limit", "1"},
+			stopsEarly: true,
+		},
+		{
+			name: "without --limit",
+			args: []string{"variables", "project", "--only-empty"},
+		},
+		{
+			name: "with a filter applied after fetching",
+			args: []string{"variables", "project", "--limit", "1", "--only-empty"},
+		},
+		{
+			name: "with a filter of another command",
+			args: []string{"tokens", "ptt", "--limit", "1", "--token-prefix", "glptt-"},
+		},
+		{
+			name: "with a sorted report",
+			args: []string{"tokens", "gat", "--limit", "1"},
+		},
+		{
+			name: "with --rollup-by",
+			args: []string{"variables", "group", "--limit", "1", "--rollup-by", "source"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := parseCommand(t, tt.args...)
+			require.NoError(t, checkLimit(command))
+
+			client, err := glclient.NewClient("token", false)
+			require.NoError(t, err)
+
+			ctx, stop := fetchContext(t.Context(), client)
+			defer stop()
+
+			if tt.stopsEarly {
+				assert.NotEqual(t, t.Context(), ctx, "the fetches stop at --limit")
+			} else {
+				assert.Equal(t, t.Context(), ctx, "the fetches collect every item")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/spf13/cobra"
+)
+
+var overdueOnly bool
+
+var milestonesCmd = &cobra.Command{
+	Use:   "milestones",
+	Short: "Fetches and displays milestones",
+	Long:  "Fetches and displays the milestones of GitLab projects and groups.",
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(milestonesCmd)
+	milestonesCmd.AddCommand(milestonesAllCmd)
+	milestonesCmd.AddCommand(milestonesGroupCmd)
+	milestonesCmd.AddCommand(milestonesProjectCmd)
+
+	for _, command := range []*cobra.Command{milestonesAllCmd, milestonesGroupCmd, milestonesProjectCmd} {
+		supportInstances(command)
+		filterAfterFetch(command, "overdue-only")
+	}
+
+	milestonesCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	milestonesCmd.PersistentFlags().BoolVar(&overdueOnly, "overdue-only", false,
+		"List only open milestones whose due date has passed")
+}
+
+// milestoneDue returns the due date of an open milestone, and nil for closed milestones, which are
+// never overdue.
+func milestoneDue(state string, dueDate *time.Time) *time.Time {
+	if state != glclient.MilestoneStateActive {
+		return nil
+	}
+
+	return dueDate
+}
+
+func projectMilestoneDue(m *glclient.ProjectMilestone) *time.Time {
+	return milestoneDue(m.State, m.DueDate)
+}
+
+func groupMilestoneDue(m *glclient.GroupMilestone) *time.Time {
+	return milestoneDue(m.State, m.DueDate)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var milestonesAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Fetch both project and group milestones",
+	Long: `Fetch the milestones of GitLab projects and groups. You can:
+- Specify a group ID to fetch project and group milestones starting from that group recursively
+- Leave blank to fetch all accessible milestones`,
+	RunE: runMilestonesAll,
+}
+
+func runMilestonesAll(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		fetchAllMilestones,
+		func(formatter output.Formatter, data []*glclient.MilestoneWithSource) error {
+			return formatter.FormatUnifiedMilestones(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching all milestones...",
+	)
+}
+
+func fetchAllMilestones(
+	ctx context.Context,
+	client *glclient.Client,
+	groupID string,
+) ([]*glclient.MilestoneWithSource, error) {
+	projectMilestones, err := fetchProjectMilestones(ctx, client, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupMilestones, err := fetchGroupMilestones(ctx, client, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	allMilestones := make([]*glclient.MilestoneWithSource, 0, len(projectMilestones)+len(groupMilestones))
+
+	for _, pm := range projectMilestones {
+		allMilestones = append(allMilestones, glclient.ConvertProjectMilestoneToUnified(pm))
+	}
+
+	for _, gm := range groupMilestones {
+		allMilestones = append(allMilestones, glclient.ConvertGroupMilestoneToUnified(gm))
+	}
+
+	return allMilestones, nil
+}
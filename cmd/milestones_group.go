@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var milestonesGroupCmd = &cobra.Command{
+	Use:     "group",
+	Aliases: []string{"groups"},
+	Short:   "Fetch group milestones",
+	Long: `Fetch the milestones of GitLab groups. You can:
+- Specify a group ID to fetch the milestones of that group and its subgroups
+- Leave blank to fetch the milestones of all accessible groups`,
+	RunE: runMilestonesGroup,
+}
+
+func runMilestonesGroup(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		fetchGroupMilestones,
+		func(formatter output.Formatter, data []*glclient.GroupMilestone) error {
+			return formatter.FormatGroupMilestones(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching group milestones...",
+	)
+}
+
+func fetchGroupMilestones(
+	ctx context.Context,
+	client *glclient.Client,
+	groupID string,
+) ([]*glclient.GroupMilestone, error) {
+	milestones, err := client.GetGroupMilestonesRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch group milestones: %w", err)
+	}
+
+	if overdueOnly {
+		milestones = report.FilterOverdue(milestones, time.Now(), groupMilestoneDue)
+	}
+
+	return milestones, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var milestonesProjectCmd = &cobra.Command{
+	Use:     "project",
+	Aliases: []string{"projects"},
+	Short:   "Fetch project milestones",
+	Long: `Fetch the milestones of GitLab projects. You can:
+- Specify a group ID to fetch the milestones of the projects in that group and its subgroups
+- Leave blank to fetch the milestones of all accessible projects`,
+	RunE: runMilestonesProject,
+}
+
+func runMilestonesProject(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		fetchProjectMilestones,
+		func(formatter output.Formatter, data []*glclient.ProjectMilestone) error {
+			return formatter.FormatProjectMilestones(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching project milestones...",
+	)
+}
+
+func fetchProjectMilestones(
+	ctx context.Context,
+	client *glclient.Client,
+	groupID string,
+) ([]*glclient.ProjectMilestone, error) {
+	milestones, err := client.GetProjectMilestonesRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch project milestones: %w", err)
+	}
+
+	if overdueOnly {
+		milestones = report.FilterOverdue(milestones, time.Now(), projectMilestoneDue)
+	}
+
+	return milestones, nil
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import "errors"
+
+var withParents bool
+
+var ErrWithParentsRequiresProject = errors.New("--with-parents requires a single --project-id")
+
+const withParentsUsage = "Also list the data of the groups the project inherits from (requires a single --project-id)"
+
+// parentsProjectID returns the project whose ancestor groups --with-parents includes.
+func parentsProjectID() (string, error) {
+	ids := projectIDs()
+	if len(ids) != 1 {
+		return "", ErrWithParentsRequiresProject
+	}
+
+	return ids[0], nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+)
+
+var (
+	policyFile string
+
+	// policy holds the rules loaded from --policy, nil without it
+	policy *report.Policy
+)
+
+var ErrPolicyViolation = errors.New("the report violates the policy")
+
+// loadPolicy reads the --policy file, if any, so that an invalid policy fails before the fetch.
+func loadPolicy() error {
+	if policyFile == "" {
+		return nil
+	}
+
+	loaded, err := report.LoadPolicy(policyFile)
+	if err != nil {
+		return err
+	}
+
+	policy = loaded
+
+	return nil
+}
+
+// checkPolicy returns the violations of the --policy rules by items. It runs before the report is
+// formatted, since redaction rewrites the items in place.
+func checkPolicy[T any](items []T) ([]report.Violation, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	violations, err := report.CheckPolicy(policy, items, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check policy: %w", err)
+	}
+
+	return violations, nil
+}
+
+// reportViolations lists the policy violations on stderr after the report and fails the command
+// when there are any.
+func reportViolations(violations []report.Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	for _, violation := range violations {
+		fmt.Fprintf(os.Stderr, "Policy violation: %s: %s\n", violation.Rule, violation.Item)
+	}
+
+	return fmt.Errorf("%w: %d violations", ErrPolicyViolation, len(violations))
+}
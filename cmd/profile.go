@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/spf13/pflag"
+)
+
+var (
+	profileName string
+	concurrency int
+	rateLimit   float64
+	pageSize    int
+	maxRetries  int
+
+	// apiProfile holds the API settings selected by --profile and the individual flags
+	apiProfile = glclient.DefaultProfile()
+)
+
+// resolveProfile returns the settings of the --profile preset, with those given by --concurrency,
+// --rate-limit, --page-size, or --max-retries taking precedence over the preset.
+func resolveProfile(flags *pflag.FlagSet) (glclient.Profile, error) {
+	profile, err := glclient.LookupProfile(profileName)
+	if err != nil {
+		return glclient.Profile{}, err
+	}
+
+	if flags.Changed("concurrency") {
+		profile.Concurrency = concurrency
+	}
+
+	if flags.Changed("rate-limit") {
+		profile.RateLimit = rateLimit
+	}
+
+	if flags.Changed("page-size") {
+		profile.PageSize = pageSize
+	}
+
+	if flags.Changed("max-retries") {
+		profile.MaxRetries = maxRetries
+	}
+
+	if err := profile.Validate(); err != nil {
+		return glclient.Profile{}, err
+	}
+
+	return profile, nil
+}
+
+// profileUsage describes the --profile flag with the settings of each preset.
+func profileUsage() string {
+	presets := make([]string, 0, len(glclient.ProfileNames()))
+
+	for _, name := range glclient.ProfileNames() {
+		profile, _ := glclient.LookupProfile(name)
+		presets = append(presets, fmt.Sprintf("%s (%s)", name, profile))
+	}
+
+	return "Preset of the --concurrency, --rate-limit, --page-size, and --max-retries settings, " +
+		"which take precedence when given: " + strings.Join(presets, "; ") +
+		". Gentle suits shared and self-managed instances, aggressive suits gitlab.com"
+}
@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"strings"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/spf13/cobra"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
+var ciDisabledOnly bool
+
 var projectsCmd = &cobra.Command{
 	Use:   "projects",
 	Short: "Fetches and displays information about projects",
@@ -26,14 +30,28 @@ func init() {
 		"The ID or path of the top-level GitLab group to start the search from. "+
 			"Can be a numeric ID, a path with namespace (org/subgroup/project). "+
 			"(optional, fetches from all accessible groups if not provided)")
+	projectsCmd.Flags().BoolVar(&ciDisabledOnly, "ci-disabled-only", false,
+		"List only projects with CI/CD disabled entirely, where no pipeline runs")
+	addDescriptionFlags(projectsCmd)
 
+	supportInstances(projectsCmd)
 	RootCmd.AddCommand(projectsCmd)
 }
 
-func runProjects(_ *cobra.Command, _ []string) error {
+func runProjects(command *cobra.Command, _ []string) error {
 	return runReportCommand(
-		func(client *glclient.Client, groupID string) ([]*gitlab.Project, error) {
-			return client.GetProjectsRecursively(groupID)
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*gitlab.Project, error) {
+			projects, err := client.GetProjectsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if ciDisabledOnly {
+				projects = report.FilterCIDisabled(projects)
+			}
+
+			return projects, nil
 		},
 		func(formatter output.Formatter, data []*gitlab.Project) error {
 			return formatter.FormatProjects(data)
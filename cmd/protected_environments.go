@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var unprotectedProduction bool
+
+var protectedEnvironmentsCmd = &cobra.Command{
+	Use:   "protected-environments",
+	Short: "Fetches and displays the protected deployment environments of projects",
+	Long: `Fetches and displays the protected deployment environments of GitLab projects: who may deploy
+to each of them and how many approvals a deployment needs.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Reading protected environments requires at least the Maintainer role on each project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runProtectedEnvironments,
+}
+
+func init() {
+	protectedEnvironmentsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	protectedEnvironmentsCmd.Flags().BoolVar(&unprotectedProduction, "unprotected-production", false,
+		"List only projects whose production environment is not protected")
+
+	supportInstances(protectedEnvironmentsCmd)
+	RootCmd.AddCommand(protectedEnvironmentsCmd)
+}
+
+func runProtectedEnvironments(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectProtectedEnvironment, error) {
+			if unprotectedProduction {
+				return client.GetUnprotectedProductionRecursively(ctx, groupID)
+			}
+
+			return client.GetProtectedEnvironmentsRecursively(ctx, groupID)
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectProtectedEnvironment) error {
+			return formatter.FormatProtectedEnvironments(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching protected environments...",
+	)
+}
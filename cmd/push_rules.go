@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var missingSecretCheck bool
+
+var pushRulesCmd = &cobra.Command{
+	Use:   "push-rules",
+	Short: "Fetches and displays the push rules of groups and projects",
+	Long: `Fetches and displays the push rules of GitLab groups and projects, a GitLab Premium and Ultimate
+feature: whether commits must be signed, whether secrets are rejected, and the branch name and
+author email patterns enforced.
+If a group ID is provided, it will fetch push rules from that group and its subgroups.
+If no group ID is provided, it will fetch push rules from all accessible groups.
+Reading push rules requires at least the Maintainer role on each group and project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runPushRules,
+}
+
+func init() {
+	pushRulesCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	pushRulesCmd.Flags().BoolVar(&missingSecretCheck, "missing-secret-check", false,
+		"List only projects whose push rules do not reject files that are likely to contain secrets")
+
+	supportInstances(pushRulesCmd)
+	RootCmd.AddCommand(pushRulesCmd)
+}
+
+func runPushRules(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.PushRulesWithSource, error) {
+			rules, err := client.GetPushRulesRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return filterPushRules(rules), nil
+		},
+		func(formatter output.Formatter, data []*glclient.PushRulesWithSource) error {
+			return formatter.FormatPushRules(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching push rules...",
+	)
+}
+
+// filterPushRules keeps the projects not rejecting secrets when --missing-secret-check is set.
+// Projects without push rules reject nothing and are kept too.
+func filterPushRules(rules []*glclient.PushRulesWithSource) []*glclient.PushRulesWithSource {
+	if !missingSecretCheck {
+		return rules
+	}
+
+	filtered := make([]*glclient.PushRulesWithSource, 0, len(rules))
+
+	for _, rule := range rules {
+		if rule.Source == "project" && !rule.PreventSecrets {
+			filtered = append(filtered, rule)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+var rateLimitCmd = &cobra.Command{
+	Use:   "ratelimit",
+	Short: "Shows the remaining API request budget of the token",
+	Long: `Shows the remaining API request budget of the token. A single lightweight request, reading the
+GitLab version, is made and the RateLimit-Limit, RateLimit-Remaining, RateLimit-Observed, and
+RateLimit-Reset headers of its response are reported. Instances with rate limits disabled send no
+such headers and are reported as an error.`,
+	RunE: runRateLimit,
+}
+
+func init() {
+	RootCmd.AddCommand(rateLimitCmd)
+}
+
+func runRateLimit(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " Fetching rate limit..."
+	s.Start()
+
+	status, err := client.GetRateLimitStatus(ctx)
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch rate limit: %w", err)
+	}
+
+	violations, err := checkPolicy([]*glclient.RateLimitStatus{status})
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.FormatRateLimit(status); err != nil {
+		return fmt.Errorf("failed to format rate limit: %w", err)
+	}
+
+	return reportViolations(violations)
+}
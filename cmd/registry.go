@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var nonEmptyRegistriesOnly bool
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Fetches and displays the container and package registry usage of projects",
+	Long: `Fetches and displays how many container repositories, image tags, and packages GitLab projects
+publish to their container and package registries. Registries disabled for a project or for the
+instance are reported as disabled.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Listing the registries of a private project requires at least the Reporter role on it.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runRegistry,
+}
+
+func init() {
+	registryCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	registryCmd.Flags().BoolVar(&nonEmptyRegistriesOnly, "non-empty-only", false,
+		"List only projects holding container repositories or packages")
+
+	supportInstances(registryCmd)
+	RootCmd.AddCommand(registryCmd)
+}
+
+func runRegistry(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectRegistry, error) {
+			registries, err := client.GetProjectRegistriesRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if nonEmptyRegistriesOnly {
+				registries = report.NonEmptyRegistries(registries)
+			}
+
+			return registries, nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectRegistry) error {
+			return formatter.FormatProjectRegistries(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching project registries...",
+	)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var resolveDetails bool
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolves group and project paths to numeric IDs and back",
+	Long: `Resolves the full path of a GitLab group or project to its numeric ID, or a numeric ID to the full path.
+The result is printed alone on a line for use in scripts. With --details, the whole group or project
+is reported in the chosen --format instead.`,
+}
+
+var resolveGroupCmd = &cobra.Command{
+	Use:   "group <id-or-path>",
+	Short: "Resolves a group path to its numeric ID, or an ID to its path",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runResolveGroup,
+}
+
+var resolveProjectCmd = &cobra.Command{
+	Use:   "project <id-or-path>",
+	Short: "Resolves a project path to its numeric ID, or an ID to its path",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runResolveProject,
+}
+
+func init() {
+	resolveCmd.PersistentFlags().BoolVar(&resolveDetails, "details", false,
+		"Report the whole group or project in the chosen --format instead of its ID or path")
+
+	RootCmd.AddCommand(resolveCmd)
+	resolveCmd.AddCommand(resolveGroupCmd)
+	resolveCmd.AddCommand(resolveProjectCmd)
+}
+
+func runResolveGroup(command *cobra.Command, args []string) error {
+	ctx := command.Context()
+	ref := strings.Trim(args[0], "/")
+
+	client, formatter, err := resolveClient(command)
+	if err != nil {
+		return err
+	}
+
+	group, err := client.GetGroup(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	if resolveDetails {
+		if err := formatter.FormatGroups([]*gitlab.Group{group}); err != nil {
+			return fmt.Errorf("failed to format group: %w", err)
+		}
+
+		return nil
+	}
+
+	return printResolved(ref, group.ID, group.FullPath)
+}
+
+func runResolveProject(command *cobra.Command, args []string) error {
+	ctx := command.Context()
+	ref := strings.Trim(args[0], "/")
+
+	client, formatter, err := resolveClient(command)
+	if err != nil {
+		return err
+	}
+
+	project, err := client.GetProject(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	if resolveDetails {
+		if err := formatter.FormatProjects([]*gitlab.Project{project}); err != nil {
+			return fmt.Errorf("failed to format project: %w", err)
+		}
+
+		return nil
+	}
+
+	return printResolved(ref, project.ID, project.PathWithNamespace)
+}
+
+// resolveClient creates the client, and the formatter used with --details.
+func resolveClient(command *cobra.Command) (*glclient.Client, output.Formatter, error) {
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return nil, nil, ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(command.Context(), tokenValue)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(command.Context(), client)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid output format: %w", err)
+	}
+
+	return client, formatter, nil
+}
+
+// printResolved prints the path of a resource looked up by numeric ID, and the ID otherwise.
+func printResolved(ref string, id int, path string) error {
+	var out io.Writer = os.Stdout
+	if reportWriter != nil {
+		out = reportWriter
+	}
+
+	value := strconv.Itoa(id)
+	if _, err := strconv.Atoi(ref); err == nil {
+		value = path
+	}
+
+	if _, err := fmt.Fprintln(out, value); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+)
+
+var rollupBy string
+
+// values of --rollup-by
+const rollupBySource = "source"
+
+var (
+	ErrInvalidRollupBy    = errors.New("invalid --rollup-by, use source")
+	ErrRollupWithBaseline = errors.New("--rollup-by cannot be combined with --baseline")
+)
+
+// checkRollupBy validates --rollup-by before anything is fetched.
+func checkRollupBy() error {
+	if rollupBy != "" && rollupBy != rollupBySource {
+		return fmt.Errorf("%w: %q", ErrInvalidRollupBy, rollupBy)
+	}
+
+	return nil
+}
+
+// formatReport prints the first --limit items with formatItems or, with --rollup-by, the first
+// --limit rows counting the items of each group or project.
+func formatReport[T any](formatter output.Formatter, items []T, formatItems func([]T) error) error {
+	if rollupBy == "" {
+		return formatItems(report.Limit(items, limit))
+	}
+
+	counts, err := report.RollupBySource(items)
+	if err != nil {
+		return fmt.Errorf("failed to roll up the report: %w", err)
+	}
+
+	if err := formatter.FormatSourceCounts(report.Limit(counts, limit)); err != nil {
+		return fmt.Errorf("failed to format source counts: %w", err)
+	}
+
+	return nil
+}
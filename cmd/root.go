@@ -1,24 +1,83 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strings"
 	"time"
 
+	"github.com/andreygrechin/glreporter/internal/cache"
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
 
 var (
-	groupID       string
-	projectID     string
-	format        string
-	token         string
-	debug         bool
-	includeValues bool
+	groupID        string
+	projectID      string
+	format         string
+	token          string
+	debug          bool
+	includeValues  bool
+	gitlabURL      string
+	cacheDir       string
+	cacheTTL       time.Duration
+	etagCache      bool
+	templateFile   string
+	templateString string
+	includeShared  bool
+	countSharedAs  string
+	showErrors     bool
+	tableStyle     string
+	maxColWidth    int
+	truncateMiddle bool
+	includeUsers   bool
+	stripQuery     bool
+	envelope       bool
+	flatten        bool
+	tree           bool
+	strict         bool
+	requestTimeout time.Duration
+	deadline       time.Duration
+	groupTimeout   time.Duration
+	partialResults bool
+	normalizePaths bool
+	idFormat       string
+	linkSuffixes   map[string]string
+	noLinkSuffixes bool
+	verifyURLs     bool
+	interactive    bool
+	redact         bool
+	redactSalt     string
+	pseudonymize   bool
+	outputFile     string
+	sqliteFile     string
+	gzipOutput     bool
+	appendOutput   bool
+	noHeader       bool
+	topic          string
+	sortNatural    bool
+	noVersionCheck bool
+	errorFormat    string
+	timezone       string
+	timeFormat     string
+
+	// cancelDeadline releases the context of the --deadline timer once the command returns
+	cancelDeadline context.CancelFunc = func() {}
+	// reportWriter is the --output file the report is written to, closed once the command returns
+	reportWriter io.WriteCloser
+	// reportAppended tells that --append adds to a file already holding a report, with its CSV header
+	reportAppended bool
+	// reportLocation is the --timezone the report timestamps are rendered in
+	reportLocation *time.Location
+	// reportTimeFormat is the --time-format the report timestamps are rendered in
+	reportTimeFormat output.TimeFormat
 )
 
 var (
@@ -26,6 +85,22 @@ var (
 		"gitlab token is required. Use --token flag or set GITLAB_TOKEN environment variable")
 	ErrBothGroupIDAndProjectIDProvided = errors.New(
 		"cannot specify both --group-id and --project-id")
+	ErrIncompleteReport        = errors.New("report is incomplete")
+	ErrPartialRequiresDeadline = errors.New("--partial-on-timeout requires --deadline")
+	ErrGzipRequiresOutput      = errors.New("--gzip requires --output")
+	ErrAppendRequiresOutput    = errors.New("--append requires --output")
+	ErrETagCacheRequiresDir    = errors.New("--etag-cache requires --cache-dir")
+	ErrVerifyURLsFormat        = errors.New("--verify-urls requires the table or csv format")
+	ErrXLSXRequiresOutput      = errors.New("--format xlsx requires --output")
+	ErrXLSXAppend              = errors.New("--append cannot add to an xlsx workbook")
+	ErrSQLiteOutput            = errors.New("--sqlite cannot be combined with --output or --format")
+)
+
+var (
+	ErrInvalidCountSharedAs        = errors.New("invalid --count-shared-as, use original or shared")
+	ErrCountSharedRequiresShared   = errors.New("--count-shared-as shared requires --include-shared-projects")
+	ErrNegativeColumnWidth         = errors.New("--max-col-width must not be negative")
+	ErrTruncateMiddleRequiresWidth = errors.New("--truncate-middle requires --max-col-width")
 )
 
 var RootCmd = &cobra.Command{
@@ -33,28 +108,289 @@ var RootCmd = &cobra.Command{
 	Short: "A CLI tool to fetch and display GitLab groups and projects",
 	Long: `A CLI tool that asynchronously fetches and displays information about ` +
 		`GitLab groups and their associated projects.`,
+	PersistentPreRunE: func(command *cobra.Command, _ []string) error {
+		if _, err := output.ParseErrorFormat(errorFormat); err != nil {
+			return err
+		}
+
+		location, err := output.LoadTimezone(timezone)
+		if err != nil {
+			return err
+		}
+
+		reportLocation = location
+
+		if reportTimeFormat, err = output.ParseTimeFormat(timeFormat); err != nil {
+			return err
+		}
+
+		if partialResults && deadline <= 0 {
+			return ErrPartialRequiresDeadline
+		}
+
+		if err := checkLimit(command); err != nil {
+			return err
+		}
+
+		if err := checkRollupBy(); err != nil {
+			return err
+		}
+
+		profile, err := resolveProfile(command.Flags())
+		if err != nil {
+			return err
+		}
+
+		apiProfile = profile
+
+		if gzipOutput && outputFile == "" {
+			return ErrGzipRequiresOutput
+		}
+
+		if appendOutput && outputFile == "" {
+			return ErrAppendRequiresOutput
+		}
+
+		if etagCache && cacheDir == "" {
+			return ErrETagCacheRequiresDir
+		}
+
+		if err := checkCountSharedAs(); err != nil {
+			return err
+		}
+
+		if maxColWidth < 0 {
+			return ErrNegativeColumnWidth
+		}
+
+		if truncateMiddle && maxColWidth == 0 {
+			return ErrTruncateMiddleRequiresWidth
+		}
+
+		format = string(output.FormatForFile(output.Format(format), command.Flags().Changed("format"), outputFile))
+
+		if err := checkXLSX(); err != nil {
+			return err
+		}
+
+		if err := checkSQLite(command); err != nil {
+			return err
+		}
+
+		if err := checkInstances(command); err != nil {
+			return err
+		}
+
+		if err := loadPolicy(); err != nil {
+			return err
+		}
+
+		if verifyURLs && output.Format(format) != output.FormatTable && output.Format(format) != output.FormatCSV {
+			return ErrVerifyURLsFormat
+		}
+
+		if err := openReportFile(); err != nil {
+			return err
+		}
+
+		if deadline > 0 {
+			var ctx context.Context
+
+			ctx, cancelDeadline = context.WithTimeout(command.Context(), deadline)
+			command.SetContext(ctx)
+		}
+
+		return nil
+	},
 }
 
 const (
 	spinnerDelay   = 100
 	spinnerCharSet = 11
+
+	defaultGitLabURL = "https://gitlab.com"
+)
+
+// failuresShown is the number of failed groups or projects of each kind listed without --show-errors
+const failuresShown = 5
+
+// values of --count-shared-as
+const (
+	countSharedAsOriginal = "original"
+	countSharedAsShared   = "shared"
+)
+
+// exit codes, distinct for the errors users can act on
+const (
+	exitCodeError         = 1
+	exitCodeGroupNotFound = 2
+	exitCodeAccessDenied  = 3
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(v, buildTime, commit string) {
 	RootCmd.Version = fmt.Sprintf("%s (built %s, commit %s)", v, buildTime, commit)
-	if err := RootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+
+	// cancel in-flight API requests on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	command, err := RootCmd.ExecuteContextC(ctx)
+
+	cancelDeadline()
+	stop()
+
+	// the output file is completed even when the command failed, to keep what was written
+	if reportWriter != nil {
+		err = errors.Join(err, reportWriter.Close())
+	}
+
+	if err != nil {
+		reportError(command, err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode returns the exit code reporting err.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, glclient.ErrGroupNotFound), errors.Is(err, glclient.ErrProjectNotFound),
+		errors.Is(err, glclient.ErrGroupIsProject):
+		return exitCodeGroupNotFound
+	case errors.Is(err, glclient.ErrAccessDenied), errors.Is(err, glclient.ErrAdminRequired):
+		return exitCodeAccessDenied
+	default:
+		return exitCodeError
 	}
 }
 
 func init() {
+	// run the root hooks, which set up the --deadline context, before the hooks of each command
+	cobra.EnableTraverseRunHooks = true
+
+	cobra.OnInitialize(silenceForJSONErrors)
+	RootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		silenceForJSONErrors()
+
+		return err
+	})
+
 	RootCmd.PersistentFlags().StringVar(&format, "format", "table",
-		"Output format: table, json, or csv")
+		"Output format: table, json, csv, xlsx (with --output), template, or dotenv (variable commands only)")
+	RootCmd.PersistentFlags().StringVar(&templateFile, "template", "",
+		"File with a Go text/template rendering the report (used with --format template)")
+	RootCmd.PersistentFlags().StringVar(&templateString, "template-string", "",
+		"Inline Go text/template rendering the report (used with --format template)")
+	RootCmd.MarkFlagsMutuallyExclusive("template", "template-string")
+	RootCmd.PersistentFlags().BoolVar(&envelope, "envelope", false,
+		"Wrap JSON reports in an object with generation metadata instead of a bare array (json format only)")
+	RootCmd.PersistentFlags().BoolVar(&flatten, "flatten", false,
+		"Inline embedded fields so each JSON item is a flat object with the CSV columns as keys (json format only)")
+	RootCmd.PersistentFlags().BoolVar(&tree, "tree", false,
+		"Nest JSON items under their groups, subgroups, and projects instead of a flat array (json format only)")
+	RootCmd.PersistentFlags().StringVar(&tableStyle, "table-style", "",
+		"Style the table format is drawn in: "+strings.Join(output.TableStyles(), ", ")+" (default default)")
+	RootCmd.PersistentFlags().IntVar(&maxColWidth, "max-col-width", 0,
+		"Cut table cells wider than this many characters with an ellipsis (table format only, default no limit)")
+	RootCmd.PersistentFlags().BoolVar(&truncateMiddle, "truncate-middle", false,
+		"Cut the middle of wide table cells instead of their end, keeping both ends of long paths visible "+
+			"(used with --max-col-width)")
+	RootCmd.PersistentFlags().StringVar(&outputFile, "output", "",
+		"Write the report to this file instead of standard output. "+
+			"Without --format, .json, .csv, and .env files select the format matching their extension")
+	RootCmd.PersistentFlags().StringVar(&sqliteFile, "sqlite", "",
+		"Write the report to a table of this SQLite database, created if absent, replacing the rows of an earlier run")
+	RootCmd.PersistentFlags().BoolVar(&gzipOutput, "gzip", false,
+		"Compress the --output file with gzip, appending .gz to its name")
+	RootCmd.PersistentFlags().BoolVar(&appendOutput, "append", false,
+		"Append to the --output file instead of replacing it; the CSV header is written only to an empty file")
+	RootCmd.PersistentFlags().BoolVar(&noHeader, "no-header", false,
+		"Leave out the header row of CSV reports, for appending them to an existing file (csv format only)")
 	RootCmd.PersistentFlags().StringVar(&token, "token", "",
 		"GitLab personal access token (can also be set via GITLAB_TOKEN env var)")
+	RootCmd.PersistentFlags().StringVar(&gitlabURL, "gitlab-url", "",
+		"URL of a self-managed GitLab instance (defaults to https://gitlab.com)")
+	RootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "",
+		"Directory to cache group and project hierarchies in (used only with --cache-ttl or --etag-cache)")
+	RootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0,
+		"How long cached group and project hierarchies are reused, e.g. 15m (default off)")
+	RootCmd.PersistentFlags().BoolVar(&etagCache, "etag-cache", false,
+		"Revalidate group and project responses cached in --cache-dir with ETags, reusing unchanged ones")
+	RootCmd.PersistentFlags().BoolVar(&includeShared, "include-shared-projects", false,
+		"Include projects shared into a group when listing its projects")
+	RootCmd.PersistentFlags().StringVar(&countSharedAs, "count-shared-as", countSharedAsOriginal,
+		"Attribute a shared project to its home namespace (original) or to each group it is shared into "+
+			"(shared, requires --include-shared-projects)")
+	RootCmd.PersistentFlags().StringVar(&topic, "topic", "",
+		"Include only projects carrying this topic in project-based reports")
+	RootCmd.PersistentFlags().BoolVar(&includeUsers, "include-personal-namespaces", false,
+		"Also list projects in user namespaces when no group is given: your own, or every user's for admins")
+	RootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", "",
+		"Report a failure on stderr as text or as a json object with a stable error code (default text)")
+	RootCmd.PersistentFlags().BoolVar(&noVersionCheck, "no-version-check", false,
+		"Skip the warning about commands the GitLab version may not support")
+	RootCmd.PersistentFlags().BoolVar(&strict, "strict", false,
+		"Fail instead of warning when groups or projects are skipped because the token cannot read them")
+	RootCmd.PersistentFlags().BoolVar(&showErrors, "show-errors", false,
+		"List every group or project whose variables or tokens failed to be fetched instead of the first few")
+	RootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 0,
+		"Maximum duration of a single API request, e.g. 30s (default no limit)")
+	RootCmd.PersistentFlags().DurationVar(&groupTimeout, "group-timeout", 0,
+		"Maximum duration of listing the subgroups or projects of a single group, e.g. 2m; "+
+			"slower groups are skipped and reported (default no limit)")
+	RootCmd.PersistentFlags().DurationVar(&deadline, "deadline", 0,
+		"Maximum duration of the whole run, e.g. 10m (default no limit)")
+	RootCmd.PersistentFlags().BoolVar(&partialResults, "partial-on-timeout", false,
+		"Print the data collected so far when --deadline expires instead of failing")
+	RootCmd.PersistentFlags().StringVar(&profileName, "profile", "", profileUsage())
+	RootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", glclient.DefaultProfile().Concurrency,
+		"Maximum number of API requests in flight at once")
+	RootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", glclient.DefaultProfile().RateLimit,
+		"Maximum number of API requests per second, 0 to follow the rate limit headers of the instance")
+	RootCmd.PersistentFlags().IntVar(&pageSize, "page-size", glclient.DefaultProfile().PageSize,
+		"Number of items per page of list requests, at most 100")
+	RootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", glclient.DefaultProfile().MaxRetries,
+		"Maximum number of retries of an API request answered with 429 Too Many Requests or a 5xx error")
+	RootCmd.PersistentFlags().IntVar(&limit, "limit", 0,
+		"Print at most this many items, and stop fetching once as many were collected (default no limit)")
+	RootCmd.PersistentFlags().BoolVar(&sortNatural, "sort-natural", false,
+		"Order items by group and project path naturally, so that sub-group-2 comes before sub-group-10")
+	RootCmd.PersistentFlags().StringVar(&rollupBy, "rollup-by", "",
+		"Print one row per group or project with the number of its report items instead of the items (source)")
+	RootCmd.PersistentFlags().StringVar(&idFormat, "id-format", "",
+		"Identify groups and projects in tables by numeric ID, path, or both "+
+			"(default both for groups, projects, and two-factor, path otherwise)")
+	RootCmd.PersistentFlags().StringVar(&timezone, "timezone", "UTC",
+		"Time zone the report timestamps are shown in, as an IANA name such as Europe/Berlin")
+	RootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", string(output.TimeFormatCustom),
+		"Format of the report timestamps in table, template, and CSV output: custom, rfc3339, or unix")
+	RootCmd.PersistentFlags().StringToStringVar(&linkSuffixes, "link-suffix", nil,
+		"Override the suffix appended to web URLs for table links, as target=suffix, for example "+
+			"group-access-tokens=/-/settings/access_tokens (repeatable)")
+	RootCmd.PersistentFlags().BoolVar(&noLinkSuffixes, "no-link-suffixes", false,
+		"Link table paths to the group or project page instead of its settings pages")
+	RootCmd.PersistentFlags().StringVar(&policyFile, "policy", "",
+		"YAML file of rules the report is checked against; violations are listed on stderr and fail the command")
+	RootCmd.PersistentFlags().StringVar(&instancesFile, "instances", "",
+		"YAML file listing GitLab instances as url, token, and label entries to run the report against "+
+			"concurrently, tagging each item with the label of its instance")
+	RootCmd.PersistentFlags().BoolVar(&verifyURLs, "verify-urls", false,
+		"Check the settings page each row links to with a HEAD request and add its HTTP status as a column "+
+			"(table and csv formats only)")
+	RootCmd.PersistentFlags().BoolVar(&interactive, "interactive", false,
+		"Pick the top-level group to start from when neither --group-id nor --project-id is given "+
+			"(ignored unless run in a terminal)")
+	RootCmd.PersistentFlags().BoolVar(&stripQuery, "strip-query-params", false,
+		"Remove query strings and fragments from the web URLs of groups, projects, and users")
+	RootCmd.PersistentFlags().BoolVar(&normalizePaths, "normalize-paths", false,
+		"Emit paths with forward slashes only and web URLs with consistently escaped path components")
+	RootCmd.PersistentFlags().BoolVar(&redact, "redact", false,
+		"Replace the names, paths, and web URLs of groups, projects, and users with salted hashes")
+	RootCmd.PersistentFlags().BoolVar(&pseudonymize, "pseudonymize-ids", false,
+		"Replace the numeric IDs of groups, projects, users, and tokens with stable salted pseudonyms")
+	RootCmd.PersistentFlags().StringVar(&redactSalt, "redact-salt", "",
+		"Salt the --redact and --pseudonymize-ids hashes with this value to correlate reports across runs "+
+			"(default random per run)")
 	RootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug logging")
 }
 
@@ -65,26 +401,44 @@ func IsDebugEnabled() bool {
 
 // runReportCommand is a generic function to handle common logic for fetching and formatting data.
 func runReportCommand[T any](
-	fetchFunc func(client *glclient.Client, groupID string) ([]T, error),
+	ctx context.Context,
+	fetchFunc func(ctx context.Context, client *glclient.Client, groupID string) ([]T, error),
 	formatFunc func(formatter output.Formatter, data []T) error,
 	tokenErr error,
 	spinnerSuffix string,
 ) error {
+	if instancesFile != "" {
+		return runInstancesReport(ctx, fetchFunc, nil, formatFunc, tokenErr, spinnerSuffix)
+	}
+
 	tokenValue := getToken()
 	if tokenValue == "" {
 		return tokenErr
 	}
 
-	client, err := glclient.NewClient(tokenValue, debug)
+	client, err := newClient(ctx, tokenValue)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
+	// invalid formats and templates are reported before spending time on the fetch
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " " + spinnerSuffix
 	s.Start()
 
-	data, err := fetchFunc(client, groupID)
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
+
+	data, err := fetchFunc(fetchCtx, client, groupID)
 
 	s.Stop()
 
@@ -92,18 +446,312 @@ func runReportCommand[T any](
 		return fmt.Errorf("failed to fetch data: %w", err)
 	}
 
-	formatter, err := output.NewFormatter(output.Format(format))
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	violations, err := checkPolicy(data)
 	if err != nil {
-		return fmt.Errorf("invalid output format: %w", err)
+		return err
 	}
 
-	if err := formatFunc(formatter, data); err != nil {
+	err = formatReport(formatter, data, func(items []T) error { return formatFunc(formatter, items) })
+	if err != nil {
 		return fmt.Errorf("failed to format data: %w", err)
 	}
 
+	return reportViolations(violations)
+}
+
+// reportIncomplete warns on stderr when a fetch returned partial results after --deadline expired,
+// about the groups and projects whose fetch failed, and about those it skipped because the token
+// cannot read them or the request timed out. With --strict, skipped resources fail the command instead.
+func reportIncomplete(ctx context.Context, client *glclient.Client) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Fprintf(os.Stderr, "Warning: the deadline of %s expired, the report is incomplete\n", deadline)
+	}
+
+	reportFailures(client)
+
+	skipped := client.Inaccessible()
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: %d inaccessible resources were skipped, the report is incomplete:\n", len(skipped))
+
+	for _, resource := range skipped {
+		fmt.Fprintf(os.Stderr, "  %s %s (%s)\n", resource.Kind, resource.Path, resource.Reason)
+	}
+
+	if strict {
+		return fmt.Errorf("%w: %d inaccessible resources were skipped", ErrIncompleteReport, len(skipped))
+	}
+
 	return nil
 }
 
+// reportFailures warns on stderr about the groups and projects whose fetch failed for reasons other
+// than access, such as server errors, listing the first few of each kind unless --show-errors is set.
+func reportFailures(client *glclient.Client) {
+	shown := failuresShown
+	if showErrors {
+		shown = 0
+	}
+
+	truncated := false
+
+	for _, summary := range report.SummarizeFailures(client.Failures(), client.Attempted()) {
+		fmt.Fprint(os.Stderr, "Warning: "+summary.Format(shown))
+
+		truncated = truncated || summary.Truncated(shown)
+	}
+
+	if truncated {
+		fmt.Fprintln(os.Stderr, "Use --show-errors to list every failure")
+	}
+}
+
+// openReportFile opens the --output file, if any, truncating it or appending to it with --append.
+func openReportFile() error {
+	var err error
+
+	switch {
+	case sqliteFile != "":
+		reportWriter, err = output.OpenDatabase(sqliteFile)
+	case outputFile == "":
+		return nil
+	case appendOutput:
+		reportWriter, _, reportAppended, err = output.AppendFile(outputFile, gzipOutput)
+	default:
+		reportWriter, _, err = output.CreateFile(outputFile, gzipOutput)
+	}
+
+	// the worksheets of an xlsx report are written to the file as a workbook once it is closed
+	if err == nil && output.Format(format) == output.FormatXLSX {
+		reportWriter = output.NewWorkbook(reportWriter)
+	}
+
+	return err
+}
+
+// checkXLSX validates the flags of the xlsx format, whose workbook is written to a new file.
+func checkXLSX() error {
+	if output.Format(format) != output.FormatXLSX {
+		return nil
+	}
+
+	if outputFile == "" {
+		return ErrXLSXRequiresOutput
+	}
+
+	if appendOutput {
+		return ErrXLSXAppend
+	}
+
+	return nil
+}
+
+// checkSQLite validates --sqlite, which selects the sqlite format and writes to the database instead
+// of a file.
+func checkSQLite(command *cobra.Command) error {
+	if sqliteFile == "" {
+		return nil
+	}
+
+	if outputFile != "" || command.Flags().Changed("format") {
+		return ErrSQLiteOutput
+	}
+
+	format = string(output.FormatSQLite)
+
+	return nil
+}
+
+// newClient creates a GitLab client with the options selected by the global flags, followed by opts,
+// and warns when the GitLab version may not support some commands.
+func newClient(ctx context.Context, token string, opts ...glclient.Option) (*glclient.Client, error) {
+	client, err := glclient.NewClient(token, debug, append(clientOptions(), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !noVersionCheck {
+		warnUnsupportedVersion(ctx, client)
+	}
+
+	return client, nil
+}
+
+// checkCountSharedAs validates --count-shared-as, which only attributes projects to the groups they
+// are shared into when those projects are listed at all.
+func checkCountSharedAs() error {
+	switch countSharedAs {
+	case countSharedAsOriginal:
+		return nil
+	case countSharedAsShared:
+		if !includeShared {
+			return ErrCountSharedRequiresShared
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidCountSharedAs, countSharedAs)
+	}
+}
+
+// clientOptions returns the client options selected by the global flags.
+func clientOptions() []glclient.Option {
+	opts := []glclient.Option{glclient.WithProfile(apiProfile)}
+	if gitlabURL != "" {
+		opts = append(opts, glclient.WithBaseURL(gitlabURL))
+	}
+
+	if requestTimeout > 0 {
+		opts = append(opts, glclient.WithRequestTimeout(requestTimeout))
+	}
+
+	if groupTimeout > 0 {
+		opts = append(opts, glclient.WithGroupTimeout(groupTimeout))
+	}
+
+	if partialResults {
+		opts = append(opts, glclient.WithPartialResults())
+	}
+
+	if cacheDir != "" && cacheTTL > 0 {
+		opts = append(opts, glclient.WithCache(cache.New(cacheDir, cacheTTL)))
+	}
+
+	if etagCache {
+		// GitLab decides whether an entry is still current, so entries never expire
+		opts = append(opts, glclient.WithETagCache(cache.New(cacheDir, 0)))
+	}
+
+	if includeShared {
+		opts = append(opts, glclient.WithSharedProjects())
+	}
+
+	if countSharedAs == countSharedAsShared {
+		opts = append(opts, glclient.WithSharedAttribution())
+	}
+
+	if includeUsers {
+		opts = append(opts, glclient.WithPersonalNamespaces())
+	}
+
+	if topic != "" {
+		opts = append(opts, glclient.WithTopic(topic))
+	}
+
+	if stripQuery {
+		opts = append(opts, glclient.WithStrippedQueryParams())
+	}
+
+	if sortNatural {
+		opts = append(opts, glclient.WithNaturalSort())
+	}
+
+	return opts
+}
+
+// formatterOptions returns the formatter options selected by the global flags. With --verify-urls,
+// links are checked through client within ctx.
+func formatterOptions(ctx context.Context, client *glclient.Client) []output.Option {
+	opts := []output.Option{output.WithTimezone(reportLocation), output.WithTimeFormat(reportTimeFormat)}
+	if templateFile != "" {
+		opts = append(opts, output.WithTemplateFile(templateFile))
+	}
+
+	if reportWriter != nil {
+		opts = append(opts, output.WithWriter(reportWriter))
+	}
+
+	if noHeader || reportAppended {
+		opts = append(opts, output.WithoutHeader())
+	}
+
+	if templateString != "" {
+		opts = append(opts, output.WithTemplate(templateString))
+	}
+
+	if normalizePaths {
+		opts = append(opts, output.WithNormalizedPaths())
+	}
+
+	if redact {
+		opts = append(opts, output.WithRedaction(redactSalt))
+	}
+
+	if pseudonymize {
+		opts = append(opts, output.WithPseudonymousIDs(redactSalt))
+	}
+
+	if idFormat != "" {
+		opts = append(opts, output.WithIDFormat(output.IDFormat(idFormat)))
+	}
+
+	if len(linkSuffixes) > 0 {
+		suffixes := make(map[output.LinkTarget]string, len(linkSuffixes))
+		for target, suffix := range linkSuffixes {
+			suffixes[output.LinkTarget(target)] = suffix
+		}
+
+		opts = append(opts, output.WithLinkSuffixes(suffixes))
+	}
+
+	if noLinkSuffixes {
+		opts = append(opts, output.WithoutLinkSuffixes())
+	}
+
+	if verifyURLs {
+		opts = append(opts, output.WithURLVerifier(func(url string) (int, error) {
+			return client.VerifyURL(ctx, url)
+		}))
+	}
+
+	if includeDescription {
+		opts = append(opts, output.WithDescriptions(descriptionWidth))
+	}
+
+	if flatten {
+		opts = append(opts, output.WithFlattening())
+	}
+
+	if tree {
+		opts = append(opts, output.WithTree())
+	}
+
+	if tableStyle != "" {
+		opts = append(opts, output.WithTableStyle(tableStyle))
+	}
+
+	if maxColWidth > 0 {
+		opts = append(opts, output.WithMaxColumnWidth(maxColWidth, truncateMiddle))
+	}
+
+	if envelope {
+		opts = append(opts, output.WithEnvelopeFunc(reportMetadata))
+	}
+
+	return opts
+}
+
+// reportMetadata describes the report for --envelope. It is called when the report is written, so
+// that it carries the group picked with --interactive and the time the data was gathered.
+func reportMetadata() output.Metadata {
+	baseURL := gitlabURL
+	if baseURL == "" {
+		baseURL = defaultGitLabURL
+	}
+
+	return output.Metadata{
+		GeneratedAt: time.Now().UTC(),
+		GitLabURL:   baseURL,
+		RootGroup:   groupID,
+	}
+}
+
 func getToken() string {
 	if token != "" {
 		return token
@@ -111,3 +759,16 @@ func getToken() string {
 
 	return os.Getenv("GITLAB_TOKEN")
 }
+
+// projectIDs returns the projects given to --project-id, which accepts a comma-separated list.
+func projectIDs() []string {
+	var ids []string
+
+	for id := range strings.SplitSeq(projectID, ",") {
+		if id = strings.Trim(strings.TrimSpace(id), "/"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var deviatingRunnerSettingsOnly bool
+
+var runnerSettingsCmd = &cobra.Command{
+	Use:   "runner-settings",
+	Short: "Fetches and displays whether projects use shared and group runners",
+	Long: `Fetches and displays whether GitLab projects run jobs on shared runners and on the runners of
+their groups, next to the shared runners setting of the group each project belongs to.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+With --deviating-only, only projects that override the default of their group are listed.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runRunnerSettings,
+}
+
+func init() {
+	runnerSettingsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	runnerSettingsCmd.Flags().BoolVar(&deviatingRunnerSettingsOnly, "deviating-only", false,
+		"List only projects whose shared or group runners setting differs from their group's default")
+
+	filterAfterFetch(runnerSettingsCmd, "deviating-only")
+	supportInstances(runnerSettingsCmd)
+	RootCmd.AddCommand(runnerSettingsCmd)
+}
+
+func runRunnerSettings(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectRunnerSettings, error) {
+			settings, err := client.GetRunnerSettingsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if deviatingRunnerSettingsOnly {
+				return report.FilterDeviatingRunnerSettings(settings), nil
+			}
+
+			return settings, nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectRunnerSettings) error {
+			return formatter.FormatRunnerSettings(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching runner settings...",
+	)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sharedRunnersOnly   bool
+	specificRunnersOnly bool
+)
+
+var runnersCmd = &cobra.Command{
+	Use:   "runners",
+	Short: "Fetches and displays the CI/CD runners available to projects",
+	Long: `Fetches and displays how many CI/CD runners of each type are available to GitLab projects:
+shared runners of the instance, such as the GitLab-hosted runners on gitlab.com, and group and
+project runners hosted for the project itself. Projects with group or project runners run on
+specific runners; the others rely on shared runners, or have no runners at all.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Listing the runners of a project requires at least the Maintainer role on it.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runRunners,
+}
+
+func init() {
+	runnersCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	runnersCmd.Flags().BoolVar(&sharedRunnersOnly, "shared-only", false,
+		"List only projects relying on shared runners, without group or project runners")
+	runnersCmd.Flags().BoolVar(&specificRunnersOnly, "specific-only", false,
+		"List only projects with group or project runners of their own")
+	runnersCmd.MarkFlagsMutuallyExclusive("shared-only", "specific-only")
+
+	filterAfterFetch(runnersCmd, "shared-only", "specific-only")
+	supportInstances(runnersCmd)
+	RootCmd.AddCommand(runnersCmd)
+}
+
+func runRunners(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectRunners, error) {
+			runners, err := client.GetProjectRunnersRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return report.FilterByRunnerReliance(runners, runnerReliance()), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectRunners) error {
+			return formatter.FormatProjectRunners(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching project runners...",
+	)
+}
+
+// runnerReliance returns the reliance selected by --shared-only or --specific-only, or an empty
+// string to keep all projects.
+func runnerReliance() string {
+	switch {
+	case sharedRunnersOnly:
+		return glclient.RunnerRelianceShared
+	case specificRunnersOnly:
+		return glclient.RunnerRelianceSpecific
+	default:
+		return ""
+	}
+}
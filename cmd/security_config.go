@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var missingSASTOnly bool
+
+var securityConfigCmd = &cobra.Command{
+	Use:   "security-config",
+	Short: "Fetches and displays the security scanners enabled for projects",
+	Long: `Fetches and displays whether SAST, DAST, and dependency scanning run on the default branch of
+GitLab projects, judged like GitLab's security configuration page by the reports of the jobs of the
+latest pipeline, and whether secret push protection is enabled where the instance's license allows.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+With --missing-sast-only, only projects not running SAST are listed.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runSecurityConfig,
+}
+
+func init() {
+	securityConfigCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	securityConfigCmd.Flags().BoolVar(&missingSASTOnly, "missing-sast-only", false,
+		"List only projects whose default branch does not run SAST")
+
+	filterAfterFetch(securityConfigCmd, "missing-sast-only")
+	supportInstances(securityConfigCmd)
+	RootCmd.AddCommand(securityConfigCmd)
+}
+
+func runSecurityConfig(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectSecurityConfig, error) {
+			configs, err := client.GetSecurityConfigsRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			if missingSASTOnly {
+				return report.FilterMissingSAST(configs), nil
+			}
+
+			return configs, nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectSecurityConfig) error {
+			return formatter.FormatSecurityConfigs(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching security configuration...",
+	)
+}
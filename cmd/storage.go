@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var largerThan string
+
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Fetches and displays the storage used by projects",
+	Long: `Fetches and displays the repository, LFS, job artifacts, and total storage of GitLab projects,
+largest first. If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.
+Reading project statistics requires at least the Reporter role on each project.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runStorage,
+}
+
+func init() {
+	storageCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+	storageCmd.Flags().StringVar(&largerThan, "larger-than", "0",
+		"List only projects using at least this much storage in total, e.g. 500MB or 1.5GiB")
+
+	supportInstances(storageCmd)
+	RootCmd.AddCommand(storageCmd)
+}
+
+func runStorage(command *cobra.Command, _ []string) error {
+	minSize, err := report.ParseSize(largerThan)
+	if err != nil {
+		return err
+	}
+
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectStorage, error) {
+			storage, err := client.GetProjectStorageRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			return report.LargestProjects(storage, minSize), nil
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectStorage) error {
+			return formatter.FormatProjectStorage(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching project storage...",
+	)
+}
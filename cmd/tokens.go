@@ -1,18 +1,50 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/spf13/cobra"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var ErrInvalidTokenState = errors.New("invalid --state, use active, inactive, or all")
+
+var (
+	tokenState     string
+	sortBy         string
+	sortOrder      string
+	minAccessLevel string
+	unusedFor      time.Duration
+	neverUsed      bool
+	scopeSummary   bool
+	resolveUsers   bool
+	resolveIPs     bool
+	usedFromCIDR   string
+	nameRegex      string
+	tokenPrefix    string
 )
 
 var tokensCmd = &cobra.Command{
 	Use:   "tokens",
 	Short: "Manage tokens operations",
 	Long:  `Manage various token operations for GitLab groups and projects.`,
-	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+	PersistentPreRunE: func(command *cobra.Command, _ []string) error {
 		groupID = strings.Trim(groupID, "/")
 		projectID = strings.Trim(projectID, "/")
+
+		if err := checkBaseline(); err != nil {
+			return err
+		}
+
+		return applyAutoDetect(command)
 	},
 }
 
@@ -24,10 +56,203 @@ func init() {
 
 	tokensCmd.PersistentFlags().StringVar(&projectID, "project-id", "",
 		"The ID or path of a GitLab project to fetch tokens for. "+
-			"Can be a numeric ID or a path with namespace (org/subgroup/project).")
+			"Can be a numeric ID or a path with namespace (org/subgroup/project). "+
+			"Separate several projects with commas.")
+
+	tokensCmd.PersistentFlags().BoolVar(&autoDetect, "auto-detect", false, autoDetectUsage)
+
+	tokensCmd.PersistentFlags().StringVar(&baselineFile, "baseline", "", baselineUsage)
+
+	tokensCmd.MarkFlagsMutuallyExclusive("group-id", "project-id", "auto-detect")
 
 	RootCmd.AddCommand(tokensCmd)
 	tokensCmd.AddCommand(gatCmd)
 	tokensCmd.AddCommand(patCmd)
 	tokensCmd.AddCommand(pttCmd)
+	tokensCmd.AddCommand(impersonationCmd)
+
+	for _, command := range []*cobra.Command{gatCmd, patCmd} {
+		command.Flags().StringVar(&tokenState, "state", string(glclient.TokenStateActive),
+			"List only tokens in this state: active, inactive (revoked or expired), or all")
+		command.Flags().StringVar(&sortBy, "sort-by", string(report.SortByExpiresAt),
+			"Sort tokens by expires-at, created-at, name, or path")
+		command.Flags().StringVar(&sortOrder, "sort-order", string(report.Ascending),
+			"Sort order: asc or desc")
+		command.Flags().StringVar(&minAccessLevel, "min-access-level", "",
+			"List only tokens with at least this role, e.g. maintainer, or numeric access level, e.g. 40")
+		command.Flags().DurationVar(&unusedFor, "unused-for", 0,
+			"List only tokens not used for at least this long, e.g. 2160h for 90 days")
+		command.Flags().BoolVar(&neverUsed, "include-never-used", false,
+			"Also list tokens that were never used (used only with --unused-for)")
+		command.Flags().BoolVar(&scopeSummary, "scope-summary", false,
+			"Print how many of the listed tokens carry each scope to stderr after the report")
+		command.Flags().BoolVar(&resolveUsers, "resolve-users", false,
+			"Look up the username of each token's bot user, one request per distinct user")
+		command.Flags().BoolVar(&resolveIPs, "resolve-ips", false,
+			"Look up the IP addresses each token was last used from where GitLab reports them, one request per token")
+		command.Flags().StringVar(&usedFromCIDR, "used-from-cidr", "",
+			"List only tokens last used from an address in this network, e.g. 203.0.113.0/24 (implies --resolve-ips)")
+		filterAfterFetch(command, "min-access-level", "unused-for", "used-from-cidr")
+		sortAfterFetch(command)
+	}
+
+	for _, command := range []*cobra.Command{gatCmd, patCmd, pttCmd, impersonationCmd} {
+		command.Flags().StringVar(&nameRegex, "name-regex", "",
+			"List only tokens whose name, or trigger description, matches this regular expression (Go RE2 syntax)")
+		filterAfterFetch(command, "name-regex")
+	}
+
+	pttCmd.Flags().StringVar(&tokenPrefix, "token-prefix", "",
+		"List only triggers whose token starts with this prefix, e.g. glptt-")
+	filterAfterFetch(pttCmd, "token-prefix")
+	filterAfterFetch(impersonationCmd, "expiring-within")
+}
+
+// tokenSort returns the token order selected by --sort-by and --sort-order.
+// By default tokens that expire soonest come first and tokens that never expire come last.
+func tokenSort() (report.TokenSort, error) {
+	sort := report.TokenSort{
+		By:      report.TokenSortField(sortBy),
+		Order:   report.SortOrder(sortOrder),
+		Natural: sortNatural,
+	}
+	if err := sort.Validate(); err != nil {
+		return report.TokenSort{}, fmt.Errorf("invalid token sort: %w", err)
+	}
+
+	return sort, nil
+}
+
+// tokenStateFilter returns the token state selected by --state, or all tokens with --include-inactive.
+func tokenStateFilter(includeInactive bool) (glclient.TokenState, error) {
+	if includeInactive {
+		return glclient.TokenStateAll, nil
+	}
+
+	switch state := glclient.TokenState(strings.ToLower(tokenState)); state {
+	case glclient.TokenStateActive, glclient.TokenStateInactive, glclient.TokenStateAll:
+		return state, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidTokenState, tokenState)
+	}
+}
+
+// tokenNetwork returns the network selected by --used-from-cidr, or no network when it is not given.
+func tokenNetwork() (netip.Prefix, error) {
+	if usedFromCIDR == "" {
+		return netip.Prefix{}, nil
+	}
+
+	network, err := report.ParseCIDR(usedFromCIDR)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid --used-from-cidr: %w", err)
+	}
+
+	return network, nil
+}
+
+// tokenAccessLevel returns the minimum access level selected by --min-access-level,
+// or no minimum when it is not given.
+func tokenAccessLevel() (gitlab.AccessLevelValue, error) {
+	if minAccessLevel == "" {
+		return gitlab.NoPermissions, nil
+	}
+
+	level, err := report.ParseAccessLevel(minAccessLevel)
+	if err != nil {
+		return gitlab.NoPermissions, fmt.Errorf("invalid --min-access-level: %w", err)
+	}
+
+	return level, nil
+}
+
+// tokenNameMatcher returns the matcher selected by --name-regex, matching every name when it is not
+// given.
+func tokenNameMatcher() (report.NameMatcher, error) {
+	match, err := report.NameRegex(nameRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --name-regex: %w", err)
+	}
+
+	return match, nil
+}
+
+func groupTokenAccessLevel(token *glclient.GroupAccessTokenWithGroup) gitlab.AccessLevelValue {
+	return token.AccessLevel
+}
+
+func projectTokenAccessLevel(token *glclient.ProjectAccessTokenWithProject) gitlab.AccessLevelValue {
+	return token.AccessLevel
+}
+
+func groupTokenScopes(token *glclient.GroupAccessTokenWithGroup) []string {
+	return token.Scopes
+}
+
+func projectTokenScopes(token *glclient.ProjectAccessTokenWithProject) []string {
+	return token.Scopes
+}
+
+// filterUsedFrom keeps the tokens last used from an address in network. Without --resolve-ips or
+// --used-from-cidr the addresses are not looked up and all tokens are kept. When GitLab reported no
+// addresses for any token, a warning tells that the filter could not apply.
+func filterUsedFrom[T any](tokens []T, network netip.Prefix, resolve func([]T), ips func(T) []string) []T {
+	if !resolveIPs && !network.IsValid() {
+		return tokens
+	}
+
+	resolve(tokens)
+
+	if network.IsValid() && len(tokens) > 0 && !slices.ContainsFunc(tokens, func(t T) bool { return ips(t) != nil }) {
+		fmt.Fprintln(os.Stderr, "Warning: GitLab does not report the IP addresses tokens were last used from, "+
+			"no token matches --used-from-cidr")
+	}
+
+	return report.FilterUsedFrom(tokens, network, ips)
+}
+
+// printScopeSummary writes the number of tokens carrying each scope to stderr when --scope-summary
+// is set, keeping the report on stdout machine-readable.
+func printScopeSummary[T any](tokens []T, scopes func(T) []string) {
+	if !scopeSummary {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, report.FormatScopeSummary(len(tokens), report.TallyScopes(tokens, scopes)))
+}
+
+func groupTokenLastUsed(token *glclient.GroupAccessTokenWithGroup) *time.Time {
+	return token.LastUsedAt
+}
+
+func projectTokenLastUsed(token *glclient.ProjectAccessTokenWithProject) *time.Time {
+	return token.LastUsedAt
+}
+
+func groupTokenName(token *glclient.GroupAccessTokenWithGroup) string {
+	return token.Name
+}
+
+func projectTokenName(token *glclient.ProjectAccessTokenWithProject) string {
+	return token.Name
+}
+
+func groupTokenIPs(token *glclient.GroupAccessTokenWithGroup) []string {
+	return token.LastUsedIPs
+}
+
+func projectTokenIPs(token *glclient.ProjectAccessTokenWithProject) []string {
+	return token.LastUsedIPs
+}
+
+func triggerDescription(trigger *glclient.PipelineTriggerWithProject) string {
+	return trigger.Description
+}
+
+func triggerToken(trigger *glclient.PipelineTriggerWithProject) string {
+	return trigger.Token
+}
+
+func impersonationTokenName(token *glclient.ImpersonationTokenWithUser) string {
+	return token.Name
 }
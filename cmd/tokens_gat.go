@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -19,55 +21,182 @@ var gatCmd = &cobra.Command{
 	Use:     "gat",
 	Aliases: []string{"group-access-tokens"},
 	Short:   "Fetches and displays group access tokens",
-	Long:    `Fetches and displays group access tokens for the specified GitLab group.`,
-	RunE:    runGAT,
+	Long: `Fetches and displays group access tokens for the specified GitLab group.
+
+With --with-parents and a single --project-id, the tokens of the groups the project inherits from
+are listed instead.`,
+	RunE: runGAT,
 }
 
 func init() {
-	gatCmd.Flags().BoolVar(&includeInactiveGAT, "include-inactive", false, "Include inactive tokens in the output")
+	gatCmd.Flags().BoolVar(&includeInactiveGAT, "include-inactive", false,
+		"Include inactive tokens in the output, like --state all")
 	gatCmd.Flags().BoolVar(&fetchAll, "all", true, "Fetch tokens from all subgroups")
+	gatCmd.Flags().BoolVar(&withParents, "with-parents", false, withParentsUsage)
+	gatCmd.MarkFlagsMutuallyExclusive("state", "include-inactive")
+	supportInstances(gatCmd)
 }
 
-func runGAT(_ *cobra.Command, _ []string) error {
+func runGAT(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	state, err := tokenStateFilter(includeInactiveGAT)
+	if err != nil {
+		return err
+	}
+
+	sort, err := tokenSort()
+	if err != nil {
+		return err
+	}
+
+	minLevel, err := tokenAccessLevel()
+	if err != nil {
+		return err
+	}
+
+	nameMatch, err := tokenNameMatcher()
+	if err != nil {
+		return err
+	}
+
+	network, err := tokenNetwork()
+	if err != nil {
+		return err
+	}
+
+	var parentsOf string
+	if withParents {
+		if parentsOf, err = parentsProjectID(); err != nil {
+			return err
+		}
+	}
+
+	// the tokens of each instance are filtered with the client that fetched them, and sorted once
+	// all instances answered
+	filter := func(
+		ctx context.Context,
+		client *glclient.Client,
+		tokens []*glclient.GroupAccessTokenWithGroup,
+	) []*glclient.GroupAccessTokenWithGroup {
+		tokens = report.FilterByAccessLevel(tokens, minLevel, groupTokenAccessLevel)
+		tokens = report.FilterUnused(tokens, unusedFor, neverUsed, time.Now(), groupTokenLastUsed)
+		tokens = report.FilterByName(tokens, nameMatch, groupTokenName)
+		tokens = filterUsedFrom(tokens, network, func(tokens []*glclient.GroupAccessTokenWithGroup) {
+			client.ResolveGroupTokenIPs(ctx, tokens)
+		}, groupTokenIPs)
+
+		if resolveUsers {
+			client.ResolveGroupTokenUsers(ctx, tokens)
+		}
+
+		return tokens
+	}
+
+	if instancesFile != "" {
+		return runInstancesReport(ctx,
+			func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.GroupAccessTokenWithGroup, error) {
+				tokens, err := fetchGroupTokens(ctx, client, groupID, "", state)
+				if err != nil {
+					return nil, err
+				}
+
+				return filter(ctx, client, tokens), nil
+			},
+			func(tokens []*glclient.GroupAccessTokenWithGroup) { report.SortGroupAccessTokens(tokens, sort) },
+			func(formatter output.Formatter, tokens []*glclient.GroupAccessTokenWithGroup) error {
+				if err := formatter.FormatGroupAccessTokens(tokens); err != nil {
+					return err
+				}
+
+				printScopeSummary(tokens, groupTokenScopes)
+
+				return nil
+			},
+			ErrGitLabTokenRequired,
+			"Fetching group access tokens...",
+		)
+	}
+
 	tokenValue := getToken()
 	if tokenValue == "" {
 		return ErrGitLabTokenRequired
 	}
 
-	client, err := glclient.NewClient(tokenValue, debug)
+	client, err := newClient(ctx, tokenValue)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " Fetching group access tokens..."
 	s.Start()
 
-	var tokens []*glclient.GroupAccessTokenWithGroup
-	if fetchAll {
-		tokens, err = client.GetGroupAccessTokensRecursively(groupID, includeInactiveGAT)
-	} else {
-		tokens, err = client.GetGroupAccessTokens(groupID, includeInactiveGAT)
-	}
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
+
+	tokens, err := fetchGroupTokens(fetchCtx, client, groupID, parentsOf, state)
 
 	s.Stop()
 
 	if err != nil {
-		if fetchAll {
-			return fmt.Errorf("failed to fetch group access tokens recursively: %w", err)
-		}
+		return err
+	}
 
-		return fmt.Errorf("failed to fetch group access tokens: %w", err)
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
 	}
 
-	formatter, err := output.NewFormatter(output.Format(format))
+	tokens = filter(ctx, client, tokens)
+	report.SortGroupAccessTokens(tokens, sort)
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
 	if err != nil {
 		return fmt.Errorf("invalid output format: %w", err)
 	}
 
-	if err := formatter.FormatGroupAccessTokens(tokens); err != nil {
+	if err := formatOrDiff(formatter, tokens, report.GroupAccessTokens, formatter.FormatGroupAccessTokens); err != nil {
 		return fmt.Errorf("failed to format group access tokens: %w", err)
 	}
 
+	printScopeSummary(tokens, groupTokenScopes)
+
 	return nil
 }
+
+// fetchGroupTokens fetches the group access tokens of the groups parentsOf inherits from with
+// --with-parents, or else of groupID and, with --all, its subgroups.
+func fetchGroupTokens(
+	ctx context.Context,
+	client *glclient.Client,
+	groupID, parentsOf string,
+	state glclient.TokenState,
+) ([]*glclient.GroupAccessTokenWithGroup, error) {
+	switch {
+	case withParents:
+		tokens, err := client.GetAncestorGroupAccessTokens(ctx, parentsOf, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch group access tokens of parent groups: %w", err)
+		}
+
+		return tokens, nil
+	case fetchAll:
+		tokens, err := client.GetGroupAccessTokensRecursively(ctx, groupID, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch group access tokens recursively: %w", err)
+		}
+
+		return tokens, nil
+	default:
+		tokens, err := client.GetGroupAccessTokens(ctx, groupID, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch group access tokens: %w", err)
+		}
+
+		return tokens, nil
+	}
+}
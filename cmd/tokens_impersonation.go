@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	includeInactiveImpersonation bool
+	expiringWithin               time.Duration
+)
+
+var impersonationCmd = &cobra.Command{
+	Use:   "impersonation",
+	Short: "Fetches and displays impersonation tokens (admin only)",
+	Long: `Fetches and displays the impersonation tokens of all users of the instance.
+Impersonation tokens are created by administrators and act as the user they belong to.
+Listing them requires an administrator token.`,
+	RunE: runImpersonation,
+}
+
+func init() {
+	impersonationCmd.Flags().BoolVar(&includeInactiveImpersonation, "include-inactive", false,
+		"Include inactive tokens in the output")
+	impersonationCmd.Flags().DurationVar(&expiringWithin, "expiring-within", 0,
+		"List only tokens expiring within this long, e.g. 720h for 30 days, including expired tokens")
+
+	// impersonation tokens belong to users, not to groups or projects
+	impersonationCmd.SetHelpFunc(func(command *cobra.Command, args []string) {
+		for _, name := range []string{"group-id", "project-id", "auto-detect"} {
+			if err := command.InheritedFlags().MarkHidden(name); err != nil {
+				fmt.Fprint(os.Stderr, err)
+			}
+		}
+		command.Parent().HelpFunc()(command, args)
+	})
+}
+
+func runImpersonation(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	nameMatch, err := tokenNameMatcher()
+	if err != nil {
+		return err
+	}
+
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " Fetching impersonation tokens..."
+	s.Start()
+
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
+
+	tokens, err := client.GetImpersonationTokens(fetchCtx, includeInactiveImpersonation)
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch impersonation tokens: %w", err)
+	}
+
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	tokens = report.FilterExpiringWithin(tokens, expiringWithin, time.Now(), impersonationTokenExpiresAt)
+	tokens = report.FilterByName(tokens, nameMatch, impersonationTokenName)
+
+	err = formatOrDiff(formatter, tokens, report.ImpersonationTokens, formatter.FormatImpersonationTokens)
+	if err != nil {
+		return fmt.Errorf("failed to format impersonation tokens: %w", err)
+	}
+
+	return nil
+}
+
+func impersonationTokenExpiresAt(token *glclient.ImpersonationTokenWithUser) *time.Time {
+	if token.ExpiresAt == nil {
+		return nil
+	}
+
+	expiresAt := time.Time(*token.ExpiresAt)
+
+	return &expiresAt
+}
@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -18,33 +20,115 @@ var patCmd = &cobra.Command{
 	Short:   "Fetches and displays project access tokens",
 	Long: `Fetches and displays project access tokens. You can:
 - Specify a group ID to fetch tokens from all projects in that group recursively
-- Specify a project ID to fetch tokens from a single project
+- Specify one or more comma-separated project IDs to fetch tokens from those projects only
 - Specify neither to fetch tokens from all accessible groups`,
 	RunE: runPAT,
 }
 
 func init() {
 	patCmd.Flags().BoolVar(&includeInactivePAT, "include-inactive", false,
-		"Include inactive tokens in the output")
+		"Include inactive tokens in the output, like --state all")
 	patCmd.MarkFlagsMutuallyExclusive("group-id", "project-id")
+	patCmd.MarkFlagsMutuallyExclusive("state", "include-inactive")
+	supportInstances(patCmd)
 }
 
-func runPAT(_ *cobra.Command, _ []string) error {
+func runPAT(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	state, err := tokenStateFilter(includeInactivePAT)
+	if err != nil {
+		return err
+	}
+
+	sort, err := tokenSort()
+	if err != nil {
+		return err
+	}
+
+	minLevel, err := tokenAccessLevel()
+	if err != nil {
+		return err
+	}
+
+	nameMatch, err := tokenNameMatcher()
+	if err != nil {
+		return err
+	}
+
+	network, err := tokenNetwork()
+	if err != nil {
+		return err
+	}
+
+	// the tokens of each instance are filtered with the client that fetched them, and sorted once
+	// all instances answered
+	filter := func(
+		ctx context.Context,
+		client *glclient.Client,
+		tokens []*glclient.ProjectAccessTokenWithProject,
+	) []*glclient.ProjectAccessTokenWithProject {
+		tokens = report.FilterByAccessLevel(tokens, minLevel, projectTokenAccessLevel)
+		tokens = report.FilterUnused(tokens, unusedFor, neverUsed, time.Now(), projectTokenLastUsed)
+		tokens = report.FilterByName(tokens, nameMatch, projectTokenName)
+		tokens = filterUsedFrom(tokens, network, func(tokens []*glclient.ProjectAccessTokenWithProject) {
+			client.ResolveProjectTokenIPs(ctx, tokens)
+		}, projectTokenIPs)
+
+		if resolveUsers {
+			client.ResolveProjectTokenUsers(ctx, tokens)
+		}
+
+		return tokens
+	}
+
+	if instancesFile != "" {
+		return runInstancesReport(ctx,
+			func(ctx context.Context, client *glclient.Client, _ string) ([]*glclient.ProjectAccessTokenWithProject, error) {
+				tokens, err := fetchTokens(ctx, client, state)
+				if err != nil {
+					return nil, err
+				}
+
+				return filter(ctx, client, tokens), nil
+			},
+			func(tokens []*glclient.ProjectAccessTokenWithProject) { report.SortProjectAccessTokens(tokens, sort) },
+			func(formatter output.Formatter, tokens []*glclient.ProjectAccessTokenWithProject) error {
+				if err := formatter.FormatProjectAccessTokens(tokens); err != nil {
+					return err
+				}
+
+				printScopeSummary(tokens, projectTokenScopes)
+
+				return nil
+			},
+			ErrGitLabTokenRequired,
+			"Fetching project access tokens...",
+		)
+	}
+
 	tokenValue := getToken()
 	if tokenValue == "" {
 		return ErrGitLabTokenRequired
 	}
 
-	client, err := glclient.NewClient(tokenValue, debug)
+	client, err := newClient(ctx, tokenValue)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " Fetching project access tokens..."
 	s.Start()
 
-	tokens, err := fetchTokens(client)
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
+
+	tokens, err := fetchTokens(fetchCtx, client, state)
 
 	s.Stop()
 
@@ -52,26 +136,40 @@ func runPAT(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
-	formatter, err := output.NewFormatter(output.Format(format))
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	tokens = filter(ctx, client, tokens)
+	report.SortProjectAccessTokens(tokens, sort)
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
 	if err != nil {
 		return fmt.Errorf("invalid output format: %w", err)
 	}
 
-	if err := formatter.FormatProjectAccessTokens(tokens); err != nil {
+	err = formatOrDiff(formatter, tokens, report.ProjectAccessTokens, formatter.FormatProjectAccessTokens)
+	if err != nil {
 		return fmt.Errorf("failed to format project access tokens: %w", err)
 	}
 
+	printScopeSummary(tokens, projectTokenScopes)
+
 	return nil
 }
 
-func fetchTokens(client *glclient.Client) ([]*glclient.ProjectAccessTokenWithProject, error) {
+func fetchTokens(
+	ctx context.Context,
+	client *glclient.Client,
+	state glclient.TokenState,
+) ([]*glclient.ProjectAccessTokenWithProject, error) {
 	if groupID != "" && projectID != "" {
 		return nil, ErrBothGroupIDAndProjectIDProvided
 	}
 
 	// If neither is specified, fetch from all accessible groups
 	if groupID == "" && projectID == "" {
-		tokens, err := client.GetProjectAccessTokensRecursively("", includeInactivePAT)
+		tokens, err := client.GetProjectAccessTokensRecursively(ctx, "", state)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch project access tokens from all groups: %w", err)
 		}
@@ -80,7 +178,7 @@ func fetchTokens(client *glclient.Client) ([]*glclient.ProjectAccessTokenWithPro
 	}
 
 	if groupID != "" {
-		tokens, err := client.GetProjectAccessTokensRecursively(groupID, includeInactivePAT)
+		tokens, err := client.GetProjectAccessTokensRecursively(ctx, groupID, state)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch project access tokens recursively: %w", err)
 		}
@@ -88,7 +186,7 @@ func fetchTokens(client *glclient.Client) ([]*glclient.ProjectAccessTokenWithPro
 		return tokens, nil
 	}
 
-	tokens, err := client.GetProjectAccessTokens(projectID, includeInactivePAT)
+	tokens, err := client.GetProjectAccessTokensForProjects(ctx, projectIDs(), state)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch project access tokens: %w", err)
 	}
@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -16,34 +18,79 @@ var pttCmd = &cobra.Command{
 	Short:   "Fetch pipeline trigger tokens",
 	Long: `Fetch pipeline trigger tokens. You can:
 - Specify a group ID to fetch tokens from all projects in that group recursively
-- Specify a project ID to fetch tokens from a single project
-- Specify neither to fetch tokens from all accessible groups`,
+- Specify one or more comma-separated project IDs to fetch tokens from those projects only
+- Specify neither to fetch tokens from all accessible groups
+
+The tokens are secrets, so printing them to a terminal asks for confirmation unless --yes is given.`,
 	RunE: runPTT,
 }
 
 func init() {
+	pttCmd.Flags().BoolVar(&assumeYes, "yes", false,
+		"Print trigger tokens to a terminal without asking for confirmation")
 	pttCmd.MarkFlagsMutuallyExclusive("group-id", "project-id")
+	supportInstances(pttCmd)
 }
 
-func runPTT(_ *cobra.Command, _ []string) error {
+func runPTT(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	nameMatch, err := tokenNameMatcher()
+	if err != nil {
+		return err
+	}
+
+	// trigger tokens are secrets, printed in every format
+	if err := confirmSecrets(); err != nil {
+		return err
+	}
+
+	if instancesFile != "" {
+		return runInstancesReport(ctx,
+			func(ctx context.Context, client *glclient.Client, _ string) ([]*glclient.PipelineTriggerWithProject, error) {
+				triggers, err := fetchTriggers(ctx, client)
+				if err != nil {
+					return nil, err
+				}
+
+				triggers = report.FilterByName(triggers, nameMatch, triggerDescription)
+
+				return report.FilterByTokenPrefix(triggers, tokenPrefix, triggerToken), nil
+			},
+			nil,
+			func(formatter output.Formatter, triggers []*glclient.PipelineTriggerWithProject) error {
+				return formatter.FormatPipelineTriggers(triggers)
+			},
+			ErrGitLabTokenRequired,
+			"Fetching pipeline trigger tokens...",
+		)
+	}
+
 	token := getToken()
 	if token == "" {
 		return ErrGitLabTokenRequired
 	}
 
 	// Create GitLab client
-	client, err := glclient.NewClient(token, debug)
+	client, err := newClient(ctx, token)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	// Create spinner for visual feedback
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " Fetching pipeline trigger tokens..."
 	s.Start()
 
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
+
 	// Fetch triggers
-	triggers, err := fetchTriggers(client)
+	triggers, err := fetchTriggers(fetchCtx, client)
 
 	s.Stop()
 
@@ -51,27 +98,34 @@ func runPTT(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to fetch pipeline triggers in runPTT: %w", err)
 	}
 
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	triggers = report.FilterByName(triggers, nameMatch, triggerDescription)
+	triggers = report.FilterByTokenPrefix(triggers, tokenPrefix, triggerToken)
+
 	// Format output
-	formatter, err := output.NewFormatter(output.Format(format))
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
-	if err := formatter.FormatPipelineTriggers(triggers); err != nil {
+	if err := formatOrDiff(formatter, triggers, report.PipelineTriggers, formatter.FormatPipelineTriggers); err != nil {
 		return fmt.Errorf("failed to format output: %w", err)
 	}
 
 	return nil
 }
 
-func fetchTriggers(client *glclient.Client) ([]*glclient.PipelineTriggerWithProject, error) {
+func fetchTriggers(ctx context.Context, client *glclient.Client) ([]*glclient.PipelineTriggerWithProject, error) {
 	if groupID != "" && projectID != "" {
 		return nil, ErrBothGroupIDAndProjectIDProvided
 	}
 
 	// If neither is specified, fetch from all accessible groups
 	if groupID == "" && projectID == "" {
-		triggers, err := client.GetPipelineTriggersRecursively("")
+		triggers, err := client.GetPipelineTriggersRecursively(ctx, "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch pipeline triggers from all groups: %w", err)
 		}
@@ -80,7 +134,7 @@ func fetchTriggers(client *glclient.Client) ([]*glclient.PipelineTriggerWithProj
 	}
 
 	if groupID != "" {
-		triggers, err := client.GetPipelineTriggersRecursively(groupID)
+		triggers, err := client.GetPipelineTriggersRecursively(ctx, groupID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch pipeline triggers: %w", err)
 		}
@@ -88,7 +142,7 @@ func fetchTriggers(client *glclient.Client) ([]*glclient.PipelineTriggerWithProj
 		return triggers, nil
 	}
 
-	triggers, err := client.GetPipelineTriggers(projectID)
+	triggers, err := client.GetPipelineTriggersForProjects(ctx, projectIDs())
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch pipeline triggers: %w", err)
 	}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var topicsCmd = &cobra.Command{
+	Use:   "topics",
+	Short: "Fetches and displays the topics of projects",
+	Long: `Fetches and displays the topics GitLab projects are classified with.
+If a group ID is provided, it will fetch projects from that group and its subgroups.
+If no group ID is provided, it will fetch projects from all accessible groups.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runTopics,
+}
+
+func init() {
+	topicsCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches from all accessible groups if not provided)")
+
+	supportInstances(topicsCmd)
+	RootCmd.AddCommand(topicsCmd)
+}
+
+func runTopics(command *cobra.Command, _ []string) error {
+	return runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.ProjectTopics, error) {
+			return client.GetProjectTopicsRecursively(ctx, groupID)
+		},
+		func(formatter output.Formatter, data []*glclient.ProjectTopics) error {
+			return formatter.FormatProjectTopics(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching projects...",
+	)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	twoFactorViolationsOnly  bool
+	failOnTwoFactorViolation bool
+)
+
+var ErrTwoFactorViolation = errors.New("two-factor authentication is not enforced")
+
+var twoFactorCmd = &cobra.Command{
+	Use:   "two-factor",
+	Short: "Fetches and displays two-factor authentication enforcement of groups",
+	Long: `Fetches and displays whether GitLab groups require two-factor authentication and their grace period.
+If a group ID is provided, it will report that group and its subgroups.
+If no group ID is provided, it will report all accessible groups.
+A group enforces two-factor authentication when it or one of its ancestors requires it.`,
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		groupID = strings.Trim(groupID, "/")
+	},
+	RunE: runTwoFactor,
+}
+
+func init() {
+	twoFactorCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
+		"The ID or path of the top-level GitLab group to start the search from. "+
+			"Can be a numeric ID or a path with namespace (org/subgroup). "+
+			"(optional, fetches all accessible groups if not provided)")
+	twoFactorCmd.Flags().BoolVar(&twoFactorViolationsOnly, "violations-only", false,
+		"List only groups that do not enforce two-factor authentication")
+	twoFactorCmd.Flags().BoolVar(&failOnTwoFactorViolation, "fail-on-violation", false,
+		"Exit with an error if any group does not enforce two-factor authentication")
+
+	supportInstances(twoFactorCmd)
+	RootCmd.AddCommand(twoFactorCmd)
+}
+
+func runTwoFactor(command *cobra.Command, _ []string) error {
+	var violations int
+
+	err := runReportCommand(
+		command.Context(),
+		func(ctx context.Context, client *glclient.Client, groupID string) ([]*glclient.GroupTwoFactor, error) {
+			statuses, err := client.GetTwoFactorStatusRecursively(ctx, groupID)
+			if err != nil {
+				return nil, err
+			}
+
+			unenforced := twoFactorViolations(statuses)
+			violations = len(unenforced)
+
+			if twoFactorViolationsOnly {
+				return unenforced, nil
+			}
+
+			return statuses, nil
+		},
+		func(formatter output.Formatter, data []*glclient.GroupTwoFactor) error {
+			return formatter.FormatTwoFactor(data)
+		},
+		ErrGitLabTokenRequired,
+		"Fetching groups...",
+	)
+	if err != nil {
+		return err
+	}
+
+	if failOnTwoFactorViolation && violations > 0 {
+		return fmt.Errorf("%w in %d groups", ErrTwoFactorViolation, violations)
+	}
+
+	return nil
+}
+
+func twoFactorViolations(statuses []*glclient.GroupTwoFactor) []*glclient.GroupTwoFactor {
+	violations := make([]*glclient.GroupTwoFactor, 0, len(statuses))
+
+	for _, status := range statuses {
+		if !status.Enforced() {
+			violations = append(violations, status)
+		}
+	}
+
+	return violations
+}
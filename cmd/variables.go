@@ -1,20 +1,41 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/picker"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+var (
+	onlyEmpty     bool
+	onlyWithValue bool
+	assumeYes     bool
+)
+
+var ErrValueFilterRequiresValues = errors.New(
+	"--only-empty and --only-with-value inspect variable values and require --include-values")
+
 var variablesCmd = &cobra.Command{
 	Use:   "variables",
 	Short: "Manage CI/CD variables",
 	Long:  "Manage CI/CD variables from GitLab projects and groups.",
-	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+	PersistentPreRunE: func(command *cobra.Command, _ []string) error {
 		projectID = strings.Trim(projectID, "/")
 		groupID = strings.Trim(groupID, "/")
+
+		if err := checkBaseline(); err != nil {
+			return err
+		}
+
+		return applyAutoDetect(command)
 	},
 }
 
@@ -24,18 +45,40 @@ func init() {
 	variablesCmd.AddCommand(variablesGroupCmd)
 	variablesCmd.AddCommand(variablesProjectCmd)
 
+	for _, command := range []*cobra.Command{variablesAllCmd, variablesGroupCmd, variablesProjectCmd} {
+		supportInstances(command)
+		filterAfterFetch(command, "only-empty", "only-with-value")
+	}
+
 	variablesCmd.PersistentFlags().StringVar(&groupID, "group-id", "",
 		`The ID or path of a GitLab group to start the search from.
 Can be a numeric ID or a path with namespace (org/subgroup).`)
 
 	variablesCmd.PersistentFlags().StringVar(&projectID, "project-id", "",
-		`The ID or path of a GitLab project to fetch tokens for.
-Can be a numeric ID or a path with namespace (org/subgroup/project).`)
+		`The ID or path of a GitLab project to fetch variables for.
+Can be a numeric ID or a path with namespace (org/subgroup/project).
+Separate several projects with commas.`)
 
 	variablesCmd.PersistentFlags().BoolVar(&includeValues, "include-values", false,
 		"Include variable values in output (excluded by default for security)")
 
-	variablesCmd.MarkFlagsMutuallyExclusive("group-id", "project-id")
+	variablesCmd.PersistentFlags().BoolVar(&assumeYes, "yes", false,
+		"Print variable values to a terminal without asking for confirmation (used with --include-values)")
+
+	variablesCmd.PersistentFlags().BoolVar(&onlyEmpty, "only-empty", false,
+		"Show only variables with an empty value (requires --include-values)")
+
+	variablesCmd.PersistentFlags().BoolVar(&onlyWithValue, "only-with-value", false,
+		"Show only variables with a non-empty value (requires --include-values)")
+
+	variablesCmd.PersistentFlags().BoolVar(&autoDetect, "auto-detect", false, autoDetectUsage)
+
+	variablesCmd.PersistentFlags().StringVar(&baselineFile, "baseline", "", baselineUsage)
+
+	variablesProjectCmd.Flags().BoolVar(&withParents, "with-parents", false, withParentsUsage)
+
+	variablesCmd.MarkFlagsMutuallyExclusive("group-id", "project-id", "auto-detect")
+	variablesCmd.MarkFlagsMutuallyExclusive("only-empty", "only-with-value")
 
 	variablesAllCmd.SetHelpFunc(func(command *cobra.Command, strings []string) {
 		if err := command.InheritedFlags().MarkHidden("project-id"); err != nil {
@@ -48,6 +91,63 @@ Can be a numeric ID or a path with namespace (org/subgroup/project).`)
 		if err := command.InheritedFlags().MarkHidden("project-id"); err != nil {
 			fmt.Fprint(os.Stderr, err)
 		}
+		if err := command.InheritedFlags().MarkHidden("auto-detect"); err != nil {
+			fmt.Fprint(os.Stderr, err)
+		}
 		command.Parent().HelpFunc()(command, strings)
 	})
 }
+
+// variableValueFilter returns the value filter selected by --only-empty or --only-with-value.
+func variableValueFilter() (report.ValueFilter, error) {
+	if (onlyEmpty || onlyWithValue) && !includeValues {
+		return report.AllValues, ErrValueFilterRequiresValues
+	}
+
+	switch {
+	case onlyEmpty:
+		return report.OnlyEmptyValues, nil
+	case onlyWithValue:
+		return report.OnlyWithValue, nil
+	default:
+		return report.AllValues, nil
+	}
+}
+
+// checkVariableFormat fails early when the output format needs values that were not requested.
+func checkVariableFormat() error {
+	if output.Format(format) == output.FormatDotenv && !includeValues {
+		return output.ErrDotenvRequiresValues
+	}
+
+	return nil
+}
+
+// confirmIncludeValues asks for confirmation before --include-values prints variable values to a
+// terminal, unless --yes is given. Reports redirected or written to an --output file are not asked for.
+func confirmIncludeValues() error {
+	if !includeValues {
+		return nil
+	}
+
+	return confirmSecrets()
+}
+
+// confirmSecrets asks for confirmation before a report holding secrets is printed to a terminal,
+// unless --yes is given.
+func confirmSecrets() error {
+	terminal := outputFile == "" && term.IsTerminal(int(os.Stdout.Fd()))
+
+	return picker.ConfirmSecrets(os.Stdin, os.Stderr, terminal, assumeYes)
+}
+
+// projectVariableValue returns the variable value. GitLab never returns the value of hidden
+// variables, so it is reported as unknown.
+func projectVariableValue(v *glclient.ProjectVariableWithProject) (string, bool) {
+	return v.Value, !v.Hidden
+}
+
+// groupVariableValue returns the variable value, reported as unknown for hidden variables.
+func groupVariableValue(v *glclient.GroupVariableWithGroup) (string, bool) {
+	return v.Value, !v.Hidden
+}
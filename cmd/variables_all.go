@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -19,7 +21,44 @@ var variablesAllCmd = &cobra.Command{
 	RunE: runVariablesAll,
 }
 
-func runVariablesAll(_ *cobra.Command, _ []string) error {
+func runVariablesAll(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	if err := checkVariableFormat(); err != nil {
+		return err
+	}
+
+	valueFilter, err := variableValueFilter()
+	if err != nil {
+		return err
+	}
+
+	if err := confirmIncludeValues(); err != nil {
+		return err
+	}
+
+	if instancesFile != "" {
+		return runInstancesReport(ctx,
+			func(ctx context.Context, client *glclient.Client, _ string) ([]*glclient.VariableWithSource, error) {
+				projectVariables, groupVariables, err := fetchAllVariables(ctx, client)
+				if err != nil {
+					return nil, err
+				}
+
+				return unifyVariables(
+					report.FilterByValue(projectVariables, valueFilter, projectVariableValue),
+					report.FilterByValue(groupVariables, valueFilter, groupVariableValue),
+				), nil
+			},
+			nil,
+			func(formatter output.Formatter, variables []*glclient.VariableWithSource) error {
+				return formatter.FormatUnifiedVariables(variables, includeValues)
+			},
+			ErrGitLabTokenRequired,
+			"Fetching all variables...",
+		)
+	}
+
 	// Check for token
 	tokenValue := getToken()
 	if tokenValue == "" {
@@ -27,22 +66,29 @@ func runVariablesAll(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create client
-	client, err := glclient.NewClient(tokenValue, debug)
+	client, err := newClient(ctx, tokenValue)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
 	// Create formatter
-	formatter, err := output.NewFormatter(output.Format(format))
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " Fetching all variables..."
 	s.Start()
 
-	projectVariables, groupVariables, err := fetchAllVariables(client)
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
+
+	projectVariables, groupVariables, err := fetchAllVariables(fetchCtx, client)
 	if err != nil {
 		s.Stop()
 
@@ -51,10 +97,17 @@ func runVariablesAll(_ *cobra.Command, _ []string) error {
 
 	s.Stop()
 
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	projectVariables = report.FilterByValue(projectVariables, valueFilter, projectVariableValue)
+	groupVariables = report.FilterByValue(groupVariables, valueFilter, groupVariableValue)
+
 	return formatAllVariables(formatter, projectVariables, groupVariables)
 }
 
-func fetchAllVariables(client *glclient.Client) (
+func fetchAllVariables(ctx context.Context, client *glclient.Client) (
 	[]*glclient.ProjectVariableWithProject,
 	[]*glclient.GroupVariableWithGroup,
 	error,
@@ -67,34 +120,17 @@ func fetchAllVariables(client *glclient.Client) (
 
 	switch {
 	case projectID != "":
-		// Single project and its parent groups
-		projectVariables, err = client.GetProjectVariables(projectID)
+		// Listed projects only
+		projectVariables, err = client.GetProjectVariablesForProjects(ctx, projectIDs())
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to fetch project variables: %w", err)
 		}
 
-	case groupID != "":
-		// All variables from a group recursively
-		projectVariables, err = client.GetProjectVariablesRecursively(groupID)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch project variables: %w", err)
-		}
-
-		groupVariables, err = client.GetGroupVariablesRecursively(groupID)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch group variables: %w", err)
-		}
-
 	default:
-		// All accessible variables
-		projectVariables, err = client.GetProjectVariablesRecursively("")
+		// All variables from a group recursively, or all accessible variables if no group is given
+		projectVariables, groupVariables, err = client.GetAllVariablesRecursively(ctx, groupID)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch project variables: %w", err)
-		}
-
-		groupVariables, err = client.GetGroupVariablesRecursively("")
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch group variables: %w", err)
+			return nil, nil, fmt.Errorf("failed to fetch variables: %w", err)
 		}
 	}
 
@@ -106,25 +142,40 @@ func formatAllVariables(
 	projectVariables []*glclient.ProjectVariableWithProject,
 	groupVariables []*glclient.GroupVariableWithGroup,
 ) error {
-	allVariables := make([]*glclient.VariableWithSource, 0, len(projectVariables)+len(groupVariables))
-
-	for _, pv := range projectVariables {
-		allVariables = append(allVariables, glclient.ConvertProjectVariableToUnified(pv))
-	}
+	allVariables := unifyVariables(projectVariables, groupVariables)
 
-	for _, gv := range groupVariables {
-		allVariables = append(allVariables, glclient.ConvertGroupVariableToUnified(gv))
-	}
-
-	if len(allVariables) == 0 {
+	// with a baseline, an empty result still reports the removed variables
+	if len(allVariables) == 0 && baselineFile == "" {
 		fmt.Println("No variables found")
 
 		return nil
 	}
 
-	if err := formatter.FormatUnifiedVariables(allVariables, includeValues); err != nil {
+	err := formatOrDiff(formatter, allVariables, report.UnifiedVariables(includeValues),
+		func(variables []*glclient.VariableWithSource) error {
+			return formatter.FormatUnifiedVariables(variables, includeValues)
+		})
+	if err != nil {
 		return fmt.Errorf("failed to format variables: %w", err)
 	}
 
 	return nil
 }
+
+// unifyVariables converts project and group variables into a single list, project variables first.
+func unifyVariables(
+	projectVariables []*glclient.ProjectVariableWithProject,
+	groupVariables []*glclient.GroupVariableWithGroup,
+) []*glclient.VariableWithSource {
+	allVariables := make([]*glclient.VariableWithSource, 0, len(projectVariables)+len(groupVariables))
+
+	for _, pv := range projectVariables {
+		allVariables = append(allVariables, glclient.ConvertProjectVariableToUnified(pv))
+	}
+
+	for _, gv := range groupVariables {
+		allVariables = append(allVariables, glclient.ConvertGroupVariableToUnified(gv))
+	}
+
+	return allVariables
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffLeft  string
+	diffRight string
+)
+
+var (
+	ErrDiffProjectsRequired = errors.New("variables diff requires both --left and --right")
+	ErrDiffBaseline         = errors.New("variables diff compares two projects and cannot be combined with --baseline")
+)
+
+var variablesDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the CI/CD variables of two projects",
+	Long: `Compare the CI/CD variables of two projects, for example staging and production. Variables are
+matched by key and environment scope; those missing from one project or set to different values are
+listed. Values are shown only with --include-values. Hidden variables, whose values GitLab never
+returns, are compared by presence only.`,
+	RunE: runVariablesDiff,
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesDiffCmd)
+
+	variablesDiffCmd.Flags().StringVar(&diffLeft, "left", "",
+		"The ID or path of the first project to compare")
+	variablesDiffCmd.Flags().StringVar(&diffRight, "right", "",
+		"The ID or path of the second project to compare")
+
+	variablesDiffCmd.SetHelpFunc(func(command *cobra.Command, args []string) {
+		hidden := []string{"group-id", "project-id", "auto-detect", "baseline", "only-empty", "only-with-value"}
+		for _, name := range hidden {
+			if err := command.InheritedFlags().MarkHidden(name); err != nil {
+				fmt.Fprint(os.Stderr, err)
+			}
+		}
+		command.Parent().HelpFunc()(command, args)
+	})
+}
+
+func runVariablesDiff(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	if diffLeft == "" || diffRight == "" {
+		return ErrDiffProjectsRequired
+	}
+
+	if baselineFile != "" {
+		return ErrDiffBaseline
+	}
+
+	if output.Format(format) == output.FormatDotenv {
+		return output.ErrDotenvVariableDifferences
+	}
+
+	if err := confirmIncludeValues(); err != nil {
+		return err
+	}
+
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("failed to create formatter: %w", err)
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " Fetching project variables..."
+	s.Start()
+
+	left, err := client.GetProjectVariables(ctx, strings.Trim(diffLeft, "/"))
+	if err != nil {
+		s.Stop()
+
+		return fmt.Errorf("failed to fetch variables of the left project: %w", err)
+	}
+
+	right, err := client.GetProjectVariables(ctx, strings.Trim(diffRight, "/"))
+	if err != nil {
+		s.Stop()
+
+		return fmt.Errorf("failed to fetch variables of the right project: %w", err)
+	}
+
+	s.Stop()
+
+	differences := report.DiffVariables(left, right)
+
+	violations, err := checkPolicy(differences)
+	if err != nil {
+		return err
+	}
+
+	if len(differences) == 0 && output.Format(format) == output.FormatTable {
+		fmt.Println("No differences found")
+
+		return nil
+	}
+
+	if err := formatter.FormatVariableDifferences(report.Limit(differences, limit), includeValues); err != nil {
+		return fmt.Errorf("failed to format variable differences: %w", err)
+	}
+
+	return reportViolations(violations)
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+var (
+	grepValueContains string
+	grepValueRegex    string
+)
+
+var (
+	ErrGrepPatternRequired = errors.New("variables grep requires --value-contains or --value-regex")
+	ErrGrepRequiresValues  = errors.New("variables grep inspects variable values and requires --include-values")
+	ErrGrepBaseline        = errors.New("variables grep cannot be combined with --baseline")
+	ErrGrepDotenv          = fmt.Errorf("%w: variables grep never prints values, use table, json, csv, or template",
+		output.ErrUnsupportedFormat)
+)
+
+var variablesGrepCmd = &cobra.Command{
+	Use:   "grep",
+	Short: "Find the CI/CD variables holding a given value",
+	Long: `Find the project and group CI/CD variables whose value contains a string or matches a regular
+expression, for example to track down a leaked credential. Values are fetched to be searched, which
+requires --include-values, but never printed: each match is reported by its key, environment scope,
+and project or group. Hidden variables, whose values GitLab never returns, cannot be searched.`,
+	RunE: runVariablesGrep,
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesGrepCmd)
+
+	variablesGrepCmd.Flags().StringVar(&grepValueContains, "value-contains", "",
+		"List the variables whose value contains this string")
+	variablesGrepCmd.Flags().StringVar(&grepValueRegex, "value-regex", "",
+		"List the variables whose value matches this regular expression (Go RE2 syntax)")
+
+	variablesGrepCmd.MarkFlagsMutuallyExclusive("value-contains", "value-regex")
+
+	variablesGrepCmd.SetHelpFunc(func(command *cobra.Command, args []string) {
+		for _, name := range []string{"baseline", "only-empty", "only-with-value", "yes"} {
+			if err := command.InheritedFlags().MarkHidden(name); err != nil {
+				fmt.Fprint(os.Stderr, err)
+			}
+		}
+		command.Parent().HelpFunc()(command, args)
+	})
+}
+
+func runVariablesGrep(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	match, err := grepValueMatcher()
+	if err != nil {
+		return err
+	}
+
+	if baselineFile != "" {
+		return ErrGrepBaseline
+	}
+
+	if output.Format(format) == output.FormatDotenv {
+		return ErrGrepDotenv
+	}
+
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("failed to create formatter: %w", err)
+	}
+
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " Searching variables..."
+	s.Start()
+
+	// every variable is searched, so --limit caps the matches instead of the fetch
+	projectVariables, groupVariables, err := fetchAllVariables(ctx, client)
+	if err != nil {
+		s.Stop()
+
+		return err
+	}
+
+	s.Stop()
+
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	matches := report.GrepVariables(unifyVariables(projectVariables, groupVariables), match)
+
+	violations, err := checkPolicy(matches)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 && output.Format(format) == output.FormatTable {
+		fmt.Println("No matching variables found")
+
+		return nil
+	}
+
+	err = formatReport(formatter, matches, func(items []*glclient.VariableWithSource) error {
+		return formatter.FormatUnifiedVariables(items, false)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to format variables: %w", err)
+	}
+
+	return reportViolations(violations)
+}
+
+// grepValueMatcher returns the matcher selected by --value-contains or --value-regex.
+func grepValueMatcher() (report.ValueMatcher, error) {
+	if grepValueContains == "" && grepValueRegex == "" {
+		return nil, ErrGrepPatternRequired
+	}
+
+	if !includeValues {
+		return nil, ErrGrepRequiresValues
+	}
+
+	if grepValueContains != "" {
+		return report.ValueContains(grepValueContains), nil
+	}
+
+	return report.ValueRegex(grepValueRegex)
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -21,10 +23,44 @@ var variablesGroupCmd = &cobra.Command{
 	RunE: runVariablesGroup,
 }
 
-func runVariablesGroup(_ *cobra.Command, _ []string) error {
+func runVariablesGroup(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
 	// Trim slashes from ID
 	groupID = strings.Trim(groupID, "/")
 
+	if err := checkVariableFormat(); err != nil {
+		return err
+	}
+
+	valueFilter, err := variableValueFilter()
+	if err != nil {
+		return err
+	}
+
+	if err := confirmIncludeValues(); err != nil {
+		return err
+	}
+
+	if instancesFile != "" {
+		return runInstancesReport(ctx,
+			func(ctx context.Context, client *glclient.Client, _ string) ([]*glclient.GroupVariableWithGroup, error) {
+				variables, err := fetchGroupVariables(ctx, client)
+				if err != nil {
+					return nil, err
+				}
+
+				return report.FilterByValue(variables, valueFilter, groupVariableValue), nil
+			},
+			nil,
+			func(formatter output.Formatter, variables []*glclient.GroupVariableWithGroup) error {
+				return formatter.FormatGroupVariables(variables, includeValues)
+			},
+			ErrGitLabTokenRequired,
+			"Fetching group variables...",
+		)
+	}
+
 	// Check for token
 	tokenValue := getToken()
 	if tokenValue == "" {
@@ -32,47 +68,71 @@ func runVariablesGroup(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create client
-	client, err := glclient.NewClient(tokenValue, debug)
+	client, err := newClient(ctx, tokenValue)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
 	// Create formatter
-	formatter, err := output.NewFormatter(output.Format(format))
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " Fetching group variables..."
 	s.Start()
 
-	var variables []*glclient.GroupVariableWithGroup
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
 
-	if groupID != "" {
-		// Single group
-		variables, err = client.GetGroupVariables(groupID)
-		if err != nil {
-			s.Stop()
+	variables, err := fetchGroupVariables(fetchCtx, client)
 
-			return fmt.Errorf("failed to fetch variables: %w", err)
-		}
-	} else {
-		// All accessible groups recursively
-		variables, err = client.GetGroupVariablesRecursively("")
-		if err != nil {
-			s.Stop()
+	s.Stop()
 
-			return fmt.Errorf("failed to fetch variables: %w", err)
-		}
+	if err != nil {
+		return err
 	}
 
-	s.Stop()
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	variables = report.FilterByValue(variables, valueFilter, groupVariableValue)
 
 	// Format variables
-	if err := formatter.FormatGroupVariables(variables, includeValues); err != nil {
+	err = formatOrDiff(formatter, variables, report.GroupVariables(includeValues),
+		func(variables []*glclient.GroupVariableWithGroup) error {
+			return formatter.FormatGroupVariables(variables, includeValues)
+		})
+	if err != nil {
 		return fmt.Errorf("failed to format variables: %w", err)
 	}
 
 	return nil
 }
+
+// fetchGroupVariables fetches the variables of the group, or of all accessible groups without one.
+func fetchGroupVariables(ctx context.Context, client *glclient.Client) ([]*glclient.GroupVariableWithGroup, error) {
+	if groupID != "" {
+		// Single group
+		variables, err := client.GetGroupVariables(ctx, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch variables: %w", err)
+		}
+
+		return variables, nil
+	}
+
+	// All accessible groups recursively
+	variables, err := client.GetGroupVariablesRecursively(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch variables: %w", err)
+	}
+
+	return variables, nil
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
 )
@@ -16,17 +18,61 @@ var variablesProjectCmd = &cobra.Command{
 	Aliases: []string{"projects"},
 	Short:   "Fetch project-level CI/CD variables",
 	Long: `Fetch project-level CI/CD variables from GitLab. You can:
-- Specify a project ID to fetch project variables from a single project
+- Specify one or more comma-separated project IDs to fetch project variables from those projects only
 - Specify a group ID to fetch project variables starting from that group recursively
-- Specify neither to fetch project variables from all accessible projects`,
+- Specify neither to fetch project variables from all accessible projects
+
+With --with-parents and a single project, the variables of the groups the project inherits from
+are listed alongside, tagged by their source.`,
 	RunE: runVariablesProject,
 }
 
-func runVariablesProject(_ *cobra.Command, _ []string) error {
+func runVariablesProject(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
 	// Trim slashes from IDs
 	projectID = strings.Trim(projectID, "/")
 	groupID = strings.Trim(groupID, "/")
 
+	if err := checkVariableFormat(); err != nil {
+		return err
+	}
+
+	valueFilter, err := variableValueFilter()
+	if err != nil {
+		return err
+	}
+
+	if err := confirmIncludeValues(); err != nil {
+		return err
+	}
+
+	var parentsOf string
+	if withParents {
+		if parentsOf, err = parentsProjectID(); err != nil {
+			return err
+		}
+	}
+
+	if instancesFile != "" {
+		return runInstancesReport(ctx,
+			func(ctx context.Context, client *glclient.Client, _ string) ([]*glclient.ProjectVariableWithProject, error) {
+				variables, err := fetchProjectVariables(ctx, client)
+				if err != nil {
+					return nil, err
+				}
+
+				return report.FilterByValue(variables, valueFilter, projectVariableValue), nil
+			},
+			nil,
+			func(formatter output.Formatter, variables []*glclient.ProjectVariableWithProject) error {
+				return formatter.FormatProjectVariables(variables, includeValues)
+			},
+			ErrGitLabTokenRequired,
+			"Fetching project variables...",
+		)
+	}
+
 	// Check for token
 	tokenValue := getToken()
 	if tokenValue == "" {
@@ -34,47 +80,92 @@ func runVariablesProject(_ *cobra.Command, _ []string) error {
 	}
 
 	// Create client
-	client, err := glclient.NewClient(tokenValue, debug)
+	client, err := newClient(ctx, tokenValue)
 	if err != nil {
 		return fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
 	// Create formatter
-	formatter, err := output.NewFormatter(output.Format(format))
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
+	if err := selectRootGroup(ctx, client); err != nil {
+		return err
+	}
+
 	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
 	s.Suffix = " Fetching project variables..."
 	s.Start()
 
-	var variables []*glclient.ProjectVariableWithProject
+	fetchCtx, stopFetch := fetchContext(ctx, client)
+	defer stopFetch()
 
-	if projectID != "" {
-		// Single project
-		variables, err = client.GetProjectVariables(projectID)
-		if err != nil {
-			s.Stop()
+	variables, err := fetchProjectVariables(fetchCtx, client)
+	if err != nil {
+		s.Stop()
 
-			return fmt.Errorf("failed to fetch variables: %w", err)
-		}
-	} else {
-		// Group recursively or all accessible
-		variables, err = client.GetProjectVariablesRecursively(groupID)
+		return err
+	}
+
+	var groupVariables []*glclient.GroupVariableWithGroup
+
+	if withParents {
+		groupVariables, err = client.GetAncestorGroupVariables(ctx, parentsOf)
 		if err != nil {
 			s.Stop()
 
-			return fmt.Errorf("failed to fetch variables: %w", err)
+			return fmt.Errorf("failed to fetch variables of parent groups: %w", err)
 		}
 	}
 
 	s.Stop()
 
+	if err := reportIncomplete(ctx, client); err != nil {
+		return err
+	}
+
+	variables = report.FilterByValue(variables, valueFilter, projectVariableValue)
+
+	// the inherited variables are listed in the format of variables all, which tells their source
+	if withParents {
+		groupVariables = report.FilterByValue(groupVariables, valueFilter, groupVariableValue)
+
+		return formatAllVariables(formatter, variables, groupVariables)
+	}
+
 	// Format variables
-	if err := formatter.FormatProjectVariables(variables, includeValues); err != nil {
+	err = formatOrDiff(formatter, variables, report.ProjectVariables(includeValues),
+		func(variables []*glclient.ProjectVariableWithProject) error {
+			return formatter.FormatProjectVariables(variables, includeValues)
+		})
+	if err != nil {
 		return fmt.Errorf("failed to format variables: %w", err)
 	}
 
 	return nil
 }
+
+// fetchProjectVariables fetches the variables of the listed projects, or else of the projects in the
+// group recursively, or of all accessible projects without a group.
+func fetchProjectVariables(
+	ctx context.Context,
+	client *glclient.Client,
+) ([]*glclient.ProjectVariableWithProject, error) {
+	if projectID != "" {
+		variables, err := client.GetProjectVariablesForProjects(ctx, projectIDs())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch variables: %w", err)
+		}
+
+		return variables, nil
+	}
+
+	variables, err := client.GetProjectVariablesRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch variables: %w", err)
+	}
+
+	return variables, nil
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+// versionRequirements lists the commands that call endpoints missing from older GitLab versions.
+// The oldest supported version applies to every command.
+var versionRequirements = []glclient.VersionRequirement{
+	{Feature: "all commands", MinVersion: "15.0"},
+	{Feature: "tokens pat", MinVersion: "13.9"},
+	{Feature: "tokens gat", MinVersion: "14.7"},
+	{Feature: "whoami", MinVersion: "15.5"},
+	{Feature: "job-token-scope", MinVersion: "17.0"},
+}
+
+// warnUnsupportedVersion warns when the GitLab instance is older than some commands need. Failures to
+// read the version are only logged in debug mode, as they do not keep the command from working.
+func warnUnsupportedVersion(ctx context.Context, client *glclient.Client) {
+	version, unsupported, err := client.UnsupportedFeatures(ctx, versionRequirements)
+	if err != nil {
+		if debug {
+			fmt.Printf("DEBUG: skipping the GitLab version check: %v\n", err)
+		}
+
+		return
+	}
+
+	if len(unsupported) == 0 {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: GitLab %s lacks API endpoints used by %s, which may fail with 404 errors "+
+		"(use --no-version-check to skip this check)\n", version, strings.Join(unsupported, ", "))
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Shows the authenticated user and the token's scopes and expiry",
+	Long: `Shows the user the token authenticates as, together with the token's name, scopes, and expiry.
+Warns when the token lacks the read_api or api scope needed by the report commands,
+is inactive, or expires within a week.`,
+	RunE: runWhoami,
+}
+
+func init() {
+	RootCmd.AddCommand(whoamiCmd)
+}
+
+func runWhoami(command *cobra.Command, _ []string) error {
+	ctx := command.Context()
+
+	tokenValue := getToken()
+	if tokenValue == "" {
+		return ErrGitLabTokenRequired
+	}
+
+	client, err := newClient(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("failed to create GitLab client: %w", err)
+	}
+
+	formatter, err := output.NewFormatter(output.Format(format), formatterOptions(ctx, client)...)
+	if err != nil {
+		return fmt.Errorf("invalid output format: %w", err)
+	}
+
+	s := spinner.New(spinner.CharSets[spinnerCharSet], spinnerDelay*time.Millisecond)
+	s.Suffix = " Fetching token details..."
+	s.Start()
+
+	info, err := client.GetTokenInfo(ctx)
+
+	s.Stop()
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch token details: %w", err)
+	}
+
+	violations, err := checkPolicy([]*glclient.TokenInfo{info})
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.FormatTokenInfo(info); err != nil {
+		return fmt.Errorf("failed to format token details: %w", err)
+	}
+
+	return reportViolations(violations)
+}
@@ -0,0 +1,138 @@
+// Package cache stores fetched data on disk so that repeated commands can skip the GitLab API.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	dirPerm  = 0o700
+	filePerm = 0o600
+)
+
+// Cache is a directory of JSON entries that expire after a fixed TTL.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// Option configures a Cache created by New.
+type Option func(*Cache)
+
+// WithClock replaces the clock used to decide whether an entry is still fresh.
+func WithClock(now func() time.Time) Option {
+	return func(c *Cache) {
+		c.now = now
+	}
+}
+
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Key      string          `json:"key"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// New returns a cache storing entries in dir that stay fresh for ttl. With a ttl of zero or less,
+// entries never expire and are only replaced by later ones.
+func New(dir string, ttl time.Duration, opts ...Option) *Cache {
+	c := &Cache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Get decodes the entry stored under key into v. It reports false when the entry
+// is missing, expired, or was stored under a different key.
+func (c *Cache) Get(key string, v any) (bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+
+	if e.Key != key || (c.ttl > 0 && c.now().Sub(e.StoredAt) >= c.ttl) {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return false, fmt.Errorf("failed to decode cached data: %w", err)
+	}
+
+	return true, nil
+}
+
+// Put stores v under key. The entry is written to a temporary file first and then renamed,
+// so concurrent readers never observe a partially written entry.
+func (c *Cache) Put(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache data: %w", err)
+	}
+
+	encoded, err := json.Marshal(entry{StoredAt: c.now(), Key: key, Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, dirPerm); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(encoded); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), filePerm); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// path hashes the key so that arbitrary group paths and URLs map to safe file names.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,135 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func TestCache_Get(t *testing.T) {
+	stored := []item{{ID: 1, Name: "root"}, {ID: 2, Name: "child"}}
+
+	t.Run("misses when nothing is stored", func(t *testing.T) {
+		c := cache.New(t.TempDir(), time.Hour)
+
+		var got []item
+
+		hit, err := c.Get("groups", &got)
+		require.NoError(t, err)
+		assert.False(t, hit)
+		assert.Nil(t, got)
+	})
+
+	t.Run("hits a fresh entry", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+		c := cache.New(t.TempDir(), time.Hour, cache.WithClock(clock.Now))
+
+		require.NoError(t, c.Put("groups", stored))
+
+		clock.now = clock.now.Add(59 * time.Minute)
+
+		var got []item
+
+		hit, err := c.Get("groups", &got)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, stored, got)
+	})
+
+	t.Run("misses an expired entry", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+		c := cache.New(t.TempDir(), time.Hour, cache.WithClock(clock.Now))
+
+		require.NoError(t, c.Put("groups", stored))
+
+		clock.now = clock.now.Add(time.Hour)
+
+		var got []item
+
+		hit, err := c.Get("groups", &got)
+		require.NoError(t, err)
+		assert.False(t, hit)
+	})
+
+	t.Run("never expires without a TTL", func(t *testing.T) {
+		clock := &fakeClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+		c := cache.New(t.TempDir(), 0, cache.WithClock(clock.Now))
+
+		require.NoError(t, c.Put("groups", stored))
+
+		clock.now = clock.now.AddDate(1, 0, 0)
+
+		var got []item
+
+		hit, err := c.Get("groups", &got)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, stored, got)
+	})
+
+	t.Run("misses a different key", func(t *testing.T) {
+		c := cache.New(t.TempDir(), time.Hour)
+
+		require.NoError(t, c.Put("groups:1", stored))
+
+		var got []item
+
+		hit, err := c.Get("groups:2", &got)
+		require.NoError(t, err)
+		assert.False(t, hit)
+	})
+
+	t.Run("returns an error for a corrupted entry", func(t *testing.T) {
+		dir := t.TempDir()
+		c := cache.New(dir, time.Hour)
+
+		require.NoError(t, c.Put("groups", stored))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		require.NoError(t, os.WriteFile(files[0], []byte("{"), 0o600))
+
+		var got []item
+
+		hit, err := c.Get("groups", &got)
+		require.Error(t, err)
+		assert.False(t, hit)
+	})
+}
+
+func TestCache_Put(t *testing.T) {
+	t.Run("creates the directory with private permissions", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "nested", "cache")
+		c := cache.New(dir, time.Hour)
+
+		require.NoError(t, c.Put("projects", []item{{ID: 10}}))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		info, err := os.Stat(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+}
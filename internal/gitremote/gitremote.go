@@ -0,0 +1,105 @@
+// Package gitremote derives GitLab project coordinates from the remotes of a local git repository.
+package gitremote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// DefaultRemote is the remote inspected when detecting the project of the current repository.
+const DefaultRemote = "origin"
+
+var (
+	ErrNoRemote        = errors.New("no git remote found")
+	ErrNotGitLabRemote = errors.New("git remote is not a GitLab project URL")
+)
+
+// scpLikeURL matches the short SSH form used by git, e.g. git@gitlab.com:group/project.git.
+var scpLikeURL = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// nonGitLabHosts lists well-known hosting services that are never GitLab instances.
+var nonGitLabHosts = map[string]bool{
+	"github.com":        true,
+	"bitbucket.org":     true,
+	"dev.azure.com":     true,
+	"ssh.dev.azure.com": true,
+	"codeberg.org":      true,
+}
+
+// Remote is a GitLab project referenced by a git remote URL.
+type Remote struct {
+	// BaseURL is the web URL of the GitLab instance, e.g. https://gitlab.example.com.
+	BaseURL string
+	// ProjectPath is the project path with namespace, e.g. group/subgroup/project.
+	ProjectPath string
+}
+
+// Parse extracts the GitLab instance and project path from a git remote URL.
+// It accepts HTTPS URLs, ssh:// URLs, and the scp-like SSH form. SSH ports are dropped from
+// the base URL because the web interface is served over HTTPS on the default port.
+func Parse(remoteURL string) (*Remote, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	var scheme, host, path string
+
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("%w: %q", ErrNotGitLabRemote, remoteURL)
+		}
+
+		scheme, host, path = u.Scheme, u.Host, u.Path
+	} else if m := scpLikeURL.FindStringSubmatch(remoteURL); m != nil {
+		scheme, host, path = "ssh", m[1], m[2]
+	} else {
+		return nil, fmt.Errorf("%w: %q", ErrNotGitLabRemote, remoteURL)
+	}
+
+	switch scheme {
+	case "http", "https":
+	case "ssh", "git+ssh", "ssh+git":
+		// the web interface is not served on the SSH port
+		host = strings.Split(host, ":")[0]
+		scheme = "https"
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %q in %q", ErrNotGitLabRemote, scheme, remoteURL)
+	}
+
+	if nonGitLabHosts[strings.ToLower(strings.Split(host, ":")[0])] {
+		return nil, fmt.Errorf("%w: %s is not a GitLab host", ErrNotGitLabRemote, host)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if strings.Count(path, "/") < 1 {
+		return nil, fmt.Errorf("%w: %q has no namespace/project path", ErrNotGitLabRemote, remoteURL)
+	}
+
+	return &Remote{
+		BaseURL:     scheme + "://" + host,
+		ProjectPath: path,
+	}, nil
+}
+
+// Detect reads the URL of the given remote of the git repository in dir and parses it.
+// An empty dir means the current working directory.
+func Detect(ctx context.Context, dir, remote string) (*Remote, error) {
+	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", remote)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoRemote, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return nil, fmt.Errorf("%w: cannot read remote %q: %w", ErrNoRemote, remote, err)
+	}
+
+	return Parse(string(out))
+}
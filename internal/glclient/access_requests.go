@@ -0,0 +1,261 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// AccessRequestWithSource represents a pending access request with the group or project it was made to.
+type AccessRequestWithSource struct {
+	ReportType   string     `json:"report_type" csv:"-"`
+	Instance     string     `json:"instance,omitempty" csv:"omitempty"`
+	ID           int        `json:"id"`
+	Username     string     `json:"username"`
+	Name         string     `json:"name"`
+	State        string     `json:"state"`
+	RequestedAt  *time.Time `json:"requested_at"`
+	PendingDays  int        `json:"pending_days"`
+	Source       string     `json:"source"` // "project" or "group"
+	SourceID     int        `json:"source_id"`
+	SourceName   string     `json:"source_name"`
+	SourcePath   string     `json:"source_path"`
+	SourceWebURL string     `json:"source_web_url"`
+}
+
+// PendingFor returns how long the request has been waiting at the given time.
+// Requests without a request date are reported as pending for zero time.
+func (r *AccessRequestWithSource) PendingFor(now time.Time) time.Duration {
+	if r.RequestedAt == nil {
+		return 0
+	}
+
+	return now.Sub(*r.RequestedAt)
+}
+
+// GetAccessRequestsRecursively fetches the pending access requests of all groups and projects
+// within a group and its subgroups.
+func (c *Client) GetAccessRequestsRecursively(ctx context.Context, groupID string) ([]*AccessRequestWithSource, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive access requests fetch for group ID %s\n", groupID)
+	}
+
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	projects := c.projectsForGroups(ctx, groups)
+	if err := c.interrupted(ctx, "project fetch"); err != nil {
+		return nil, err
+	}
+
+	var (
+		allRequests []*AccessRequestWithSource
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+
+		groupID := strconv.Itoa(group.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchAccessRequestsForGroup(ctx, groupID, group, &allRequests, &mu)
+		})
+	}
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchAccessRequestsForProject(ctx, projectID, project, &allRequests, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "access requests fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortAccessRequests(allRequests)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive access requests fetch, found %d requests\n", len(allRequests))
+	}
+
+	return allRequests, nil
+}
+
+func (c *Client) listAccessRequestsForGroup(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+) ([]*AccessRequestWithSource, error) {
+	var allRequests []*AccessRequestWithSource
+
+	opt := &gitlab.ListAccessRequestsOptions{
+		PerPage: c.pageSize,
+		Page:    1,
+	}
+
+	now := time.Now()
+
+	for {
+		requests, resp, err := c.client.AccessRequests.ListGroupAccessRequests(groupID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group access requests: %w", err)
+		}
+
+		for _, request := range requests {
+			allRequests = append(allRequests, newAccessRequestWithSource(
+				request, "group", group.ID, group.Name, group.FullPath, c.webURL(group.WebURL), now))
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d access requests for group %s\n", len(requests), groupID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allRequests, nil
+}
+
+func (c *Client) fetchAccessRequestsForGroup(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+	requests *[]*AccessRequestWithSource,
+	mu *sync.Mutex,
+) {
+	groupRequests, err := c.listAccessRequestsForGroup(ctx, groupID, group)
+	if err != nil {
+		c.recordInaccessible(ctx, "group", group.FullPath, "group access requests", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching access requests for group %s: %v\n", groupID, err)
+		}
+
+		return
+	}
+
+	mu.Lock()
+	*requests = append(*requests, groupRequests...)
+	mu.Unlock()
+	c.countItems(len(groupRequests))
+}
+
+func (c *Client) listAccessRequestsForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) ([]*AccessRequestWithSource, error) {
+	var allRequests []*AccessRequestWithSource
+
+	opt := &gitlab.ListAccessRequestsOptions{
+		PerPage: c.pageSize,
+		Page:    1,
+	}
+
+	now := time.Now()
+
+	for {
+		requests, resp, err := c.client.AccessRequests.ListProjectAccessRequests(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list project access requests: %w", err)
+		}
+
+		for _, request := range requests {
+			allRequests = append(allRequests, newAccessRequestWithSource(
+				request, "project", project.ID, project.Name, project.PathWithNamespace, c.webURL(project.WebURL), now))
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d access requests for project %s\n", len(requests), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allRequests, nil
+}
+
+func (c *Client) fetchAccessRequestsForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+	requests *[]*AccessRequestWithSource,
+	mu *sync.Mutex,
+) {
+	projectRequests, err := c.listAccessRequestsForProject(ctx, projectID, project)
+	if err != nil {
+		c.recordInaccessible(ctx, "project", project.PathWithNamespace, "project access requests", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching access requests for project %s: %v\n", projectID, err)
+		}
+
+		return
+	}
+
+	mu.Lock()
+	*requests = append(*requests, projectRequests...)
+	mu.Unlock()
+	c.countItems(len(projectRequests))
+}
+
+// newAccessRequestWithSource wraps an access request. GitLab sets the request date on pending
+// requests; the creation date is used for the few responses without one.
+func newAccessRequestWithSource(
+	request *gitlab.AccessRequest,
+	source string,
+	sourceID int,
+	sourceName, sourcePath, sourceWebURL string,
+	now time.Time,
+) *AccessRequestWithSource {
+	requestedAt := request.RequestedAt
+	if requestedAt == nil {
+		requestedAt = request.CreatedAt
+	}
+
+	reportType := ReportTypeProjectAccessRequest
+	if source == "group" {
+		reportType = ReportTypeGroupAccessRequest
+	}
+
+	wrapped := &AccessRequestWithSource{
+		ReportType:   reportType,
+		ID:           request.ID,
+		Username:     request.Username,
+		Name:         request.Name,
+		State:        request.State,
+		RequestedAt:  requestedAt,
+		Source:       source,
+		SourceID:     sourceID,
+		SourceName:   sourceName,
+		SourcePath:   sourcePath,
+		SourceWebURL: sourceWebURL,
+	}
+	wrapped.PendingDays = int(wrapped.PendingFor(now).Hours() / 24)
+
+	return wrapped
+}
@@ -0,0 +1,139 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccessRequestsRecursively(t *testing.T) {
+	rootGroup := &gitlab.Group{
+		ID:       1,
+		Name:     "root-group",
+		FullPath: "root-group",
+		WebURL:   "https://gitlab.com/groups/root-group",
+	}
+
+	projects := []*gitlab.Project{
+		{
+			ID:                10,
+			Name:              "project",
+			PathWithNamespace: "root-group/project",
+			Namespace:         &gitlab.ProjectNamespace{FullPath: "root-group"},
+			WebURL:            "https://gitlab.com/root-group/project",
+		},
+		{
+			ID:                11,
+			Name:              "quiet",
+			PathWithNamespace: "root-group/quiet",
+			Namespace:         &gitlab.ProjectNamespace{FullPath: "root-group"},
+		},
+	}
+
+	t.Run("fetches group and project requests", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects, &gitlab.Response{}, nil)
+
+		requestedAt := time.Now().Add(-10 * 24 * time.Hour)
+		createdAt := time.Now().Add(-3 * 24 * time.Hour)
+
+		mockClient.MockAccessRequests.EXPECT().
+			ListGroupAccessRequests("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AccessRequest{
+				{ID: 5, Username: "alice", Name: "Alice", State: "awaiting", RequestedAt: &requestedAt},
+			}, &gitlab.Response{}, nil)
+
+		mockClient.MockAccessRequests.EXPECT().
+			ListProjectAccessRequests("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AccessRequest{
+				{ID: 6, Username: "bob", Name: "Bob", State: "awaiting", CreatedAt: &createdAt},
+			}, &gitlab.Response{}, nil)
+
+		mockClient.MockAccessRequests.EXPECT().
+			ListProjectAccessRequests("11", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AccessRequest{}, &gitlab.Response{}, nil)
+
+		requests, err := client.GetAccessRequestsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+
+		bySource := make(map[string]*glclient.AccessRequestWithSource)
+		for _, request := range requests {
+			bySource[request.Source] = request
+		}
+
+		group := bySource["group"]
+		require.NotNil(t, group)
+		assert.Equal(t, glclient.ReportTypeGroupAccessRequest, group.ReportType)
+		assert.Equal(t, "alice", group.Username)
+		assert.Equal(t, 1, group.SourceID)
+		assert.Equal(t, "root-group", group.SourcePath)
+		assert.Equal(t, 10, group.PendingDays)
+
+		project := bySource["project"]
+		require.NotNil(t, project)
+		assert.Equal(t, glclient.ReportTypeProjectAccessRequest, project.ReportType)
+		assert.Equal(t, "bob", project.Username)
+		assert.Equal(t, 10, project.SourceID)
+		assert.Equal(t, "root-group/project", project.SourcePath)
+		assert.Equal(t, &createdAt, project.RequestedAt, "falls back to the creation date")
+		assert.Equal(t, 3, project.PendingDays)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("skips and reports resources without maintainer access", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects[:1], &gitlab.Response{}, nil)
+
+		mockClient.MockAccessRequests.EXPECT().
+			ListGroupAccessRequests("1", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		mockClient.MockAccessRequests.EXPECT().
+			ListProjectAccessRequests("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AccessRequest{}, &gitlab.Response{}, nil)
+
+		requests, err := client.GetAccessRequestsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "group", Path: "root-group", Reason: "group access requests: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
+
+func TestAccessRequestWithSource_PendingFor(t *testing.T) {
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	requestedAt := now.Add(-36 * time.Hour)
+
+	assert.Equal(t, 36*time.Hour, (&glclient.AccessRequestWithSource{RequestedAt: &requestedAt}).PendingFor(now))
+	assert.Zero(t, (&glclient.AccessRequestWithSource{}).PendingFor(now))
+}
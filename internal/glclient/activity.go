@@ -0,0 +1,61 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ProjectActivity represents when a project was last active.
+type ProjectActivity struct {
+	ReportType     string     `json:"report_type" csv:"-"`
+	Instance       string     `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID      int        `json:"project_id"`
+	ProjectName    string     `json:"project_name"`
+	ProjectPath    string     `json:"project_path"`
+	ProjectWebURL  string     `json:"project_web_url"`
+	LastActivityAt *time.Time `json:"last_activity_at"`
+	InactiveDays   int        `json:"inactive_days"`
+}
+
+// InactiveFor returns how long the project has been inactive at the given time.
+// Projects without an activity date are reported as inactive for the longest possible time.
+func (p *ProjectActivity) InactiveFor(now time.Time) time.Duration {
+	if p.LastActivityAt == nil {
+		return math.MaxInt64
+	}
+
+	return now.Sub(*p.LastActivityAt)
+}
+
+// GetProjectActivityRecursively fetches the last activity of all projects within a group and its
+// subgroups. The project listing already includes it, so no further requests are made.
+func (c *Client) GetProjectActivityRecursively(ctx context.Context, groupID string) ([]*ProjectActivity, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	now := time.Now()
+	activity := make([]*ProjectActivity, 0, len(projects))
+
+	for _, project := range projects {
+		wrapped := &ProjectActivity{
+			ReportType:     ReportTypeProjectActivity,
+			ProjectID:      project.ID,
+			ProjectName:    project.Name,
+			ProjectPath:    project.PathWithNamespace,
+			ProjectWebURL:  c.webURL(project.WebURL),
+			LastActivityAt: project.LastActivityAt,
+		}
+
+		if wrapped.LastActivityAt != nil {
+			wrapped.InactiveDays = int(wrapped.InactiveFor(now).Hours() / 24)
+		}
+
+		activity = append(activity, wrapped)
+	}
+
+	return activity, nil
+}
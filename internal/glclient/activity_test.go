@@ -0,0 +1,68 @@
+package glclient_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectActivityRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	lastActivity := time.Now().Add(-72 * time.Hour)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			{
+				ID:                10,
+				Name:              "api",
+				PathWithNamespace: "root-group/api",
+				WebURL:            "https://gitlab.com/root-group/api",
+				LastActivityAt:    &lastActivity,
+			},
+			{ID: 11, Name: "unknown", PathWithNamespace: "root-group/unknown"},
+		}, &gitlab.Response{}, nil)
+
+	activity, err := client.GetProjectActivityRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*glclient.ProjectActivity{
+		{
+			ReportType:     glclient.ReportTypeProjectActivity,
+			ProjectID:      10,
+			ProjectName:    "api",
+			ProjectPath:    "root-group/api",
+			ProjectWebURL:  "https://gitlab.com/root-group/api",
+			LastActivityAt: &lastActivity,
+			InactiveDays:   3,
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectActivity,
+			ProjectID:   11,
+			ProjectName: "unknown",
+			ProjectPath: "root-group/unknown",
+		},
+	}, activity)
+}
+
+func TestProjectActivity_InactiveFor(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastActivity := now.Add(-48 * time.Hour)
+
+	assert.Equal(t, 48*time.Hour, (&glclient.ProjectActivity{LastActivityAt: &lastActivity}).InactiveFor(now))
+	assert.Greater(t, (&glclient.ProjectActivity{}).InactiveFor(now), 100*365*24*time.Hour)
+}
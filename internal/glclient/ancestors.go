@@ -0,0 +1,141 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// GetAncestorGroups returns the groups a project inherits from, from its top-level group down to the
+// group it belongs to directly. Each ancestor is looked up by the path segments of the project's
+// namespace. Ancestors the token cannot read are reported as inaccessible and skipped. Projects in a
+// personal namespace have no ancestor groups.
+func (c *Client) GetAncestorGroups(ctx context.Context, projectID string) ([]*gitlab.Group, error) {
+	project, err := c.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Namespace != nil && project.Namespace.Kind == "user" {
+		return nil, nil
+	}
+
+	var groups []*gitlab.Group
+
+	for _, path := range ancestorPaths(projectNamespace(project)) {
+		group, err := c.GetGroup(ctx, path)
+		if err != nil {
+			if _, ok := inaccessibleReason(err); !ok {
+				return nil, err
+			}
+
+			c.recordInaccessible(ctx, "group", path, "ancestor group", err)
+
+			if c.debug {
+				fmt.Printf("DEBUG: skipping ancestor group %s of project %s: %v\n", path, projectID, err)
+			}
+
+			continue
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// GetAncestorGroupVariables fetches the CI/CD variables of the groups a project inherits from.
+func (c *Client) GetAncestorGroupVariables(ctx context.Context, projectID string) ([]*GroupVariableWithGroup, error) {
+	variables, err := fetchForAncestors(ctx, c, projectID, "ancestor group variables", c.fetchVariablesForGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	c.sortGroupVariables(variables)
+
+	return variables, nil
+}
+
+// GetAncestorGroupAccessTokens fetches the access tokens of the groups a project inherits from.
+// Group access tokens also grant access to the projects of the group.
+func (c *Client) GetAncestorGroupAccessTokens(
+	ctx context.Context,
+	projectID string,
+	state TokenState,
+) ([]*GroupAccessTokenWithGroup, error) {
+	tokens, err := fetchForAncestors(ctx, c, projectID, "ancestor group access tokens",
+		func(
+			ctx context.Context,
+			groupID string,
+			group *gitlab.Group,
+			tokens *[]*GroupAccessTokenWithGroup,
+			mu *sync.Mutex,
+		) {
+			c.fetchTokensForGroup(ctx, groupID, group, state, tokens, mu)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	c.sortGroupAccessTokens(tokens)
+
+	return tokens, nil
+}
+
+// fetchForAncestors runs fetch for each ancestor group of a project on the client's worker pool.
+func fetchForAncestors[T any](
+	ctx context.Context,
+	c *Client,
+	projectID, what string,
+	fetch func(ctx context.Context, groupID string, group *gitlab.Group, items *[]T, mu *sync.Mutex),
+) ([]T, error) {
+	groups, err := c.GetAncestorGroups(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor groups: %w", err)
+	}
+
+	var (
+		items []T
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+
+		groupID := strconv.Itoa(group.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			fetch(ctx, groupID, group, &items, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, what+" fetch"); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ancestorPaths returns the full path of every group in a namespace, top-level group first.
+func ancestorPaths(namespace string) []string {
+	if namespace == "" {
+		return nil
+	}
+
+	segments := strings.Split(namespace, "/")
+	paths := make([]string, 0, len(segments))
+
+	for i := range segments {
+		paths = append(paths, strings.Join(segments[:i+1], "/"))
+	}
+
+	return paths
+}
@@ -0,0 +1,111 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// expectNestedProject expects the lookup of a project two levels below its top-level group.
+func expectNestedProject(mockClient *gitlabtesting.TestClient) {
+	mockClient.MockProjects.EXPECT().
+		GetProject("org/platform/api", nil, gomock.Any()).
+		Return(&gitlab.Project{
+			ID:                30,
+			PathWithNamespace: "org/platform/api",
+			Namespace:         &gitlab.ProjectNamespace{Kind: "group", FullPath: "org/platform"},
+		}, &gitlab.Response{}, nil)
+}
+
+func TestGetAncestorGroups(t *testing.T) {
+	t.Run("resolves every ancestor of a nested project", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectNestedProject(mockClient)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("org", gomock.Any(), gomock.Any()).
+			Return(&gitlab.Group{ID: 1, FullPath: "org"}, &gitlab.Response{}, nil)
+		mockClient.MockGroups.EXPECT().
+			GetGroup("org/platform", gomock.Any(), gomock.Any()).
+			Return(&gitlab.Group{ID: 2, FullPath: "org/platform"}, &gitlab.Response{}, nil)
+
+		groups, err := client.GetAncestorGroups(t.Context(), "org/platform/api")
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "org", groups[0].FullPath)
+		assert.Equal(t, "org/platform", groups[1].FullPath)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("skips ancestors without access", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectNestedProject(mockClient)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("org", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusNotFound))
+		mockClient.MockGroups.EXPECT().
+			GetGroup("org/platform", gomock.Any(), gomock.Any()).
+			Return(&gitlab.Group{ID: 2, FullPath: "org/platform"}, &gitlab.Response{}, nil)
+
+		groups, err := client.GetAncestorGroups(t.Context(), "org/platform/api")
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "org/platform", groups[0].FullPath)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "group", Path: "org", Reason: "ancestor group: 404 Not Found"},
+		}, client.Inaccessible())
+	})
+
+	t.Run("has no ancestors in a personal namespace", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockProjects.EXPECT().
+			GetProject("alice/scratch", nil, gomock.Any()).
+			Return(&gitlab.Project{
+				ID:                40,
+				PathWithNamespace: "alice/scratch",
+				Namespace:         &gitlab.ProjectNamespace{Kind: "user", FullPath: "alice"},
+			}, &gitlab.Response{}, nil)
+
+		groups, err := client.GetAncestorGroups(t.Context(), "alice/scratch")
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+}
+
+func TestGetAncestorGroupVariables(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	expectNestedProject(mockClient)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("org", gomock.Any(), gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Path: "org", FullPath: "org"}, &gitlab.Response{}, nil)
+	mockClient.MockGroups.EXPECT().
+		GetGroup("org/platform", gomock.Any(), gomock.Any()).
+		Return(&gitlab.Group{ID: 2, Path: "platform", FullPath: "org/platform"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroupVariables.EXPECT().
+		ListVariables("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.GroupVariable{{Key: "REGISTRY"}}, &gitlab.Response{}, nil)
+	mockClient.MockGroupVariables.EXPECT().
+		ListVariables("2", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.GroupVariable{{Key: "DEPLOY_TOKEN"}}, &gitlab.Response{}, nil)
+
+	variables, err := client.GetAncestorGroupVariables(t.Context(), "org/platform/api")
+	require.NoError(t, err)
+	require.Len(t, variables, 2)
+	assert.Equal(t, "org", variables[0].GroupFullPath)
+	assert.Equal(t, "REGISTRY", variables[0].Key)
+	assert.Equal(t, "org/platform", variables[1].GroupFullPath)
+	assert.Equal(t, "DEPLOY_TOKEN", variables[1].Key)
+}
@@ -0,0 +1,306 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const badgePlaceholderPrefix = "%{"
+
+// BadgeWithSource represents a project or group badge with source identification.
+type BadgeWithSource struct {
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Kind             string `json:"kind"`
+	LinkURL          string `json:"link_url"`
+	ImageURL         string `json:"image_url"`
+	RenderedLinkURL  string `json:"rendered_link_url"`
+	RenderedImageURL string `json:"rendered_image_url"`
+	Source           string `json:"source"` // "project" or "group"
+	SourceID         int    `json:"source_id"`
+	SourceName       string `json:"source_name"`
+	SourcePath       string `json:"source_path"`
+	SourceWebURL     string `json:"source_web_url"`
+	ImageStatus      int    `json:"image_status,omitempty"` // only set by CheckBadgeImages
+	ImageError       string `json:"image_error,omitempty"`  // only set by CheckBadgeImages
+}
+
+// CheckableImageURL returns the URL that should be requested to verify the badge image.
+// The rendered URL is preferred because GitLab has already substituted the %{...} placeholders.
+// An empty string is returned when the URL still contains unresolved placeholders,
+// which is the case for group badges that reference project-only placeholders.
+func (b *BadgeWithSource) CheckableImageURL() string {
+	url := b.RenderedImageURL
+	if url == "" {
+		url = b.ImageURL
+	}
+
+	if url == "" || strings.Contains(url, badgePlaceholderPrefix) {
+		return ""
+	}
+
+	return url
+}
+
+// IsBroken reports whether a checked badge image did not respond with HTTP 200.
+// Badges without a checkable image URL are never reported as broken.
+func (b *BadgeWithSource) IsBroken() bool {
+	if b.CheckableImageURL() == "" {
+		return false
+	}
+
+	return b.ImageStatus != http.StatusOK
+}
+
+// GetBadgesRecursively fetches all group and project badges within a group and its subgroups.
+// Group badges inherited by projects are reported once, on the group that defines them.
+func (c *Client) GetBadgesRecursively(ctx context.Context, groupID string) ([]*BadgeWithSource, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive badges fetch for group ID %s\n", groupID)
+	}
+
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	projects := c.projectsForGroups(ctx, groups)
+	if err := c.interrupted(ctx, "project fetch"); err != nil {
+		return nil, err
+	}
+
+	var (
+		allBadges []*BadgeWithSource
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+
+		groupID := strconv.Itoa(group.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchBadgesForGroup(ctx, groupID, group, &allBadges, &mu)
+		})
+	}
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchBadgesForProject(ctx, projectID, project, &allBadges, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "badges fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortBadges(allBadges)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive badges fetch, found %d badges\n", len(allBadges))
+	}
+
+	return allBadges, nil
+}
+
+// CheckBadgeImages issues a HEAD request against each badge image URL and records the result
+// in ImageStatus and ImageError. Requests are throttled by the client's link rate limiter.
+func (c *Client) CheckBadgeImages(ctx context.Context, badges []*BadgeWithSource) {
+	var wg sync.WaitGroup
+
+	for _, badge := range badges {
+		url := badge.CheckableImageURL()
+		if url == "" {
+			continue
+		}
+
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			status, err := c.headStatus(ctx, c.httpClient, url)
+			if err != nil {
+				badge.ImageError = err.Error()
+			}
+
+			badge.ImageStatus = status
+		})
+	}
+
+	wg.Wait()
+}
+
+func (c *Client) listBadgesForGroup(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+) ([]*BadgeWithSource, error) {
+	var allBadges []*BadgeWithSource
+
+	opt := &gitlab.ListGroupBadgesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		badges, resp, err := c.client.GroupBadges.ListGroupBadges(groupID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group badges: %w", err)
+		}
+
+		for _, badge := range badges {
+			allBadges = append(allBadges, &BadgeWithSource{
+				ReportType:       ReportTypeGroupBadge,
+				ID:               badge.ID,
+				Name:             badge.Name,
+				Kind:             string(badge.Kind),
+				LinkURL:          badge.LinkURL,
+				ImageURL:         badge.ImageURL,
+				RenderedLinkURL:  badge.RenderedLinkURL,
+				RenderedImageURL: badge.RenderedImageURL,
+				Source:           "group",
+				SourceID:         group.ID,
+				SourceName:       group.Name,
+				SourcePath:       group.FullPath,
+				SourceWebURL:     c.webURL(group.WebURL),
+			})
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d badges for group %s\n", len(badges), groupID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allBadges, nil
+}
+
+func (c *Client) fetchBadgesForGroup(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+	badges *[]*BadgeWithSource,
+	mu *sync.Mutex,
+) {
+	groupBadges, err := c.listBadgesForGroup(ctx, groupID, group)
+	if err != nil {
+		c.recordInaccessible(ctx, "group", group.FullPath, "group badges", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching badges for group %s: %v\n", groupID, err)
+		}
+
+		return
+	}
+
+	mu.Lock()
+	*badges = append(*badges, groupBadges...)
+	mu.Unlock()
+	c.countItems(len(groupBadges))
+}
+
+func (c *Client) listBadgesForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) ([]*BadgeWithSource, error) {
+	var allBadges []*BadgeWithSource
+
+	opt := &gitlab.ListProjectBadgesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		badges, resp, err := c.client.ProjectBadges.ListProjectBadges(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list project badges: %w", err)
+		}
+
+		for _, badge := range badges {
+			// inherited group badges are reported by the group that owns them
+			if badge.Kind == string(gitlab.GroupBadgeKind) {
+				continue
+			}
+
+			allBadges = append(allBadges, &BadgeWithSource{
+				ReportType:       ReportTypeProjectBadge,
+				ID:               badge.ID,
+				Name:             badge.Name,
+				Kind:             badge.Kind,
+				LinkURL:          badge.LinkURL,
+				ImageURL:         badge.ImageURL,
+				RenderedLinkURL:  badge.RenderedLinkURL,
+				RenderedImageURL: badge.RenderedImageURL,
+				Source:           "project",
+				SourceID:         project.ID,
+				SourceName:       project.Name,
+				SourcePath:       project.PathWithNamespace,
+				SourceWebURL:     c.webURL(project.WebURL),
+			})
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d badges for project %s\n", len(badges), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allBadges, nil
+}
+
+func (c *Client) fetchBadgesForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+	badges *[]*BadgeWithSource,
+	mu *sync.Mutex,
+) {
+	projectBadges, err := c.listBadgesForProject(ctx, projectID, project)
+	if err != nil {
+		c.recordInaccessible(ctx, "project", project.PathWithNamespace, "project badges", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching badges for project %s: %v\n", projectID, err)
+		}
+
+		return
+	}
+
+	mu.Lock()
+	*badges = append(*badges, projectBadges...)
+	mu.Unlock()
+	c.countItems(len(projectBadges))
+}
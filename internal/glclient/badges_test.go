@@ -0,0 +1,175 @@
+package glclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetBadgesRecursively(t *testing.T) {
+	t.Run("fetches group and project badges", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		rootGroup := &gitlab.Group{
+			ID:       1,
+			Name:     "root-group",
+			FullPath: "root-group",
+			WebURL:   "https://gitlab.com/groups/root-group",
+		}
+
+		project := &gitlab.Project{
+			ID:                10,
+			Name:              "project",
+			PathWithNamespace: "root-group/project",
+			Namespace:         &gitlab.ProjectNamespace{FullPath: "root-group"},
+			WebURL:            "https://gitlab.com/root-group/project",
+		}
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Project{project}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroupBadges.EXPECT().
+			ListGroupBadges("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.GroupBadge{
+				{
+					ID:               1,
+					Name:             "coverage",
+					Kind:             gitlab.GroupBadgeKind,
+					ImageURL:         "https://gitlab.com/%{project_path}/badges/%{default_branch}/coverage.svg",
+					RenderedImageURL: "https://gitlab.com/%{project_path}/badges/%{default_branch}/coverage.svg",
+				},
+			}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectBadges.EXPECT().
+			ListProjectBadges("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectBadge{
+				{
+					ID:       1,
+					Name:     "coverage",
+					Kind:     string(gitlab.GroupBadgeKind),
+					ImageURL: "https://gitlab.com/%{project_path}/badges/%{default_branch}/coverage.svg",
+				},
+				{
+					ID:               2,
+					Name:             "pipeline",
+					Kind:             string(gitlab.ProjectBadgeKind),
+					ImageURL:         "https://gitlab.com/%{project_path}/badges/main/pipeline.svg",
+					RenderedImageURL: "https://gitlab.com/root-group/project/badges/main/pipeline.svg",
+				},
+			}, &gitlab.Response{}, nil)
+
+		badges, err := client.GetBadgesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, badges, 2)
+
+		sources := make(map[string]*glclient.BadgeWithSource)
+		for _, badge := range badges {
+			sources[badge.Source] = badge
+		}
+
+		require.Contains(t, sources, "group")
+		require.Contains(t, sources, "project")
+		assert.Equal(t, glclient.ReportTypeGroupBadge, sources["group"].ReportType)
+		assert.Equal(t, glclient.ReportTypeProjectBadge, sources["project"].ReportType)
+		assert.Equal(t, "root-group", sources["group"].SourcePath)
+		assert.Equal(t, "root-group/project", sources["project"].SourcePath)
+		assert.Equal(t, "pipeline", sources["project"].Name)
+	})
+
+	t.Run("returns error when root group fails", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(nil, nil, errAPI)
+
+		badges, err := client.GetBadgesRecursively(t.Context(), "1")
+		require.Error(t, err)
+		assert.Nil(t, badges)
+	})
+}
+
+func TestCheckBadgeImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		if r.URL.Path == "/missing.svg" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := testClient(t)
+
+	okBadge := &glclient.BadgeWithSource{Name: "ok", RenderedImageURL: server.URL + "/ok.svg"}
+	missingBadge := &glclient.BadgeWithSource{Name: "missing", RenderedImageURL: server.URL + "/missing.svg"}
+	templatedBadge := &glclient.BadgeWithSource{Name: "templated", ImageURL: server.URL + "/%{project_path}.svg"}
+
+	client.CheckBadgeImages(t.Context(), []*glclient.BadgeWithSource{okBadge, missingBadge, templatedBadge})
+
+	assert.Equal(t, http.StatusOK, okBadge.ImageStatus)
+	assert.False(t, okBadge.IsBroken())
+
+	assert.Equal(t, http.StatusNotFound, missingBadge.ImageStatus)
+	assert.True(t, missingBadge.IsBroken())
+
+	assert.Zero(t, templatedBadge.ImageStatus)
+	assert.False(t, templatedBadge.IsBroken())
+}
+
+func TestBadgeWithSource_CheckableImageURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		badge glclient.BadgeWithSource
+		want  string
+	}{
+		{
+			name: "prefers rendered URL",
+			badge: glclient.BadgeWithSource{
+				ImageURL:         "https://example.com/%{project_path}.svg",
+				RenderedImageURL: "https://example.com/org/app.svg",
+			},
+			want: "https://example.com/org/app.svg",
+		},
+		{
+			name:  "falls back to raw URL without placeholders",
+			badge: glclient.BadgeWithSource{ImageURL: "https://example.com/static.svg"},
+			want:  "https://example.com/static.svg",
+		},
+		{
+			name:  "skips unresolved placeholders",
+			badge: glclient.BadgeWithSource{ImageURL: "https://example.com/%{commit_sha}.svg"},
+			want:  "",
+		},
+		{
+			name:  "skips empty URL",
+			badge: glclient.BadgeWithSource{},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.badge.CheckableImageURL())
+		})
+	}
+}
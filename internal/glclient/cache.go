@@ -0,0 +1,99 @@
+package glclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// cached returns the value stored in the client's cache for the given kind and root group ID,
+// or calls fetch and stores its sanitized result. Cache failures never fail the fetch itself.
+func cached[T any](
+	ctx context.Context,
+	c *Client,
+	kind, groupID string,
+	sanitize func(T) T,
+	fetch func() (T, error),
+) (T, error) {
+	if c.cache == nil {
+		return fetch()
+	}
+
+	// entries are per GitLab instance and token, so the same group ID on another instance is not
+	// reused, nor are the groups a more privileged token could read
+	key := fmt.Sprintf("%s|%s|%s|%s", c.baseURL, c.credential, kind, groupID)
+
+	var value T
+
+	hit, err := c.cache.Get(key, &value)
+	if err != nil && c.debug {
+		fmt.Printf("DEBUG: ignoring unreadable cache entry for %s of group %q: %v\n", kind, groupID, err)
+	}
+
+	if hit {
+		if c.debug {
+			fmt.Printf("DEBUG: using cached %s for group %q\n", kind, groupID)
+		}
+
+		return value, nil
+	}
+
+	skipped := c.inaccessibleCount()
+
+	value, err = fetch()
+	if err != nil {
+		return value, err
+	}
+
+	// an incomplete result would hide the skipped resources from later runs
+	if c.inaccessibleCount() > skipped || ctx.Err() != nil {
+		if c.debug {
+			fmt.Printf("DEBUG: not caching incomplete %s for group %q\n", kind, groupID)
+		}
+
+		return value, nil
+	}
+
+	if err := c.cache.Put(key, sanitize(value)); err != nil && c.debug {
+		fmt.Printf("DEBUG: failed to cache %s for group %q: %v\n", kind, groupID, err)
+	}
+
+	return value, nil
+}
+
+// tokenFingerprint returns a short hash of token that tells tokens apart without revealing them in
+// the cache keys.
+func tokenFingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// sanitizeGroups returns copies of the groups without secrets, suitable for storing on disk.
+func sanitizeGroups(groups []*gitlab.Group) []*gitlab.Group {
+	sanitized := make([]*gitlab.Group, 0, len(groups))
+
+	for _, group := range groups {
+		g := *group
+		g.RunnersToken = ""
+		sanitized = append(sanitized, &g)
+	}
+
+	return sanitized
+}
+
+// sanitizeProjects returns copies of the projects without secrets, suitable for storing on disk.
+func sanitizeProjects(projects []*gitlab.Project) []*gitlab.Project {
+	sanitized := make([]*gitlab.Project, 0, len(projects))
+
+	for _, project := range projects {
+		p := *project
+		p.RunnersToken = ""
+		sanitized = append(sanitized, &p)
+	}
+
+	return sanitized
+}
@@ -0,0 +1,153 @@
+package glclient_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/cache"
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetGroupsRecursively_cache(t *testing.T) {
+	rootGroup := &gitlab.Group{
+		ID:           1,
+		Name:         "root-group",
+		FullPath:     "root-group",
+		RunnersToken: "runner-secret",
+	}
+
+	setup := func(t *testing.T) (*glclient.Client, *gitlabtesting.TestClient, *time.Time, string) {
+		t.Helper()
+
+		dir := t.TempDir()
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		store := cache.New(dir, time.Hour, cache.WithClock(func() time.Time { return now }))
+
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithCache(store))
+
+		return client, mockClient, &now, dir
+	}
+
+	expectFetch := func(mockClient *gitlabtesting.TestClient) {
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+	}
+
+	t.Run("reuses a fresh entry", func(t *testing.T) {
+		client, mockClient, now, _ := setup(t)
+		expectFetch(mockClient)
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+
+		*now = now.Add(30 * time.Minute)
+
+		cachedGroups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, cachedGroups, 1)
+		assert.Equal(t, "root-group", cachedGroups[0].FullPath)
+	})
+
+	t.Run("fetches again after the TTL expires", func(t *testing.T) {
+		client, mockClient, now, _ := setup(t)
+		expectFetch(mockClient)
+		expectFetch(mockClient)
+
+		_, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		*now = now.Add(2 * time.Hour)
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+	})
+
+	t.Run("does not store secrets", func(t *testing.T) {
+		client, mockClient, _, dir := setup(t)
+		expectFetch(mockClient)
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, "runner-secret", groups[0].RunnersToken)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		data, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "runner-secret")
+	})
+}
+
+func TestGetGroupsRecursively_cachePerToken(t *testing.T) {
+	const (
+		group     = `{"id": 1, "full_path": "root-group"}`
+		subgroups = `[{"id": 2, "full_path": "root-group/private", "parent_id": 1}]`
+	)
+
+	dir := t.TempDir()
+	transport := newScriptedTransport(
+		scriptedResponse{status: http.StatusOK, body: group},
+		scriptedResponse{status: http.StatusOK, body: subgroups},
+		scriptedResponse{status: http.StatusOK, body: `[]`},
+		scriptedResponse{status: http.StatusOK, body: group},
+		scriptedResponse{status: http.StatusOK, body: `[]`},
+	)
+
+	newCachedClient := func(token string) *glclient.Client {
+		client, err := glclient.NewClientWithTransport(token, transport, false,
+			glclient.WithBaseURL("https://gitlab.example.com"), glclient.WithCache(cache.New(dir, time.Hour)))
+		require.NoError(t, err)
+
+		return client
+	}
+
+	admin, err := newCachedClient("admin-token").GetGroupsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	require.Len(t, admin, 2)
+
+	// a token sharing the cache directory fetches the groups it can read itself
+	limited, err := newCachedClient("limited-token").GetGroupsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+	assert.Equal(t, "root-group", limited[0].FullPath)
+
+	// each token reuses its own entry
+	cachedAdmin, err := newCachedClient("admin-token").GetGroupsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Len(t, cachedAdmin, 2)
+
+	cachedLimited, err := newCachedClient("limited-token").GetGroupsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Len(t, cachedLimited, 1)
+
+	assert.Len(t, transport.requests(), 5)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "admin-token")
+		assert.NotContains(t, string(data), "limited-token")
+	}
+}
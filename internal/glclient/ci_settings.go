@@ -0,0 +1,139 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectCISettings represents the general CI/CD settings of a project.
+type ProjectCISettings struct {
+	ReportType               string `json:"report_type" csv:"-"`
+	Instance                 string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID                int    `json:"project_id"`
+	ProjectName              string `json:"project_name"`
+	ProjectPath              string `json:"project_path"`
+	ProjectWebURL            string `json:"project_web_url"`
+	CIEnabled                bool   `json:"ci_enabled"`
+	PublicPipelines          bool   `json:"public_pipelines"` // job logs and artifacts visible to non-members
+	GitStrategy              string `json:"git_strategy"`
+	GitDepth                 int    `json:"git_depth"`
+	ConfigPath               string `json:"config_path"`
+	Timeout                  int    `json:"timeout"` // seconds
+	KeepLatestArtifact       bool   `json:"keep_latest_artifact"`
+	ForkPipelinesInParent    bool   `json:"fork_pipelines_in_parent"`
+	JobTokenAllowlistEnabled bool   `json:"job_token_allowlist_enabled"` // limits access to this project
+	JobTokenPushAllowed      bool   `json:"job_token_push_allowed"`
+}
+
+// GetCISettingsRecursively fetches the CI/CD settings of all projects within a group and its subgroups.
+// GitLab only includes the CI/CD settings for projects the token maintains, so each project is
+// fetched again along with its job token access settings. Projects whose settings the token cannot
+// read, which requires at least the Maintainer role, are reported as inaccessible. Projects with
+// CI/CD disabled are listed without querying their job token settings.
+func (c *Client) GetCISettingsRecursively(ctx context.Context, groupID string) ([]*ProjectCISettings, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive CI/CD settings fetch for group ID %s\n", groupID)
+	}
+
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allSettings []*ProjectCISettings
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchCISettingsForProject(ctx, projectID, project, &allSettings, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "CI/CD settings fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortCISettings(allSettings)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive CI/CD settings fetch, found %d projects\n", len(allSettings))
+	}
+
+	return allSettings, nil
+}
+
+func (c *Client) getCISettingsForProject(ctx context.Context, projectID string) (*ProjectCISettings, error) {
+	project, _, err := c.client.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	settings := &ProjectCISettings{
+		ReportType:            ReportTypeProjectCISettings,
+		CIEnabled:             project.BuildsAccessLevel != gitlab.DisabledAccessControl,
+		PublicPipelines:       project.PublicJobs,
+		GitStrategy:           project.BuildGitStrategy,
+		GitDepth:              project.CIDefaultGitDepth,
+		ConfigPath:            project.CIConfigPath,
+		Timeout:               project.BuildTimeout,
+		KeepLatestArtifact:    project.KeepLatestArtifact,
+		ForkPipelinesInParent: project.CIAllowForkPipelinesToRunInParentProject,
+		JobTokenPushAllowed:   project.CIPushRepositoryForJobTokenAllowed,
+	}
+
+	if !settings.CIEnabled {
+		return settings, nil
+	}
+
+	access, _, err := c.client.JobTokenScope.GetProjectJobTokenAccessSettings(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job token access settings: %w", err)
+	}
+
+	settings.JobTokenAllowlistEnabled = access.InboundEnabled
+
+	return settings, nil
+}
+
+func (c *Client) fetchCISettingsForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+	allSettings *[]*ProjectCISettings,
+	mu *sync.Mutex,
+) {
+	settings, err := c.getCISettingsForProject(ctx, projectID)
+	if err != nil {
+		c.recordInaccessible(ctx, "project", project.PathWithNamespace, "CI/CD settings", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching CI/CD settings for project %s: %v\n", projectID, err)
+		}
+
+		return
+	}
+
+	settings.ProjectID = project.ID
+	settings.ProjectName = project.Name
+	settings.ProjectPath = project.PathWithNamespace
+	settings.ProjectWebURL = c.webURL(project.WebURL)
+
+	mu.Lock()
+	*allSettings = append(*allSettings, settings)
+	mu.Unlock()
+	c.countItems(1)
+}
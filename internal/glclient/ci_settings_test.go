@@ -0,0 +1,108 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCISettingsRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	projects := []*gitlab.Project{
+		{
+			ID:                10,
+			Name:              "api",
+			PathWithNamespace: "root-group/api",
+			WebURL:            "https://gitlab.com/root-group/api",
+		},
+		{ID: 11, Name: "docs", PathWithNamespace: "root-group/docs"},
+		{ID: 12, Name: "secret", PathWithNamespace: "root-group/secret"},
+	}
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return(projects, &gitlab.Response{}, nil)
+
+	mockClient.MockProjects.EXPECT().
+		GetProject("10", nil, gomock.Any()).
+		Return(&gitlab.Project{
+			ID:                                 10,
+			BuildsAccessLevel:                  gitlab.EnabledAccessControl,
+			PublicJobs:                         true,
+			BuildGitStrategy:                   "fetch",
+			CIDefaultGitDepth:                  20,
+			CIConfigPath:                       ".gitlab-ci.yml",
+			BuildTimeout:                       3600,
+			KeepLatestArtifact:                 true,
+			CIPushRepositoryForJobTokenAllowed: true,
+		}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenAccessSettings("10", gomock.Any()).
+		Return(&gitlab.JobTokenAccessSettings{InboundEnabled: true}, &gitlab.Response{}, nil)
+
+	// CI/CD is disabled, so the job token settings are not queried
+	mockClient.MockProjects.EXPECT().
+		GetProject("11", nil, gomock.Any()).
+		Return(&gitlab.Project{ID: 11, BuildsAccessLevel: gitlab.DisabledAccessControl}, &gitlab.Response{}, nil)
+
+	mockClient.MockProjects.EXPECT().
+		GetProject("12", nil, gomock.Any()).
+		Return(&gitlab.Project{ID: 12, BuildsAccessLevel: gitlab.PrivateAccessControl}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenAccessSettings("12", gomock.Any()).
+		Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+	settings, err := client.GetCISettingsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	require.Len(t, settings, 2)
+
+	byPath := make(map[string]*glclient.ProjectCISettings, len(settings))
+	for _, s := range settings {
+		byPath[s.ProjectPath] = s
+	}
+
+	assert.Equal(t, &glclient.ProjectCISettings{
+		ReportType:               glclient.ReportTypeProjectCISettings,
+		ProjectID:                10,
+		ProjectName:              "api",
+		ProjectPath:              "root-group/api",
+		ProjectWebURL:            "https://gitlab.com/root-group/api",
+		CIEnabled:                true,
+		PublicPipelines:          true,
+		GitStrategy:              "fetch",
+		GitDepth:                 20,
+		ConfigPath:               ".gitlab-ci.yml",
+		Timeout:                  3600,
+		KeepLatestArtifact:       true,
+		JobTokenAllowlistEnabled: true,
+		JobTokenPushAllowed:      true,
+	}, byPath["root-group/api"])
+
+	assert.Equal(t, &glclient.ProjectCISettings{
+		ReportType:  glclient.ReportTypeProjectCISettings,
+		ProjectID:   11,
+		ProjectName: "docs",
+		ProjectPath: "root-group/docs",
+	}, byPath["root-group/docs"])
+
+	assert.Equal(t, []glclient.Inaccessible{
+		{Kind: "project", Path: "root-group/secret", Reason: "CI/CD settings: 403 Forbidden"},
+	}, client.Inaccessible())
+}
@@ -1,35 +1,59 @@
 package glclient
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/andreygrechin/glreporter/internal/cache"
 	"github.com/andreygrechin/glreporter/internal/worker"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 )
 
 // GroupAccessTokenWithGroup represents a group access token with associated group information.
 type GroupAccessTokenWithGroup struct {
 	*gitlab.GroupAccessToken
+	ReportType  string `json:"report_type" csv:"-"`
+	Instance    string `json:"instance,omitempty" csv:"omitempty"`
+	GroupID     int    `json:"group_id"`
 	GroupName   string `json:"group_name"`
 	GroupPath   string `json:"group_path"`
 	GroupWebURL string `json:"group_web_url"`
+	Username    string `json:"username,omitempty"` // of the token's bot user, set by ResolveGroupTokenUsers
+	// LastUsedIPs are the addresses the token was last used from, set by ResolveGroupTokenIPs where
+	// GitLab reports them.
+	LastUsedIPs []string `json:"last_used_ips,omitempty"`
 }
 
 // ProjectAccessTokenWithProject represents a project access token with associated project information.
 type ProjectAccessTokenWithProject struct {
 	*gitlab.ProjectAccessToken
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID        int    `json:"project_id"`
 	ProjectName      string `json:"project_name"`
 	ProjectPath      string `json:"project_path"`
 	ProjectNamespace string `json:"project_namespace"`
 	ProjectWebURL    string `json:"project_web_url"`
+	Username         string `json:"username,omitempty"` // of the token's bot user, set by ResolveProjectTokenUsers
+	// LastUsedIPs are the addresses the token was last used from, set by ResolveProjectTokenIPs where
+	// GitLab reports them.
+	LastUsedIPs []string `json:"last_used_ips,omitempty"`
 }
 
 // PipelineTriggerWithProject represents a pipeline trigger with associated project information.
 type PipelineTriggerWithProject struct {
 	*gitlab.PipelineTrigger
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID        int    `json:"project_id"`
 	ProjectName      string `json:"project_name"`
 	ProjectPath      string `json:"project_path"`
 	ProjectNamespace string `json:"project_namespace"`
@@ -39,6 +63,9 @@ type PipelineTriggerWithProject struct {
 // ProjectVariableWithProject represents a project variable with associated project information.
 type ProjectVariableWithProject struct {
 	*gitlab.ProjectVariable
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID        int    `json:"project_id"`
 	ProjectName      string `json:"project_name"`
 	ProjectPath      string `json:"project_path"`
 	ProjectNamespace string `json:"project_namespace"`
@@ -48,6 +75,9 @@ type ProjectVariableWithProject struct {
 // GroupVariableWithGroup represents a GitLab group variable with additional group information.
 type GroupVariableWithGroup struct {
 	*gitlab.GroupVariable
+	ReportType    string `json:"report_type" csv:"-"`
+	Instance      string `json:"instance,omitempty" csv:"omitempty"`
+	GroupID       int    `json:"group_id"`
 	GroupName     string `json:"group_name"`
 	GroupPath     string `json:"group_path"`
 	GroupWebURL   string `json:"group_web_url"`
@@ -56,6 +86,8 @@ type GroupVariableWithGroup struct {
 
 // VariableWithSource represents a CI/CD variable from either a project or group with source identification.
 type VariableWithSource struct {
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
 	Key              string `json:"key"`
 	Value            string `json:"value"`
 	VariableType     string `json:"variable_type"`
@@ -66,6 +98,7 @@ type VariableWithSource struct {
 	EnvironmentScope string `json:"environment_scope"`
 	Description      string `json:"description"`
 	Source           string `json:"source"` // "project" or "group"
+	SourceID         int    `json:"source_id"`
 	SourceName       string `json:"source_name"`
 	SourcePath       string `json:"source_path"`
 	SourceWebURL     string `json:"source_web_url"`
@@ -74,6 +107,8 @@ type VariableWithSource struct {
 
 // ProjectVariableWithProjectFiltered represents a project variable without the Value field for security.
 type ProjectVariableWithProjectFiltered struct {
+	ReportType       string                   `json:"report_type" csv:"-"`
+	Instance         string                   `json:"instance,omitempty" csv:"omitempty"`
 	Key              string                   `json:"key"`
 	VariableType     gitlab.VariableTypeValue `json:"variable_type"`
 	Protected        bool                     `json:"protected"`
@@ -82,6 +117,7 @@ type ProjectVariableWithProjectFiltered struct {
 	Raw              bool                     `json:"raw"`
 	EnvironmentScope string                   `json:"environment_scope"`
 	Description      string                   `json:"description"`
+	ProjectID        int                      `json:"project_id"`
 	ProjectName      string                   `json:"project_name"`
 	ProjectPath      string                   `json:"project_path"`
 	ProjectNamespace string                   `json:"project_namespace"`
@@ -90,6 +126,8 @@ type ProjectVariableWithProjectFiltered struct {
 
 // GroupVariableWithGroupFiltered represents a group variable without the Value field for security.
 type GroupVariableWithGroupFiltered struct {
+	ReportType       string                   `json:"report_type" csv:"-"`
+	Instance         string                   `json:"instance,omitempty" csv:"omitempty"`
 	Key              string                   `json:"key"`
 	VariableType     gitlab.VariableTypeValue `json:"variable_type"`
 	Protected        bool                     `json:"protected"`
@@ -98,6 +136,7 @@ type GroupVariableWithGroupFiltered struct {
 	Raw              bool                     `json:"raw"`
 	EnvironmentScope string                   `json:"environment_scope"`
 	Description      string                   `json:"description"`
+	GroupID          int                      `json:"group_id"`
 	GroupName        string                   `json:"group_name"`
 	GroupPath        string                   `json:"group_path"`
 	GroupWebURL      string                   `json:"group_web_url"`
@@ -106,6 +145,8 @@ type GroupVariableWithGroupFiltered struct {
 
 // VariableWithSourceFiltered represents a variable without the Value field for security.
 type VariableWithSourceFiltered struct {
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
 	Key              string `json:"key"`
 	VariableType     string `json:"variable_type"`
 	Protected        bool   `json:"protected"`
@@ -115,6 +156,7 @@ type VariableWithSourceFiltered struct {
 	EnvironmentScope string `json:"environment_scope"`
 	Description      string `json:"description"`
 	Source           string `json:"source"` // "project" or "group"
+	SourceID         int    `json:"source_id"`
 	SourceName       string `json:"source_name"`
 	SourcePath       string `json:"source_path"`
 	SourceWebURL     string `json:"source_web_url"`
@@ -124,6 +166,8 @@ type VariableWithSourceFiltered struct {
 // ConvertProjectVariableToUnified converts a ProjectVariableWithProject to VariableWithSource.
 func ConvertProjectVariableToUnified(pv *ProjectVariableWithProject) *VariableWithSource {
 	return &VariableWithSource{
+		ReportType:       ReportTypeProjectVariable,
+		Instance:         pv.Instance,
 		Key:              pv.Key,
 		Value:            pv.Value,
 		VariableType:     string(pv.VariableType),
@@ -134,6 +178,7 @@ func ConvertProjectVariableToUnified(pv *ProjectVariableWithProject) *VariableWi
 		EnvironmentScope: pv.EnvironmentScope,
 		Description:      pv.Description,
 		Source:           "project",
+		SourceID:         pv.ProjectID,
 		SourceName:       pv.ProjectName,
 		SourcePath:       pv.ProjectPath,
 		SourceWebURL:     pv.ProjectWebURL,
@@ -144,6 +189,8 @@ func ConvertProjectVariableToUnified(pv *ProjectVariableWithProject) *VariableWi
 // ConvertGroupVariableToUnified converts a GroupVariableWithGroup to VariableWithSource.
 func ConvertGroupVariableToUnified(gv *GroupVariableWithGroup) *VariableWithSource {
 	return &VariableWithSource{
+		ReportType:       ReportTypeGroupVariable,
+		Instance:         gv.Instance,
 		Key:              gv.Key,
 		Value:            gv.Value,
 		VariableType:     string(gv.VariableType),
@@ -154,6 +201,7 @@ func ConvertGroupVariableToUnified(gv *GroupVariableWithGroup) *VariableWithSour
 		EnvironmentScope: gv.EnvironmentScope,
 		Description:      gv.Description,
 		Source:           "group",
+		SourceID:         gv.GroupID,
 		SourceName:       gv.GroupName,
 		SourcePath:       gv.GroupFullPath,
 		SourceWebURL:     gv.GroupWebURL,
@@ -162,47 +210,191 @@ func ConvertGroupVariableToUnified(gv *GroupVariableWithGroup) *VariableWithSour
 
 // Client is a wrapper around the GitLab API client that includes a worker pool for concurrent operations.
 type Client struct {
-	client *gitlab.Client
-	pool   *worker.Pool
-	debug  bool
+	client      *gitlab.Client
+	pool        *worker.Pool
+	httpClient  *http.Client
+	urlClient   *http.Client
+	linkLimiter *rate.Limiter
+	cache       *cache.Cache
+	baseURL     string
+	debug       bool
+	pageSize    int
+
+	// credential fingerprints the token, so that cached hierarchies are not shared between tokens
+	credential string
+
+	// sharedProjects includes projects shared into a group in its project listing
+	sharedProjects bool
+	// sharedAttribution lists shared projects once per group they are shared into
+	sharedAttribution bool
+	// personalNamespaces adds projects in user namespaces when listing the projects of all groups
+	personalNamespaces bool
+	// stripQueryParams removes query strings and fragments from web URLs in the results
+	stripQueryParams bool
+	// partial returns the data collected so far when the context deadline expires
+	partial bool
+	// groupTimeout limits how long listing the subgroups or projects of a single group may take
+	groupTimeout time.Duration
+	// topic limits the listed projects to those carrying it
+	topic string
+	// naturalSort orders items by the paths of their groups and projects naturally
+	naturalSort bool
+
+	// limit stops the fetches once they collected the items asked for by LimitItems
+	limit *itemLimit
+
+	// keysetUnsupported records list endpoints that rejected keyset pagination
+	keysetUnsupported sync.Map
+	// usernames memoizes the lookups of Username by user ID
+	usernames sync.Map
+	// namespaceGroups memoizes the lookups of namespaceGroup by group path
+	namespaceGroups sync.Map
+	// inaccessible records the groups and projects skipped because the token cannot read them
+	inaccessible inaccessibleLog
+	// failures records the groups and projects whose variables or tokens could not be fetched
+	failures failureLog
+	// versionOnce guards the fetch of version, the GitLab version of the instance
+	versionOnce sync.Once
+	version     serverVersion
 }
 
 const (
-	maxPageSize   = 50  // Maximum number of items per page
-	maxNumWorkers = 100 // Maximum number of concurrent workers
+	defaultPageSize   = 50               // Number of items per page
+	defaultNumWorkers = 100              // Number of concurrent workers
+	defaultMaxRetries = 5                // Retries of a request rejected with 429 or failing with 5xx
+	linkCheckRate     = 10               // Maximum number of link checks per second
+	linkCheckTimeout  = 10 * time.Second // Timeout of a single link check
 )
 
 // NewClient creates a new GitLab client with a worker pool.
-func NewClient(token string, debug bool) (*Client, error) {
-	client, err := gitlab.NewClient(token)
+func NewClient(token string, debug bool, opts ...Option) (*Client, error) {
+	return NewClientWithTransport(token, http.DefaultTransport, debug, opts...)
+}
+
+// NewClientWithTransport creates a new client sending its API requests through transport instead of
+// the default HTTP transport (useful for testing). The retries of rate-limited requests and the request
+// timeout are layered on top of transport exactly as for NewClient, so tests can drive them with
+// scripted responses without a network.
+func NewClientWithTransport(token string, transport http.RoundTripper, debug bool, opts ...Option) (*Client, error) {
+	o := newOptions(opts)
+
+	var clientOpts []gitlab.ClientOptionFunc
+	if o.baseURL != "" {
+		clientOpts = append(clientOpts, gitlab.WithBaseURL(o.baseURL))
+	}
+
+	if o.etagCache != nil {
+		transport = &etagTransport{base: transport, store: o.etagCache, debug: debug}
+	}
+
+	clientOpts = append(clientOpts,
+		gitlab.WithHTTPClient(&http.Client{
+			Transport: &secondaryRateLimitTransport{base: transport, debug: debug},
+			Timeout:   o.requestTimeout,
+		}),
+		gitlab.WithCustomRetryMax(o.profile.MaxRetries),
+	)
+
+	// without a limit of its own, the GitLab client paces requests by the rate limit headers of the instance
+	if o.profile.RateLimit > 0 {
+		clientOpts = append(clientOpts, gitlab.WithCustomLimiter(rate.NewLimiter(rate.Limit(o.profile.RateLimit), 1)))
+	}
+
+	client, err := gitlab.NewClient(token, clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitLab client: %w", err)
 	}
 
-	return &Client{
-		client: client,
-		pool:   worker.NewPool(maxNumWorkers),
-		debug:  debug,
-	}, nil
+	c := newClient(client, debug, o)
+	c.credential = tokenFingerprint(token)
+
+	return c, nil
 }
 
 // NewClientWithGitLabClient creates a new client with a provided GitLab client (useful for testing).
-func NewClientWithGitLabClient(gitlabClient *gitlab.Client, debug bool) *Client {
+// Options that configure the GitLab client itself, such as WithBaseURL and the rate limit and retries
+// of WithProfile, are ignored.
+func NewClientWithGitLabClient(gitlabClient *gitlab.Client, debug bool, opts ...Option) *Client {
+	return newClient(gitlabClient, debug, newOptions(opts))
+}
+
+func newClient(gitlabClient *gitlab.Client, debug bool, o options) *Client {
 	return &Client{
-		client: gitlabClient,
-		pool:   worker.NewPool(maxNumWorkers),
-		debug:  debug,
+		client:      gitlabClient,
+		pool:        worker.NewPool(o.profile.Concurrency),
+		httpClient:  &http.Client{Timeout: linkCheckTimeout},
+		urlClient:   &http.Client{Timeout: linkCheckTimeout, CheckRedirect: keepRedirect},
+		linkLimiter: rate.NewLimiter(linkCheckRate, 1),
+		cache:       o.cache,
+		baseURL:     o.baseURL,
+		debug:       debug,
+		pageSize:    o.profile.PageSize,
+
+		sharedProjects:     o.sharedProjects,
+		sharedAttribution:  o.sharedAttribution,
+		personalNamespaces: o.personalNamespaces,
+		stripQueryParams:   o.stripQueryParams,
+		partial:            o.partial,
+		groupTimeout:       o.groupTimeout,
+		topic:              o.topic,
+		naturalSort:        o.naturalSort,
 	}
 }
 
 // GetGroupsRecursively fetches all groups and their subgroups starting from a given group ID.
 // If groupID is negative, return an error.
-func (c *Client) GetGroupsRecursively(groupID string) ([]*gitlab.Group, error) {
-	// If no group ID is provided, fetch all accessible groups
+// When the client has a cache, a fresh cached result is returned instead of querying the API.
+func (c *Client) GetGroupsRecursively(ctx context.Context, groupID string) ([]*gitlab.Group, error) {
+	groups, err := cached(ctx, c, c.groupsKind("groups"), groupID, sanitizeGroups, func() ([]*gitlab.Group, error) {
+		// If no group ID is provided, fetch all accessible groups
+		if groupID == "" {
+			return c.GetAllGroups(ctx)
+		}
+
+		return c.getGroupTree(ctx, groupID, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stripWebURLs(c, groups)
+
+	return groups, nil
+}
+
+// GetDirectSubgroups fetches a group and its direct subgroups, without descending further.
+// When the client has a cache, a fresh cached result is returned instead of querying the API.
+func (c *Client) GetDirectSubgroups(ctx context.Context, groupID string) ([]*gitlab.Group, error) {
 	if groupID == "" {
-		return c.GetAllGroups()
+		return nil, ErrGroupRequired
 	}
 
+	kind := c.groupsKind("direct subgroups")
+
+	groups, err := cached(ctx, c, kind, groupID, sanitizeGroups, func() ([]*gitlab.Group, error) {
+		return c.getGroupTree(ctx, groupID, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stripWebURLs(c, groups)
+
+	return groups, nil
+}
+
+// groupsKind returns the cache kind of a group listing, kept apart by the order of its groups.
+func (c *Client) groupsKind(kind string) string {
+	if c.naturalSort {
+		return kind + " sorted naturally"
+	}
+
+	return kind
+}
+
+// getGroupTree fetches a group and its subgroups, descending into all levels when recursive is set
+// and into the direct subgroups only otherwise.
+func (c *Client) getGroupTree(ctx context.Context, groupID string, recursive bool) ([]*gitlab.Group, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: starting recursive group fetch for group ID %s\n", groupID)
 	}
@@ -213,9 +405,9 @@ func (c *Client) GetGroupsRecursively(groupID string) ([]*gitlab.Group, error) {
 		wg     sync.WaitGroup
 	)
 
-	rootGroup, _, err := c.client.Groups.GetGroup(groupID, nil)
+	rootGroup, _, err := c.client.Groups.GetGroup(groupID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get root group: %w", err)
+		return nil, fmt.Errorf("failed to get root group: %w", c.rootGroupLookupError(ctx, groupID, err))
 	}
 
 	groups = append(groups, rootGroup)
@@ -223,11 +415,17 @@ func (c *Client) GetGroupsRecursively(groupID string) ([]*gitlab.Group, error) {
 	wg.Add(1)
 	c.pool.Submit(func() {
 		defer wg.Done()
-		c.fetchSubgroups(groupID, &groups, &mu, &wg)
+		c.fetchSubgroups(ctx, groupID, rootGroup.FullPath, recursive, &groups, &mu, &wg)
 	})
 
 	wg.Wait()
 
+	if err := c.interrupted(ctx, "group fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortGroups(groups)
+
 	if c.debug {
 		fmt.Printf("DEBUG: completed group fetch, found %d groups\n", len(groups))
 	}
@@ -236,37 +434,34 @@ func (c *Client) GetGroupsRecursively(groupID string) ([]*gitlab.Group, error) {
 }
 
 // GetAllGroups fetches all accessible groups.
-func (c *Client) GetAllGroups() ([]*gitlab.Group, error) {
+func (c *Client) GetAllGroups(ctx context.Context) ([]*gitlab.Group, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: fetching all accessible groups\n")
 	}
 
 	opt := &gitlab.ListGroupsOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: maxPageSize,
+			PerPage: c.pageSize,
 			Page:    1,
 		},
 	}
 
 	var allGroups []*gitlab.Group
 
-	for {
-		groups, resp, err := c.client.Groups.ListGroups(opt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list groups: %w", err)
-		}
-
-		allGroups = append(allGroups, groups...)
-
-		if c.debug {
-			fmt.Printf("DEBUG: fetched %d groups on page %d\n", len(groups), opt.Page)
-		}
-
-		if resp.NextPage == 0 {
-			break
-		}
+	err := listPages(c, "groups", &opt.ListOptions,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+			return c.client.Groups.ListGroups(opt, append(options, gitlab.WithContext(ctx))...)
+		},
+		func(groups []*gitlab.Group) {
+			allGroups = append(allGroups, groups...)
 
-		opt.Page = resp.NextPage
+			if c.debug {
+				fmt.Printf("DEBUG: fetched %d groups\n", len(groups))
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
 	}
 
 	if c.debug {
@@ -277,18 +472,85 @@ func (c *Client) GetAllGroups() ([]*gitlab.Group, error) {
 }
 
 // GetProjectsRecursively fetches all projects within a group and its subgroups.
-func (c *Client) GetProjectsRecursively(groupID string) ([]*gitlab.Project, error) {
-	groups, err := c.GetGroupsRecursively(groupID)
+// Projects shared into several of the groups are returned once, unless the client attributes them to
+// each group they are shared into, and only those carrying the topic of the client when it has one.
+// When the client has a cache, a fresh cached result is returned instead of querying the API.
+func (c *Client) GetProjectsRecursively(ctx context.Context, groupID string) ([]*gitlab.Project, error) {
+	return c.projectsRecursively(ctx, groupID, nil)
+}
+
+// projectsRecursively is GetProjectsRecursively for a group whose hierarchy may already be fetched.
+// Non-nil groups are used as the hierarchy of groupID instead of fetching it again.
+func (c *Client) projectsRecursively(
+	ctx context.Context,
+	groupID string,
+	groups []*gitlab.Group,
+) ([]*gitlab.Project, error) {
+	kind := "projects"
+	if c.sharedProjects {
+		kind += " with shared"
+
+		if c.sharedAttribution {
+			kind += " per group"
+		}
+	}
+
+	if c.personalNamespaces && groupID == "" {
+		kind += " with personal"
+	}
+
+	projects, err := cached(ctx, c, kind, groupID, sanitizeProjects, func() ([]*gitlab.Project, error) {
+		return c.getProjectsRecursively(ctx, groupID, groups)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	stripWebURLs(c, projects)
+
+	return filterByTopic(projects, c.topic), nil
+}
+
+func (c *Client) getProjectsRecursively(
+	ctx context.Context,
+	groupID string,
+	groups []*gitlab.Group,
+) ([]*gitlab.Project, error) {
+	if groups == nil {
+		var err error
+		if groups, err = c.GetGroupsRecursively(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	projects := c.projectsForGroups(ctx, groups)
+
+	if err := c.interrupted(ctx, "project fetch"); err != nil {
+		return nil, err
+	}
+
+	if !c.personalNamespaces || groupID != "" {
+		return projects, nil
+	}
+
+	personal, err := c.personalProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeProjects(projects, personal), nil
+}
+
+// projectsForGroups fetches the projects of every given group, deduplicated and sorted by ID. With
+// shared attribution, a project shared into groups is kept once for each of them, attributed to the
+// group by its namespace, and sorted by namespace after ID.
+func (c *Client) projectsForGroups(ctx context.Context, groups []*gitlab.Group) []*gitlab.Project {
 	if c.debug {
 		fmt.Printf("DEBUG: starting project fetch for %d groups\n", len(groups))
 	}
 
-	// Use a map to track unique projects by ID
-	projectMap := make(map[int]*gitlab.Project)
+	// Use a map to track unique projects by ID, and by attributed namespace with shared attribution
+	projectMap := make(map[projectKey]*gitlab.Project)
 
 	var (
 		wg    sync.WaitGroup
@@ -301,8 +563,10 @@ func (c *Client) GetProjectsRecursively(groupID string) ([]*gitlab.Project, erro
 		c.pool.Submit(func() {
 			defer wg.Done()
 			// Fetch projects for this group
-			groupProjects, err := c.fetchProjectsForGroupWithDedupe(group.FullPath)
+			groupProjects, err := c.fetchProjectsForGroupWithDedupe(ctx, group.FullPath)
 			if err != nil {
+				c.recordInaccessible(ctx, "group", group.FullPath, "projects", err)
+
 				if c.debug {
 					fmt.Printf("DEBUG: error fetching projects for group %s: %v\n", group.FullPath, err)
 				}
@@ -313,8 +577,13 @@ func (c *Client) GetProjectsRecursively(groupID string) ([]*gitlab.Project, erro
 			// Add unique projects to the map
 			mapMu.Lock()
 			for _, project := range groupProjects {
-				if _, exists := projectMap[project.ID]; !exists {
-					projectMap[project.ID] = project
+				if c.sharedAttribution {
+					project = attributeToGroup(project, group)
+				}
+
+				key := c.projectKey(project)
+				if _, exists := projectMap[key]; !exists {
+					projectMap[key] = project
 				}
 			}
 			mapMu.Unlock()
@@ -331,33 +600,79 @@ func (c *Client) GetProjectsRecursively(groupID string) ([]*gitlab.Project, erro
 
 	// Sort projects by ID to ensure deterministic order
 	sort.Slice(projects, func(i, j int) bool {
-		return projects[i].ID < projects[j].ID
+		if projects[i].ID != projects[j].ID {
+			return projects[i].ID < projects[j].ID
+		}
+
+		return projectNamespace(projects[i]) < projectNamespace(projects[j])
 	})
 
 	if c.debug {
 		fmt.Printf("DEBUG: completed project fetch, found %d unique projects\n", len(projects))
 	}
 
-	return projects, nil
+	return projects
+}
+
+// projectKey identifies a project in the result of projectsForGroups.
+type projectKey struct {
+	id        int
+	namespace string
+}
+
+// projectKey returns the key deduplicating project: its ID, along with the namespace it is attributed
+// to with shared attribution.
+func (c *Client) projectKey(project *gitlab.Project) projectKey {
+	if !c.sharedAttribution {
+		return projectKey{id: project.ID}
+	}
+
+	return projectKey{id: project.ID, namespace: projectNamespace(project)}
+}
+
+// attributeToGroup returns project as listed by group. A project shared into the group from another
+// namespace is copied with the group as its namespace; its path still names its home namespace.
+func attributeToGroup(project *gitlab.Project, group *gitlab.Group) *gitlab.Project {
+	if project.Namespace != nil && project.Namespace.ID == group.ID {
+		return project
+	}
+
+	attributed := *project
+	attributed.Namespace = &gitlab.ProjectNamespace{
+		ID:       group.ID,
+		Name:     group.Name,
+		Path:     group.Path,
+		Kind:     "group",
+		FullPath: group.FullPath,
+		WebURL:   group.WebURL,
+	}
+
+	return &attributed
 }
 
-// GetGroupAccessTokens fetches all access tokens for a specific group.
-func (c *Client) GetGroupAccessTokens(groupID string, includeInactive bool) ([]*GroupAccessTokenWithGroup, error) {
+// GetGroupAccessTokens fetches the access tokens in state for a specific group.
+func (c *Client) GetGroupAccessTokens(
+	ctx context.Context,
+	groupID string,
+	state TokenState,
+) ([]*GroupAccessTokenWithGroup, error) {
 	// Get the group information first
-	group, _, err := c.client.Groups.GetGroup(groupID, nil)
+	group, _, err := c.client.Groups.GetGroup(groupID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get group info: %w", err)
+		return nil, fmt.Errorf("failed to get group info: %w", c.rootGroupLookupError(ctx, groupID, err))
 	}
 
-	return c.listTokensForGroup(groupID, group, includeInactive)
+	return c.listTokensForGroup(ctx, groupID, group, state)
 }
 
-// GetGroupAccessTokensRecursively fetches all access tokens for all groups within a group and its subgroups.
+// GetGroupAccessTokensRecursively fetches the access tokens in state for all groups within a group and its
+// subgroups.
 func (c *Client) GetGroupAccessTokensRecursively(
+	ctx context.Context,
 	groupID string,
-	includeInactive bool,
+	state TokenState,
 ) ([]*GroupAccessTokenWithGroup, error) {
-	groups, err := c.GetGroupsRecursively(groupID)
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -380,12 +695,18 @@ func (c *Client) GetGroupAccessTokensRecursively(
 
 		c.pool.Submit(func() {
 			defer wg.Done()
-			c.fetchTokensForGroup(groupID, groupCopy, includeInactive, &tokens, &mu)
+			c.fetchTokensForGroup(ctx, groupID, groupCopy, state, &tokens, &mu)
 		})
 	}
 
 	wg.Wait()
 
+	if err := c.interrupted(ctx, "group access token fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortGroupAccessTokens(tokens)
+
 	if c.debug {
 		fmt.Printf("DEBUG: completed recursive token fetch, found %d tokens\n", len(tokens))
 	}
@@ -393,22 +714,23 @@ func (c *Client) GetGroupAccessTokensRecursively(
 	return tokens, nil
 }
 
-// GetProjectAccessTokens fetches all access tokens for a specific project.
+// GetProjectAccessTokens fetches the access tokens in state for a specific project.
 func (c *Client) GetProjectAccessTokens(
+	ctx context.Context,
 	projectID string,
-	includeInactive bool,
+	state TokenState,
 ) ([]*ProjectAccessTokenWithProject, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: fetching project access tokens for project %s\n", projectID)
 	}
 
 	// First, get the project information
-	project, _, err := c.client.Projects.GetProject(projectID, nil)
+	project, _, err := c.client.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, projectLookupError(err))
 	}
 
-	tokens, err := c.listTokensForProject(projectID, project, includeInactive)
+	tokens, err := c.listTokensForProject(ctx, projectID, project, state)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tokens for project %s: %w", projectID, err)
 	}
@@ -416,17 +738,19 @@ func (c *Client) GetProjectAccessTokens(
 	return tokens, nil
 }
 
-// GetProjectAccessTokensRecursively fetches all access tokens for all projects within a group and its subgroups.
+// GetProjectAccessTokensRecursively fetches the access tokens in state for all projects within a group and its
+// subgroups.
 func (c *Client) GetProjectAccessTokensRecursively(
+	ctx context.Context,
 	groupID string,
-	includeInactive bool,
+	state TokenState,
 ) ([]*ProjectAccessTokenWithProject, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: starting recursive project access token fetch for group ID %s\n", groupID)
 	}
 
 	// First, get all projects recursively
-	projects, err := c.GetProjectsRecursively(groupID)
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
 	}
@@ -444,12 +768,18 @@ func (c *Client) GetProjectAccessTokensRecursively(
 
 		c.pool.Submit(func() {
 			defer wg.Done()
-			c.fetchTokensForProject(projectID, project, includeInactive, &allTokens, &mu)
+			c.fetchTokensForProject(ctx, projectID, project, state, &allTokens, &mu)
 		})
 	}
 
 	wg.Wait()
 
+	if err := c.interrupted(ctx, "project access token fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectAccessTokens(allTokens)
+
 	if c.debug {
 		fmt.Printf("DEBUG: completed recursive project access token fetch, found %d tokens\n", len(allTokens))
 	}
@@ -458,28 +788,31 @@ func (c *Client) GetProjectAccessTokensRecursively(
 }
 
 // GetPipelineTriggers fetches all pipeline triggers for a specific project.
-func (c *Client) GetPipelineTriggers(projectID string) ([]*PipelineTriggerWithProject, error) {
+func (c *Client) GetPipelineTriggers(ctx context.Context, projectID string) ([]*PipelineTriggerWithProject, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: fetching pipeline trigger tokens for project ID %s\n", projectID)
 	}
 
 	// First get project info
-	project, _, err := c.client.Projects.GetProject(projectID, nil)
+	project, _, err := c.client.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project: %w", err)
+		return nil, fmt.Errorf("failed to get project: %w", projectLookupError(err))
 	}
 
-	return c.listTriggersForProject(projectID, project)
+	return c.listTriggersForProject(ctx, projectID, project)
 }
 
 // GetPipelineTriggersRecursively fetches all pipeline triggers for all projects within a group and its subgroups.
-func (c *Client) GetPipelineTriggersRecursively(groupID string) ([]*PipelineTriggerWithProject, error) {
+func (c *Client) GetPipelineTriggersRecursively(
+	ctx context.Context,
+	groupID string,
+) ([]*PipelineTriggerWithProject, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: starting recursive pipeline trigger tokens fetch for group ID %s\n", groupID)
 	}
 
 	// First, get all projects recursively
-	projects, err := c.GetProjectsRecursively(groupID)
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
 	}
@@ -497,12 +830,18 @@ func (c *Client) GetPipelineTriggersRecursively(groupID string) ([]*PipelineTrig
 
 		c.pool.Submit(func() {
 			defer wg.Done()
-			c.fetchTriggersForProject(projectID, project, &allTriggers, &mu)
+			c.fetchTriggersForProject(ctx, projectID, project, &allTriggers, &mu)
 		})
 	}
 
 	wg.Wait()
 
+	if err := c.interrupted(ctx, "pipeline trigger fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortPipelineTriggers(allTriggers)
+
 	if c.debug {
 		fmt.Printf("DEBUG: completed recursive pipeline trigger tokens fetch, found %d trigger tokens\n", len(allTriggers))
 	}
@@ -511,18 +850,18 @@ func (c *Client) GetPipelineTriggersRecursively(groupID string) ([]*PipelineTrig
 }
 
 // GetProjectVariables fetches all CI/CD variables for a specific project.
-func (c *Client) GetProjectVariables(projectID string) ([]*ProjectVariableWithProject, error) {
+func (c *Client) GetProjectVariables(ctx context.Context, projectID string) ([]*ProjectVariableWithProject, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: fetching project variables for project %s\n", projectID)
 	}
 
 	// First, get the project information
-	project, _, err := c.client.Projects.GetProject(projectID, nil)
+	project, _, err := c.client.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, projectLookupError(err))
 	}
 
-	variables, err := c.listVariablesForProject(projectID, project)
+	variables, err := c.listVariablesForProject(ctx, projectID, project)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list variables for project %s: %w", projectID, err)
 	}
@@ -531,17 +870,28 @@ func (c *Client) GetProjectVariables(projectID string) ([]*ProjectVariableWithPr
 }
 
 // GetProjectVariablesRecursively fetches all CI/CD variables for all projects within a group and its subgroups.
-func (c *Client) GetProjectVariablesRecursively(groupID string) ([]*ProjectVariableWithProject, error) {
+func (c *Client) GetProjectVariablesRecursively(
+	ctx context.Context,
+	groupID string,
+) ([]*ProjectVariableWithProject, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: starting recursive project variables fetch for group ID %s\n", groupID)
 	}
 
 	// First, get all projects recursively
-	projects, err := c.GetProjectsRecursively(groupID)
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
 	}
 
+	return c.projectVariables(ctx, projects)
+}
+
+// projectVariables fetches the CI/CD variables of projects.
+func (c *Client) projectVariables(
+	ctx context.Context,
+	projects []*gitlab.Project,
+) ([]*ProjectVariableWithProject, error) {
 	var (
 		allVariables []*ProjectVariableWithProject
 		mu           sync.Mutex
@@ -556,12 +906,18 @@ func (c *Client) GetProjectVariablesRecursively(groupID string) ([]*ProjectVaria
 
 		c.pool.Submit(func() {
 			defer wg.Done()
-			c.fetchVariablesForProject(projectID, projectCopy, &allVariables, &mu)
+			c.fetchVariablesForProject(ctx, projectID, projectCopy, &allVariables, &mu)
 		})
 	}
 
 	wg.Wait()
 
+	if err := c.interrupted(ctx, "project variables fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectVariables(allVariables)
+
 	if c.debug {
 		fmt.Printf("DEBUG: completed recursive project variables fetch, found %d variables\n", len(allVariables))
 	}
@@ -570,18 +926,18 @@ func (c *Client) GetProjectVariablesRecursively(groupID string) ([]*ProjectVaria
 }
 
 // GetGroupVariables fetches all CI/CD variables for a specific group.
-func (c *Client) GetGroupVariables(groupID string) ([]*GroupVariableWithGroup, error) {
+func (c *Client) GetGroupVariables(ctx context.Context, groupID string) ([]*GroupVariableWithGroup, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: fetching group variables for group %s\n", groupID)
 	}
 
 	// First, get the group information
-	group, _, err := c.client.Groups.GetGroup(groupID, nil)
+	group, _, err := c.client.Groups.GetGroup(groupID, nil, gitlab.WithContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get group %s: %w", groupID, err)
+		return nil, fmt.Errorf("failed to get group %s: %w", groupID, c.rootGroupLookupError(ctx, groupID, err))
 	}
 
-	variables, err := c.listVariablesForGroup(groupID, group)
+	variables, err := c.listVariablesForGroup(ctx, groupID, group)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list variables for group %s: %w", groupID, err)
 	}
@@ -591,17 +947,22 @@ func (c *Client) GetGroupVariables(groupID string) ([]*GroupVariableWithGroup, e
 
 // GetGroupVariablesRecursively fetches all group CI/CD variables
 // for all groups within a parent group and its subgroups.
-func (c *Client) GetGroupVariablesRecursively(groupID string) ([]*GroupVariableWithGroup, error) {
+func (c *Client) GetGroupVariablesRecursively(ctx context.Context, groupID string) ([]*GroupVariableWithGroup, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: starting recursive group variables fetch for group ID %s\n", groupID)
 	}
 
 	// First, get all groups recursively
-	groups, err := c.GetGroupsRecursively(groupID)
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
 	}
 
+	return c.groupVariables(ctx, groups)
+}
+
+// groupVariables fetches the CI/CD variables of groups.
+func (c *Client) groupVariables(ctx context.Context, groups []*gitlab.Group) ([]*GroupVariableWithGroup, error) {
 	var (
 		allVariables []*GroupVariableWithGroup
 		mu           sync.Mutex
@@ -616,12 +977,18 @@ func (c *Client) GetGroupVariablesRecursively(groupID string) ([]*GroupVariableW
 
 		c.pool.Submit(func() {
 			defer wg.Done()
-			c.fetchVariablesForGroup(groupID, groupCopy, &allVariables, &mu)
+			c.fetchVariablesForGroup(ctx, groupID, groupCopy, &allVariables, &mu)
 		})
 	}
 
 	wg.Wait()
 
+	if err := c.interrupted(ctx, "group variables fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortGroupVariables(allVariables)
+
 	if c.debug {
 		fmt.Printf("DEBUG: completed recursive group variables fetch, found %d variables\n", len(allVariables))
 	}
@@ -629,10 +996,68 @@ func (c *Client) GetGroupVariablesRecursively(groupID string) ([]*GroupVariableW
 	return allVariables, nil
 }
 
+// GetAllVariablesRecursively fetches project and group CI/CD variables within a group and its subgroups.
+// The group hierarchy is fetched once and shared by the project and group variable fetches.
+// Both fetches run concurrently and submit their API requests to the client's shared worker pool,
+// so the number of in-flight requests stays bounded by the pool size. The first error cancels the other fetch.
+func (c *Client) GetAllVariablesRecursively(
+	ctx context.Context,
+	groupID string,
+) ([]*ProjectVariableWithProject, []*GroupVariableWithGroup, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive variables fetch for group ID %s\n", groupID)
+	}
+
+	// the hierarchy is resolved once and shared by both fetches
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	var (
+		projectVariables []*ProjectVariableWithProject
+		groupVariables   []*GroupVariableWithGroup
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		projects, err := c.projectsRecursively(gctx, groupID, groups)
+		if err != nil {
+			return fmt.Errorf("failed to get projects recursively: %w", err)
+		}
+
+		projectVariables, err = c.projectVariables(gctx, projects)
+		if err != nil {
+			return fmt.Errorf("failed to fetch project variables: %w", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		var err error
+
+		groupVariables, err = c.groupVariables(gctx, groups)
+		if err != nil {
+			return fmt.Errorf("failed to fetch group variables: %w", err)
+		}
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	return projectVariables, groupVariables, nil
+}
+
 func (c *Client) listTokensForGroup(
+	ctx context.Context,
 	groupID string,
 	group *gitlab.Group,
-	includeInactive bool,
+	state TokenState,
 ) ([]*GroupAccessTokenWithGroup, error) {
 	if c.debug {
 		fmt.Printf("DEBUG: fetching group access tokens for group ID %s\n", groupID)
@@ -640,31 +1065,34 @@ func (c *Client) listTokensForGroup(
 
 	opt := &gitlab.ListGroupAccessTokensOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: maxPageSize,
+			PerPage: c.pageSize,
 			Page:    1,
 		},
 	}
 
-	if !includeInactive {
-		state := gitlab.AccessTokenStateActive
-		opt.State = &state
-	}
+	opt.State = state.accessTokenState()
 
 	var allTokens []*GroupAccessTokenWithGroup
 
 	for {
-		tokens, resp, err := c.client.GroupAccessTokens.ListGroupAccessTokens(groupID, opt)
+		tokens, resp, err := c.client.GroupAccessTokens.ListGroupAccessTokens(groupID, opt, gitlab.WithContext(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to list group access tokens: %w", err)
 		}
 
 		// Wrap each token with group information
 		for _, token := range tokens {
+			if !state.includes(token.Active) {
+				continue
+			}
+
 			tokenWithGroup := &GroupAccessTokenWithGroup{
+				ReportType:       ReportTypeGroupAccessToken,
 				GroupAccessToken: token,
+				GroupID:          group.ID,
 				GroupName:        group.Name,
 				GroupPath:        group.FullPath,
-				GroupWebURL:      group.WebURL,
+				GroupWebURL:      c.webURL(group.WebURL),
 			}
 			allTokens = append(allTokens, tokenWithGroup)
 		}
@@ -687,96 +1115,122 @@ func (c *Client) listTokensForGroup(
 	return allTokens, nil
 }
 
-func (c *Client) fetchSubgroups(parentID string, groups *[]*gitlab.Group, mu *sync.Mutex, wg *sync.WaitGroup) {
+func (c *Client) fetchSubgroups(
+	ctx context.Context,
+	parentID string,
+	parentPath string,
+	recursive bool,
+	groups *[]*gitlab.Group,
+	mu *sync.Mutex,
+	wg *sync.WaitGroup,
+) {
 	opt := &gitlab.ListSubGroupsOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: maxPageSize,
+			PerPage: c.pageSize,
 			Page:    1,
 		},
 	}
 
-	for {
-		subgroups, resp, err := c.client.Groups.ListSubGroups(parentID, opt)
-		if err != nil {
-			if c.debug {
-				fmt.Printf("DEBUG: error fetching subgroups for group %s: %v\n", parentID, err)
-			}
+	var found []*gitlab.Group
 
-			return
-		}
+	err := c.withinGroupBudget(ctx, func(groupCtx context.Context) error {
+		return listPages(c, "subgroups", &opt.ListOptions,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Group, *gitlab.Response, error) {
+				return c.client.Groups.ListSubGroups(parentID, opt, append(options, gitlab.WithContext(groupCtx))...)
+			},
+			func(subgroups []*gitlab.Group) {
+				found = append(found, subgroups...)
 
-		mu.Lock()
-		*groups = append(*groups, subgroups...)
-		mu.Unlock()
+				if c.debug {
+					fmt.Printf("DEBUG: fetched %d subgroups for group %s\n", len(subgroups), parentID)
+				}
+			},
+		)
+	})
+	if err != nil {
+		c.recordInaccessible(ctx, "group", parentPath, "subgroups", err)
 
 		if c.debug {
-			fmt.Printf("DEBUG: fetched %d subgroups for group %s\n", len(subgroups), parentID)
+			fmt.Printf("DEBUG: error fetching subgroups for group %s: %v\n", parentID, err)
 		}
+	}
 
-		for _, subgroup := range subgroups {
-			wg.Add(1)
+	mu.Lock()
+	*groups = append(*groups, found...)
+	mu.Unlock()
 
-			subgroupID := strconv.Itoa(subgroup.ID)
+	if !recursive {
+		return
+	}
 
-			c.pool.Submit(func() {
-				defer wg.Done()
-				c.fetchSubgroups(subgroupID, groups, mu, wg)
-			})
-		}
+	// subgroups found before the budget of this group ran out are traversed with their own budget.
+	// They are submitted once the listing is done, as a full queue runs them in this goroutine,
+	// which must not count against the budget of this group. The caller holds wg for this group
+	// until it returns, so wg cannot reach zero while subgroups are still being added.
+	for _, subgroup := range found {
+		wg.Add(1)
 
-		if resp.NextPage == 0 {
-			break
-		}
+		subgroupID := strconv.Itoa(subgroup.ID)
 
-		opt.Page = resp.NextPage
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchSubgroups(ctx, subgroupID, subgroup.FullPath, recursive, groups, mu, wg)
+		})
 	}
 }
 
-func (c *Client) fetchProjectsForGroupWithDedupe(groupID string) ([]*gitlab.Project, error) {
+func (c *Client) fetchProjectsForGroupWithDedupe(ctx context.Context, groupID string) ([]*gitlab.Project, error) {
 	opt := &gitlab.ListGroupProjectsOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: maxPageSize,
+			PerPage: c.pageSize,
 			Page:    1,
 		},
+		// keyset pagination of projects is only available when ordered by ID
+		OrderBy:    gitlab.Ptr("id"),
+		WithShared: gitlab.Ptr(c.sharedProjects),
 	}
 
 	var allProjects []*gitlab.Project
 
-	for {
-		groupProjects, resp, err := c.client.Groups.ListGroupProjects(groupID, opt)
-		if err != nil {
-			if c.debug {
-				fmt.Printf("DEBUG: error fetching projects for group %s: %v\n", groupID, err)
-			}
-
-			return allProjects, fmt.Errorf("failed to fetch projects for group %s: %w", groupID, err)
-		}
-
-		allProjects = append(allProjects, groupProjects...)
+	err := c.withinGroupBudget(ctx, func(groupCtx context.Context) error {
+		return listPages(c, "group projects", &opt.ListOptions,
+			func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+				return c.client.Groups.ListGroupProjects(groupID, opt, append(options, gitlab.WithContext(groupCtx))...)
+			},
+			func(groupProjects []*gitlab.Project) {
+				allProjects = append(allProjects, groupProjects...)
 
+				if c.debug {
+					fmt.Printf("DEBUG: fetched %d projects for group %s\n", len(groupProjects), groupID)
+				}
+			},
+		)
+	})
+	if err != nil {
 		if c.debug {
-			fmt.Printf("DEBUG: fetched %d projects for group %s\n", len(groupProjects), groupID)
+			fmt.Printf("DEBUG: error fetching projects for group %s: %v\n", groupID, err)
 		}
 
-		if resp.NextPage == 0 {
-			break
-		}
-
-		opt.Page = resp.NextPage
+		return allProjects, fmt.Errorf("failed to fetch projects for group %s: %w", groupID, err)
 	}
 
 	return allProjects, nil
 }
 
 func (c *Client) fetchTokensForGroup(
+	ctx context.Context,
 	groupID string,
 	group *gitlab.Group,
-	includeInactive bool,
+	state TokenState,
 	tokens *[]*GroupAccessTokenWithGroup,
 	mu *sync.Mutex,
 ) {
-	groupTokens, err := c.listTokensForGroup(groupID, group, includeInactive)
+	c.countAttempt("group")
+
+	groupTokens, err := c.listTokensForGroup(ctx, groupID, group, state)
 	if err != nil {
+		c.recordFetchError(ctx, "group", group.FullPath, "group access tokens", err)
+
 		if c.debug {
 			fmt.Printf("DEBUG: error fetching tokens for group %s: %v\n", groupID, err)
 		}
@@ -787,46 +1241,48 @@ func (c *Client) fetchTokensForGroup(
 	mu.Lock()
 	*tokens = append(*tokens, groupTokens...)
 	mu.Unlock()
+	c.countItems(len(groupTokens))
 }
 
 func (c *Client) listTokensForProject(
+	ctx context.Context,
 	projectID string,
 	project *gitlab.Project,
-	includeInactive bool,
+	state TokenState,
 ) ([]*ProjectAccessTokenWithProject, error) {
 	var allTokens []*ProjectAccessTokenWithProject
 
 	opt := &gitlab.ListProjectAccessTokensOptions{
 		ListOptions: gitlab.ListOptions{
-			PerPage: maxPageSize,
+			PerPage: c.pageSize,
 			Page:    1,
 		},
 	}
 
-	if !includeInactive {
-		state := string(gitlab.AccessTokenStateActive)
-		opt.State = &state
+	if accessTokenState := state.accessTokenState(); accessTokenState != nil {
+		opt.State = gitlab.Ptr(string(*accessTokenState))
 	}
 
 	for {
-		tokens, resp, err := c.client.ProjectAccessTokens.ListProjectAccessTokens(projectID, opt)
+		tokens, resp, err := c.client.ProjectAccessTokens.ListProjectAccessTokens(projectID, opt, gitlab.WithContext(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to list project access tokens: %w", err)
 		}
 
 		// Wrap each token with project information
 		for _, token := range tokens {
-			// Skip inactive tokens if not requested
-			if !includeInactive && !token.Active {
+			if !state.includes(token.Active) {
 				continue
 			}
 
 			tokenWithProject := &ProjectAccessTokenWithProject{
+				ReportType:         ReportTypeProjectAccessToken,
 				ProjectAccessToken: token,
+				ProjectID:          project.ID,
 				ProjectName:        project.Name,
 				ProjectPath:        project.PathWithNamespace,
-				ProjectNamespace:   project.Namespace.FullPath,
-				ProjectWebURL:      project.WebURL,
+				ProjectNamespace:   projectNamespace(project),
+				ProjectWebURL:      c.webURL(project.WebURL),
 			}
 			allTokens = append(allTokens, tokenWithProject)
 		}
@@ -846,14 +1302,19 @@ func (c *Client) listTokensForProject(
 }
 
 func (c *Client) fetchTokensForProject(
+	ctx context.Context,
 	projectID string,
 	project *gitlab.Project,
-	includeInactive bool,
+	state TokenState,
 	tokens *[]*ProjectAccessTokenWithProject,
 	mu *sync.Mutex,
 ) {
-	projectTokens, err := c.listTokensForProject(projectID, project, includeInactive)
+	c.countAttempt("project")
+
+	projectTokens, err := c.listTokensForProject(ctx, projectID, project, state)
 	if err != nil {
+		c.recordFetchError(ctx, "project", project.PathWithNamespace, "project access tokens", err)
+
 		if c.debug {
 			fmt.Printf("DEBUG: error fetching tokens for project %s: %v\n", projectID, err)
 		}
@@ -864,21 +1325,23 @@ func (c *Client) fetchTokensForProject(
 	mu.Lock()
 	*tokens = append(*tokens, projectTokens...)
 	mu.Unlock()
+	c.countItems(len(projectTokens))
 }
 
 func (c *Client) listTriggersForProject(
+	ctx context.Context,
 	projectID string,
 	project *gitlab.Project,
 ) ([]*PipelineTriggerWithProject, error) {
 	var allTriggers []*PipelineTriggerWithProject
 
 	opt := &gitlab.ListPipelineTriggersOptions{
-		PerPage: maxPageSize,
+		PerPage: c.pageSize,
 		Page:    1,
 	}
 
 	for {
-		triggers, resp, err := c.client.PipelineTriggers.ListPipelineTriggers(projectID, opt)
+		triggers, resp, err := c.client.PipelineTriggers.ListPipelineTriggers(projectID, opt, gitlab.WithContext(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to list pipeline trigger tokens: %w", err)
 		}
@@ -886,11 +1349,13 @@ func (c *Client) listTriggersForProject(
 		// Wrap each trigger with project information
 		for _, trigger := range triggers {
 			triggerWithProject := &PipelineTriggerWithProject{
+				ReportType:       ReportTypePipelineTrigger,
 				PipelineTrigger:  trigger,
+				ProjectID:        project.ID,
 				ProjectName:      project.Name,
 				ProjectPath:      project.PathWithNamespace,
-				ProjectNamespace: project.Namespace.FullPath,
-				ProjectWebURL:    project.WebURL,
+				ProjectNamespace: projectNamespace(project),
+				ProjectWebURL:    c.webURL(project.WebURL),
 			}
 			allTriggers = append(allTriggers, triggerWithProject)
 		}
@@ -910,13 +1375,18 @@ func (c *Client) listTriggersForProject(
 }
 
 func (c *Client) fetchTriggersForProject(
+	ctx context.Context,
 	projectID string,
 	project *gitlab.Project,
 	triggers *[]*PipelineTriggerWithProject,
 	mu *sync.Mutex,
 ) {
-	projectTriggers, err := c.listTriggersForProject(projectID, project)
+	c.countAttempt("project")
+
+	projectTriggers, err := c.listTriggersForProject(ctx, projectID, project)
 	if err != nil {
+		c.recordFetchError(ctx, "project", project.PathWithNamespace, "pipeline trigger tokens", err)
+
 		if c.debug {
 			fmt.Printf("DEBUG: error fetching trigger tokens for project %s: %v\n", projectID, err)
 		}
@@ -927,21 +1397,23 @@ func (c *Client) fetchTriggersForProject(
 	mu.Lock()
 	*triggers = append(*triggers, projectTriggers...)
 	mu.Unlock()
+	c.countItems(len(projectTriggers))
 }
 
 func (c *Client) listVariablesForProject(
+	ctx context.Context,
 	projectID string,
 	project *gitlab.Project,
 ) ([]*ProjectVariableWithProject, error) {
 	var allVariables []*ProjectVariableWithProject
 
 	opt := &gitlab.ListProjectVariablesOptions{
-		PerPage: maxPageSize,
+		PerPage: c.pageSize,
 		Page:    1,
 	}
 
 	for {
-		variables, resp, err := c.client.ProjectVariables.ListVariables(projectID, opt)
+		variables, resp, err := c.client.ProjectVariables.ListVariables(projectID, opt, gitlab.WithContext(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to list project variables: %w", err)
 		}
@@ -949,11 +1421,13 @@ func (c *Client) listVariablesForProject(
 		// Wrap each variable with project information
 		for _, variable := range variables {
 			variableWithProject := &ProjectVariableWithProject{
+				ReportType:       ReportTypeProjectVariable,
 				ProjectVariable:  variable,
+				ProjectID:        project.ID,
 				ProjectName:      project.Name,
 				ProjectPath:      project.PathWithNamespace,
-				ProjectNamespace: project.Namespace.FullPath,
-				ProjectWebURL:    project.WebURL,
+				ProjectNamespace: projectNamespace(project),
+				ProjectWebURL:    c.webURL(project.WebURL),
 			}
 			allVariables = append(allVariables, variableWithProject)
 		}
@@ -973,13 +1447,18 @@ func (c *Client) listVariablesForProject(
 }
 
 func (c *Client) fetchVariablesForProject(
+	ctx context.Context,
 	projectID string,
 	project *gitlab.Project,
 	variables *[]*ProjectVariableWithProject,
 	mu *sync.Mutex,
 ) {
-	projectVariables, err := c.listVariablesForProject(projectID, project)
+	c.countAttempt("project")
+
+	projectVariables, err := c.listVariablesForProject(ctx, projectID, project)
 	if err != nil {
+		c.recordFetchError(ctx, "project", project.PathWithNamespace, "project variables", err)
+
 		if c.debug {
 			fmt.Printf("DEBUG: error fetching variables for project %s: %v\n", projectID, err)
 		}
@@ -990,21 +1469,23 @@ func (c *Client) fetchVariablesForProject(
 	mu.Lock()
 	*variables = append(*variables, projectVariables...)
 	mu.Unlock()
+	c.countItems(len(projectVariables))
 }
 
 func (c *Client) listVariablesForGroup(
+	ctx context.Context,
 	groupID string,
 	group *gitlab.Group,
 ) ([]*GroupVariableWithGroup, error) {
 	var allVariables []*GroupVariableWithGroup
 
 	opt := &gitlab.ListGroupVariablesOptions{
-		PerPage: maxPageSize,
+		PerPage: c.pageSize,
 		Page:    1,
 	}
 
 	for {
-		variables, resp, err := c.client.GroupVariables.ListVariables(groupID, opt)
+		variables, resp, err := c.client.GroupVariables.ListVariables(groupID, opt, gitlab.WithContext(ctx))
 		if err != nil {
 			return nil, fmt.Errorf("failed to list group variables: %w", err)
 		}
@@ -1012,10 +1493,12 @@ func (c *Client) listVariablesForGroup(
 		// Wrap each variable with group information
 		for _, variable := range variables {
 			variableWithGroup := &GroupVariableWithGroup{
+				ReportType:    ReportTypeGroupVariable,
 				GroupVariable: variable,
+				GroupID:       group.ID,
 				GroupName:     group.Name,
 				GroupPath:     group.Path,
-				GroupWebURL:   group.WebURL,
+				GroupWebURL:   c.webURL(group.WebURL),
 				GroupFullPath: group.FullPath,
 			}
 			allVariables = append(allVariables, variableWithGroup)
@@ -1036,13 +1519,18 @@ func (c *Client) listVariablesForGroup(
 }
 
 func (c *Client) fetchVariablesForGroup(
+	ctx context.Context,
 	groupID string,
 	group *gitlab.Group,
 	variables *[]*GroupVariableWithGroup,
 	mu *sync.Mutex,
 ) {
-	groupVariables, err := c.listVariablesForGroup(groupID, group)
+	c.countAttempt("group")
+
+	groupVariables, err := c.listVariablesForGroup(ctx, groupID, group)
 	if err != nil {
+		c.recordFetchError(ctx, "group", group.FullPath, "group variables", err)
+
 		if c.debug {
 			fmt.Printf("DEBUG: error fetching variables for group %s: %v\n", groupID, err)
 		}
@@ -1053,4 +1541,19 @@ func (c *Client) fetchVariablesForGroup(
 	mu.Lock()
 	*variables = append(*variables, groupVariables...)
 	mu.Unlock()
+	c.countItems(len(groupVariables))
+}
+
+// projectNamespace returns the full path of the namespace a project belongs to. GitLab omits the
+// namespace in some responses, in which case it is derived from the path of the project.
+func projectNamespace(project *gitlab.Project) string {
+	if project.Namespace != nil {
+		return project.Namespace.FullPath
+	}
+
+	if i := strings.LastIndex(project.PathWithNamespace, "/"); i >= 0 {
+		return project.PathWithNamespace[:i]
+	}
+
+	return ""
 }
@@ -47,6 +47,18 @@ func TestNewClient(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, client)
 	})
+
+	t.Run("creates client for a self-managed instance", func(t *testing.T) {
+		client, err := glclient.NewClient("test-token", false, glclient.WithBaseURL("https://gitlab.example.com"))
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("rejects an invalid base URL", func(t *testing.T) {
+		client, err := glclient.NewClient("test-token", false, glclient.WithBaseURL("://invalid"))
+		require.Error(t, err)
+		assert.Nil(t, client)
+	})
 }
 
 func TestGetGroupsRecursively(t *testing.T) {
@@ -61,14 +73,14 @@ func TestGetGroupsRecursively(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
-		groups, err := client.GetGroupsRecursively("1")
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, groups, 1)
 		assert.Equal(t, rootGroup, groups[0])
@@ -107,30 +119,30 @@ func TestGetGroupsRecursively(t *testing.T) {
 
 		// Root group
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		// Subgroups of root
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{subGroup1, subGroup2}, &gitlab.Response{}, nil)
 
 		// Subgroups of subGroup1
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("2", gomock.Any()).
+			ListSubGroups("2", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{nestedSubGroup}, &gitlab.Response{}, nil)
 
 		// Subgroups of subGroup2 (empty)
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("3", gomock.Any()).
+			ListSubGroups("3", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Subgroups of nestedSubGroup (empty)
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("4", gomock.Any()).
+			ListSubGroups("4", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
-		groups, err := client.GetGroupsRecursively("1")
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, groups, 4)
 
@@ -176,30 +188,30 @@ func TestGetGroupsRecursively(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		// First page
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return(page1Groups, &gitlab.Response{NextPage: 2}, nil).
 			Times(1)
 
 		// Second page
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return(page2Groups, &gitlab.Response{}, nil).
 			Times(1)
 
 		// Each subgroup has no children
 		for i := 2; i <= 75; i++ {
 			mockClient.MockGroups.EXPECT().
-				ListSubGroups(strconv.Itoa(i), gomock.Any()).
+				ListSubGroups(strconv.Itoa(i), gomock.Any(), gomock.Any()).
 				Return([]*gitlab.Group{}, &gitlab.Response{}, nil).
 				AnyTimes()
 		}
 
-		groups, err := client.GetGroupsRecursively("1")
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, groups, 75) // 1 root + 74 subgroups
 	})
@@ -215,13 +227,14 @@ func TestGetGroupsRecursively(t *testing.T) {
 		mockClient.MockGroups.EXPECT().
 			ListGroups(&gitlab.ListGroupsOptions{
 				ListOptions: gitlab.ListOptions{
-					PerPage: 50,
-					Page:    1,
+					PerPage:    50,
+					Page:       1,
+					Pagination: "keyset",
 				},
-			}).
+			}, gomock.Any()).
 			Return(allGroups, &gitlab.Response{}, nil)
 
-		groups, err := client.GetGroupsRecursively("")
+		groups, err := client.GetGroupsRecursively(t.Context(), "")
 		require.NoError(t, err)
 		assert.Len(t, groups, 2)
 		assert.Equal(t, allGroups, groups)
@@ -231,10 +244,10 @@ func TestGetGroupsRecursively(t *testing.T) {
 		client, mockClient := testClient(t)
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(nil, nil, errAPI)
 
-		groups, err := client.GetGroupsRecursively("1")
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get root group")
 		assert.Nil(t, groups)
@@ -258,23 +271,25 @@ func TestGetAllGroups(t *testing.T) {
 		mockClient.MockGroups.EXPECT().
 			ListGroups(&gitlab.ListGroupsOptions{
 				ListOptions: gitlab.ListOptions{
-					PerPage: 50,
-					Page:    1,
+					PerPage:    50,
+					Page:       1,
+					Pagination: "keyset",
 				},
-			}).
+			}, gomock.Any()).
 			Return(page1Groups, &gitlab.Response{NextPage: 2}, nil)
 
 		// Second page
 		mockClient.MockGroups.EXPECT().
 			ListGroups(&gitlab.ListGroupsOptions{
 				ListOptions: gitlab.ListOptions{
-					PerPage: 50,
-					Page:    2,
+					PerPage:    50,
+					Page:       2,
+					Pagination: "keyset",
 				},
-			}).
+			}, gomock.Any()).
 			Return(page2Groups, &gitlab.Response{NextPage: 0}, nil)
 
-		groups, err := client.GetAllGroups()
+		groups, err := client.GetAllGroups(t.Context())
 		require.NoError(t, err)
 		assert.Len(t, groups, 3)
 		assert.Equal(t, "Group 1", groups[0].Name)
@@ -288,13 +303,14 @@ func TestGetAllGroups(t *testing.T) {
 		mockClient.MockGroups.EXPECT().
 			ListGroups(&gitlab.ListGroupsOptions{
 				ListOptions: gitlab.ListOptions{
-					PerPage: 50,
-					Page:    1,
+					PerPage:    50,
+					Page:       1,
+					Pagination: "keyset",
 				},
-			}).
+			}, gomock.Any()).
 			Return(nil, nil, errAPI)
 
-		groups, err := client.GetAllGroups()
+		groups, err := client.GetAllGroups(t.Context())
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to list groups")
 		assert.Nil(t, groups)
@@ -329,19 +345,19 @@ func TestGetProjectsRecursively(t *testing.T) {
 
 		// Groups setup
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Projects setup
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project1, project2}, &gitlab.Response{}, nil)
 
-		projects, err := client.GetProjectsRecursively("1")
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, projects, 2)
 		assert.Equal(t, project1, projects[0])
@@ -379,27 +395,27 @@ func TestGetProjectsRecursively(t *testing.T) {
 
 		// Groups setup
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{subGroup}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("2", gomock.Any()).
+			ListSubGroups("2", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Projects setup
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{rootProject}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group/sub-group", gomock.Any()).
+			ListGroupProjects("root-group/sub-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{subProject}, &gitlab.Response{}, nil)
 
-		projects, err := client.GetProjectsRecursively("1")
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, projects, 2)
 
@@ -423,18 +439,18 @@ func TestGetProjectsRecursively(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{}, &gitlab.Response{}, nil)
 
-		projects, err := client.GetProjectsRecursively("1")
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Empty(t, projects)
 	})
@@ -475,7 +491,7 @@ func TestGetGroupAccessTokens(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(group, &gitlab.Response{}, nil)
 
 		activeState := gitlab.AccessTokenState("active")
@@ -483,15 +499,17 @@ func TestGetGroupAccessTokens(t *testing.T) {
 			ListGroupAccessTokens("1", &gitlab.ListGroupAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.GroupAccessToken{token1, token2}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetGroupAccessTokens("1", false)
+		tokens, err := client.GetGroupAccessTokens(t.Context(), "1", glclient.TokenStateActive)
 		require.NoError(t, err)
 		assert.Len(t, tokens, 2)
 
 		// Verify token wrapping
 		assert.Equal(t, token1, tokens[0].GroupAccessToken)
+		assert.Equal(t, glclient.ReportTypeGroupAccessToken, tokens[0].ReportType)
+		assert.Equal(t, 1, tokens[0].GroupID)
 		assert.Equal(t, "test-group", tokens[0].GroupName)
 		assert.Equal(t, "test-group", tokens[0].GroupPath)
 		assert.Equal(t, "https://gitlab.com/test-group", tokens[0].GroupWebURL)
@@ -526,16 +544,16 @@ func TestGetGroupAccessTokens(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(group, &gitlab.Response{}, nil)
 
 		mockClient.MockGroupAccessTokens.EXPECT().
 			ListGroupAccessTokens("1", &gitlab.ListGroupAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.GroupAccessToken{activeToken, inactiveToken}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetGroupAccessTokens("1", true)
+		tokens, err := client.GetGroupAccessTokens(t.Context(), "1", glclient.TokenStateAll)
 		require.NoError(t, err)
 		assert.Len(t, tokens, 2)
 	})
@@ -544,10 +562,10 @@ func TestGetGroupAccessTokens(t *testing.T) {
 		client, mockClient := testClient(t)
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("invalid-id", nil).
+			GetGroup("invalid-id", nil, gomock.Any()).
 			Return(nil, nil, errAPI)
 
-		tokens, err := client.GetGroupAccessTokens("invalid-id", false)
+		tokens, err := client.GetGroupAccessTokens(t.Context(), "invalid-id", glclient.TokenStateActive)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get group info")
 		assert.Nil(t, tokens)
@@ -592,15 +610,15 @@ func TestGetGroupAccessTokensRecursively(t *testing.T) {
 
 		// Groups setup
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{subGroup}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("2", gomock.Any()).
+			ListSubGroups("2", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Tokens setup
@@ -610,17 +628,17 @@ func TestGetGroupAccessTokensRecursively(t *testing.T) {
 			ListGroupAccessTokens("1", &gitlab.ListGroupAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.GroupAccessToken{rootToken}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroupAccessTokens.EXPECT().
 			ListGroupAccessTokens("2", &gitlab.ListGroupAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.GroupAccessToken{subToken}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetGroupAccessTokensRecursively("1", false)
+		tokens, err := client.GetGroupAccessTokensRecursively(t.Context(), "1", glclient.TokenStateActive)
 		require.NoError(t, err)
 		assert.Len(t, tokens, 2)
 
@@ -657,7 +675,7 @@ func TestGetProjectAccessTokens(t *testing.T) {
 		}
 
 		mockClient.MockProjects.EXPECT().
-			GetProject("1", nil).
+			GetProject("1", nil, gomock.Any()).
 			Return(project, &gitlab.Response{}, nil)
 
 		activeState := "active"
@@ -665,22 +683,46 @@ func TestGetProjectAccessTokens(t *testing.T) {
 			ListProjectAccessTokens("1", &gitlab.ListProjectAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.ProjectAccessToken{token}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetProjectAccessTokens("1", false)
+		tokens, err := client.GetProjectAccessTokens(t.Context(), "1", glclient.TokenStateActive)
 		require.NoError(t, err)
 		assert.Len(t, tokens, 1)
 
 		// Verify token wrapping
 		assert.Equal(t, token, tokens[0].ProjectAccessToken)
+		assert.Equal(t, glclient.ReportTypeProjectAccessToken, tokens[0].ReportType)
+		assert.Equal(t, 1, tokens[0].ProjectID)
 		assert.Equal(t, "test-project", tokens[0].ProjectName)
 		assert.Equal(t, "group/test-project", tokens[0].ProjectPath)
 		assert.Equal(t, "group", tokens[0].ProjectNamespace)
 		assert.Equal(t, "https://gitlab.com/group/test-project", tokens[0].ProjectWebURL)
 	})
 
-	t.Run("filters out inactive tokens when includeInactive is false", func(t *testing.T) {
+	t.Run("derives the namespace of a project without one", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		project := &gitlab.Project{ID: 1, Name: "test-project", PathWithNamespace: "org/group/test-project"}
+		token := &gitlab.ProjectAccessToken{
+			PersonalAccessToken: gitlab.PersonalAccessToken{ID: 1, Name: "project-token", Active: true},
+		}
+
+		mockClient.MockProjects.EXPECT().
+			GetProject("1", nil, gomock.Any()).
+			Return(project, &gitlab.Response{}, nil)
+		mockClient.MockProjectAccessTokens.EXPECT().
+			ListProjectAccessTokens("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectAccessToken{token}, &gitlab.Response{}, nil)
+
+		tokens, err := client.GetProjectAccessTokens(t.Context(), "1", glclient.TokenStateActive)
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, "org/group", tokens[0].ProjectNamespace)
+		assert.Equal(t, "org/group/test-project", tokens[0].ProjectPath)
+	})
+
+	t.Run("filters out inactive tokens in the active state", func(t *testing.T) {
 		client, mockClient := testClient(t)
 
 		project := &gitlab.Project{
@@ -711,7 +753,7 @@ func TestGetProjectAccessTokens(t *testing.T) {
 		}
 
 		mockClient.MockProjects.EXPECT().
-			GetProject("1", nil).
+			GetProject("1", nil, gomock.Any()).
 			Return(project, &gitlab.Response{}, nil)
 
 		activeState := "active"
@@ -719,10 +761,10 @@ func TestGetProjectAccessTokens(t *testing.T) {
 			ListProjectAccessTokens("1", &gitlab.ListProjectAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.ProjectAccessToken{activeToken, inactiveToken}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetProjectAccessTokens("1", false)
+		tokens, err := client.GetProjectAccessTokens(t.Context(), "1", glclient.TokenStateActive)
 		require.NoError(t, err)
 		assert.Len(t, tokens, 1)
 		assert.Equal(t, "active-token", tokens[0].Name)
@@ -775,16 +817,16 @@ func TestGetProjectAccessTokensRecursively(t *testing.T) {
 
 		// Groups setup
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Projects setup
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project1, project2}, &gitlab.Response{}, nil)
 
 		// Project access tokens
@@ -794,17 +836,17 @@ func TestGetProjectAccessTokensRecursively(t *testing.T) {
 			ListProjectAccessTokens("1", &gitlab.ListProjectAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.ProjectAccessToken{token1}, &gitlab.Response{}, nil)
 
 		mockClient.MockProjectAccessTokens.EXPECT().
 			ListProjectAccessTokens("2", &gitlab.ListProjectAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.ProjectAccessToken{token2}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetProjectAccessTokensRecursively("1", false)
+		tokens, err := client.GetProjectAccessTokensRecursively(t.Context(), "1", glclient.TokenStateActive)
 		require.NoError(t, err)
 		assert.Len(t, tokens, 2)
 
@@ -829,15 +871,16 @@ func TestGetProjectAccessTokensRecursively(t *testing.T) {
 		mockClient.MockGroups.EXPECT().
 			ListGroups(&gitlab.ListGroupsOptions{
 				ListOptions: gitlab.ListOptions{
-					PerPage: 50,
-					Page:    1,
+					PerPage:    50,
+					Page:       1,
+					Pagination: "keyset",
 				},
-			}).
+			}, gomock.Any()).
 			Return(allGroups, &gitlab.Response{}, nil)
 
 		// No subgroups
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil).
 			AnyTimes()
 
@@ -851,7 +894,7 @@ func TestGetProjectAccessTokensRecursively(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("group1", gomock.Any()).
+			ListGroupProjects("group1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project}, &gitlab.Response{}, nil)
 
 		// No tokens for this project
@@ -860,10 +903,10 @@ func TestGetProjectAccessTokensRecursively(t *testing.T) {
 			ListProjectAccessTokens("10", &gitlab.ListProjectAccessTokensOptions{
 				ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
 				State:       &activeState,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.ProjectAccessToken{}, &gitlab.Response{}, nil)
 
-		tokens, err := client.GetProjectAccessTokensRecursively("", false)
+		tokens, err := client.GetProjectAccessTokensRecursively(t.Context(), "", glclient.TokenStateActive)
 		require.NoError(t, err)
 		assert.Empty(t, tokens)
 	})
@@ -894,22 +937,23 @@ func TestGetPipelineTriggers(t *testing.T) {
 		}
 
 		mockClient.MockProjects.EXPECT().
-			GetProject("1", nil).
+			GetProject("1", nil, gomock.Any()).
 			Return(project, &gitlab.Response{}, nil)
 
 		mockClient.MockPipelineTriggers.EXPECT().
 			ListPipelineTriggers("1", &gitlab.ListPipelineTriggersOptions{
 				PerPage: 50,
 				Page:    1,
-			}).
+			}, gomock.Any()).
 			Return([]*gitlab.PipelineTrigger{trigger1, trigger2}, &gitlab.Response{}, nil)
 
-		triggers, err := client.GetPipelineTriggers("1")
+		triggers, err := client.GetPipelineTriggers(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, triggers, 2)
 
 		// Verify trigger wrapping
 		assert.Equal(t, trigger1, triggers[0].PipelineTrigger)
+		assert.Equal(t, glclient.ReportTypePipelineTrigger, triggers[0].ReportType)
 		assert.Equal(t, "test-project", triggers[0].ProjectName)
 		assert.Equal(t, "group/test-project", triggers[0].ProjectPath)
 		assert.Equal(t, "group", triggers[0].ProjectNamespace)
@@ -928,14 +972,14 @@ func TestGetPipelineTriggers(t *testing.T) {
 		}
 
 		mockClient.MockProjects.EXPECT().
-			GetProject("1", nil).
+			GetProject("1", nil, gomock.Any()).
 			Return(project, &gitlab.Response{}, nil)
 
 		mockClient.MockPipelineTriggers.EXPECT().
-			ListPipelineTriggers("1", gomock.Any()).
+			ListPipelineTriggers("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.PipelineTrigger{}, &gitlab.Response{}, nil)
 
-		triggers, err := client.GetPipelineTriggers("1")
+		triggers, err := client.GetPipelineTriggers(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Empty(t, triggers)
 	})
@@ -979,28 +1023,28 @@ func TestGetPipelineTriggersRecursively(t *testing.T) {
 
 		// Groups setup
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Projects setup
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project1, project2}, &gitlab.Response{}, nil)
 
 		// Pipeline triggers
 		mockClient.MockPipelineTriggers.EXPECT().
-			ListPipelineTriggers("1", gomock.Any()).
+			ListPipelineTriggers("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.PipelineTrigger{trigger1}, &gitlab.Response{}, nil)
 
 		mockClient.MockPipelineTriggers.EXPECT().
-			ListPipelineTriggers("2", gomock.Any()).
+			ListPipelineTriggers("2", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.PipelineTrigger{trigger2}, &gitlab.Response{}, nil)
 
-		triggers, err := client.GetPipelineTriggersRecursively("1")
+		triggers, err := client.GetPipelineTriggersRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, triggers, 2)
 
@@ -1032,24 +1076,24 @@ func TestGetPipelineTriggersRecursively(t *testing.T) {
 
 		// Groups setup
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		// Projects setup
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project}, &gitlab.Response{}, nil)
 
 		// No triggers
 		mockClient.MockPipelineTriggers.EXPECT().
-			ListPipelineTriggers("1", gomock.Any()).
+			ListPipelineTriggers("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.PipelineTrigger{}, &gitlab.Response{}, nil)
 
-		triggers, err := client.GetPipelineTriggersRecursively("1")
+		triggers, err := client.GetPipelineTriggersRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Empty(t, triggers)
 	})
@@ -1089,20 +1133,21 @@ func TestGetProjectVariables(t *testing.T) {
 
 		// Mock expectations
 		mockClient.MockProjects.EXPECT().
-			GetProject("10", nil).
+			GetProject("10", nil, gomock.Any()).
 			Return(project, &gitlab.Response{}, nil)
 
 		mockClient.MockProjectVariables.EXPECT().
-			ListVariables("10", gomock.Any()).
+			ListVariables("10", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.ProjectVariable{variable1, variable2}, &gitlab.Response{}, nil)
 
 		// Execute
-		variables, err := client.GetProjectVariables("10")
+		variables, err := client.GetProjectVariables(t.Context(), "10")
 		require.NoError(t, err)
 		require.Len(t, variables, 2)
 
 		// Verify the results include project information
 		for _, v := range variables {
+			assert.Equal(t, glclient.ReportTypeProjectVariable, v.ReportType)
 			assert.Equal(t, "test-project", v.ProjectName)
 			assert.Equal(t, "group/test-project", v.ProjectPath)
 			assert.Equal(t, "group", v.ProjectNamespace)
@@ -1127,10 +1172,10 @@ func TestGetProjectVariables(t *testing.T) {
 		client, mockClient := testClient(t)
 
 		mockClient.MockProjects.EXPECT().
-			GetProject("10", nil).
+			GetProject("10", nil, gomock.Any()).
 			Return(nil, nil, errAPI)
 
-		variables, err := client.GetProjectVariables("10")
+		variables, err := client.GetProjectVariables(t.Context(), "10")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get project")
 		assert.Nil(t, variables)
@@ -1150,14 +1195,14 @@ func TestGetProjectVariables(t *testing.T) {
 		}
 
 		mockClient.MockProjects.EXPECT().
-			GetProject("10", nil).
+			GetProject("10", nil, gomock.Any()).
 			Return(project, &gitlab.Response{}, nil)
 
 		mockClient.MockProjectVariables.EXPECT().
-			ListVariables("10", gomock.Any()).
+			ListVariables("10", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.ProjectVariable{}, &gitlab.Response{}, nil)
 
-		variables, err := client.GetProjectVariables("10")
+		variables, err := client.GetProjectVariables(t.Context(), "10")
 		require.NoError(t, err)
 		assert.Empty(t, variables)
 	})
@@ -1215,29 +1260,29 @@ func TestGetProjectVariablesRecursively(t *testing.T) {
 
 		// Mock expectations
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project1, project2}, &gitlab.Response{}, nil)
 
 		// Project 1 variables
 		mockClient.MockProjectVariables.EXPECT().
-			ListVariables("10", gomock.Any()).
+			ListVariables("10", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.ProjectVariable{var1}, &gitlab.Response{}, nil)
 
 		// Project 2 variables
 		mockClient.MockProjectVariables.EXPECT().
-			ListVariables("11", gomock.Any()).
+			ListVariables("11", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.ProjectVariable{var2}, &gitlab.Response{}, nil)
 
 		// Execute
-		variables, err := client.GetProjectVariablesRecursively("1")
+		variables, err := client.GetProjectVariablesRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		require.Len(t, variables, 2)
 
@@ -1267,10 +1312,10 @@ func TestGetProjectVariablesRecursively(t *testing.T) {
 		client, mockClient := testClient(t)
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(nil, nil, errAPI)
 
-		variables, err := client.GetProjectVariablesRecursively("1")
+		variables, err := client.GetProjectVariablesRecursively(t.Context(), "1")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get root group")
 		assert.Nil(t, variables)
@@ -1317,29 +1362,29 @@ func TestGetProjectVariablesRecursively(t *testing.T) {
 
 		// Mock expectations
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListGroupProjects("root-group", gomock.Any()).
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Project{project1, project2}, &gitlab.Response{}, nil)
 
 		// Project 1 fails to get variables
 		mockClient.MockProjectVariables.EXPECT().
-			ListVariables("10", gomock.Any()).
+			ListVariables("10", gomock.Any(), gomock.Any()).
 			Return(nil, nil, errAPI)
 
 		// Project 2 succeeds
 		mockClient.MockProjectVariables.EXPECT().
-			ListVariables("11", gomock.Any()).
+			ListVariables("11", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.ProjectVariable{var2}, &gitlab.Response{}, nil)
 
 		// Execute
-		variables, err := client.GetProjectVariablesRecursively("1")
+		variables, err := client.GetProjectVariablesRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		require.Len(t, variables, 1)
 		assert.Equal(t, "VAR2", variables[0].Key)
@@ -1359,15 +1404,300 @@ func TestDebugMode(t *testing.T) {
 		}
 
 		mockClient.MockGroups.EXPECT().
-			GetGroup("1", nil).
+			GetGroup("1", nil, gomock.Any()).
 			Return(rootGroup, &gitlab.Response{}, nil)
 
 		mockClient.MockGroups.EXPECT().
-			ListSubGroups("1", gomock.Any()).
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
 			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
 
-		groups, err := client.GetGroupsRecursively("1")
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
 		require.NoError(t, err)
 		assert.Len(t, groups, 1)
 	})
 }
+
+func TestGetAllVariablesRecursively(t *testing.T) {
+	rootGroup := &gitlab.Group{
+		ID:       1,
+		Name:     "root-group",
+		FullPath: "root-group",
+		WebURL:   "https://gitlab.com/root-group",
+	}
+
+	project := &gitlab.Project{
+		ID:                10,
+		Name:              "project-1",
+		PathWithNamespace: "root-group/project-1",
+		WebURL:            "https://gitlab.com/root-group/project-1",
+		Namespace: &gitlab.ProjectNamespace{
+			FullPath: "root-group",
+		},
+	}
+
+	t.Run("dispatches both fetches before either completes", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		// the group hierarchy is resolved once for both fetches
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil).
+			Times(1)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil).
+			Times(1)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Project{project}, &gitlab.Response{}, nil)
+
+		// Each branch blocks until the other one has started, so a sequential
+		// implementation would time out here
+		projectStarted := make(chan struct{})
+		groupStarted := make(chan struct{})
+
+		waitFor := func(ch <-chan struct{}) error {
+			select {
+			case <-ch:
+				return nil
+			case <-time.After(5 * time.Second):
+				return errAPI
+			}
+		}
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("10", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ any,
+				_ *gitlab.ListProjectVariablesOptions,
+				_ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.ProjectVariable, *gitlab.Response, error) {
+				close(projectStarted)
+
+				if err := waitFor(groupStarted); err != nil {
+					return nil, nil, err
+				}
+
+				return []*gitlab.ProjectVariable{{Key: "PROJECT_VAR"}}, &gitlab.Response{}, nil
+			})
+
+		mockClient.MockGroupVariables.EXPECT().
+			ListVariables("1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ any,
+				_ *gitlab.ListGroupVariablesOptions,
+				_ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.GroupVariable, *gitlab.Response, error) {
+				close(groupStarted)
+
+				if err := waitFor(projectStarted); err != nil {
+					return nil, nil, err
+				}
+
+				return []*gitlab.GroupVariable{{Key: "GROUP_VAR"}}, &gitlab.Response{}, nil
+			})
+
+		projectVariables, groupVariables, err := client.GetAllVariablesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, projectVariables, 1)
+		require.Len(t, groupVariables, 1)
+		assert.Equal(t, "PROJECT_VAR", projectVariables[0].Key)
+		assert.Equal(t, "GROUP_VAR", groupVariables[0].Key)
+		assert.Equal(t, glclient.ReportTypeProjectVariable, projectVariables[0].ReportType)
+		assert.Equal(t, glclient.ReportTypeGroupVariable, groupVariables[0].ReportType)
+	})
+
+	t.Run("surfaces error from either fetch", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(nil, nil, errAPI).
+			Times(1)
+
+		projectVariables, groupVariables, err := client.GetAllVariablesRecursively(t.Context(), "1")
+		require.Error(t, err)
+		require.ErrorIs(t, err, errAPI)
+		assert.Nil(t, projectVariables)
+		assert.Nil(t, groupVariables)
+	})
+}
+
+func TestConvertVariableToUnified(t *testing.T) {
+	project := glclient.ConvertProjectVariableToUnified(&glclient.ProjectVariableWithProject{
+		ProjectVariable: &gitlab.ProjectVariable{Key: "PROJECT_VAR"},
+		ProjectID:       10,
+		ProjectPath:     "group/project",
+	})
+	assert.Equal(t, glclient.ReportTypeProjectVariable, project.ReportType)
+	assert.Equal(t, "project", project.Source)
+
+	group := glclient.ConvertGroupVariableToUnified(&glclient.GroupVariableWithGroup{
+		GroupVariable: &gitlab.GroupVariable{Key: "GROUP_VAR"},
+		GroupID:       1,
+		GroupFullPath: "group",
+	})
+	assert.Equal(t, glclient.ReportTypeGroupVariable, group.ReportType)
+	assert.Equal(t, "group", group.Source)
+}
+
+func TestGetProjectsRecursively_sharedProjects(t *testing.T) {
+	rootGroup := &gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}
+	subGroup := &gitlab.Group{ID: 2, Name: "sub-group", FullPath: "root-group/sub-group"}
+	sharedProject := &gitlab.Project{
+		ID:                99,
+		PathWithNamespace: "other-group/shared-project",
+		Namespace:         &gitlab.ProjectNamespace{ID: 50, FullPath: "other-group"},
+	}
+
+	// the shared project is shared into both groups, as GitLab would list it with with_shared
+	expectFetch := func(mockClient *gitlabtesting.TestClient, withShared bool) {
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{subGroup}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("2", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				gid any,
+				opt *gitlab.ListGroupProjectsOptions,
+				_ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.Project, *gitlab.Response, error) {
+				require.NotNil(t, opt.WithShared)
+				assert.Equal(t, withShared, *opt.WithShared)
+
+				projects := []*gitlab.Project{{ID: 1}}
+				if gid == "root-group/sub-group" {
+					projects = []*gitlab.Project{{ID: 2}}
+				}
+
+				if *opt.WithShared {
+					projects = append(projects, sharedProject)
+				}
+
+				return projects, &gitlab.Response{}, nil
+			}).
+			Times(2)
+	}
+
+	projectIDs := func(projects []*gitlab.Project) []int {
+		ids := make([]int, 0, len(projects))
+		for _, p := range projects {
+			ids = append(ids, p.ID)
+		}
+
+		return ids
+	}
+
+	t.Run("excludes shared projects by default", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectFetch(mockClient, false)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, projectIDs(projects))
+	})
+
+	t.Run("includes shared projects once when requested", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithSharedProjects())
+		expectFetch(mockClient, true)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 99}, projectIDs(projects))
+	})
+
+	attribution := func(projects []*gitlab.Project) []string {
+		attributed := make([]string, 0, len(projects))
+		for _, p := range projects {
+			namespace := ""
+			if p.Namespace != nil {
+				namespace = p.Namespace.FullPath
+			}
+
+			attributed = append(attributed, fmt.Sprintf("%d:%s", p.ID, namespace))
+		}
+
+		return attributed
+	}
+
+	t.Run("attributes shared projects to their home namespace by default", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithSharedProjects())
+		expectFetch(mockClient, true)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1:", "2:", "99:other-group"}, attribution(projects))
+	})
+
+	t.Run("attributes shared projects to each group they are shared into", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false,
+			glclient.WithSharedProjects(), glclient.WithSharedAttribution())
+		expectFetch(mockClient, true)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"1:root-group",
+			"2:root-group/sub-group",
+			"99:root-group",
+			"99:root-group/sub-group",
+		}, attribution(projects))
+
+		for _, project := range projects {
+			assert.Equal(t, project.ID == 99, project.PathWithNamespace == "other-group/shared-project",
+				"the path still names the home namespace")
+		}
+
+		assert.Equal(t, "other-group", sharedProject.Namespace.FullPath, "the listed project is left unchanged")
+	})
+}
+
+func TestGetDirectSubgroups(t *testing.T) {
+	t.Run("returns the group and its direct subgroups only", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		rootGroup := &gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}
+		subGroup1 := &gitlab.Group{ID: 2, Name: "sub-group-1", FullPath: "root-group/sub-group-1"}
+		subGroup2 := &gitlab.Group{ID: 3, Name: "sub-group-2", FullPath: "root-group/sub-group-2"}
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		// the subgroups of sub-group-1 and sub-group-2 are never listed
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{subGroup1, subGroup2}, &gitlab.Response{}, nil)
+
+		groups, err := client.GetDirectSubgroups(t.Context(), "1")
+		require.NoError(t, err)
+
+		paths := make([]string, 0, len(groups))
+		for _, group := range groups {
+			paths = append(paths, group.FullPath)
+		}
+
+		assert.Equal(t, []string{"root-group", "root-group/sub-group-1", "root-group/sub-group-2"}, paths)
+	})
+
+	t.Run("requires a group", func(t *testing.T) {
+		client, _ := testClient(t)
+
+		_, err := client.GetDirectSubgroups(t.Context(), "")
+		require.ErrorIs(t, err, glclient.ErrGroupRequired)
+	})
+}
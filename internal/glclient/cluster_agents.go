@@ -0,0 +1,191 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Connection statuses of a Kubernetes agent, as shown by GitLab on the Kubernetes clusters page.
+const (
+	AgentConnected      = "connected"
+	AgentNotConnected   = "not_connected"
+	AgentNeverConnected = "never_connected"
+)
+
+// agentConnectedWithin is how recently an agent must have contacted GitLab to count as connected.
+// Connected agents check in every few minutes; GitLab shows them as not connected after eight.
+const agentConnectedWithin = 8 * time.Minute
+
+// ProjectClusterAgent represents a GitLab agent for Kubernetes registered in a project, with when it
+// last contacted GitLab.
+type ProjectClusterAgent struct {
+	ReportType    string `json:"report_type" csv:"-"`
+	Instance      string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int    `json:"project_id"`
+	ProjectName   string `json:"project_name"`
+	ProjectPath   string `json:"project_path"`
+	ProjectWebURL string `json:"project_web_url"`
+	AgentID       int    `json:"agent_id"`
+	Name          string `json:"name"`
+	// ConfigProject is the path of the project holding the agent's configuration file.
+	ConfigProject    string     `json:"config_project"`
+	CreatedAt        *time.Time `json:"created_at"`
+	LastContactAt    *time.Time `json:"last_contact_at"`
+	ConnectionStatus string     `json:"connection_status"` // connected, not_connected, or never_connected
+}
+
+// AgentConnectionStatus returns the connection status at the given time of an agent that last
+// contacted GitLab at lastContact, nil if it never did.
+func AgentConnectionStatus(lastContact *time.Time, now time.Time) string {
+	switch {
+	case lastContact == nil:
+		return AgentNeverConnected
+	case now.Sub(*lastContact) <= agentConnectedWithin:
+		return AgentConnected
+	default:
+		return AgentNotConnected
+	}
+}
+
+// GetClusterAgentsRecursively fetches the Kubernetes agents registered in all projects within a
+// group and its subgroups. An agent last contacted GitLab when one of its tokens was last used.
+// Projects without agents contribute no entries. Listing agents requires at least the Developer
+// role; other projects are reported as inaccessible.
+func (c *Client) GetClusterAgentsRecursively(ctx context.Context, groupID string) ([]*ProjectClusterAgent, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allAgents []*ProjectClusterAgent
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+
+	now := time.Now()
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			agents, err := c.listClusterAgents(ctx, projectID)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, "cluster agents", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching cluster agents for project %s: %v\n", projectID, err)
+				}
+
+				return
+			}
+
+			for _, agent := range agents {
+				agent.ProjectID = project.ID
+				agent.ProjectName = project.Name
+				agent.ProjectPath = project.PathWithNamespace
+				agent.ProjectWebURL = c.webURL(project.WebURL)
+				agent.ConnectionStatus = AgentConnectionStatus(agent.LastContactAt, now)
+			}
+
+			mu.Lock()
+			allAgents = append(allAgents, agents...)
+			mu.Unlock()
+			c.countItems(len(agents))
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "cluster agents fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortClusterAgents(allAgents)
+
+	return allAgents, nil
+}
+
+func (c *Client) listClusterAgents(ctx context.Context, projectID string) ([]*ProjectClusterAgent, error) {
+	var allAgents []*ProjectClusterAgent
+
+	opt := &gitlab.ListAgentsOptions{
+		PerPage: c.pageSize,
+		Page:    1,
+	}
+
+	for {
+		agents, resp, err := c.client.ClusterAgents.ListAgents(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cluster agents: %w", err)
+		}
+
+		for _, agent := range agents {
+			lastContact, err := c.agentLastContact(ctx, projectID, agent.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			allAgents = append(allAgents, &ProjectClusterAgent{
+				ReportType:    ReportTypeProjectClusterAgent,
+				AgentID:       agent.ID,
+				Name:          agent.Name,
+				ConfigProject: agent.ConfigProject.PathWithNamespace,
+				CreatedAt:     agent.CreatedAt,
+				LastContactAt: lastContact,
+			})
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d cluster agents for project %s\n", len(agents), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allAgents, nil
+}
+
+// agentLastContact returns when any token of an agent was last used, or nil if none was.
+func (c *Client) agentLastContact(ctx context.Context, projectID string, agentID int) (*time.Time, error) {
+	var lastContact *time.Time
+
+	opt := &gitlab.ListAgentTokensOptions{
+		PerPage: c.pageSize,
+		Page:    1,
+	}
+
+	for {
+		tokens, resp, err := c.client.ClusterAgents.ListAgentTokens(projectID, agentID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tokens of cluster agent %d: %w", agentID, err)
+		}
+
+		for _, token := range tokens {
+			if token.LastUsedAt != nil && (lastContact == nil || token.LastUsedAt.After(*lastContact)) {
+				lastContact = token.LastUsedAt
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return lastContact, nil
+}
@@ -0,0 +1,123 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetClusterAgentsRecursively(t *testing.T) {
+	t.Run("lists the agents of every project with their connection status", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectTopicProjects(mockClient)
+
+		config := gitlab.ConfigProject{ID: 10, PathWithNamespace: "root-group/payments"}
+		recent := time.Now().Add(-2 * time.Minute)
+		old := time.Now().AddDate(0, 0, -3)
+
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgents("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Agent{
+				{ID: 1, Name: "production", ConfigProject: config},
+				{ID: 2, Name: "staging", ConfigProject: config},
+			}, &gitlab.Response{}, nil)
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgentTokens("10", 1, gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AgentToken{
+				{ID: 7, LastUsedAt: &old},
+				{ID: 8, LastUsedAt: &recent},
+			}, &gitlab.Response{}, nil)
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgentTokens("10", 2, gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AgentToken{{ID: 9, LastUsedAt: &old}}, &gitlab.Response{}, nil)
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgents("11", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Agent{
+				{ID: 3, Name: "docs", ConfigProject: gitlab.ConfigProject{PathWithNamespace: "root-group/docs"}},
+			}, &gitlab.Response{}, nil)
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgentTokens("11", 3, gomock.Any(), gomock.Any()).
+			Return([]*gitlab.AgentToken{{ID: 10}}, &gitlab.Response{}, nil)
+		// projects without agents contribute nothing
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgents(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Agent{}, &gitlab.Response{}, nil).
+			Times(2)
+
+		agents, err := client.GetClusterAgentsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []*glclient.ProjectClusterAgent{
+			{
+				ReportType:       glclient.ReportTypeProjectClusterAgent,
+				ProjectID:        11,
+				ProjectName:      "docs",
+				ProjectPath:      "root-group/docs",
+				AgentID:          3,
+				Name:             "docs",
+				ConfigProject:    "root-group/docs",
+				ConnectionStatus: glclient.AgentNeverConnected,
+			},
+			{
+				ReportType:       glclient.ReportTypeProjectClusterAgent,
+				ProjectID:        10,
+				ProjectName:      "payments",
+				ProjectPath:      "root-group/payments",
+				AgentID:          1,
+				Name:             "production",
+				ConfigProject:    "root-group/payments",
+				LastContactAt:    &recent,
+				ConnectionStatus: glclient.AgentConnected,
+			},
+			{
+				ReportType:       glclient.ReportTypeProjectClusterAgent,
+				ProjectID:        10,
+				ProjectName:      "payments",
+				ProjectPath:      "root-group/payments",
+				AgentID:          2,
+				Name:             "staging",
+				ConfigProject:    "root-group/payments",
+				LastContactAt:    &old,
+				ConnectionStatus: glclient.AgentNotConnected,
+			},
+		}, agents)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("skips projects that fail", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectTopicProjects(mockClient)
+
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgents("12", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+		mockClient.MockClusterAgents.EXPECT().
+			ListAgents(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Agent{}, &gitlab.Response{}, nil).
+			Times(3)
+
+		agents, err := client.GetClusterAgentsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Empty(t, agents)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "project", Path: "root-group/billing", Reason: "cluster agents: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
+
+func TestAgentConnectionStatus(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-5 * time.Minute)
+	stale := now.Add(-time.Hour)
+
+	assert.Equal(t, glclient.AgentConnected, glclient.AgentConnectionStatus(&recent, now))
+	assert.Equal(t, glclient.AgentNotConnected, glclient.AgentConnectionStatus(&stale, now))
+	assert.Equal(t, glclient.AgentNeverConnected, glclient.AgentConnectionStatus(nil, now))
+}
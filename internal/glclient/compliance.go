@@ -0,0 +1,159 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectComplianceFrameworks represents the compliance frameworks applied to a project.
+type ProjectComplianceFrameworks struct {
+	ReportType    string `json:"report_type" csv:"-"`
+	Instance      string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int    `json:"project_id"`
+	ProjectName   string `json:"project_name"`
+	ProjectPath   string `json:"project_path"`
+	ProjectWebURL string `json:"project_web_url"`
+	// Frameworks are the names of the frameworks, empty for a project without one.
+	Frameworks []string `json:"frameworks"`
+	// FrameworksAvailable is false where the frameworks cannot be read, such as on instances without
+	// GitLab Premium, in which case Frameworks is empty as well.
+	FrameworksAvailable bool `json:"frameworks_available"`
+}
+
+// complianceFrameworksQuery asks for the compliance frameworks applied to the project at a full path.
+const complianceFrameworksQuery = `query { project(fullPath: %s) { complianceFrameworks { nodes { name } } } }`
+
+type complianceFrameworksResponse struct {
+	Data struct {
+		Project *struct {
+			ComplianceFrameworks *struct {
+				Nodes []struct {
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"complianceFrameworks"`
+		} `json:"project"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// errComplianceFrameworksNotReturned reports a GraphQL answer without compliance frameworks, as given
+// by GitLab editions without them or to tokens that cannot read them.
+var errComplianceFrameworksNotReturned = errors.New("compliance frameworks not returned")
+
+// GetComplianceFrameworksRecursively fetches the compliance frameworks applied to all projects within
+// a group and its subgroups. Where GitLab does not return the frameworks, such as without GitLab
+// Premium, the projects are still reported with FrameworksAvailable false. Projects that cannot be
+// queried are reported as inaccessible.
+func (c *Client) GetComplianceFrameworksRecursively(
+	ctx context.Context,
+	groupID string,
+) ([]*ProjectComplianceFrameworks, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allFrameworks []*ProjectComplianceFrameworks
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			frameworks, err := c.getComplianceFrameworks(ctx, project)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, "compliance frameworks", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching compliance frameworks for project %d: %v\n", project.ID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allFrameworks = append(allFrameworks, frameworks)
+			mu.Unlock()
+			c.countItems(1)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "compliance frameworks fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortComplianceFrameworks(allFrameworks)
+
+	return allFrameworks, nil
+}
+
+func (c *Client) getComplianceFrameworks(
+	ctx context.Context,
+	project *gitlab.Project,
+) (*ProjectComplianceFrameworks, error) {
+	frameworks := &ProjectComplianceFrameworks{
+		ReportType:    ReportTypeProjectComplianceFrameworks,
+		ProjectID:     project.ID,
+		ProjectName:   project.Name,
+		ProjectPath:   project.PathWithNamespace,
+		ProjectWebURL: c.webURL(project.WebURL),
+		Frameworks:    []string{},
+	}
+
+	names, err := c.queryComplianceFrameworks(ctx, project.PathWithNamespace)
+
+	switch {
+	case err == nil:
+		frameworks.Frameworks = names
+		frameworks.FrameworksAvailable = true
+	// GitLab editions without compliance frameworks reject the field of the query
+	case errors.Is(err, errComplianceFrameworksNotReturned):
+		if c.debug {
+			fmt.Printf("DEBUG: compliance frameworks of project %d are not available: %v\n", project.ID, err)
+		}
+	default:
+		return nil, err
+	}
+
+	return frameworks, nil
+}
+
+// queryComplianceFrameworks returns the names of the compliance frameworks of the project at path.
+func (c *Client) queryComplianceFrameworks(ctx context.Context, path string) ([]string, error) {
+	query := gitlab.GraphQLQuery{Query: fmt.Sprintf(complianceFrameworksQuery, strconv.Quote(path))}
+
+	var response complianceFrameworksResponse
+	if _, err := c.client.GraphQL.Do(query, &response, gitlab.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to query compliance frameworks: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("%w: %s", errComplianceFrameworksNotReturned, response.Errors[0].Message)
+	}
+
+	project := response.Data.Project
+	if project == nil || project.ComplianceFrameworks == nil {
+		return nil, errComplianceFrameworksNotReturned
+	}
+
+	names := make([]string, 0, len(project.ComplianceFrameworks.Nodes))
+	for _, node := range project.ComplianceFrameworks.Nodes {
+		names = append(names, node.Name)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,114 @@
+package glclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// expectComplianceFrameworksQuery answers the compliance frameworks query of a project with body.
+func expectComplianceFrameworksQuery(mockClient *gitlabtesting.TestClient, path, body string) {
+	mockClient.MockGraphQL.EXPECT().
+		Do(gomock.Cond(func(query gitlab.GraphQLQuery) bool {
+			return strings.Contains(query.Query, strconv.Quote(path))
+		}), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ gitlab.GraphQLQuery, response any, _ ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+			return &gitlab.Response{}, json.Unmarshal([]byte(body), response)
+		})
+}
+
+func TestGetComplianceFrameworksRecursively(t *testing.T) {
+	expectProjects := func(mockClient *gitlabtesting.TestClient) {
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Project{
+				{ID: 10, Name: "api", PathWithNamespace: "root-group/api"},
+				{ID: 11, Name: "web", PathWithNamespace: "root-group/web"},
+			}, &gitlab.Response{}, nil)
+	}
+
+	t.Run("reports the frameworks of each project", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		expectComplianceFrameworksQuery(mockClient, "root-group/api",
+			`{"data":{"project":{"complianceFrameworks":{"nodes":[{"name":"SOC 2"},{"name":"HIPAA"}]}}}}`)
+		expectComplianceFrameworksQuery(mockClient, "root-group/web",
+			`{"data":{"project":{"complianceFrameworks":{"nodes":[]}}}}`)
+
+		frameworks, err := client.GetComplianceFrameworksRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []*glclient.ProjectComplianceFrameworks{
+			{
+				ReportType:          glclient.ReportTypeProjectComplianceFrameworks,
+				ProjectID:           10,
+				ProjectName:         "api",
+				ProjectPath:         "root-group/api",
+				Frameworks:          []string{"SOC 2", "HIPAA"},
+				FrameworksAvailable: true,
+			},
+			{
+				ReportType:          glclient.ReportTypeProjectComplianceFrameworks,
+				ProjectID:           11,
+				ProjectName:         "web",
+				ProjectPath:         "root-group/web",
+				Frameworks:          []string{},
+				FrameworksAvailable: true,
+			},
+		}, frameworks)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("keeps projects where frameworks are not licensed", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		// GitLab editions without compliance frameworks reject the field
+		expectComplianceFrameworksQuery(mockClient, "root-group/api",
+			`{"errors":[{"message":"Field 'complianceFrameworks' doesn't exist on type 'Project'"}]}`)
+		expectComplianceFrameworksQuery(mockClient, "root-group/web", `{"data":{"project":null}}`)
+
+		frameworks, err := client.GetComplianceFrameworksRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, frameworks, 2)
+
+		for _, project := range frameworks {
+			assert.False(t, project.FrameworksAvailable)
+			assert.Empty(t, project.Frameworks)
+		}
+
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("records projects that cannot be queried", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		mockClient.MockGraphQL.EXPECT().
+			Do(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errStatus(http.StatusForbidden)).
+			Times(2)
+
+		frameworks, err := client.GetComplianceFrameworksRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Empty(t, frameworks)
+		assert.Len(t, client.Inaccessible(), 2)
+	})
+}
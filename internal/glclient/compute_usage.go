@@ -0,0 +1,188 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ErrComputeUsageUnavailable is returned when no group lets the token read its compute usage.
+var ErrComputeUsageUnavailable = errors.New("compute usage is not available: it requires GitLab Premium or " +
+	"Ultimate, or gitlab.com, and the Owner role on the top-level groups")
+
+// GroupComputeUsage represents the CI/CD compute minutes a top-level group used on shared runners in
+// a month, against its monthly quota. A limit of 0 means the group has no quota, or that the token
+// cannot read it: GitLab only returns quotas to administrators.
+type GroupComputeUsage struct {
+	ReportType   string `json:"report_type" csv:"-"`
+	Instance     string `json:"instance,omitempty" csv:"omitempty"`
+	GroupID      int    `json:"group_id"`
+	GroupName    string `json:"group_name"`
+	GroupPath    string `json:"group_path"`
+	GroupWebURL  string `json:"group_web_url"`
+	Month        string `json:"month"` // YYYY-MM
+	MinutesUsed  int    `json:"minutes_used"`
+	MinutesLimit int    `json:"minutes_limit"` // monthly quota including purchased minutes
+	OverLimit    bool   `json:"over_limit"`
+}
+
+// computeUsageQuery asks for the compute minutes a namespace used in the month of the given date.
+const computeUsageQuery = `query { ciMinutesUsage(namespaceId: "gid://gitlab/Group/%d", date: "%s") ` +
+	`{ nodes { minutes } } }`
+
+type computeUsageResponse struct {
+	Data struct {
+		CIMinutesUsage *struct {
+			Nodes []struct {
+				Minutes int `json:"minutes"`
+			} `json:"nodes"`
+		} `json:"ciMinutesUsage"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetComputeUsageRecursively fetches the compute minutes used in the current month by the top-level
+// groups of a group hierarchy. Compute usage is tracked for top-level groups only, so subgroups are
+// reported through their top-level group. Groups whose usage the token cannot read are skipped and
+// reported by Inaccessible, unless no group's usage can be read, in which case
+// ErrComputeUsageUnavailable is returned.
+func (c *Client) GetComputeUsageRecursively(ctx context.Context, groupID string) ([]*GroupComputeUsage, error) {
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	roots := topLevelPaths(groups)
+	month := time.Now().UTC()
+
+	var (
+		allUsage    []*GroupComputeUsage
+		unavailable int
+		reason      string
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+	)
+
+	for _, root := range roots {
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			usage, err := c.getComputeUsage(ctx, root, month)
+			if err != nil {
+				mu.Lock()
+				unavailable++
+				reason = err.Error()
+				mu.Unlock()
+
+				c.recordComputeUsageError(ctx, root, err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching compute usage for group %s: %v\n", root, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allUsage = append(allUsage, usage)
+			mu.Unlock()
+			c.countItems(1)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "compute usage fetch"); err != nil {
+		return nil, err
+	}
+
+	if len(roots) > 0 && unavailable == len(roots) {
+		return nil, fmt.Errorf("%w: %s", ErrComputeUsageUnavailable, reason)
+	}
+
+	c.sortComputeUsage(allUsage)
+
+	return allUsage, nil
+}
+
+// topLevelPaths returns the distinct full paths of the top-level groups of groups, sorted.
+func topLevelPaths(groups []*gitlab.Group) []string {
+	var roots []string
+
+	for _, group := range groups {
+		root, _, _ := strings.Cut(group.FullPath, "/")
+		if !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+
+	slices.Sort(roots)
+
+	return roots
+}
+
+// errComputeUsageNotReturned reports a GraphQL answer without compute usage, as given to tokens
+// without the Owner role or by GitLab editions that do not track it.
+var errComputeUsageNotReturned = errors.New("not available to the token")
+
+func (c *Client) getComputeUsage(ctx context.Context, rootPath string, month time.Time) (*GroupComputeUsage, error) {
+	// listed groups lack the quotas, which GitLab only includes for a single group
+	group, _, err := c.client.Groups.GetGroup(rootPath, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	query := gitlab.GraphQLQuery{Query: fmt.Sprintf(computeUsageQuery, group.ID, month.Format("2006-01")+"-01")}
+
+	var response computeUsageResponse
+	if _, err := c.client.GraphQL.Do(query, &response, gitlab.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to query compute usage: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("%w: %s", errComputeUsageNotReturned, response.Errors[0].Message)
+	}
+
+	if response.Data.CIMinutesUsage == nil {
+		return nil, errComputeUsageNotReturned
+	}
+
+	usage := &GroupComputeUsage{
+		ReportType:   ReportTypeGroupComputeUsage,
+		GroupID:      group.ID,
+		GroupName:    group.Name,
+		GroupPath:    group.FullPath,
+		GroupWebURL:  c.webURL(group.WebURL),
+		Month:        month.Format("2006-01"),
+		MinutesLimit: group.SharedRunnersMinutesLimit + group.ExtraSharedRunnersMinutesLimit,
+	}
+
+	for _, node := range response.Data.CIMinutesUsage.Nodes {
+		usage.MinutesUsed += node.Minutes
+	}
+
+	usage.OverLimit = usage.MinutesLimit > 0 && usage.MinutesUsed > usage.MinutesLimit
+
+	return usage, nil
+}
+
+// recordComputeUsageError reports a top-level group whose compute usage could not be read.
+func (c *Client) recordComputeUsageError(ctx context.Context, path string, err error) {
+	if errors.Is(err, errComputeUsageNotReturned) {
+		c.addInaccessible("group", path, "compute usage: "+err.Error())
+
+		return
+	}
+
+	c.recordInaccessible(ctx, "group", path, "compute usage", err)
+}
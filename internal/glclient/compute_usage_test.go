@@ -0,0 +1,131 @@
+package glclient_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// expectComputeUsageQuery answers the compute usage query of a group with body.
+func expectComputeUsageQuery(mockClient *gitlabtesting.TestClient, groupID int, body string) {
+	namespace := fmt.Sprintf(`"gid://gitlab/Group/%d"`, groupID)
+
+	mockClient.MockGraphQL.EXPECT().
+		Do(gomock.Cond(func(query gitlab.GraphQLQuery) bool {
+			return strings.Contains(query.Query, namespace)
+		}), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ gitlab.GraphQLQuery, response any, _ ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+			return &gitlab.Response{}, json.Unmarshal([]byte(body), response)
+		})
+}
+
+func TestGetComputeUsageRecursively(t *testing.T) {
+	acme := &gitlab.Group{ID: 1, Name: "acme", FullPath: "acme", WebURL: "https://gitlab.com/acme"}
+	acmeTeam := &gitlab.Group{ID: 2, Name: "team", FullPath: "acme/team"}
+	beta := &gitlab.Group{ID: 3, Name: "beta", FullPath: "beta"}
+	month := time.Now().UTC().Format("2006-01")
+
+	t.Run("reports the top-level groups", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{acme, acmeTeam, beta}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("acme", nil, gomock.Any()).
+			Return(&gitlab.Group{
+				ID: 1, Name: "acme", FullPath: "acme", WebURL: "https://gitlab.com/acme",
+				SharedRunnersMinutesLimit: 400, ExtraSharedRunnersMinutesLimit: 100,
+			}, &gitlab.Response{}, nil)
+		expectComputeUsageQuery(mockClient, 1,
+			`{"data":{"ciMinutesUsage":{"nodes":[{"minutes":450},{"minutes":90}]}}}`)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("beta", nil, gomock.Any()).
+			Return(beta, &gitlab.Response{}, nil)
+		expectComputeUsageQuery(mockClient, 3, `{"data":{"ciMinutesUsage":{"nodes":[{"minutes":12}]}}}`)
+
+		usage, err := client.GetComputeUsageRecursively(t.Context(), "")
+		require.NoError(t, err)
+		assert.Equal(t, []*glclient.GroupComputeUsage{
+			{
+				ReportType:   glclient.ReportTypeGroupComputeUsage,
+				GroupID:      1,
+				GroupName:    "acme",
+				GroupPath:    "acme",
+				GroupWebURL:  "https://gitlab.com/acme",
+				Month:        month,
+				MinutesUsed:  540,
+				MinutesLimit: 500,
+				OverLimit:    true,
+			},
+			{
+				ReportType:  glclient.ReportTypeGroupComputeUsage,
+				GroupID:     3,
+				GroupName:   "beta",
+				GroupPath:   "beta",
+				Month:       month,
+				MinutesUsed: 12,
+			},
+		}, usage)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("skips groups whose usage is not returned", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{acme, beta}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("acme", nil, gomock.Any()).
+			Return(acme, &gitlab.Response{}, nil)
+		expectComputeUsageQuery(mockClient, 1, `{"data":{"ciMinutesUsage":{"nodes":[]}}}`)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("beta", nil, gomock.Any()).
+			Return(beta, &gitlab.Response{}, nil)
+		expectComputeUsageQuery(mockClient, 3, `{"data":{"ciMinutesUsage":null},`+
+			`"errors":[{"message":"The resource that you are attempting to access does not exist"}]}`)
+
+		usage, err := client.GetComputeUsageRecursively(t.Context(), "")
+		require.NoError(t, err)
+		require.Len(t, usage, 1)
+		assert.Equal(t, "acme", usage[0].GroupPath)
+		assert.Equal(t, []glclient.Inaccessible{{
+			Kind: "group", Path: "beta",
+			Reason: "compute usage: not available to the token: " +
+				"The resource that you are attempting to access does not exist",
+		}}, client.Inaccessible())
+	})
+
+	t.Run("fails when no group's usage is available", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{acme, acmeTeam}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("acme", nil, gomock.Any()).
+			Return(acme, &gitlab.Response{}, nil)
+		mockClient.MockGraphQL.EXPECT().
+			Do(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errStatus(http.StatusForbidden))
+
+		_, err := client.GetComputeUsageRecursively(t.Context(), "")
+		require.ErrorIs(t, err, glclient.ErrComputeUsageUnavailable)
+	})
+}
@@ -0,0 +1,20 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// interrupted returns an error when ctx is done, unless the client returns partial results and
+// the deadline expired, or the fetches collected the items asked for by LimitItems. what names the
+// interrupted fetch in the error.
+func (c *Client) interrupted(ctx context.Context, what string) error {
+	err := ctx.Err()
+	if err == nil || (c.partial && errors.Is(err, context.DeadlineExceeded)) ||
+		errors.Is(context.Cause(ctx), errItemLimitReached) {
+		return nil
+	}
+
+	return fmt.Errorf("%s interrupted: %w", what, err)
+}
@@ -0,0 +1,119 @@
+package glclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowServer serves a group with two projects. The variables of the second project are only
+// returned after delay, or when the request is abandoned.
+func slowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/groups/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "name": "root-group", "full_path": "root-group"}`))
+	})
+	mux.HandleFunc("/api/v4/groups/1/subgroups", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("/api/v4/groups/root-group/projects", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id": 10, "path_with_namespace": "root-group/fast", "namespace": {"full_path": "root-group"}},
+			{"id": 11, "path_with_namespace": "root-group/slow", "namespace": {"full_path": "root-group"}}
+		]`))
+	})
+	mux.HandleFunc("/api/v4/projects/10/variables", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"key": "FAST"}]`))
+	})
+	mux.HandleFunc("/api/v4/projects/11/variables", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			_, _ = w.Write([]byte(`[{"key": "SLOW"}]`))
+		case <-r.Context().Done():
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func variableKeys(variables []*glclient.ProjectVariableWithProject) []string {
+	keys := make([]string, 0, len(variables))
+	for _, v := range variables {
+		keys = append(keys, v.Key)
+	}
+
+	return keys
+}
+
+func TestClient_requestTimeout(t *testing.T) {
+	server := slowServer(t, 5*time.Second)
+
+	client, err := glclient.NewClient("token", false,
+		glclient.WithBaseURL(server.URL), glclient.WithRequestTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+
+	variables, err := client.GetProjectVariablesRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FAST"}, variableKeys(variables))
+
+	assert.Equal(t, []glclient.Inaccessible{
+		{Kind: "project", Path: "root-group/slow", Reason: "project variables: request timed out"},
+	}, client.Inaccessible())
+}
+
+func TestClient_deadline(t *testing.T) {
+	t.Run("fails when the deadline expires", func(t *testing.T) {
+		server := slowServer(t, 5*time.Second)
+
+		client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 300*time.Millisecond)
+		defer cancel()
+
+		variables, err := client.GetProjectVariablesRecursively(ctx, "1")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Nil(t, variables)
+	})
+
+	t.Run("returns partial results when requested", func(t *testing.T) {
+		server := slowServer(t, 5*time.Second)
+
+		client, err := glclient.NewClient("token", false,
+			glclient.WithBaseURL(server.URL), glclient.WithPartialResults())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 300*time.Millisecond)
+		defer cancel()
+
+		variables, err := client.GetProjectVariablesRecursively(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"FAST"}, variableKeys(variables))
+		assert.Empty(t, client.Inaccessible(), "the expired deadline is not reported per project")
+	})
+
+	t.Run("still fails on cancellation", func(t *testing.T) {
+		server := slowServer(t, 5*time.Second)
+
+		client, err := glclient.NewClient("token", false,
+			glclient.WithBaseURL(server.URL), glclient.WithPartialResults())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		time.AfterFunc(300*time.Millisecond, cancel)
+
+		_, err = client.GetProjectVariablesRecursively(ctx, "1")
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
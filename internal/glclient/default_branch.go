@@ -0,0 +1,41 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// ProjectDefaultBranch represents the default branch of a project.
+type ProjectDefaultBranch struct {
+	ReportType    string `json:"report_type" csv:"-"`
+	Instance      string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int    `json:"project_id"`
+	ProjectName   string `json:"project_name"`
+	ProjectPath   string `json:"project_path"`
+	ProjectWebURL string `json:"project_web_url"`
+	DefaultBranch string `json:"default_branch"` // empty for projects without a repository or commits
+}
+
+// GetDefaultBranchesRecursively fetches the default branch of all projects within a group and its
+// subgroups. The project listing already includes it, so no further requests are made.
+func (c *Client) GetDefaultBranchesRecursively(ctx context.Context, groupID string) ([]*ProjectDefaultBranch, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	branches := make([]*ProjectDefaultBranch, 0, len(projects))
+
+	for _, project := range projects {
+		branches = append(branches, &ProjectDefaultBranch{
+			ReportType:    ReportTypeProjectDefaultBranch,
+			ProjectID:     project.ID,
+			ProjectName:   project.Name,
+			ProjectPath:   project.PathWithNamespace,
+			ProjectWebURL: c.webURL(project.WebURL),
+			DefaultBranch: project.DefaultBranch,
+		})
+	}
+
+	return branches, nil
+}
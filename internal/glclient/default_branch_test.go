@@ -0,0 +1,56 @@
+package glclient_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetDefaultBranchesRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			{
+				ID:                10,
+				Name:              "api",
+				PathWithNamespace: "root-group/api",
+				WebURL:            "https://gitlab.com/root-group/api",
+				DefaultBranch:     "main",
+			},
+			{ID: 11, Name: "empty", PathWithNamespace: "root-group/empty"},
+		}, &gitlab.Response{}, nil)
+
+	branches, err := client.GetDefaultBranchesRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*glclient.ProjectDefaultBranch{
+		{
+			ReportType:    glclient.ReportTypeProjectDefaultBranch,
+			ProjectID:     10,
+			ProjectName:   "api",
+			ProjectPath:   "root-group/api",
+			ProjectWebURL: "https://gitlab.com/root-group/api",
+			DefaultBranch: "main",
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectDefaultBranch,
+			ProjectID:   11,
+			ProjectName: "empty",
+			ProjectPath: "root-group/empty",
+		},
+	}, branches)
+}
@@ -0,0 +1,179 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ErrEpicsUnavailable is returned when no group lets the token list its epics.
+var ErrEpicsUnavailable = errors.New(
+	"epics are not available: they require GitLab Premium or Ultimate, and the token must be able to read the groups")
+
+// Epic states accepted by GetEpicsRecursively.
+const (
+	EpicStateOpened = "opened"
+	EpicStateClosed = "closed"
+)
+
+// GroupEpic represents an epic with the group it belongs to.
+type GroupEpic struct {
+	ReportType     string     `json:"report_type" csv:"-"`
+	Instance       string     `json:"instance,omitempty" csv:"omitempty"`
+	GroupID        int        `json:"group_id"`
+	GroupName      string     `json:"group_name"`
+	GroupPath      string     `json:"group_path"`
+	GroupWebURL    string     `json:"group_web_url"`
+	ID             int        `json:"id"`
+	IID            int        `json:"iid"`
+	Title          string     `json:"title"`
+	State          string     `json:"state"`
+	AuthorUsername string     `json:"author_username"`
+	DueDate        *time.Time `json:"due_date"`
+	WebURL         string     `json:"web_url"`
+}
+
+// GetEpicsRecursively fetches the epics of all groups within a group and its subgroups. A non-empty
+// state, EpicStateOpened or EpicStateClosed, limits them to epics in that state. Groups that do not
+// let the token list their epics are skipped and reported by Inaccessible, unless no group does, in
+// which case ErrEpicsUnavailable is returned.
+func (c *Client) GetEpicsRecursively(ctx context.Context, groupID, state string) ([]*GroupEpic, error) {
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	var (
+		allEpics  []*GroupEpic
+		forbidden int
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+
+		groupID := strconv.Itoa(group.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			epics, err := c.listEpicsForGroup(ctx, groupID, group, state)
+			if err != nil {
+				if isForbidden(err) {
+					mu.Lock()
+					forbidden++
+					mu.Unlock()
+				}
+
+				c.recordInaccessible(ctx, "group", group.FullPath, "epics", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching epics for group %s: %v\n", groupID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allEpics = append(allEpics, epics...)
+			mu.Unlock()
+			c.countItems(len(epics))
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "epics fetch"); err != nil {
+		return nil, err
+	}
+
+	// GitLab answers 403 for every group when epics are not part of the license
+	if len(groups) > 0 && forbidden == len(groups) {
+		return nil, ErrEpicsUnavailable
+	}
+
+	c.sortEpics(allEpics)
+
+	return allEpics, nil
+}
+
+func (c *Client) listEpicsForGroup(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+	state string,
+) ([]*GroupEpic, error) {
+	var allEpics []*GroupEpic
+
+	opt := &gitlab.ListGroupEpicsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+		// each group of the hierarchy is listed on its own
+		IncludeDescendantGroups: gitlab.Ptr(false),
+	}
+
+	if state != "" {
+		opt.State = gitlab.Ptr(state)
+	}
+
+	for {
+		epics, resp, err := c.client.Epics.ListGroupEpics(groupID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group epics: %w", err)
+		}
+
+		for _, epic := range epics {
+			allEpics = append(allEpics, c.newGroupEpic(epic, group))
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d epics for group %s\n", len(epics), groupID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allEpics, nil
+}
+
+func (c *Client) newGroupEpic(epic *gitlab.Epic, group *gitlab.Group) *GroupEpic {
+	wrapped := &GroupEpic{
+		ReportType:  ReportTypeGroupEpic,
+		GroupID:     group.ID,
+		GroupName:   group.Name,
+		GroupPath:   group.FullPath,
+		GroupWebURL: c.webURL(group.WebURL),
+		ID:          epic.ID,
+		IID:         epic.IID,
+		Title:       epic.Title,
+		State:       epic.State,
+		DueDate:     isoDate(epic.DueDate),
+		WebURL:      c.webURL(epic.WebURL),
+	}
+
+	if epic.Author != nil {
+		wrapped.AuthorUsername = epic.Author.Username
+	}
+
+	return wrapped
+}
+
+// isForbidden reports whether err is a 403 response of the GitLab API.
+func isForbidden(err error) bool {
+	var errResp *gitlab.ErrorResponse
+
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusForbidden
+}
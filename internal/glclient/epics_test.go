@@ -0,0 +1,130 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// expectEpicGroups sets up a root group with a single subgroup.
+func expectEpicGroups(mockClient *gitlabtesting.TestClient) {
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{
+			ID:       1,
+			Name:     "root-group",
+			FullPath: "root-group",
+			WebURL:   "https://gitlab.com/groups/root-group",
+		}, &gitlab.Response{}, nil)
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{{ID: 2, Name: "team", FullPath: "root-group/team"}}, &gitlab.Response{}, nil)
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("2", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+}
+
+func TestGetEpicsRecursively(t *testing.T) {
+	t.Run("lists the epics of every group", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		dueDate := gitlab.ISOTime(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+
+		expectEpicGroups(mockClient)
+
+		mockClient.MockEpics.EXPECT().
+			ListGroupEpics("1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ any, opt *gitlab.ListGroupEpicsOptions, _ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.Epic, *gitlab.Response, error) {
+				assert.Equal(t, glclient.EpicStateOpened, *opt.State)
+				assert.False(t, *opt.IncludeDescendantGroups)
+
+				return []*gitlab.Epic{
+					{
+						ID:      101,
+						IID:     2,
+						Title:   "Migrate runners",
+						State:   "opened",
+						Author:  &gitlab.EpicAuthor{Username: "alice"},
+						DueDate: &dueDate,
+						WebURL:  "https://gitlab.com/groups/root-group/-/epics/2",
+					},
+				}, &gitlab.Response{}, nil
+			})
+		mockClient.MockEpics.EXPECT().
+			ListGroupEpics("2", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Epic{{ID: 102, IID: 1, Title: "Onboarding", State: "opened"}}, &gitlab.Response{}, nil)
+
+		epics, err := client.GetEpicsRecursively(t.Context(), "1", glclient.EpicStateOpened)
+		require.NoError(t, err)
+
+		due := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, []*glclient.GroupEpic{
+			{
+				ReportType:     glclient.ReportTypeGroupEpic,
+				GroupID:        1,
+				GroupName:      "root-group",
+				GroupPath:      "root-group",
+				GroupWebURL:    "https://gitlab.com/groups/root-group",
+				ID:             101,
+				IID:            2,
+				Title:          "Migrate runners",
+				State:          "opened",
+				AuthorUsername: "alice",
+				DueDate:        &due,
+				WebURL:         "https://gitlab.com/groups/root-group/-/epics/2",
+			},
+			{
+				ReportType: glclient.ReportTypeGroupEpic,
+				GroupID:    2,
+				GroupName:  "team",
+				GroupPath:  "root-group/team",
+				ID:         102,
+				IID:        1,
+				Title:      "Onboarding",
+				State:      "opened",
+			},
+		}, epics)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("reports epics as unavailable when every group forbids them", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectEpicGroups(mockClient)
+		mockClient.MockEpics.EXPECT().
+			ListGroupEpics(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden)).
+			Times(2)
+
+		_, err := client.GetEpicsRecursively(t.Context(), "1", "")
+		require.ErrorIs(t, err, glclient.ErrEpicsUnavailable)
+	})
+
+	t.Run("skips groups that forbid listing their epics", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectEpicGroups(mockClient)
+		mockClient.MockEpics.EXPECT().
+			ListGroupEpics("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Epic{{ID: 101, IID: 1, Title: "Roadmap", State: "closed"}}, &gitlab.Response{}, nil)
+		mockClient.MockEpics.EXPECT().
+			ListGroupEpics("2", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		epics, err := client.GetEpicsRecursively(t.Context(), "1", "")
+		require.NoError(t, err)
+		require.Len(t, epics, 1)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "group", Path: "root-group/team", Reason: "epics: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
@@ -0,0 +1,59 @@
+package glclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var (
+	// ErrGroupNotFound is returned when the requested group does not exist. GitLab answers the same way
+	// for private groups the token cannot see, so the group may also exist without being visible.
+	ErrGroupNotFound = errors.New(
+		"group not found: check the group ID or path, and that the token's user can see the group")
+	// ErrProjectNotFound is returned when the requested project does not exist, or is private and not
+	// visible to the token.
+	ErrProjectNotFound = errors.New(
+		"project not found: check the project ID or path, and that the token's user can see the project")
+	// ErrGroupIsProject is returned when the group to start from is not found because its ID or path
+	// names a project.
+	ErrGroupIsProject = errors.New("the group ID or path names a project, not a group")
+	// ErrGroupRequired is returned when listing direct subgroups without a group to start from.
+	ErrGroupRequired = errors.New("a group is required to list its direct subgroups")
+	// ErrAccessDenied is returned when the token is not allowed to read the requested group or project.
+	ErrAccessDenied = errors.New(
+		"access denied: check that the token is valid, has the read_api scope, and its user can read the group or project")
+)
+
+// groupLookupError classifies an error returned when looking up a group, wrapping ErrGroupNotFound
+// or ErrAccessDenied depending on the status of the response. Other errors are returned unchanged.
+func groupLookupError(err error) error {
+	return lookupError(err, ErrGroupNotFound)
+}
+
+// projectLookupError classifies an error returned when looking up a project like groupLookupError,
+// wrapping ErrProjectNotFound for missing projects.
+func projectLookupError(err error) error {
+	return lookupError(err, ErrProjectNotFound)
+}
+
+func lookupError(err, notFound error) error {
+	// the GitLab client answers 404 with its ErrNotFound sentinel rather than an ErrorResponse
+	if errors.Is(err, gitlab.ErrNotFound) {
+		return fmt.Errorf("%w: %w", notFound, err)
+	}
+
+	var errResp *gitlab.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return err
+	}
+
+	switch errResp.Response.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrAccessDenied, err)
+	default:
+		return err
+	}
+}
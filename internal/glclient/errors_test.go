@@ -0,0 +1,110 @@
+package glclient_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGroupLookupErrors(t *testing.T) {
+	errUnavailable := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "not found", err: gitlab.ErrNotFound, expected: glclient.ErrGroupNotFound},
+		{name: "forbidden", err: errStatus(http.StatusForbidden), expected: glclient.ErrAccessDenied},
+		{name: "unauthorized", err: errStatus(http.StatusUnauthorized), expected: glclient.ErrAccessDenied},
+		{name: "other status", err: errStatus(http.StatusInternalServerError)},
+		{name: "no response", err: errUnavailable},
+	}
+
+	fetches := map[string]func(client *glclient.Client) error{
+		"GetGroupsRecursively": func(client *glclient.Client) error {
+			_, err := client.GetGroupsRecursively(t.Context(), "missing")
+
+			return err
+		},
+		"GetGroupAccessTokens": func(client *glclient.Client) error {
+			_, err := client.GetGroupAccessTokens(t.Context(), "missing", glclient.TokenStateActive)
+
+			return err
+		},
+		"GetGroupVariables": func(client *glclient.Client) error {
+			_, err := client.GetGroupVariables(t.Context(), "missing")
+
+			return err
+		},
+	}
+
+	for fetchName, fetch := range fetches {
+		for _, tt := range tests {
+			t.Run(fetchName+"/"+tt.name, func(t *testing.T) {
+				client, mockClient := testClient(t)
+
+				mockClient.MockGroups.EXPECT().
+					GetGroup("missing", nil, gomock.Any()).
+					Return(nil, nil, tt.err)
+
+				if tt.expected == glclient.ErrGroupNotFound {
+					mockClient.MockProjects.EXPECT().
+						GetProject("missing", nil, gomock.Any()).
+						Return(nil, nil, gitlab.ErrNotFound)
+				}
+
+				err := fetch(client)
+				require.ErrorIs(t, err, tt.err)
+
+				for _, sentinel := range []error{glclient.ErrGroupNotFound, glclient.ErrAccessDenied} {
+					assert.Equal(t, sentinel == tt.expected, errors.Is(err, sentinel), sentinel)
+				}
+			})
+		}
+	}
+}
+
+func TestGroupLookupErrors_project(t *testing.T) {
+	fetches := map[string]func(client *glclient.Client) error{
+		"GetGroupsRecursively": func(client *glclient.Client) error {
+			_, err := client.GetGroupsRecursively(t.Context(), "org/api")
+
+			return err
+		},
+		"GetGroupAccessTokens": func(client *glclient.Client) error {
+			_, err := client.GetGroupAccessTokens(t.Context(), "org/api", glclient.TokenStateActive)
+
+			return err
+		},
+		"GetGroupVariables": func(client *glclient.Client) error {
+			_, err := client.GetGroupVariables(t.Context(), "org/api")
+
+			return err
+		},
+	}
+
+	for fetchName, fetch := range fetches {
+		t.Run(fetchName, func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockGroups.EXPECT().
+				GetGroup("org/api", nil, gomock.Any()).
+				Return(nil, nil, gitlab.ErrNotFound)
+			mockClient.MockProjects.EXPECT().
+				GetProject("org/api", nil, gomock.Any()).
+				Return(&gitlab.Project{ID: 7, PathWithNamespace: "org/api"}, &gitlab.Response{}, nil)
+
+			err := fetch(client)
+			require.ErrorIs(t, err, glclient.ErrGroupIsProject)
+			assert.NotErrorIs(t, err, glclient.ErrGroupNotFound)
+			assert.Contains(t, err.Error(), "org/api is project 7, did you mean --project-id?")
+		})
+	}
+}
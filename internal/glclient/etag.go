@@ -0,0 +1,198 @@
+package glclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/cache"
+)
+
+// etagHeaders are the response headers stored with a cached body, so that a revalidated response
+// still carries its content type and pagination.
+var etagHeaders = []string{
+	"Content-Type",
+	"ETag",
+	"Link",
+	"X-Next-Page",
+	"X-Page",
+	"X-Per-Page",
+	"X-Prev-Page",
+	"X-Total",
+	"X-Total-Pages",
+}
+
+// etagSecrets are the fields removed from cached bodies, at any depth.
+var etagSecrets = []string{"runners_token"}
+
+type etagEntry struct {
+	ETag   string          `json:"etag"`
+	Header http.Header     `json:"header"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// etagTransport revalidates group and project responses stored in a cache with If-None-Match, and
+// answers a 304 Not Modified with the stored response, so that unchanged data is not downloaded again.
+// Only structural group and project endpoints are cached; tokens and variables never are.
+type etagTransport struct {
+	base  http.RoundTripper
+	store *cache.Cache
+	debug bool
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !etagCacheable(req.URL.EscapedPath()) {
+		return t.base.RoundTrip(req)
+	}
+
+	key := "etag|" + req.URL.String()
+
+	var stored etagEntry
+
+	hit, err := t.store.Get(key, &stored)
+	if err != nil && t.debug {
+		fmt.Printf("DEBUG: ignoring unreadable ETag cache entry for %s: %v\n", req.URL.Path, err)
+	}
+
+	if hit && stored.ETag != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", stored.ETag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hit:
+		// the connection is only reused once the body was read to the end
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if t.debug {
+			fmt.Printf("DEBUG: %s not modified, using the cached response\n", req.URL.Path)
+		}
+
+		return &http.Response{
+			Status:        http.StatusText(http.StatusOK),
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        stored.Header,
+			Body:          io.NopCloser(bytes.NewReader(stored.Body)),
+			ContentLength: int64(len(stored.Body)),
+			Request:       req,
+		}, nil
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		return t.storeResponse(key, resp)
+	default:
+		return resp, nil
+	}
+}
+
+// storeResponse caches the body of resp without secrets and returns resp with its body intact.
+func (t *etagTransport) storeResponse(key string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	sanitized, err := sanitizeBody(body)
+	if err != nil {
+		if t.debug {
+			fmt.Printf("DEBUG: not caching undecodable response for %s: %v\n", resp.Request.URL.Path, err)
+		}
+
+		return resp, nil
+	}
+
+	header := make(http.Header, len(etagHeaders))
+
+	for _, name := range etagHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			header[name] = values
+		}
+	}
+
+	entry := etagEntry{ETag: resp.Header.Get("ETag"), Header: header, Body: sanitized}
+	if err := t.store.Put(key, entry); err != nil && t.debug {
+		fmt.Printf("DEBUG: failed to cache response for %s: %v\n", resp.Request.URL.Path, err)
+	}
+
+	return resp, nil
+}
+
+// etagCacheable reports whether the API path, with its IDs and paths escaped, lists or reads groups
+// or projects.
+func etagCacheable(path string) bool {
+	_, rest, ok := strings.Cut(path, "/api/v4/")
+	if !ok {
+		return false
+	}
+
+	segments := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+
+	switch len(segments) {
+	case 1, 2:
+		return segments[0] == "groups" || segments[0] == "projects"
+	case 3:
+		switch segments[0] {
+		case "groups":
+			switch segments[2] {
+			case "subgroups", "descendant_groups", "projects", "shared_projects":
+				return true
+			}
+		case "users":
+			return segments[2] == "projects"
+		}
+	}
+
+	return false
+}
+
+// sanitizeBody removes secrets from a JSON response body.
+func sanitizeBody(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// numbers are kept as written rather than rounded through float64
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	removeSecrets(value)
+
+	sanitized, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response body: %w", err)
+	}
+
+	return sanitized, nil
+}
+
+func removeSecrets(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range etagSecrets {
+			delete(v, name)
+		}
+
+		for _, nested := range v {
+			removeSecrets(nested)
+		}
+	case []any:
+		for _, nested := range v {
+			removeSecrets(nested)
+		}
+	}
+}
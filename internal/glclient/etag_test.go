@@ -0,0 +1,100 @@
+package glclient_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/cache"
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func etagClient(t *testing.T, dir string, transport http.RoundTripper) *glclient.Client {
+	t.Helper()
+
+	client, err := glclient.NewClientWithTransport("token", transport, false,
+		glclient.WithBaseURL("https://gitlab.example.com"), glclient.WithETagCache(cache.New(dir, 0)))
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestWithETagCache(t *testing.T) {
+	const team = `{"id": 42, "full_path": "org/team", "runners_token": "runner-secret"}`
+
+	t.Run("reuses the cached response on 304", func(t *testing.T) {
+		dir := t.TempDir()
+		transport := newScriptedTransport(
+			scriptedResponse{status: http.StatusOK, etag: `W/"v1"`, body: team},
+			scriptedResponse{status: http.StatusNotModified, etag: `W/"v1"`},
+		)
+
+		first, err := etagClient(t, dir, transport).GetGroup(t.Context(), "org/team")
+		require.NoError(t, err)
+		assert.Equal(t, "runner-secret", first.RunnersToken)
+
+		// a later run starts with a new client reading the same directory
+		second, err := etagClient(t, dir, transport).GetGroup(t.Context(), "org/team")
+		require.NoError(t, err)
+		assert.Equal(t, 42, second.ID)
+		assert.Equal(t, "org/team", second.FullPath)
+		assert.Empty(t, second.RunnersToken)
+
+		assert.Equal(t, []string{"", `W/"v1"`}, transport.conditions())
+	})
+
+	t.Run("refreshes the cache on 200", func(t *testing.T) {
+		dir := t.TempDir()
+		transport := newScriptedTransport(
+			scriptedResponse{status: http.StatusOK, etag: `W/"v1"`, body: team},
+			scriptedResponse{status: http.StatusOK, etag: `W/"v2"`, body: `{"id": 42, "full_path": "org/renamed"}`},
+			scriptedResponse{status: http.StatusNotModified},
+		)
+
+		_, err := etagClient(t, dir, transport).GetGroup(t.Context(), "org/team")
+		require.NoError(t, err)
+
+		changed, err := etagClient(t, dir, transport).GetGroup(t.Context(), "org/team")
+		require.NoError(t, err)
+		assert.Equal(t, "org/renamed", changed.FullPath)
+
+		cached, err := etagClient(t, dir, transport).GetGroup(t.Context(), "org/team")
+		require.NoError(t, err)
+		assert.Equal(t, "org/renamed", cached.FullPath)
+
+		assert.Equal(t, []string{"", `W/"v1"`, `W/"v2"`}, transport.conditions())
+	})
+
+	t.Run("never caches secrets", func(t *testing.T) {
+		const app = `{"id": 7, "namespace": {"full_path": "org"}, "runners_token": "runner-secret"}`
+
+		dir := t.TempDir()
+		transport := newScriptedTransport(
+			scriptedResponse{status: http.StatusOK, etag: `W/"p1"`, body: app},
+			scriptedResponse{status: http.StatusOK, etag: `W/"v1"`, body: `[{"key": "TOKEN", "value": "var-secret"}]`},
+			scriptedResponse{status: http.StatusNotModified},
+			scriptedResponse{status: http.StatusOK, etag: `W/"v1"`, body: `[{"key": "TOKEN", "value": "var-secret"}]`},
+		)
+
+		for range 2 {
+			variables, err := etagClient(t, dir, transport).GetProjectVariables(t.Context(), "7")
+			require.NoError(t, err)
+			require.Len(t, variables, 1)
+			assert.Equal(t, "var-secret", variables[0].Value)
+		}
+
+		assert.Equal(t, []string{"", "", `W/"p1"`, ""}, transport.conditions())
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		data, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "runner-secret")
+		assert.NotContains(t, string(data), "var-secret")
+	})
+}
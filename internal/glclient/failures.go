@@ -0,0 +1,97 @@
+package glclient
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Failure describes a group or project whose data was left out of a report because fetching it
+// failed for a reason Inaccessible does not cover, such as a server error that outlasted the retries.
+type Failure struct {
+	Kind   string `json:"kind"` // "group" or "project"
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// failureLog collects the resources whose fetch failed, and how many resources of each kind were
+// fetched, so that failures can be told apart from a complete report.
+type failureLog struct {
+	mu        sync.Mutex
+	attempted map[string]int
+	items     []Failure
+}
+
+// Failures returns the groups and projects whose fetch failed so far, sorted by kind and path.
+// Recursive fetches continue past them, so a non-empty result means the reports of this client are
+// incomplete.
+func (c *Client) Failures() []Failure {
+	c.failures.mu.Lock()
+	defer c.failures.mu.Unlock()
+
+	items := slices.Clone(c.failures.items)
+	slices.SortStableFunc(items, func(a, b Failure) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Path, b.Path))
+	})
+
+	return items
+}
+
+// Attempted returns how many groups and projects, keyed by kind, the fetches reporting Failures
+// tried so far.
+func (c *Client) Attempted() map[string]int {
+	c.failures.mu.Lock()
+	defer c.failures.mu.Unlock()
+
+	return maps.Clone(c.failures.attempted)
+}
+
+func (c *Client) countAttempt(kind string) {
+	c.failures.mu.Lock()
+	defer c.failures.mu.Unlock()
+
+	if c.failures.attempted == nil {
+		c.failures.attempted = make(map[string]int)
+	}
+
+	c.failures.attempted[kind]++
+}
+
+// recordFetchError records a group or project whose fetch of what failed with err, as inaccessible
+// when the token cannot read it or the request timed out, and as a failure otherwise.
+func (c *Client) recordFetchError(ctx context.Context, kind, path, what string, err error) {
+	// an interrupted run fails every remaining request and is reported once by the caller
+	if ctx.Err() != nil {
+		return
+	}
+
+	if reason, ok := inaccessibleReason(err); ok {
+		c.addInaccessible(kind, path, what+": "+reason)
+
+		return
+	}
+
+	c.failures.mu.Lock()
+	defer c.failures.mu.Unlock()
+
+	c.failures.items = append(c.failures.items, Failure{Kind: kind, Path: path, Reason: what + ": " + failureReason(err)})
+}
+
+// failureReason returns the status of a failed API response, or the error itself when there is none.
+func failureReason(err error) string {
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		code := errResp.Response.StatusCode
+
+		return fmt.Sprintf("%d %s", code, http.StatusText(code))
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,104 @@
+package glclient_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClient_Failures(t *testing.T) {
+	rootGroup := &gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}
+	namespace := &gitlab.ProjectNamespace{FullPath: "root-group"}
+
+	t.Run("records projects whose variables failed for other reasons than access", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		projects := []*gitlab.Project{
+			{ID: 10, PathWithNamespace: "root-group/readable", Namespace: namespace},
+			{ID: 11, PathWithNamespace: "root-group/forbidden", Namespace: namespace},
+			{ID: 12, PathWithNamespace: "root-group/broken", Namespace: namespace},
+			{ID: 13, PathWithNamespace: "root-group/unreachable", Namespace: namespace},
+		}
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectVariable{{Key: "VISIBLE"}}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("11", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("12", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusInternalServerError))
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("13", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errors.New("connection reset by peer"))
+
+		variables, err := client.GetProjectVariablesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, variables, 1)
+
+		assert.Equal(t, []glclient.Failure{
+			{Kind: "project", Path: "root-group/broken", Reason: "project variables: 500 Internal Server Error"},
+			{
+				Kind: "project", Path: "root-group/unreachable",
+				Reason: "project variables: failed to list project variables: connection reset by peer",
+			},
+		}, client.Failures())
+		assert.Equal(t, map[string]int{"project": 4}, client.Attempted())
+
+		assert.Len(t, client.Inaccessible(), 1, "forbidden projects are reported as inaccessible only")
+	})
+
+	t.Run("records groups whose access tokens failed", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroupAccessTokens.EXPECT().
+			ListGroupAccessTokens("1", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusBadGateway))
+
+		tokens, err := client.GetGroupAccessTokensRecursively(t.Context(), "1", glclient.TokenStateActive)
+		require.NoError(t, err)
+		assert.Empty(t, tokens)
+
+		assert.Equal(t, []glclient.Failure{
+			{Kind: "group", Path: "root-group", Reason: "group access tokens: 502 Bad Gateway"},
+		}, client.Failures())
+		assert.Equal(t, map[string]int{"group": 1}, client.Attempted())
+	})
+
+	t.Run("is empty when every fetch succeeds", func(t *testing.T) {
+		client, _ := testClient(t)
+
+		assert.Empty(t, client.Failures())
+		assert.Empty(t, client.Attempted())
+	})
+}
@@ -0,0 +1,106 @@
+package glclient_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	// stressWidth and stressDepth shape a hierarchy far larger than the worker pool and its queue
+	stressWidth = 8
+	stressDepth = 4
+	// stressTimeout is how long the fetch may take before it is considered deadlocked
+	stressTimeout = 30 * time.Second
+)
+
+// stressHierarchySize returns the number of groups in the stress hierarchy, the root included.
+func stressHierarchySize() int {
+	size, level := 0, 1
+	for range stressDepth + 1 {
+		size += level
+		level *= stressWidth
+	}
+
+	return size
+}
+
+// stressSubgroups returns the subgroups of the group with the given ID. The children of group n are
+// numbered n*stressWidth to n*stressWidth+stressWidth-1, so groups at depth d are numbered from
+// stressWidth^d, and those at stressDepth are leaves.
+func stressSubgroups(parentID string) []*gitlab.Group {
+	id, _ := strconv.Atoi(parentID)
+
+	leaves := 1
+	for range stressDepth {
+		leaves *= stressWidth
+	}
+
+	if id >= leaves {
+		return []*gitlab.Group{}
+	}
+
+	subgroups := make([]*gitlab.Group, 0, stressWidth)
+	for i := range stressWidth {
+		childID := id*stressWidth + i
+		subgroups = append(subgroups, &gitlab.Group{ID: childID, FullPath: "root/" + strconv.Itoa(childID)})
+	}
+
+	return subgroups
+}
+
+func TestGetGroupAccessTokensRecursively_deepWideHierarchy(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, FullPath: "root"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			gid any, _ *gitlab.ListSubGroupsOptions, _ ...gitlab.RequestOptionFunc,
+		) ([]*gitlab.Group, *gitlab.Response, error) {
+			return stressSubgroups(gid.(string)), &gitlab.Response{}, nil
+		}).
+		Times(stressHierarchySize())
+
+	mockClient.MockGroupAccessTokens.EXPECT().
+		ListGroupAccessTokens(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			gid any, _ *gitlab.ListGroupAccessTokensOptions, _ ...gitlab.RequestOptionFunc,
+		) ([]*gitlab.GroupAccessToken, *gitlab.Response, error) {
+			token := &gitlab.GroupAccessToken{}
+			token.Name = "token-" + gid.(string)
+			token.Active = true
+
+			return []*gitlab.GroupAccessToken{token}, &gitlab.Response{}, nil
+		}).
+		Times(stressHierarchySize())
+
+	type result struct {
+		count int
+		err   error
+	}
+
+	done := make(chan result, 1)
+
+	go func() {
+		tokens, err := client.GetGroupAccessTokensRecursively(t.Context(), "1", glclient.TokenStateActive)
+		done <- result{count: len(tokens), err: err}
+	}()
+
+	select {
+	case res := <-done:
+		require.NoError(t, res.err)
+		assert.Equal(t, stressHierarchySize(), res.count)
+	case <-time.After(stressTimeout):
+		t.Fatal("recursive fetch did not finish, the worker pool is likely deadlocked")
+	}
+}
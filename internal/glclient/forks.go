@@ -0,0 +1,183 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectFork represents a fork of a project. Ahead and Behind compare the default branch of the fork
+// with the default branch of its source; they are unknown when either repository is empty or the
+// comparison fails.
+type ProjectFork struct {
+	ReportType    string `json:"report_type" csv:"-"`
+	Instance      string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int    `json:"project_id"`
+	ProjectName   string `json:"project_name"`
+	ProjectPath   string `json:"project_path"`
+	ProjectWebURL string `json:"project_web_url"`
+	ForkID        int    `json:"fork_id"`
+	ForkPath      string `json:"fork_path"`
+	ForkWebURL    string `json:"fork_web_url"`
+	ForkNamespace string `json:"fork_namespace"`
+	External      bool   `json:"external"` // outside the top-level group of the source project
+	Ahead         *int   `json:"ahead"`    // commits of the fork missing from the source
+	Behind        *int   `json:"behind"`   // commits of the source missing from the fork
+}
+
+// GetProjectForksRecursively fetches the forks of all projects within a group and its subgroups.
+// Projects without forks contribute no entries and are not queried.
+func (c *Client) GetProjectForksRecursively(ctx context.Context, groupID string) ([]*ProjectFork, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allForks []*ProjectFork
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		if project.ForksCount == 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			forks, err := c.listForksForProject(ctx, projectID, project)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, "project forks", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching forks for project %s: %v\n", projectID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allForks = append(allForks, forks...)
+			mu.Unlock()
+			c.countItems(len(forks))
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "project forks fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectForks(allForks)
+
+	return allForks, nil
+}
+
+func (c *Client) listForksForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) ([]*ProjectFork, error) {
+	var allForks []*ProjectFork
+
+	opt := &gitlab.ListProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		forks, resp, err := c.client.Projects.ListProjectForks(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list project forks: %w", err)
+		}
+
+		for _, fork := range forks {
+			namespace := projectNamespace(fork)
+
+			wrapped := &ProjectFork{
+				ReportType:    ReportTypeProjectFork,
+				ProjectID:     project.ID,
+				ProjectName:   project.Name,
+				ProjectPath:   project.PathWithNamespace,
+				ProjectWebURL: c.webURL(project.WebURL),
+				ForkID:        fork.ID,
+				ForkPath:      fork.PathWithNamespace,
+				ForkWebURL:    c.webURL(fork.WebURL),
+				ForkNamespace: namespace,
+				External:      topLevelGroup(namespace) != topLevelGroup(project.PathWithNamespace),
+			}
+
+			wrapped.Ahead = c.countDivergence(ctx, project, fork)
+			wrapped.Behind = c.countDivergence(ctx, fork, project)
+
+			allForks = append(allForks, wrapped)
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d forks for project %s\n", len(forks), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allForks, nil
+}
+
+// countDivergence returns the number of commits on the default branch of target that are missing
+// from the default branch of base, or nil when it cannot be determined.
+func (c *Client) countDivergence(ctx context.Context, base, target *gitlab.Project) *int {
+	if base.DefaultBranch == "" || target.DefaultBranch == "" {
+		return nil
+	}
+
+	compare, _, err := c.client.Repositories.Compare(target.ID, &gitlab.CompareOptions{
+		From: gitlab.Ptr(base.DefaultBranch),
+		To:   gitlab.Ptr(target.DefaultBranch),
+	}, gitlab.WithContext(ctx), withFromProject(base.ID))
+	if err != nil {
+		if c.debug {
+			fmt.Printf("DEBUG: error comparing %s with %s: %v\n", target.PathWithNamespace, base.PathWithNamespace, err)
+		}
+
+		return nil
+	}
+
+	return gitlab.Ptr(len(compare.Commits))
+}
+
+// withFromProject compares with the refs of another project, which the client does not support as
+// an option of the compare request.
+func withFromProject(projectID int) gitlab.RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		query := req.URL.Query()
+		query.Set("from_project_id", strconv.Itoa(projectID))
+		req.URL.RawQuery = query.Encode()
+
+		return nil
+	}
+}
+
+// topLevelGroup returns the first segment of a group or project path.
+func topLevelGroup(path string) string {
+	top, _, _ := strings.Cut(path, "/")
+
+	return top
+}
@@ -0,0 +1,114 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectForksRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "org", FullPath: "org"}, &gitlab.Response{}, nil)
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	// docs has no forks and is not queried
+	api := &gitlab.Project{ID: 10, Name: "api", PathWithNamespace: "org/api", DefaultBranch: "main", ForksCount: 2}
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("org", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			api,
+			{ID: 11, Name: "docs", PathWithNamespace: "org/docs", DefaultBranch: "main"},
+		}, &gitlab.Response{}, nil)
+
+	mockClient.MockProjects.EXPECT().
+		ListProjectForks("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			{
+				ID:                20,
+				PathWithNamespace: "alice/api",
+				DefaultBranch:     "main",
+				Namespace:         &gitlab.ProjectNamespace{Kind: "user", FullPath: "alice"},
+			},
+			{
+				ID:                21,
+				PathWithNamespace: "org/sandbox/api",
+				Namespace:         &gitlab.ProjectNamespace{Kind: "group", FullPath: "org/sandbox"},
+			},
+		}, &gitlab.Response{}, nil)
+
+	// the external fork is 3 commits ahead and 1 behind; the empty fork has no default branch
+	mockClient.MockRepositories.EXPECT().
+		Compare(20, gomock.Any(), gomock.Any()).
+		Return(&gitlab.Compare{Commits: make([]*gitlab.Commit, 3)}, &gitlab.Response{}, nil)
+	mockClient.MockRepositories.EXPECT().
+		Compare(10, gomock.Any(), gomock.Any()).
+		Return(&gitlab.Compare{Commits: make([]*gitlab.Commit, 1)}, &gitlab.Response{}, nil)
+
+	forks, err := client.GetProjectForksRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, []*glclient.ProjectFork{
+		{
+			ReportType:    glclient.ReportTypeProjectFork,
+			ProjectID:     10,
+			ProjectName:   "api",
+			ProjectPath:   "org/api",
+			ForkID:        20,
+			ForkPath:      "alice/api",
+			ForkNamespace: "alice",
+			External:      true,
+			Ahead:         gitlab.Ptr(3),
+			Behind:        gitlab.Ptr(1),
+		},
+		{
+			ReportType:    glclient.ReportTypeProjectFork,
+			ProjectID:     10,
+			ProjectName:   "api",
+			ProjectPath:   "org/api",
+			ForkID:        21,
+			ForkPath:      "org/sandbox/api",
+			ForkNamespace: "org/sandbox",
+		},
+	}, forks)
+	assert.Empty(t, client.Inaccessible())
+}
+
+func TestGetProjectForksRecursively_failedComparison(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "org", FullPath: "org"}, &gitlab.Response{}, nil)
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("org", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			{ID: 10, PathWithNamespace: "org/api", DefaultBranch: "main", ForksCount: 1},
+		}, &gitlab.Response{}, nil)
+	mockClient.MockProjects.EXPECT().
+		ListProjectForks("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{{ID: 20, PathWithNamespace: "bob/api", DefaultBranch: "main"}}, &gitlab.Response{}, nil)
+	mockClient.MockRepositories.EXPECT().
+		Compare(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil, errStatus(http.StatusNotFound)).
+		Times(2)
+
+	forks, err := client.GetProjectForksRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	require.Len(t, forks, 1)
+	assert.Equal(t, "bob", forks[0].ForkNamespace)
+	assert.True(t, forks[0].External)
+	assert.Nil(t, forks[0].Ahead)
+	assert.Nil(t, forks[0].Behind)
+}
@@ -0,0 +1,70 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// FilterGroupsWithProjects returns the groups that directly contain at least one project, preserving
+// their order. Each group costs one API call listing a single project; projects shared into a group
+// count only when the client includes shared projects. Groups whose projects the token cannot read
+// are kept and reported as inaccessible, so that they are not hidden.
+func (c *Client) FilterGroupsWithProjects(ctx context.Context, groups []*gitlab.Group) ([]*gitlab.Group, error) {
+	keep := make([]bool, len(groups))
+
+	var wg sync.WaitGroup
+
+	for i, group := range groups {
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			hasProjects, err := c.hasProjects(ctx, group.FullPath)
+			if err != nil {
+				c.recordInaccessible(ctx, "group", group.FullPath, "projects", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error checking projects of group %s: %v\n", group.FullPath, err)
+				}
+			}
+
+			// each worker writes its own element, so no lock is needed
+			keep[i] = hasProjects || err != nil
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "group project check"); err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*gitlab.Group, 0, len(groups))
+
+	for i, group := range groups {
+		if keep[i] {
+			filtered = append(filtered, group)
+		}
+	}
+
+	return filtered, nil
+}
+
+// hasProjects reports whether the group directly contains at least one project.
+func (c *Client) hasProjects(ctx context.Context, groupPath string) (bool, error) {
+	opt := &gitlab.ListGroupProjectsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 1, Page: 1},
+		WithShared:  gitlab.Ptr(c.sharedProjects),
+	}
+
+	projects, _, err := c.client.Groups.ListGroupProjects(groupPath, opt, gitlab.WithContext(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to list group projects: %w", err)
+	}
+
+	return len(projects) > 0, nil
+}
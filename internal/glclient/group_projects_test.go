@@ -0,0 +1,56 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFilterGroupsWithProjects(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	groups := []*gitlab.Group{
+		{ID: 1, FullPath: "root"},
+		{ID: 2, FullPath: "root/empty"},
+		{ID: 3, FullPath: "root/team"},
+		{ID: 4, FullPath: "root/restricted"},
+		{ID: 5, FullPath: "root/team/empty"},
+	}
+
+	projectsOf := map[string][]*gitlab.Project{
+		"root":            {},
+		"root/empty":      {},
+		"root/team":       {{ID: 10}},
+		"root/team/empty": {},
+	}
+
+	for path, projects := range projectsOf {
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects(path, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, opt *gitlab.ListGroupProjectsOptions, _ ...gitlab.RequestOptionFunc) (
+				[]*gitlab.Project, *gitlab.Response, error,
+			) {
+				assert.Equal(t, 1, opt.PerPage)
+
+				return projects, &gitlab.Response{}, nil
+			})
+	}
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root/restricted", gomock.Any(), gomock.Any()).
+		Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+	filtered, err := client.FilterGroupsWithProjects(t.Context(), groups)
+	require.NoError(t, err)
+
+	// groups that cannot be checked are kept
+	assert.Equal(t, []*gitlab.Group{groups[2], groups[3]}, filtered)
+	assert.Equal(t, []glclient.Inaccessible{
+		{Kind: "group", Path: "root/restricted", Reason: "projects: 403 Forbidden"},
+	}, client.Inaccessible())
+}
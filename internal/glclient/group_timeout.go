@@ -0,0 +1,30 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// errGroupTimeout marks a listing of a group's subgroups or projects that exceeded the time budget
+// set by WithGroupTimeout.
+var errGroupTimeout = errors.New("group time budget exceeded")
+
+// withinGroupBudget runs fetch, which lists the direct subgroups or projects of a group, with the
+// time budget of a group. When the budget expires before ctx is done, the returned error wraps
+// errGroupTimeout so that the group is reported as skipped.
+func (c *Client) withinGroupBudget(ctx context.Context, fetch func(ctx context.Context) error) error {
+	if c.groupTimeout <= 0 {
+		return fetch(ctx)
+	}
+
+	groupCtx, cancel := context.WithTimeout(ctx, c.groupTimeout)
+	defer cancel()
+
+	err := fetch(groupCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(groupCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %w", errGroupTimeout, c.groupTimeout, err)
+	}
+
+	return err
+}
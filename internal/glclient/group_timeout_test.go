@@ -0,0 +1,98 @@
+package glclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// slowResponse stands in for a group whose listings outlast the group time budget.
+const slowResponse = 200 * time.Millisecond
+
+func TestGetProjectsRecursively_groupTimeout(t *testing.T) {
+	mockClient := gitlabtesting.NewTestClient(t)
+	client := glclient.NewClientWithGitLabClient(mockClient.Client, false,
+		glclient.WithGroupTimeout(20*time.Millisecond))
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, FullPath: "root"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{
+			{ID: 2, FullPath: "root/slow"},
+			{ID: 3, FullPath: "root/fine"},
+		}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("2", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(any, *gitlab.ListSubGroupsOptions, ...gitlab.RequestOptionFunc) (
+			[]*gitlab.Group, *gitlab.Response, error,
+		) {
+			time.Sleep(slowResponse)
+
+			return nil, nil, context.DeadlineExceeded
+		})
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("3", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{{ID: 10, PathWithNamespace: "root/api"}}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root/slow", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(any, *gitlab.ListGroupProjectsOptions, ...gitlab.RequestOptionFunc) (
+			[]*gitlab.Project, *gitlab.Response, error,
+		) {
+			time.Sleep(slowResponse)
+
+			return nil, nil, context.DeadlineExceeded
+		})
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root/fine", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{{ID: 11, PathWithNamespace: "root/fine/web"}}, &gitlab.Response{}, nil)
+
+	projects, err := client.GetProjectsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	require.Len(t, projects, 2)
+	assert.Equal(t, 10, projects[0].ID)
+	assert.Equal(t, 11, projects[1].ID)
+
+	assert.ElementsMatch(t, []glclient.Inaccessible{
+		{Kind: "group", Path: "root/slow", Reason: "subgroups: skipped (timeout)"},
+		{Kind: "group", Path: "root/slow", Reason: "projects: skipped (timeout)"},
+	}, client.Inaccessible())
+}
+
+func TestGetGroupsRecursively_withinGroupTimeout(t *testing.T) {
+	mockClient := gitlabtesting.NewTestClient(t)
+	client := glclient.NewClientWithGitLabClient(mockClient.Client, false,
+		glclient.WithGroupTimeout(time.Minute))
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, FullPath: "root"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	groups, err := client.GetGroupsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	require.Len(t, groups, 1)
+	assert.Empty(t, client.Inaccessible())
+}
@@ -0,0 +1,171 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ErrAdminRequired is returned when GitLab refuses a request only administrators may make.
+var ErrAdminRequired = errors.New("admin token required: check that the token belongs to an administrator " +
+	"and, when Admin Mode is enabled, has the admin_mode scope")
+
+// ImpersonationTokenWithUser represents an impersonation token with the user it acts as.
+type ImpersonationTokenWithUser struct {
+	*gitlab.ImpersonationToken
+	ReportType string `json:"report_type" csv:"-"`
+	UserID     int    `json:"user_id"`
+	Username   string `json:"username"`
+	UserWebURL string `json:"user_web_url"`
+}
+
+// GetImpersonationTokens fetches the impersonation tokens of all users of the instance. Only
+// administrators may list them; if GitLab refuses, ErrAdminRequired is returned.
+func (c *Client) GetImpersonationTokens(
+	ctx context.Context,
+	includeInactive bool,
+) ([]*ImpersonationTokenWithUser, error) {
+	users, err := c.listUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first refusal stops the remaining requests, which would be refused alike
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		allTokens []*ImpersonationTokenWithUser
+		adminErr  error
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+
+	for _, user := range users {
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			tokens, err := c.listImpersonationTokensForUser(fetchCtx, user, includeInactive)
+			if err != nil {
+				if isForbidden(err) {
+					mu.Lock()
+					adminErr = fmt.Errorf("%w: %w", ErrAdminRequired, err)
+					mu.Unlock()
+					cancel()
+
+					return
+				}
+
+				c.recordInaccessible(fetchCtx, "user", user.Username, "impersonation tokens", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching impersonation tokens for user %s: %v\n", user.Username, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allTokens = append(allTokens, tokens...)
+			mu.Unlock()
+			c.countItems(len(tokens))
+		})
+	}
+
+	wg.Wait()
+
+	if adminErr != nil {
+		return nil, adminErr
+	}
+
+	if err := c.interrupted(ctx, "impersonation tokens fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortImpersonationTokens(allTokens)
+
+	return allTokens, nil
+}
+
+// listUsers lists all users of the instance. Bots of group and project access tokens cannot hold
+// impersonation tokens and are left out.
+func (c *Client) listUsers(ctx context.Context) ([]*gitlab.User, error) {
+	opt := &gitlab.ListUsersOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+		// keyset pagination of users is only available when ordered by ID
+		OrderBy:            gitlab.Ptr("id"),
+		WithoutProjectBots: gitlab.Ptr(true),
+	}
+
+	var users []*gitlab.User
+
+	err := listPages(c, "users", &opt.ListOptions,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.User, *gitlab.Response, error) {
+			return c.client.Users.ListUsers(opt, append(options, gitlab.WithContext(ctx))...)
+		},
+		func(page []*gitlab.User) {
+			users = append(users, page...)
+
+			if c.debug {
+				fmt.Printf("DEBUG: fetched %d users\n", len(page))
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (c *Client) listImpersonationTokensForUser(
+	ctx context.Context,
+	user *gitlab.User,
+	includeInactive bool,
+) ([]*ImpersonationTokenWithUser, error) {
+	var allTokens []*ImpersonationTokenWithUser
+
+	opt := &gitlab.GetAllImpersonationTokensOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	if !includeInactive {
+		opt.State = gitlab.Ptr("active")
+	}
+
+	for {
+		tokens, resp, err := c.client.Users.GetAllImpersonationTokens(user.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list impersonation tokens: %w", err)
+		}
+
+		for _, token := range tokens {
+			allTokens = append(allTokens, &ImpersonationTokenWithUser{
+				ReportType:         ReportTypeImpersonationToken,
+				ImpersonationToken: token,
+				UserID:             user.ID,
+				Username:           user.Username,
+				UserWebURL:         c.webURL(user.WebURL),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allTokens, nil
+}
@@ -0,0 +1,93 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetImpersonationTokens(t *testing.T) {
+	users := []*gitlab.User{
+		{ID: 2, Username: "bob", WebURL: "https://gitlab.example.com/bob"},
+		{ID: 1, Username: "alice", WebURL: "https://gitlab.example.com/alice"},
+	}
+
+	t.Run("lists the active tokens of every user", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			ListUsers(gomock.Any(), gomock.Any()).
+			Return(users, &gitlab.Response{}, nil)
+
+		mockClient.MockUsers.EXPECT().
+			GetAllImpersonationTokens(1, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ int, opt *gitlab.GetAllImpersonationTokensOptions, _ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.ImpersonationToken, *gitlab.Response, error) {
+				assert.Equal(t, "active", *opt.State)
+
+				return []*gitlab.ImpersonationToken{
+					{ID: 11, Name: "support", Active: true, Scopes: []string{"api"}},
+				}, &gitlab.Response{}, nil
+			})
+		mockClient.MockUsers.EXPECT().
+			GetAllImpersonationTokens(2, gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ImpersonationToken{}, &gitlab.Response{}, nil)
+
+		tokens, err := client.GetImpersonationTokens(t.Context(), false)
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, 11, tokens[0].ID)
+		assert.Equal(t, "support", tokens[0].Name)
+		assert.Equal(t, 1, tokens[0].UserID)
+		assert.Equal(t, "alice", tokens[0].Username)
+		assert.Equal(t, "https://gitlab.example.com/alice", tokens[0].UserWebURL)
+		assert.Equal(t, glclient.ReportTypeImpersonationToken, tokens[0].ReportType)
+	})
+
+	t.Run("includes inactive tokens", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			ListUsers(gomock.Any(), gomock.Any()).
+			Return(users, &gitlab.Response{}, nil)
+		mockClient.MockUsers.EXPECT().
+			GetAllImpersonationTokens(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				user int, opt *gitlab.GetAllImpersonationTokensOptions, _ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.ImpersonationToken, *gitlab.Response, error) {
+				assert.Nil(t, opt.State)
+
+				return []*gitlab.ImpersonationToken{{ID: user * 10, Name: "revoked", Revoked: true}}, &gitlab.Response{}, nil
+			}).
+			Times(2)
+
+		tokens, err := client.GetImpersonationTokens(t.Context(), true)
+		require.NoError(t, err)
+		require.Len(t, tokens, 2)
+		assert.Equal(t, "alice", tokens[0].Username)
+		assert.Equal(t, "bob", tokens[1].Username)
+	})
+
+	t.Run("requires an admin token", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			ListUsers(gomock.Any(), gomock.Any()).
+			Return(users, &gitlab.Response{}, nil)
+		mockClient.MockUsers.EXPECT().
+			GetAllImpersonationTokens(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden)).
+			MinTimes(1)
+
+		tokens, err := client.GetImpersonationTokens(t.Context(), false)
+		require.ErrorIs(t, err, glclient.ErrAdminRequired)
+		assert.Nil(t, tokens)
+		assert.Empty(t, client.Inaccessible())
+	})
+}
@@ -0,0 +1,97 @@
+package glclient
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Inaccessible describes a group or project whose data was left out of a report because the token
+// is not allowed to read it, the request timed out, or the group exceeded its time budget.
+type Inaccessible struct {
+	Kind   string `json:"kind"` // "group" or "project"
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// inaccessibleLog collects the resources skipped by recursive fetches.
+type inaccessibleLog struct {
+	mu    sync.Mutex
+	items []Inaccessible
+}
+
+// Inaccessible returns the groups and projects skipped so far because the token cannot read them
+// or the request timed out, sorted by kind and path. Recursive fetches continue past such resources,
+// so a non-empty result means the reports of this client are incomplete.
+func (c *Client) Inaccessible() []Inaccessible {
+	c.inaccessible.mu.Lock()
+	defer c.inaccessible.mu.Unlock()
+
+	items := slices.Clone(c.inaccessible.items)
+	slices.SortStableFunc(items, func(a, b Inaccessible) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Path, b.Path))
+	})
+
+	return items
+}
+
+func (c *Client) inaccessibleCount() int {
+	c.inaccessible.mu.Lock()
+	defer c.inaccessible.mu.Unlock()
+
+	return len(c.inaccessible.items)
+}
+
+// recordInaccessible records a group or project skipped while fetching what, if err means the token
+// is not allowed to read it or the request timed out. Other errors are only logged in debug mode
+// by the callers.
+func (c *Client) recordInaccessible(ctx context.Context, kind, path, what string, err error) {
+	// an interrupted run fails every remaining request and is reported once by the caller
+	if ctx.Err() != nil {
+		return
+	}
+
+	reason, ok := inaccessibleReason(err)
+	if !ok {
+		return
+	}
+
+	c.addInaccessible(kind, path, what+": "+reason)
+}
+
+func (c *Client) addInaccessible(kind, path, reason string) {
+	c.inaccessible.mu.Lock()
+	defer c.inaccessible.mu.Unlock()
+
+	c.inaccessible.items = append(c.inaccessible.items, Inaccessible{Kind: kind, Path: path, Reason: reason})
+}
+
+func inaccessibleReason(err error) (string, bool) {
+	if errors.Is(err, errGroupTimeout) {
+		return "skipped (timeout)", true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "request timed out", true
+	}
+
+	code := http.StatusNotFound
+	if !isNotFound(err) {
+		var errResp *gitlab.ErrorResponse
+		if !errors.As(err, &errResp) || errResp.Response == nil || errResp.Response.StatusCode != http.StatusForbidden {
+			return "", false
+		}
+
+		code = errResp.Response.StatusCode
+	}
+
+	return fmt.Sprintf("%d %s", code, http.StatusText(code)), true
+}
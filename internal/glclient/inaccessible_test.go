@@ -0,0 +1,121 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+// errStatus returns the error the GitLab client returns for a response with code. Like CheckResponse,
+// it answers 404 with the ErrNotFound sentinel rather than an ErrorResponse.
+func errStatus(code int) error {
+	if code == http.StatusNotFound {
+		return gitlab.ErrNotFound
+	}
+
+	return &gitlab.ErrorResponse{Response: &http.Response{StatusCode: code}}
+}
+
+func TestClient_Inaccessible(t *testing.T) {
+	rootGroup := &gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}
+	openGroup := &gitlab.Group{ID: 2, Name: "open", FullPath: "root-group/open"}
+	secretGroup := &gitlab.Group{ID: 3, Name: "secret", FullPath: "root-group/secret"}
+
+	t.Run("records subgroups the token cannot list", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{openGroup, secretGroup}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("2", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("3", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Len(t, groups, 3)
+
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "group", Path: "root-group/secret", Reason: "subgroups: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+
+	t.Run("records projects whose variables are forbidden or missing", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		namespace := &gitlab.ProjectNamespace{FullPath: "root-group"}
+		projects := []*gitlab.Project{
+			{ID: 10, PathWithNamespace: "root-group/readable", Namespace: namespace},
+			{ID: 11, PathWithNamespace: "root-group/forbidden", Namespace: namespace},
+			{ID: 12, PathWithNamespace: "root-group/gone", Namespace: namespace},
+			{ID: 13, PathWithNamespace: "root-group/broken", Namespace: namespace},
+		}
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectVariable{{Key: "VISIBLE"}}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("11", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("12", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusNotFound))
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables("13", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusInternalServerError))
+
+		variables, err := client.GetProjectVariablesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, variables, 1)
+
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "project", Path: "root-group/forbidden", Reason: "project variables: 403 Forbidden"},
+			{Kind: "project", Path: "root-group/gone", Reason: "project variables: 404 Not Found"},
+		}, client.Inaccessible())
+	})
+
+	t.Run("is empty when everything is readable", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		_, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Empty(t, client.Inaccessible())
+	})
+}
@@ -0,0 +1,51 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// InstanceTokenSettings holds the instance-wide settings governing access tokens.
+type InstanceTokenSettings struct {
+	ReportType string `json:"report_type" csv:"-"`
+	// MaxPersonalAccessTokenLifetime is the maximum lifetime of new access tokens in days, 0 when
+	// unlimited.
+	MaxPersonalAccessTokenLifetime        int    `json:"max_personal_access_token_lifetime"`
+	RequirePersonalAccessTokenExpiry      bool   `json:"require_personal_access_token_expiry"`
+	EnforcePATExpiration                  bool   `json:"enforce_pat_expiration"`
+	ServiceAccessTokensExpirationEnforced bool   `json:"service_access_tokens_expiration_enforced"`
+	DisablePersonalAccessTokens           bool   `json:"disable_personal_access_tokens"`
+	PersonalAccessTokenPrefix             string `json:"personal_access_token_prefix"`
+	DisableFeedToken                      bool   `json:"disable_feed_token"`
+	AllowRunnerRegistrationToken          bool   `json:"allow_runner_registration_token"`
+	EnforceCIInboundJobTokenScopeEnabled  bool   `json:"enforce_ci_inbound_job_token_scope_enabled"`
+}
+
+// GetInstanceTokenSettings fetches the instance-wide settings governing access tokens with a single
+// request. Only administrators may read the settings; if GitLab refuses, ErrAdminRequired is
+// returned.
+func (c *Client) GetInstanceTokenSettings(ctx context.Context) (*InstanceTokenSettings, error) {
+	settings, _, err := c.client.Settings.GetSettings(gitlab.WithContext(ctx))
+	if err != nil {
+		if isForbidden(err) {
+			return nil, fmt.Errorf("%w: %w", ErrAdminRequired, err)
+		}
+
+		return nil, fmt.Errorf("failed to get application settings: %w", err)
+	}
+
+	return &InstanceTokenSettings{
+		ReportType:                            ReportTypeInstanceTokenSettings,
+		MaxPersonalAccessTokenLifetime:        settings.MaxPersonalAccessTokenLifetime,
+		RequirePersonalAccessTokenExpiry:      settings.RequirePersonalAccessTokenExpiry,
+		EnforcePATExpiration:                  settings.EnforcePATExpiration,
+		ServiceAccessTokensExpirationEnforced: settings.ServiceAccessTokensExpirationEnforced,
+		DisablePersonalAccessTokens:           settings.DisablePersonalAccessTokens,
+		PersonalAccessTokenPrefix:             settings.PersonalAccessTokenPrefix,
+		DisableFeedToken:                      settings.DisableFeedToken,
+		AllowRunnerRegistrationToken:          settings.AllowRunnerRegistrationToken,
+		EnforceCIInboundJobTokenScopeEnabled:  settings.EnforceCIInboundJobTokenScopeEnabled,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package glclient_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetInstanceTokenSettings(t *testing.T) {
+	t.Run("reports the token settings", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockSettings.EXPECT().
+			GetSettings(gomock.Any()).
+			Return(&gitlab.Settings{
+				MaxPersonalAccessTokenLifetime:   90,
+				RequirePersonalAccessTokenExpiry: true,
+				DisablePersonalAccessTokens:      true,
+				PersonalAccessTokenPrefix:        "glpat-",
+				AllowRunnerRegistrationToken:     true,
+				SessionExpireDelay:               10080,
+			}, &gitlab.Response{}, nil)
+
+		settings, err := client.GetInstanceTokenSettings(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, &glclient.InstanceTokenSettings{
+			ReportType:                       glclient.ReportTypeInstanceTokenSettings,
+			MaxPersonalAccessTokenLifetime:   90,
+			RequirePersonalAccessTokenExpiry: true,
+			DisablePersonalAccessTokens:      true,
+			PersonalAccessTokenPrefix:        "glpat-",
+			AllowRunnerRegistrationToken:     true,
+		}, settings)
+	})
+
+	t.Run("requires an admin token", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockSettings.EXPECT().
+			GetSettings(gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		_, err := client.GetInstanceTokenSettings(t.Context())
+		require.ErrorIs(t, err, glclient.ErrAdminRequired)
+	})
+
+	t.Run("reports other errors", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockSettings.EXPECT().
+			GetSettings(gomock.Any()).
+			Return(nil, nil, errors.New("connection refused"))
+
+		_, err := client.GetInstanceTokenSettings(t.Context())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, glclient.ErrAdminRequired)
+		assert.Contains(t, err.Error(), "failed to get application settings")
+	})
+}
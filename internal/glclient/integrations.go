@@ -0,0 +1,296 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const (
+	// IntegrationTypeIntegration marks project integrations, such as Slack or Jira.
+	IntegrationTypeIntegration = "integration"
+	// IntegrationTypeWebhook marks project webhooks.
+	IntegrationTypeWebhook = "webhook"
+
+	// redactedEndpointPath replaces the path of endpoints, which often holds a secret token.
+	redactedEndpointPath = "/[redacted]"
+)
+
+// ProjectIntegration represents an integration or webhook of a project. Only the fields common to
+// all integration types are kept.
+type ProjectIntegration struct {
+	ReportType    string   `json:"report_type" csv:"-"`
+	Instance      string   `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int      `json:"project_id"`
+	ProjectName   string   `json:"project_name"`
+	ProjectPath   string   `json:"project_path"`
+	ProjectWebURL string   `json:"project_web_url"`
+	Type          string   `json:"type"`
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	Slug          string   `json:"slug"`
+	Active        bool     `json:"active"`
+	EndpointURL   string   `json:"endpoint_url"` // scheme and host only, see redactEndpoint
+	Events        []string `json:"events"`
+}
+
+// integrationEndpoints reads the endpoint of the integration types that call an external URL.
+// Other integration types are listed without an endpoint.
+var integrationEndpoints = map[string]func(
+	ctx context.Context, services gitlab.ServicesServiceInterface, projectID string,
+) (string, error){
+	"slack": func(ctx context.Context, services gitlab.ServicesServiceInterface, projectID string) (string, error) {
+		service, _, err := services.GetSlackService(projectID, gitlab.WithContext(ctx))
+		if err != nil || service.Properties == nil {
+			return "", err
+		}
+
+		return service.Properties.WebHook, nil
+	},
+	"mattermost": func(ctx context.Context, services gitlab.ServicesServiceInterface, projectID string) (string, error) {
+		service, _, err := services.GetMattermostService(projectID, gitlab.WithContext(ctx))
+		if err != nil || service.Properties == nil {
+			return "", err
+		}
+
+		return service.Properties.WebHook, nil
+	},
+	"microsoft-teams": func(
+		ctx context.Context, services gitlab.ServicesServiceInterface, projectID string,
+	) (string, error) {
+		service, _, err := services.GetMicrosoftTeamsService(projectID, gitlab.WithContext(ctx))
+		if err != nil || service.Properties == nil {
+			return "", err
+		}
+
+		return service.Properties.WebHook, nil
+	},
+	"jira": func(ctx context.Context, services gitlab.ServicesServiceInterface, projectID string) (string, error) {
+		service, _, err := services.GetJiraService(projectID, gitlab.WithContext(ctx))
+		if err != nil || service.Properties == nil {
+			return "", err
+		}
+
+		return service.Properties.URL, nil
+	},
+	"jenkins": func(ctx context.Context, services gitlab.ServicesServiceInterface, projectID string) (string, error) {
+		service, _, err := services.GetJenkinsCIService(projectID, gitlab.WithContext(ctx))
+		if err != nil || service.Properties == nil {
+			return "", err
+		}
+
+		return service.Properties.URL, nil
+	},
+	"drone-ci": func(ctx context.Context, services gitlab.ServicesServiceInterface, projectID string) (string, error) {
+		service, _, err := services.GetDroneCIService(projectID, gitlab.WithContext(ctx))
+		if err != nil || service.Properties == nil {
+			return "", err
+		}
+
+		return service.Properties.DroneURL, nil
+	},
+}
+
+// GetIntegrationsRecursively fetches the integrations and webhooks of all projects within a group
+// and its subgroups. GitLab lists only active integrations, while webhooks are listed whether or not
+// GitLab disabled them after repeated failures. Endpoints are reduced to their scheme and host, as
+// their paths and query strings often hold secrets.
+func (c *Client) GetIntegrationsRecursively(ctx context.Context, groupID string) ([]*ProjectIntegration, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive integrations fetch for group ID %s\n", groupID)
+	}
+
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allIntegrations []*ProjectIntegration
+		mu              sync.Mutex
+		wg              sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchIntegrationsForProject(ctx, projectID, project, &allIntegrations, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "integrations fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortIntegrations(allIntegrations)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive integrations fetch, found %d integrations\n", len(allIntegrations))
+	}
+
+	return allIntegrations, nil
+}
+
+func (c *Client) getIntegrationsForProject(ctx context.Context, projectID string) ([]*ProjectIntegration, error) {
+	services, _, err := c.client.Services.ListServices(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list integrations: %w", err)
+	}
+
+	integrations := make([]*ProjectIntegration, 0, len(services))
+
+	for _, service := range services {
+		integration := &ProjectIntegration{
+			ReportType: ReportTypeProjectIntegration,
+			Type:       IntegrationTypeIntegration,
+			ID:         service.ID,
+			Name:       service.Title,
+			Slug:       service.Slug,
+			Active:     service.Active,
+			Events:     serviceEvents(service),
+		}
+
+		if endpoint, ok := integrationEndpoints[service.Slug]; ok {
+			raw, err := endpoint(ctx, c.client.Services, projectID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s integration: %w", service.Slug, err)
+			}
+
+			integration.EndpointURL = redactEndpoint(raw)
+		}
+
+		integrations = append(integrations, integration)
+	}
+
+	opt := &gitlab.ListProjectHooksOptions{
+		PerPage: c.pageSize,
+		Page:    1,
+	}
+
+	for {
+		hooks, resp, err := c.client.Projects.ListProjectHooks(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		for _, hook := range hooks {
+			integrations = append(integrations, &ProjectIntegration{
+				ReportType: ReportTypeProjectIntegration,
+				Type:       IntegrationTypeWebhook,
+				ID:         hook.ID,
+				Name:       hook.Name,
+				// webhooks failing repeatedly are disabled by GitLab, temporarily or for good
+				Active:      hook.AlertStatus == "" || hook.AlertStatus == "executable",
+				EndpointURL: redactEndpoint(hook.URL),
+				Events:      hookEvents(hook),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return integrations, nil
+}
+
+func (c *Client) fetchIntegrationsForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+	allIntegrations *[]*ProjectIntegration,
+	mu *sync.Mutex,
+) {
+	integrations, err := c.getIntegrationsForProject(ctx, projectID)
+	if err != nil {
+		c.recordInaccessible(ctx, "project", project.PathWithNamespace, "integrations", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching integrations for project %s: %v\n", projectID, err)
+		}
+
+		return
+	}
+
+	for _, integration := range integrations {
+		integration.ProjectID = project.ID
+		integration.ProjectName = project.Name
+		integration.ProjectPath = project.PathWithNamespace
+		integration.ProjectWebURL = c.webURL(project.WebURL)
+	}
+
+	mu.Lock()
+	*allIntegrations = append(*allIntegrations, integrations...)
+	mu.Unlock()
+	c.countItems(len(integrations))
+}
+
+// redactEndpoint returns the scheme and host of an endpoint URL, replacing a path with a placeholder
+// and dropping credentials, query strings, and fragments. Values that are not absolute URLs are
+// dropped entirely.
+func redactEndpoint(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return ""
+	}
+
+	endpoint := u.Scheme + "://" + u.Host
+	if u.Path != "" && u.Path != "/" {
+		endpoint += redactedEndpointPath
+	}
+
+	return endpoint
+}
+
+// events returns the names of the enabled events, in the order given.
+func events(enabled []bool, names []string) []string {
+	var result []string
+
+	for i, name := range names {
+		if enabled[i] {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func serviceEvents(s *gitlab.Service) []string {
+	return events(
+		[]bool{
+			s.PushEvents, s.TagPushEvents, s.MergeRequestsEvents, s.IssuesEvents, s.ConfidentialIssuesEvents,
+			s.NoteEvents, s.ConfidentialNoteEvents, s.PipelineEvents, s.JobEvents, s.WikiPageEvents,
+			s.DeploymentEvents, s.AlertEvents, s.IncidentEvents, s.VulnerabilityEvents,
+		},
+		[]string{
+			"push", "tag_push", "merge_requests", "issues", "confidential_issues",
+			"note", "confidential_note", "pipeline", "job", "wiki_page",
+			"deployment", "alert", "incident", "vulnerability",
+		})
+}
+
+func hookEvents(h *gitlab.ProjectHook) []string {
+	return events(
+		[]bool{
+			h.PushEvents, h.TagPushEvents, h.MergeRequestsEvents, h.IssuesEvents, h.ConfidentialIssuesEvents,
+			h.NoteEvents, h.ConfidentialNoteEvents, h.PipelineEvents, h.JobEvents, h.WikiPageEvents,
+			h.DeploymentEvents, h.ReleasesEvents, h.ResourceAccessTokenEvents,
+		},
+		[]string{
+			"push", "tag_push", "merge_requests", "issues", "confidential_issues",
+			"note", "confidential_note", "pipeline", "job", "wiki_page",
+			"deployment", "releases", "resource_access_token",
+		})
+}
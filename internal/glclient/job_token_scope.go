@@ -0,0 +1,155 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectJobTokenScope represents the CI/CD job token allowlist of a project: the projects and groups
+// whose job tokens may access it.
+type ProjectJobTokenScope struct {
+	ReportType          string   `json:"report_type" csv:"-"`
+	Instance            string   `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID           int      `json:"project_id"`
+	ProjectName         string   `json:"project_name"`
+	ProjectPath         string   `json:"project_path"`
+	ProjectWebURL       string   `json:"project_web_url"`
+	AllowlistEnabled    bool     `json:"allowlist_enabled"` // job tokens of other projects are rejected unless listed
+	AllowedProjectPaths []string `json:"allowed_project_paths"`
+	AllowedGroupPaths   []string `json:"allowed_group_paths"`
+}
+
+// GetJobTokenScopesRecursively fetches the CI/CD job token allowlists of all projects within a group
+// and its subgroups. Projects whose settings the token cannot read, which requires at least the
+// Maintainer role, are reported as inaccessible.
+func (c *Client) GetJobTokenScopesRecursively(ctx context.Context, groupID string) ([]*ProjectJobTokenScope, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive job token scope fetch for group ID %s\n", groupID)
+	}
+
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allScopes []*ProjectJobTokenScope
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchJobTokenScopeForProject(ctx, projectID, project, &allScopes, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "job token scope fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortJobTokenScopes(allScopes)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive job token scope fetch, found %d projects\n", len(allScopes))
+	}
+
+	return allScopes, nil
+}
+
+func (c *Client) getJobTokenScopeForProject(ctx context.Context, projectID string) (*ProjectJobTokenScope, error) {
+	access, _, err := c.client.JobTokenScope.GetProjectJobTokenAccessSettings(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job token access settings: %w", err)
+	}
+
+	scope := &ProjectJobTokenScope{
+		ReportType:       ReportTypeProjectJobTokenScope,
+		AllowlistEnabled: access.InboundEnabled,
+	}
+
+	projectOpt := &gitlab.GetJobTokenInboundAllowListOptions{
+		ListOptions: gitlab.ListOptions{PerPage: c.pageSize, Page: 1},
+	}
+
+	for {
+		projects, resp, err := c.client.JobTokenScope.GetProjectJobTokenInboundAllowList(
+			projectID, projectOpt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list allowed projects: %w", err)
+		}
+
+		for _, project := range projects {
+			scope.AllowedProjectPaths = append(scope.AllowedProjectPaths, project.PathWithNamespace)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		projectOpt.Page = resp.NextPage
+	}
+
+	groupOpt := &gitlab.GetJobTokenAllowlistGroupsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: c.pageSize, Page: 1},
+	}
+
+	for {
+		groups, resp, err := c.client.JobTokenScope.GetJobTokenAllowlistGroups(projectID, groupOpt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list allowed groups: %w", err)
+		}
+
+		for _, group := range groups {
+			scope.AllowedGroupPaths = append(scope.AllowedGroupPaths, group.FullPath)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		groupOpt.Page = resp.NextPage
+	}
+
+	return scope, nil
+}
+
+func (c *Client) fetchJobTokenScopeForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+	allScopes *[]*ProjectJobTokenScope,
+	mu *sync.Mutex,
+) {
+	scope, err := c.getJobTokenScopeForProject(ctx, projectID)
+	if err != nil {
+		c.recordInaccessible(ctx, "project", project.PathWithNamespace, "job token scope", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching job token scope for project %s: %v\n", projectID, err)
+		}
+
+		return
+	}
+
+	scope.ProjectID = project.ID
+	scope.ProjectName = project.Name
+	scope.ProjectPath = project.PathWithNamespace
+	scope.ProjectWebURL = c.webURL(project.WebURL)
+
+	mu.Lock()
+	*allScopes = append(*allScopes, scope)
+	mu.Unlock()
+	c.countItems(1)
+}
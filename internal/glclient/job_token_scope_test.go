@@ -0,0 +1,97 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetJobTokenScopesRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	projects := []*gitlab.Project{
+		{
+			ID:                10,
+			Name:              "api",
+			PathWithNamespace: "root-group/api",
+			WebURL:            "https://gitlab.com/root-group/api",
+		},
+		{ID: 11, Name: "docs", PathWithNamespace: "root-group/docs"},
+		{ID: 12, Name: "secret", PathWithNamespace: "root-group/secret"},
+	}
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return(projects, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenAccessSettings("10", gomock.Any()).
+		Return(&gitlab.JobTokenAccessSettings{InboundEnabled: true}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenInboundAllowList("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{{PathWithNamespace: "root-group/api"}}, &gitlab.Response{NextPage: 2}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenInboundAllowList("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{{PathWithNamespace: "other/deployer"}}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetJobTokenAllowlistGroups("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{{FullPath: "platform"}}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenAccessSettings("11", gomock.Any()).
+		Return(&gitlab.JobTokenAccessSettings{InboundEnabled: false}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenInboundAllowList("11", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetJobTokenAllowlistGroups("11", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockJobTokenScope.EXPECT().
+		GetProjectJobTokenAccessSettings("12", gomock.Any()).
+		Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+	scopes, err := client.GetJobTokenScopesRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*glclient.ProjectJobTokenScope{
+		{
+			ReportType:          glclient.ReportTypeProjectJobTokenScope,
+			ProjectID:           10,
+			ProjectName:         "api",
+			ProjectPath:         "root-group/api",
+			ProjectWebURL:       "https://gitlab.com/root-group/api",
+			AllowlistEnabled:    true,
+			AllowedProjectPaths: []string{"root-group/api", "other/deployer"},
+			AllowedGroupPaths:   []string{"platform"},
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectJobTokenScope,
+			ProjectID:   11,
+			ProjectName: "docs",
+			ProjectPath: "root-group/docs",
+		},
+	}, scopes)
+
+	inaccessible := client.Inaccessible()
+	require.Len(t, inaccessible, 1)
+	assert.Equal(t, "root-group/secret", inaccessible[0].Path)
+}
@@ -0,0 +1,41 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// errItemLimitReached cancels the context of LimitItems once enough items were collected.
+var errItemLimitReached = errors.New("item limit reached")
+
+// itemLimit counts the report items the fetches of a client collected against their limit.
+type itemLimit struct {
+	limit     int64
+	collected atomic.Int64
+	stop      context.CancelCauseFunc
+}
+
+// LimitItems returns a context that stops the recursive fetches of the client once they collected n
+// report items, such as tokens or variables. Fetches stopped this way return what they collected
+// without an error, which may be more than n items since requests already answered are kept. The
+// groups and projects a fetch starts from are always listed in full. LimitItems must be called
+// before the fetches start; the returned cancel function releases the context.
+func (c *Client) LimitItems(ctx context.Context, n int) (context.Context, context.CancelFunc) {
+	limited, stop := context.WithCancelCause(ctx)
+	c.limit = &itemLimit{limit: int64(n), stop: stop}
+
+	return limited, func() { stop(context.Canceled) }
+}
+
+// countItems records that a fetch collected n report items, stopping the fetches once the limit of
+// LimitItems is reached.
+func (c *Client) countItems(n int) {
+	if c.limit == nil {
+		return
+	}
+
+	if c.limit.collected.Add(int64(n)) >= c.limit.limit {
+		c.limit.stop(errItemLimitReached)
+	}
+}
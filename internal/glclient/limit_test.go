@@ -0,0 +1,43 @@
+package glclient_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LimitItems(t *testing.T) {
+	t.Run("stops fetching once the limit is reached", func(t *testing.T) {
+		server := slowServer(t, 5*time.Second)
+
+		client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+		require.NoError(t, err)
+
+		ctx, cancel := client.LimitItems(t.Context(), 1)
+		defer cancel()
+
+		start := time.Now()
+		variables, err := client.GetProjectVariablesRecursively(ctx, "1")
+		require.NoError(t, err)
+		assert.Less(t, time.Since(start), 2*time.Second, "the slow project is not waited for")
+		assert.Equal(t, []string{"FAST"}, variableKeys(variables))
+		assert.Empty(t, client.Inaccessible(), "abandoned requests are not reported as inaccessible")
+	})
+
+	t.Run("fetches everything below the limit", func(t *testing.T) {
+		server := slowServer(t, 10*time.Millisecond)
+
+		client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+		require.NoError(t, err)
+
+		ctx, cancel := client.LimitItems(t.Context(), 3)
+		defer cancel()
+
+		variables, err := client.GetProjectVariablesRecursively(ctx, "1")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"FAST", "SLOW"}, variableKeys(variables))
+	})
+}
@@ -0,0 +1,44 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// VerifyURL issues a HEAD request against url and returns the response status code, for checking
+// that the links in a report resolve. Redirects are not followed, so a page GitLab sends anonymous
+// visitors to the sign-in page from reports the redirect instead of the status of the sign-in page.
+func (c *Client) VerifyURL(ctx context.Context, url string) (int, error) {
+	return c.headStatus(ctx, c.urlClient, url)
+}
+
+// headStatus issues a HEAD request against url with client and returns the response status code.
+// Requests are throttled by the link rate limiter so that link checks don't flood remote hosts.
+func (c *Client) headStatus(ctx context.Context, client *http.Client, url string) (int, error) {
+	if err := c.linkLimiter.Wait(ctx); err != nil {
+		return 0, fmt.Errorf("failed to wait for rate limiter: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if c.debug {
+			fmt.Printf("DEBUG: error checking link %s: %v\n", url, err)
+		}
+
+		return 0, fmt.Errorf("failed to check %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// keepRedirect stops a client at the first redirect, returning the redirect response itself.
+func keepRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
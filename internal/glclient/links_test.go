@@ -0,0 +1,57 @@
+package glclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		switch r.URL.Path {
+		case "/org/app/-/settings/ci_cd":
+			w.WriteHeader(http.StatusOK)
+		case "/org/private/-/settings/ci_cd":
+			http.Redirect(w, r, "/users/sign_in", http.StatusFound)
+		case "/org/broken/-/settings/ci_cd":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := testClient(t)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "reachable page", path: "/org/app/-/settings/ci_cd", want: http.StatusOK},
+		{name: "missing page", path: "/org/gone/-/settings/ci_cd", want: http.StatusNotFound},
+		{name: "redirect is not followed", path: "/org/private/-/settings/ci_cd", want: http.StatusFound},
+		{name: "server error", path: "/org/broken/-/settings/ci_cd", want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := client.VerifyURL(t.Context(), server.URL+tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+
+	t.Run("unreachable host", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		_, err := client.VerifyURL(t.Context(), unreachable.URL+"/org/app")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,329 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Milestone states reported by GitLab.
+const (
+	MilestoneStateActive = "active"
+	MilestoneStateClosed = "closed"
+)
+
+// ProjectMilestone represents a milestone with the project it belongs to.
+type ProjectMilestone struct {
+	ReportType    string     `json:"report_type" csv:"-"`
+	Instance      string     `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int        `json:"project_id"`
+	ProjectName   string     `json:"project_name"`
+	ProjectPath   string     `json:"project_path"`
+	ProjectWebURL string     `json:"project_web_url"`
+	ID            int        `json:"id"`
+	IID           int        `json:"iid"`
+	Title         string     `json:"title"`
+	State         string     `json:"state"`
+	StartDate     *time.Time `json:"start_date"`
+	DueDate       *time.Time `json:"due_date"`
+	WebURL        string     `json:"web_url"`
+}
+
+// GroupMilestone represents a milestone with the group it belongs to.
+type GroupMilestone struct {
+	ReportType  string     `json:"report_type" csv:"-"`
+	Instance    string     `json:"instance,omitempty" csv:"omitempty"`
+	GroupID     int        `json:"group_id"`
+	GroupName   string     `json:"group_name"`
+	GroupPath   string     `json:"group_path"`
+	GroupWebURL string     `json:"group_web_url"`
+	ID          int        `json:"id"`
+	IID         int        `json:"iid"`
+	Title       string     `json:"title"`
+	State       string     `json:"state"`
+	StartDate   *time.Time `json:"start_date"`
+	DueDate     *time.Time `json:"due_date"`
+	WebURL      string     `json:"web_url"`
+}
+
+// MilestoneWithSource represents a milestone from either a project or group with source identification.
+type MilestoneWithSource struct {
+	ReportType   string     `json:"report_type" csv:"-"`
+	Instance     string     `json:"instance,omitempty" csv:"omitempty"`
+	ID           int        `json:"id"`
+	IID          int        `json:"iid"`
+	Title        string     `json:"title"`
+	State        string     `json:"state"`
+	StartDate    *time.Time `json:"start_date"`
+	DueDate      *time.Time `json:"due_date"`
+	WebURL       string     `json:"web_url"`
+	Source       string     `json:"source"` // "project" or "group"
+	SourceID     int        `json:"source_id"`
+	SourceName   string     `json:"source_name"`
+	SourcePath   string     `json:"source_path"`
+	SourceWebURL string     `json:"source_web_url"`
+}
+
+// ConvertProjectMilestoneToUnified converts a ProjectMilestone to MilestoneWithSource.
+func ConvertProjectMilestoneToUnified(pm *ProjectMilestone) *MilestoneWithSource {
+	return &MilestoneWithSource{
+		ReportType:   ReportTypeProjectMilestone,
+		ID:           pm.ID,
+		IID:          pm.IID,
+		Title:        pm.Title,
+		State:        pm.State,
+		StartDate:    pm.StartDate,
+		DueDate:      pm.DueDate,
+		WebURL:       pm.WebURL,
+		Source:       "project",
+		SourceID:     pm.ProjectID,
+		SourceName:   pm.ProjectName,
+		SourcePath:   pm.ProjectPath,
+		SourceWebURL: pm.ProjectWebURL,
+	}
+}
+
+// ConvertGroupMilestoneToUnified converts a GroupMilestone to MilestoneWithSource.
+func ConvertGroupMilestoneToUnified(gm *GroupMilestone) *MilestoneWithSource {
+	return &MilestoneWithSource{
+		ReportType:   ReportTypeGroupMilestone,
+		ID:           gm.ID,
+		IID:          gm.IID,
+		Title:        gm.Title,
+		State:        gm.State,
+		StartDate:    gm.StartDate,
+		DueDate:      gm.DueDate,
+		WebURL:       gm.WebURL,
+		Source:       "group",
+		SourceID:     gm.GroupID,
+		SourceName:   gm.GroupName,
+		SourcePath:   gm.GroupPath,
+		SourceWebURL: gm.GroupWebURL,
+	}
+}
+
+// GetProjectMilestonesRecursively fetches the milestones of all projects within a group and its
+// subgroups. Milestones inherited from groups are reported by GetGroupMilestonesRecursively.
+func (c *Client) GetProjectMilestonesRecursively(ctx context.Context, groupID string) ([]*ProjectMilestone, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allMilestones []*ProjectMilestone
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			milestones, err := c.listMilestonesForProject(ctx, projectID, project)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, "project milestones", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching milestones for project %s: %v\n", projectID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allMilestones = append(allMilestones, milestones...)
+			mu.Unlock()
+			c.countItems(len(milestones))
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "project milestones fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectMilestones(allMilestones)
+
+	return allMilestones, nil
+}
+
+// GetGroupMilestonesRecursively fetches the milestones of all groups within a group and its subgroups.
+func (c *Client) GetGroupMilestonesRecursively(ctx context.Context, groupID string) ([]*GroupMilestone, error) {
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	var (
+		allMilestones []*GroupMilestone
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+
+		groupID := strconv.Itoa(group.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			milestones, err := c.listMilestonesForGroup(ctx, groupID, group)
+			if err != nil {
+				c.recordInaccessible(ctx, "group", group.FullPath, "group milestones", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching milestones for group %s: %v\n", groupID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allMilestones = append(allMilestones, milestones...)
+			mu.Unlock()
+			c.countItems(len(milestones))
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "group milestones fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortGroupMilestones(allMilestones)
+
+	return allMilestones, nil
+}
+
+func (c *Client) listMilestonesForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) ([]*ProjectMilestone, error) {
+	var allMilestones []*ProjectMilestone
+
+	opt := &gitlab.ListMilestonesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		milestones, resp, err := c.client.Milestones.ListMilestones(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list project milestones: %w", err)
+		}
+
+		for _, milestone := range milestones {
+			allMilestones = append(allMilestones, &ProjectMilestone{
+				ReportType:    ReportTypeProjectMilestone,
+				ProjectID:     project.ID,
+				ProjectName:   project.Name,
+				ProjectPath:   project.PathWithNamespace,
+				ProjectWebURL: c.webURL(project.WebURL),
+				ID:            milestone.ID,
+				IID:           milestone.IID,
+				Title:         milestone.Title,
+				State:         milestone.State,
+				StartDate:     isoDate(milestone.StartDate),
+				DueDate:       isoDate(milestone.DueDate),
+				WebURL:        c.webURL(milestone.WebURL),
+			})
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d milestones for project %s\n", len(milestones), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allMilestones, nil
+}
+
+func (c *Client) listMilestonesForGroup(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+) ([]*GroupMilestone, error) {
+	var allMilestones []*GroupMilestone
+
+	opt := &gitlab.ListGroupMilestonesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	groupWebURL := c.webURL(group.WebURL)
+
+	for {
+		milestones, resp, err := c.client.GroupMilestones.ListGroupMilestones(groupID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group milestones: %w", err)
+		}
+
+		for _, milestone := range milestones {
+			wrapped := &GroupMilestone{
+				ReportType:  ReportTypeGroupMilestone,
+				GroupID:     group.ID,
+				GroupName:   group.Name,
+				GroupPath:   group.FullPath,
+				GroupWebURL: groupWebURL,
+				ID:          milestone.ID,
+				IID:         milestone.IID,
+				Title:       milestone.Title,
+				State:       milestone.State,
+				StartDate:   isoDate(milestone.StartDate),
+				DueDate:     isoDate(milestone.DueDate),
+			}
+
+			// the group milestones API does not return the web URL
+			if groupWebURL != "" {
+				wrapped.WebURL = groupWebURL + "/-/milestones/" + strconv.Itoa(milestone.IID)
+			}
+
+			allMilestones = append(allMilestones, wrapped)
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d milestones for group %s\n", len(milestones), groupID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allMilestones, nil
+}
+
+// isoDate converts a date returned by the GitLab API, keeping nil for dates that are not set.
+func isoDate(date *gitlab.ISOTime) *time.Time {
+	if date == nil {
+		return nil
+	}
+
+	t := time.Time(*date)
+
+	return &t
+}
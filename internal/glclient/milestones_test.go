@@ -0,0 +1,164 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectMilestonesRecursively(t *testing.T) {
+	t.Run("lists the milestones of every project", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		dueDate := gitlab.ISOTime(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+
+		expectTopicProjects(mockClient)
+
+		mockClient.MockMilestones.EXPECT().
+			ListMilestones("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Milestone{
+				{
+					ID:      201,
+					IID:     2,
+					Title:   "v2.0",
+					State:   glclient.MilestoneStateActive,
+					DueDate: &dueDate,
+					WebURL:  "https://gitlab.com/root-group/payments/-/milestones/2",
+				},
+				{ID: 200, IID: 1, Title: "v1.0", State: glclient.MilestoneStateClosed},
+			}, &gitlab.Response{}, nil)
+		mockClient.MockMilestones.EXPECT().
+			ListMilestones(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Milestone{}, &gitlab.Response{}, nil).
+			Times(3)
+
+		milestones, err := client.GetProjectMilestonesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		due := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, []*glclient.ProjectMilestone{
+			{
+				ReportType:  glclient.ReportTypeProjectMilestone,
+				ProjectID:   10,
+				ProjectName: "payments",
+				ProjectPath: "root-group/payments",
+				ID:          200,
+				IID:         1,
+				Title:       "v1.0",
+				State:       glclient.MilestoneStateClosed,
+			},
+			{
+				ReportType:  glclient.ReportTypeProjectMilestone,
+				ProjectID:   10,
+				ProjectName: "payments",
+				ProjectPath: "root-group/payments",
+				ID:          201,
+				IID:         2,
+				Title:       "v2.0",
+				State:       glclient.MilestoneStateActive,
+				DueDate:     &due,
+				WebURL:      "https://gitlab.com/root-group/payments/-/milestones/2",
+			},
+		}, milestones)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("skips projects that fail", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectTopicProjects(mockClient)
+
+		mockClient.MockMilestones.EXPECT().
+			ListMilestones("11", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+		mockClient.MockMilestones.EXPECT().
+			ListMilestones(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Milestone{}, &gitlab.Response{}, nil).
+			Times(3)
+
+		milestones, err := client.GetProjectMilestonesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Empty(t, milestones)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "project", Path: "root-group/docs", Reason: "project milestones: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
+
+func TestGetGroupMilestonesRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	startDate := gitlab.ISOTime(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	dueDate := gitlab.ISOTime(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+
+	expectEpicGroups(mockClient)
+
+	mockClient.MockGroupMilestones.EXPECT().
+		ListGroupMilestones("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.GroupMilestone{
+			{ID: 301, IID: 4, Title: "Q2", State: "active", StartDate: &startDate, DueDate: &dueDate},
+		}, &gitlab.Response{}, nil)
+	mockClient.MockGroupMilestones.EXPECT().
+		ListGroupMilestones("2", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.GroupMilestone{{ID: 302, IID: 1, Title: "Sprint 1", State: "closed"}}, &gitlab.Response{}, nil)
+
+	milestones, err := client.GetGroupMilestonesRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []*glclient.GroupMilestone{
+		{
+			ReportType:  glclient.ReportTypeGroupMilestone,
+			GroupID:     1,
+			GroupName:   "root-group",
+			GroupPath:   "root-group",
+			GroupWebURL: "https://gitlab.com/groups/root-group",
+			ID:          301,
+			IID:         4,
+			Title:       "Q2",
+			State:       "active",
+			StartDate:   &start,
+			DueDate:     &due,
+			WebURL:      "https://gitlab.com/groups/root-group/-/milestones/4",
+		},
+		{
+			ReportType: glclient.ReportTypeGroupMilestone,
+			GroupID:    2,
+			GroupName:  "team",
+			GroupPath:  "root-group/team",
+			ID:         302,
+			IID:        1,
+			Title:      "Sprint 1",
+			State:      "closed",
+		},
+	}, milestones)
+}
+
+func TestConvertMilestoneToUnified(t *testing.T) {
+	due := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	project := glclient.ConvertProjectMilestoneToUnified(&glclient.ProjectMilestone{
+		ProjectID: 10, ProjectName: "payments", ProjectPath: "root-group/payments", IID: 2, Title: "v2.0", DueDate: &due,
+	})
+	assert.Equal(t, glclient.ReportTypeProjectMilestone, project.ReportType)
+	assert.Equal(t, "project", project.Source)
+	assert.Equal(t, 10, project.SourceID)
+	assert.Equal(t, "root-group/payments", project.SourcePath)
+	assert.Equal(t, &due, project.DueDate)
+
+	group := glclient.ConvertGroupMilestoneToUnified(&glclient.GroupMilestone{
+		GroupID: 1, GroupName: "root-group", GroupPath: "root-group", IID: 4, Title: "Q2",
+	})
+	assert.Equal(t, glclient.ReportTypeGroupMilestone, group.ReportType)
+	assert.Equal(t, "group", group.Source)
+	assert.Equal(t, 1, group.SourceID)
+	assert.Equal(t, "root-group", group.SourcePath)
+	assert.Equal(t, "Q2", group.Title)
+}
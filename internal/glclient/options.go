@@ -0,0 +1,143 @@
+package glclient
+
+import (
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/cache"
+)
+
+// Option configures a Client created by NewClient.
+type Option func(*options)
+
+type options struct {
+	baseURL            string
+	cache              *cache.Cache
+	etagCache          *cache.Cache
+	sharedProjects     bool
+	sharedAttribution  bool
+	personalNamespaces bool
+	stripQueryParams   bool
+	requestTimeout     time.Duration
+	groupTimeout       time.Duration
+	partial            bool
+	topic              string
+	naturalSort        bool
+	profile            Profile
+}
+
+func newOptions(opts []Option) options {
+	o := options{profile: DefaultProfile()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithBaseURL points the client at a self-managed GitLab instance instead of gitlab.com.
+// The API path is appended automatically, so the instance web URL can be passed as is.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithCache reuses group and project hierarchies stored in the given cache while they are fresh.
+func WithCache(store *cache.Cache) Option {
+	return func(o *options) {
+		o.cache = store
+	}
+}
+
+// WithETagCache stores group and project API responses with their ETags in the given cache and
+// revalidates them on later runs, reusing the stored response when GitLab answers 304 Not Modified.
+// Runner registration tokens are removed before writing. It has no effect on NewClientWithGitLabClient.
+func WithETagCache(store *cache.Cache) Option {
+	return func(o *options) {
+		o.etagCache = store
+	}
+}
+
+// WithSharedProjects includes projects shared into a group when listing the group's projects.
+// By default only projects that belong to the group are listed.
+func WithSharedProjects() Option {
+	return func(o *options) {
+		o.sharedProjects = true
+	}
+}
+
+// WithSharedAttribution attributes a project shared into groups to each group of a recursive fetch
+// listing it, instead of once to its home namespace, so that reports count it toward every group it
+// is shared into. It only has an effect together with WithSharedProjects.
+func WithSharedAttribution() Option {
+	return func(o *options) {
+		o.sharedAttribution = true
+	}
+}
+
+// WithPersonalNamespaces adds the projects in user namespaces to the projects of all accessible groups,
+// which are otherwise never listed: those of the authenticated user, or of every user for administrators.
+// Fetches starting from a given group are not affected.
+func WithPersonalNamespaces() Option {
+	return func(o *options) {
+		o.personalNamespaces = true
+	}
+}
+
+// WithStrippedQueryParams removes query strings and fragments from the web URLs of groups, projects,
+// and users in all results, for instances that add tracking parameters to them.
+func WithStrippedQueryParams() Option {
+	return func(o *options) {
+		o.stripQueryParams = true
+	}
+}
+
+// WithRequestTimeout limits how long a single API request may take, including reading the response,
+// so that one unresponsive endpoint cannot stall a recursive fetch. Requests that time out are skipped
+// like inaccessible resources and reported by Inaccessible.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.requestTimeout = timeout
+	}
+}
+
+// WithGroupTimeout limits how long listing the direct subgroups or the projects of a single group may
+// take, so that one slow subtree cannot stall a recursive fetch. Groups over the limit are skipped
+// from that point on and reported by Inaccessible; subgroups listed before are still traversed.
+func WithGroupTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.groupTimeout = timeout
+	}
+}
+
+// WithPartialResults makes recursive fetches return what they collected when the context deadline
+// expires, instead of failing. Cancellation still fails the fetch.
+func WithPartialResults() Option {
+	return func(o *options) {
+		o.partial = true
+	}
+}
+
+// WithTopic limits the projects of recursive fetches to those carrying the given topic, so that every
+// project-based report covers only them. Groups are not affected.
+func WithTopic(topic string) Option {
+	return func(o *options) {
+		o.topic = topic
+	}
+}
+
+// WithNaturalSort orders the items of recursive fetches by the paths of their groups and projects
+// naturally, comparing runs of digits by their numeric value, instead of lexically.
+func WithNaturalSort() Option {
+	return func(o *options) {
+		o.naturalSort = true
+	}
+}
+
+// WithProfile sets the concurrency, rate limit, page size, and retries of the client's API requests,
+// which default to DefaultProfile.
+func WithProfile(profile Profile) Option {
+	return func(o *options) {
+		o.profile = profile
+	}
+}
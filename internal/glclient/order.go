@@ -0,0 +1,246 @@
+package glclient
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// sortBySource sorts the items of a recursive result by the path of the group or project they
+// belong to, compared with comparePaths, then by compare. Workers append results in the order they
+// finish, so without this two runs over the same data would list items differently.
+func sortBySource[T any](
+	items []T,
+	comparePaths func(a, b string) int,
+	path func(T) string,
+	compare func(a, b T) int,
+) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		if c := comparePaths(path(a), path(b)); c != 0 {
+			return c
+		}
+
+		return compare(a, b)
+	})
+}
+
+// comparePaths orders the paths of groups and projects, lexically or, with WithNaturalSort, naturally.
+func (c *Client) comparePaths(a, b string) int {
+	if c.naturalSort {
+		return NaturalCompare(a, b)
+	}
+
+	return strings.Compare(a, b)
+}
+
+// NaturalCompare compares strings like strings.Compare, except that runs of digits are compared by
+// their numeric value, so that sub-group-2 sorts before sub-group-10. Strings differing only in
+// leading zeros are ordered lexically.
+func NaturalCompare(a, b string) int {
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		if !isDigit(a[i]) || !isDigit(b[j]) {
+			if a[i] != b[j] {
+				return cmp.Compare(a[i], b[j])
+			}
+
+			i, j = i+1, j+1
+
+			continue
+		}
+
+		endA, endB := digitsEnd(a, i), digitsEnd(b, j)
+		numberA, numberB := strings.TrimLeft(a[i:endA], "0"), strings.TrimLeft(b[j:endB], "0")
+
+		// without leading zeros, the longer number is the larger one
+		if c := cmp.Or(cmp.Compare(len(numberA), len(numberB)), strings.Compare(numberA, numberB)); c != 0 {
+			return c
+		}
+
+		i, j = endA, endB
+	}
+
+	return cmp.Or(cmp.Compare(len(a)-i, len(b)-j), strings.Compare(a, b))
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// digitsEnd returns the index after the run of digits of s starting at i.
+func digitsEnd(s string, i int) int {
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+
+	return i
+}
+
+func (c *Client) sortGroups(groups []*gitlab.Group) {
+	sortBySource(groups, c.comparePaths,
+		func(g *gitlab.Group) string { return g.FullPath },
+		func(a, b *gitlab.Group) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+func (c *Client) sortGroupAccessTokens(tokens []*GroupAccessTokenWithGroup) {
+	sortBySource(tokens, c.comparePaths,
+		func(t *GroupAccessTokenWithGroup) string { return t.GroupPath },
+		func(a, b *GroupAccessTokenWithGroup) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+func (c *Client) sortProjectAccessTokens(tokens []*ProjectAccessTokenWithProject) {
+	sortBySource(tokens, c.comparePaths,
+		func(t *ProjectAccessTokenWithProject) string { return t.ProjectPath },
+		func(a, b *ProjectAccessTokenWithProject) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+func (c *Client) sortPipelineTriggers(triggers []*PipelineTriggerWithProject) {
+	sortBySource(triggers, c.comparePaths,
+		func(t *PipelineTriggerWithProject) string { return t.ProjectPath },
+		func(a, b *PipelineTriggerWithProject) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+func (c *Client) sortProjectVariables(variables []*ProjectVariableWithProject) {
+	sortBySource(variables, c.comparePaths,
+		func(v *ProjectVariableWithProject) string { return v.ProjectPath },
+		func(a, b *ProjectVariableWithProject) int {
+			return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.EnvironmentScope, b.EnvironmentScope))
+		})
+}
+
+func (c *Client) sortGroupVariables(variables []*GroupVariableWithGroup) {
+	sortBySource(variables, c.comparePaths,
+		func(v *GroupVariableWithGroup) string { return v.GroupFullPath },
+		func(a, b *GroupVariableWithGroup) int {
+			return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.EnvironmentScope, b.EnvironmentScope))
+		})
+}
+
+func (c *Client) sortBadges(badges []*BadgeWithSource) {
+	sortBySource(badges, c.comparePaths,
+		func(b *BadgeWithSource) string { return b.SourcePath },
+		func(a, b *BadgeWithSource) int {
+			return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.ID, b.ID))
+		})
+}
+
+func (c *Client) sortPushRules(rules []*PushRulesWithSource) {
+	sortBySource(rules, c.comparePaths,
+		func(r *PushRulesWithSource) string { return r.SourcePath },
+		func(a, b *PushRulesWithSource) int { return cmp.Compare(a.Source, b.Source) })
+}
+
+func (c *Client) sortAccessRequests(requests []*AccessRequestWithSource) {
+	sortBySource(requests, c.comparePaths,
+		func(r *AccessRequestWithSource) string { return r.SourcePath },
+		func(a, b *AccessRequestWithSource) int {
+			return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.ID, b.ID))
+		})
+}
+
+func (c *Client) sortCISettings(settings []*ProjectCISettings) {
+	sortBySource(settings, c.comparePaths,
+		func(s *ProjectCISettings) string { return s.ProjectPath },
+		func(a, b *ProjectCISettings) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortProjectStorage(storage []*ProjectStorage) {
+	sortBySource(storage, c.comparePaths,
+		func(s *ProjectStorage) string { return s.ProjectPath },
+		func(a, b *ProjectStorage) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortIntegrations(integrations []*ProjectIntegration) {
+	sortBySource(integrations, c.comparePaths,
+		func(i *ProjectIntegration) string { return i.ProjectPath },
+		func(a, b *ProjectIntegration) int {
+			return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.ID, b.ID))
+		})
+}
+
+func (c *Client) sortJobTokenScopes(scopes []*ProjectJobTokenScope) {
+	sortBySource(scopes, c.comparePaths,
+		func(s *ProjectJobTokenScope) string { return s.ProjectPath },
+		func(a, b *ProjectJobTokenScope) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortProjectTopics(topics []*ProjectTopics) {
+	sortBySource(topics, c.comparePaths,
+		func(t *ProjectTopics) string { return t.ProjectPath },
+		func(a, b *ProjectTopics) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortEpics(epics []*GroupEpic) {
+	sortBySource(epics, c.comparePaths,
+		func(e *GroupEpic) string { return e.GroupPath },
+		func(a, b *GroupEpic) int { return cmp.Compare(a.IID, b.IID) })
+}
+
+func (c *Client) sortProjectMilestones(milestones []*ProjectMilestone) {
+	sortBySource(milestones, c.comparePaths,
+		func(m *ProjectMilestone) string { return m.ProjectPath },
+		func(a, b *ProjectMilestone) int { return cmp.Compare(a.IID, b.IID) })
+}
+
+func (c *Client) sortGroupMilestones(milestones []*GroupMilestone) {
+	sortBySource(milestones, c.comparePaths,
+		func(m *GroupMilestone) string { return m.GroupPath },
+		func(a, b *GroupMilestone) int { return cmp.Compare(a.IID, b.IID) })
+}
+
+func (c *Client) sortProtectedEnvironments(environments []*ProjectProtectedEnvironment) {
+	sortBySource(environments, c.comparePaths,
+		func(e *ProjectProtectedEnvironment) string { return e.ProjectPath },
+		func(a, b *ProjectProtectedEnvironment) int { return cmp.Compare(a.Name, b.Name) })
+}
+
+func (c *Client) sortProjectRunners(runners []*ProjectRunners) {
+	sortBySource(runners, c.comparePaths,
+		func(r *ProjectRunners) string { return r.ProjectPath },
+		func(a, b *ProjectRunners) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortClusterAgents(agents []*ProjectClusterAgent) {
+	sortBySource(agents, c.comparePaths,
+		func(a *ProjectClusterAgent) string { return a.ProjectPath },
+		func(a, b *ProjectClusterAgent) int { return cmp.Compare(a.Name, b.Name) })
+}
+
+func (c *Client) sortSecurityConfigs(configs []*ProjectSecurityConfig) {
+	sortBySource(configs, c.comparePaths,
+		func(s *ProjectSecurityConfig) string { return s.ProjectPath },
+		func(a, b *ProjectSecurityConfig) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortComplianceFrameworks(frameworks []*ProjectComplianceFrameworks) {
+	sortBySource(frameworks, c.comparePaths,
+		func(f *ProjectComplianceFrameworks) string { return f.ProjectPath },
+		func(a, b *ProjectComplianceFrameworks) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortComputeUsage(usage []*GroupComputeUsage) {
+	sortBySource(usage, c.comparePaths,
+		func(u *GroupComputeUsage) string { return u.GroupPath },
+		func(a, b *GroupComputeUsage) int { return cmp.Compare(a.GroupID, b.GroupID) })
+}
+
+func (c *Client) sortProjectRegistries(registries []*ProjectRegistry) {
+	sortBySource(registries, c.comparePaths,
+		func(r *ProjectRegistry) string { return r.ProjectPath },
+		func(a, b *ProjectRegistry) int { return cmp.Compare(a.ProjectID, b.ProjectID) })
+}
+
+func (c *Client) sortProjectForks(forks []*ProjectFork) {
+	sortBySource(forks, c.comparePaths,
+		func(f *ProjectFork) string { return f.ProjectPath },
+		func(a, b *ProjectFork) int { return cmp.Compare(a.ForkPath, b.ForkPath) })
+}
+
+func (c *Client) sortImpersonationTokens(tokens []*ImpersonationTokenWithUser) {
+	sortBySource(tokens, c.comparePaths,
+		func(t *ImpersonationTokenWithUser) string { return t.Username },
+		func(a, b *ImpersonationTokenWithUser) int { return cmp.Compare(a.ID, b.ID) })
+}
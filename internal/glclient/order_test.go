@@ -0,0 +1,226 @@
+package glclient_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+const orderRuns = 5
+
+// shuffled returns a shuffled copy of items.
+func shuffled[T any](items []T) []T {
+	items = slices.Clone(items)
+	rand.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+
+	return items
+}
+
+// jitter delays a mocked response so that workers finish in a different order on every run.
+func jitter() {
+	time.Sleep(time.Duration(rand.IntN(3)) * time.Millisecond)
+}
+
+func TestGetGroupAccessTokensRecursively_order(t *testing.T) {
+	subgroups := make([]*gitlab.Group, 0, 4)
+	for i := range 4 {
+		subgroups = append(subgroups, &gitlab.Group{ID: 10 + i, FullPath: fmt.Sprintf("root-group/sub-%d", 4-i)})
+	}
+
+	tokens := []*gitlab.GroupAccessToken{
+		{PersonalAccessToken: gitlab.PersonalAccessToken{ID: 3, Name: "c"}},
+		{PersonalAccessToken: gitlab.PersonalAccessToken{ID: 1, Name: "a"}},
+		{PersonalAccessToken: gitlab.PersonalAccessToken{ID: 2, Name: "b"}},
+	}
+
+	paths := []string{"root-group", "root-group/sub-1", "root-group/sub-2", "root-group/sub-3", "root-group/sub-4"}
+
+	var want []string
+	for _, path := range paths {
+		for _, id := range []int{1, 2, 3} {
+			want = append(want, fmt.Sprintf("%s#%d", path, id))
+		}
+	}
+
+	for range orderRuns {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return(shuffled(subgroups), &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups(gomock.Not("1"), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil).
+			Times(len(subgroups))
+
+		mockClient.MockGroupAccessTokens.EXPECT().
+			ListGroupAccessTokens(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(any, *gitlab.ListGroupAccessTokensOptions, ...gitlab.RequestOptionFunc) (
+				[]*gitlab.GroupAccessToken, *gitlab.Response, error,
+			) {
+				jitter()
+
+				return shuffled(tokens), &gitlab.Response{}, nil
+			}).
+			Times(len(subgroups) + 1)
+
+		result, err := client.GetGroupAccessTokensRecursively(t.Context(), "1", glclient.TokenStateAll)
+		require.NoError(t, err)
+
+		got := make([]string, 0, len(result))
+		for _, token := range result {
+			got = append(got, fmt.Sprintf("%s#%d", token.GroupPath, token.ID))
+		}
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestGetProjectVariablesRecursively_order(t *testing.T) {
+	projects := make([]*gitlab.Project, 0, 4)
+	for i := range 4 {
+		projects = append(projects, &gitlab.Project{
+			ID:                10 + i,
+			PathWithNamespace: fmt.Sprintf("root-group/project-%d", 4-i),
+			Namespace:         &gitlab.ProjectNamespace{FullPath: "root-group"},
+		})
+	}
+
+	variables := []*gitlab.ProjectVariable{
+		{Key: "TOKEN", EnvironmentScope: "production"},
+		{Key: "API_URL", EnvironmentScope: "*"},
+		{Key: "TOKEN", EnvironmentScope: "*"},
+	}
+
+	var want []string
+	for i := 1; i <= 4; i++ {
+		for _, key := range []string{"API_URL/*", "TOKEN/*", "TOKEN/production"} {
+			want = append(want, "root-group/project-"+strconv.Itoa(i)+":"+key)
+		}
+	}
+
+	for range orderRuns {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(shuffled(projects), &gitlab.Response{}, nil)
+
+		mockClient.MockProjectVariables.EXPECT().
+			ListVariables(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(any, *gitlab.ListProjectVariablesOptions, ...gitlab.RequestOptionFunc) (
+				[]*gitlab.ProjectVariable, *gitlab.Response, error,
+			) {
+				jitter()
+
+				return shuffled(variables), &gitlab.Response{}, nil
+			}).
+			Times(len(projects))
+
+		result, err := client.GetProjectVariablesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		got := make([]string, 0, len(result))
+		for _, variable := range result {
+			got = append(got, variable.ProjectPath+":"+variable.Key+"/"+variable.EnvironmentScope)
+		}
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		lexical int
+		natural int
+	}{
+		{a: "root-group/sub-group-2", b: "root-group/sub-group-10", lexical: 1, natural: -1},
+		{a: "root-group/sub-group-51", b: "root-group/sub-group-52", lexical: -1, natural: -1},
+		{a: "root-group/sub-group-9/api", b: "root-group/sub-group-10", lexical: 1, natural: -1},
+		{a: "team-3", b: "team-3-archive", lexical: -1, natural: -1},
+		{a: "v007", b: "v7", lexical: -1, natural: -1},
+		{a: "app-02", b: "app-1", lexical: -1, natural: 1},
+		{a: "alpha", b: "beta", lexical: -1, natural: -1},
+		{a: "same-1", b: "same-1", lexical: 0, natural: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.lexical, strings.Compare(tt.a, tt.b), "lexical")
+			assert.Equal(t, tt.natural, glclient.NaturalCompare(tt.a, tt.b), "natural")
+			assert.Equal(t, -tt.natural, glclient.NaturalCompare(tt.b, tt.a), "natural reversed")
+		})
+	}
+}
+
+func TestGetGroupsRecursively_naturalOrder(t *testing.T) {
+	subgroups := make([]*gitlab.Group, 0, 12)
+	for i := range 12 {
+		subgroups = append(subgroups, &gitlab.Group{ID: 10 + i, FullPath: fmt.Sprintf("root-group/sub-group-%d", i+1)})
+	}
+
+	fetch := func(t *testing.T, opts ...glclient.Option) []string {
+		t.Helper()
+
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, opts...)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return(shuffled(subgroups), &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups(gomock.Not("1"), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil).
+			Times(len(subgroups))
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		paths := make([]string, 0, len(groups))
+		for _, group := range groups {
+			paths = append(paths, strings.TrimPrefix(group.FullPath, "root-group/"))
+		}
+
+		return paths
+	}
+
+	assert.Equal(t, []string{
+		"root-group", "sub-group-1", "sub-group-10", "sub-group-11", "sub-group-12", "sub-group-2",
+		"sub-group-3", "sub-group-4", "sub-group-5", "sub-group-6", "sub-group-7", "sub-group-8", "sub-group-9",
+	}, fetch(t))
+
+	assert.Equal(t, []string{
+		"root-group", "sub-group-1", "sub-group-2", "sub-group-3", "sub-group-4", "sub-group-5", "sub-group-6",
+		"sub-group-7", "sub-group-8", "sub-group-9", "sub-group-10", "sub-group-11", "sub-group-12",
+	}, fetch(t, glclient.WithNaturalSort()))
+}
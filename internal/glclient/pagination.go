@@ -0,0 +1,64 @@
+package glclient
+
+import (
+	"errors"
+	"net/http"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const keysetPagination = "keyset"
+
+// listPages calls list for every page of a collection and passes each page to handle.
+//
+// Keyset pagination is requested first, because offset pagination degrades on large collections.
+// Endpoints that ignore the request answer with offset pagination headers, which are followed instead.
+// Endpoints that reject it with 405 Method Not Allowed are listed again with offset pagination, and the
+// endpoint is remembered so later listings on the same client skip the failing keyset request.
+func listPages[T any](
+	c *Client,
+	endpoint string,
+	opt *gitlab.ListOptions,
+	list func(options ...gitlab.RequestOptionFunc) ([]T, *gitlab.Response, error),
+	handle func(page []T),
+) error {
+	if _, unsupported := c.keysetUnsupported.Load(endpoint); !unsupported {
+		opt.Pagination = keysetPagination
+	}
+
+	var options []gitlab.RequestOptionFunc
+
+	for {
+		items, resp, err := list(options...)
+		if err != nil {
+			if opt.Pagination == keysetPagination && options == nil && isKeysetUnsupported(err) {
+				c.keysetUnsupported.Store(endpoint, true)
+				opt.Pagination = ""
+
+				continue
+			}
+
+			return err
+		}
+
+		handle(items)
+
+		switch {
+		case resp.NextLink != "":
+			options = []gitlab.RequestOptionFunc{gitlab.WithKeysetPaginationParameters(resp.NextLink)}
+		case resp.NextPage != 0:
+			opt.Page = resp.NextPage
+		default:
+			return nil
+		}
+	}
+}
+
+// isKeysetUnsupported reports whether GitLab rejected a keyset pagination request.
+func isKeysetUnsupported(err error) bool {
+	var errResp *gitlab.ErrorResponse
+
+	return errors.As(err, &errResp) &&
+		errResp.Response != nil &&
+		errResp.Response.StatusCode == http.StatusMethodNotAllowed
+}
@@ -0,0 +1,151 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+const nextLink = "https://gitlab.com/api/v4/groups?cursor=abc&pagination=keyset&per_page=50"
+
+func errMethodNotAllowed() error {
+	return &gitlab.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusMethodNotAllowed},
+		Message:  "Keyset pagination is not yet available for this type of request",
+	}
+}
+
+func TestGetAllGroups_keysetPagination(t *testing.T) {
+	t.Run("follows keyset cursors until the last page", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		var calls int
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				opt *gitlab.ListGroupsOptions,
+				options ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.Group, *gitlab.Response, error) {
+				calls++
+
+				assert.Equal(t, "keyset", opt.Pagination)
+				assert.Len(t, options, 1, "first page carries only the context")
+
+				return []*gitlab.Group{{ID: 1}}, &gitlab.Response{NextLink: nextLink}, nil
+			})
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				opt *gitlab.ListGroupsOptions,
+				_ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.Group, *gitlab.Response, error) {
+				calls++
+
+				assert.Equal(t, "keyset", opt.Pagination)
+				assert.Equal(t, 1, opt.Page, "keyset cursors do not advance the offset page")
+
+				if calls == 2 {
+					return []*gitlab.Group{{ID: 2}}, &gitlab.Response{NextLink: nextLink + "&cursor=def"}, nil
+				}
+
+				return []*gitlab.Group{{ID: 3}}, &gitlab.Response{}, nil
+			}).
+			Times(2)
+
+		groups, err := client.GetAllGroups(t.Context())
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("falls back to offset pagination when keyset is rejected", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		var paginations []string
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				opt *gitlab.ListGroupsOptions,
+				_ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.Group, *gitlab.Response, error) {
+				paginations = append(paginations, opt.Pagination)
+
+				switch {
+				case opt.Pagination == "keyset":
+					return nil, nil, errMethodNotAllowed()
+				case opt.Page == 1:
+					return []*gitlab.Group{{ID: 1}}, &gitlab.Response{NextPage: 2}, nil
+				default:
+					return []*gitlab.Group{{ID: 2}}, &gitlab.Response{}, nil
+				}
+			}).
+			Times(5)
+
+		groups, err := client.GetAllGroups(t.Context())
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		// the rejection is remembered, so the next listing goes straight to offset pagination
+		groups, err = client.GetAllGroups(t.Context())
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		assert.Equal(t, []string{"keyset", "", "", "", ""}, paginations)
+	})
+
+	t.Run("returns other errors without falling back", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			Return(nil, nil, errAPI)
+
+		groups, err := client.GetAllGroups(t.Context())
+		require.ErrorIs(t, err, errAPI)
+		assert.Nil(t, groups)
+	})
+}
+
+func TestGetProjectsRecursively_keysetPagination(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	rootGroup := &gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(rootGroup, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ any,
+			opt *gitlab.ListGroupProjectsOptions,
+			_ ...gitlab.RequestOptionFunc,
+		) ([]*gitlab.Project, *gitlab.Response, error) {
+			assert.Equal(t, "keyset", opt.Pagination)
+			assert.Equal(t, "id", *opt.OrderBy)
+
+			return []*gitlab.Project{{ID: 10}}, &gitlab.Response{NextLink: nextLink}, nil
+		})
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{{ID: 11}}, &gitlab.Response{}, nil)
+
+	projects, err := client.GetProjectsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, 10, projects[0].ID)
+	assert.Equal(t, 11, projects[1].ID)
+}
@@ -0,0 +1,78 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// userNamespaceKind is the namespace kind of projects owned by a user rather than a group.
+const userNamespaceKind = "user"
+
+// personalProjects lists the projects in user namespaces: those of the authenticated user, or of
+// every user when the token belongs to an administrator.
+func (c *Client) personalProjects(ctx context.Context) ([]*gitlab.Project, error) {
+	user, _, err := c.client.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	opt := &gitlab.ListProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+		// keyset pagination of projects is only available when ordered by ID
+		OrderBy: gitlab.Ptr("id"),
+	}
+
+	// administrators see the projects of all users, everyone else only their own
+	if !user.IsAdmin {
+		opt.Owned = gitlab.Ptr(true)
+	}
+
+	var projects []*gitlab.Project
+
+	err = listPages(c, "projects", &opt.ListOptions,
+		func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+			return c.client.Projects.ListProjects(opt, append(options, gitlab.WithContext(ctx))...)
+		},
+		func(page []*gitlab.Project) {
+			for _, project := range page {
+				if project.Namespace != nil && project.Namespace.Kind == userNamespaceKind {
+					projects = append(projects, project)
+				}
+			}
+
+			if c.debug {
+				fmt.Printf("DEBUG: fetched %d projects while listing personal namespaces\n", len(page))
+			}
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// mergeProjects adds the extra projects missing from projects and keeps the result sorted by ID.
+func mergeProjects(projects, extra []*gitlab.Project) []*gitlab.Project {
+	seen := make(map[int]bool, len(projects))
+	for _, project := range projects {
+		seen[project.ID] = true
+	}
+
+	for _, project := range extra {
+		if !seen[project.ID] {
+			seen[project.ID] = true
+			projects = append(projects, project)
+		}
+	}
+
+	slices.SortFunc(projects, func(a, b *gitlab.Project) int { return a.ID - b.ID })
+
+	return projects
+}
@@ -0,0 +1,108 @@
+package glclient_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectsRecursively_personalNamespaces(t *testing.T) {
+	groupProject := &gitlab.Project{
+		ID:                20,
+		PathWithNamespace: "group1/api",
+		Namespace:         &gitlab.ProjectNamespace{Kind: "group", FullPath: "group1"},
+	}
+	personalProject := &gitlab.Project{
+		ID:                5,
+		PathWithNamespace: "alice/scratch",
+		Namespace:         &gitlab.ProjectNamespace{Kind: "user", FullPath: "alice"},
+	}
+
+	expectGroups := func(mockClient *gitlabtesting.TestClient) {
+		mockClient.MockGroups.EXPECT().
+			ListGroups(gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{{ID: 1, FullPath: "group1"}}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("group1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Project{groupProject}, &gitlab.Response{}, nil)
+	}
+
+	// the listing also returns the group project, which must not be reported twice
+	expectPersonal := func(mockClient *gitlabtesting.TestClient, admin bool) {
+		mockClient.MockUsers.EXPECT().
+			CurrentUser(gomock.Any()).
+			Return(&gitlab.User{Username: "alice", IsAdmin: admin}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjects.EXPECT().
+			ListProjects(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(opt *gitlab.ListProjectsOptions, _ ...gitlab.RequestOptionFunc) (
+				[]*gitlab.Project, *gitlab.Response, error,
+			) {
+				if admin {
+					assert.Nil(t, opt.Owned)
+				} else {
+					assert.Equal(t, gitlab.Ptr(true), opt.Owned)
+				}
+
+				return []*gitlab.Project{groupProject, personalProject}, &gitlab.Response{}, nil
+			})
+	}
+
+	t.Run("excludes personal namespaces by default", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectGroups(mockClient)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "")
+		require.NoError(t, err)
+		assert.Equal(t, []*gitlab.Project{groupProject}, projects)
+	})
+
+	t.Run("adds the user's own projects when requested", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithPersonalNamespaces())
+		expectGroups(mockClient)
+		expectPersonal(mockClient, false)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "")
+		require.NoError(t, err)
+		assert.Equal(t, []*gitlab.Project{personalProject, groupProject}, projects)
+	})
+
+	t.Run("adds the projects of all users for administrators", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithPersonalNamespaces())
+		expectGroups(mockClient)
+		expectPersonal(mockClient, true)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "")
+		require.NoError(t, err)
+		assert.Equal(t, []*gitlab.Project{personalProject, groupProject}, projects)
+	})
+
+	t.Run("ignores personal namespaces when starting from a group", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithPersonalNamespaces())
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("group1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, FullPath: "group1"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("group1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("group1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Project{groupProject}, &gitlab.Response{}, nil)
+
+		projects, err := client.GetProjectsRecursively(t.Context(), "group1")
+		require.NoError(t, err)
+		assert.Equal(t, []*gitlab.Project{groupProject}, projects)
+	})
+}
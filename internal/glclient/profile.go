@@ -0,0 +1,96 @@
+package glclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Names of the profiles returned by LookupProfile.
+const (
+	ProfileGentle     = "gentle"
+	ProfileBalanced   = "balanced"
+	ProfileAggressive = "aggressive"
+)
+
+// maxAPIPageSize is the largest page size the GitLab API accepts.
+const maxAPIPageSize = 100
+
+// Profile holds the settings that govern how hard a client drives the GitLab API.
+type Profile struct {
+	Concurrency int     // API requests in flight at once
+	RateLimit   float64 // API requests per second, or 0 for no limit
+	PageSize    int     // items per page of list requests
+	MaxRetries  int     // retries of a request answered with 429 Too Many Requests or a 5xx error
+}
+
+// profiles are the presets selectable by name. Gentle suits shared and self-managed instances,
+// aggressive suits gitlab.com and instances with high rate limits.
+var profiles = map[string]Profile{
+	ProfileGentle:     {Concurrency: 4, RateLimit: 5, PageSize: 20, MaxRetries: 8},
+	ProfileBalanced:   {Concurrency: 20, RateLimit: 25, PageSize: 50, MaxRetries: 5},
+	ProfileAggressive: {Concurrency: 100, RateLimit: 0, PageSize: 100, MaxRetries: 3},
+}
+
+var (
+	ErrUnknownProfile = errors.New("unknown profile")
+	ErrInvalidProfile = errors.New("invalid client settings")
+)
+
+// DefaultProfile returns the settings of a client created without WithProfile.
+func DefaultProfile() Profile {
+	return Profile{
+		Concurrency: defaultNumWorkers,
+		PageSize:    defaultPageSize,
+		MaxRetries:  defaultMaxRetries,
+	}
+}
+
+// ProfileNames returns the names of the profiles, from the gentlest to the most aggressive.
+func ProfileNames() []string {
+	return []string{ProfileGentle, ProfileBalanced, ProfileAggressive}
+}
+
+// LookupProfile returns the profile with the given name, or DefaultProfile for an empty name.
+func LookupProfile(name string) (Profile, error) {
+	if name == "" {
+		return DefaultProfile(), nil
+	}
+
+	profile, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w %q, use one of: %s", ErrUnknownProfile, name,
+			strings.Join(ProfileNames(), ", "))
+	}
+
+	return profile, nil
+}
+
+// Validate checks that the settings are usable: at least one request in flight, a page size GitLab
+// accepts, and no negative rate limit or retry count.
+func (p Profile) Validate() error {
+	switch {
+	case p.Concurrency < 1:
+		return fmt.Errorf("%w: concurrency must be at least 1, got %d", ErrInvalidProfile, p.Concurrency)
+	case p.RateLimit < 0:
+		return fmt.Errorf("%w: rate limit must not be negative, got %g", ErrInvalidProfile, p.RateLimit)
+	case p.PageSize < 1 || p.PageSize > maxAPIPageSize:
+		return fmt.Errorf("%w: page size must be between 1 and %d, got %d",
+			ErrInvalidProfile, maxAPIPageSize, p.PageSize)
+	case p.MaxRetries < 0:
+		return fmt.Errorf("%w: max retries must not be negative, got %d", ErrInvalidProfile, p.MaxRetries)
+	default:
+		return nil
+	}
+}
+
+// String describes the settings as they are documented for the --profile flag.
+func (p Profile) String() string {
+	rate := "no rate limit"
+	if p.RateLimit > 0 {
+		rate = fmt.Sprintf("%g requests/s", p.RateLimit)
+	}
+
+	return fmt.Sprintf("%d concurrent requests, %s, %d items per page, %d retries",
+		p.Concurrency, rate, p.PageSize, p.MaxRetries)
+}
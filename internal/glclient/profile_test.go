@@ -0,0 +1,99 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLookupProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		want glclient.Profile
+	}{
+		{name: "", want: glclient.Profile{Concurrency: 100, RateLimit: 0, PageSize: 50, MaxRetries: 5}},
+		{name: "gentle", want: glclient.Profile{Concurrency: 4, RateLimit: 5, PageSize: 20, MaxRetries: 8}},
+		{name: "balanced", want: glclient.Profile{Concurrency: 20, RateLimit: 25, PageSize: 50, MaxRetries: 5}},
+		{name: "aggressive", want: glclient.Profile{Concurrency: 100, RateLimit: 0, PageSize: 100, MaxRetries: 3}},
+		{name: "Gentle", want: glclient.Profile{Concurrency: 4, RateLimit: 5, PageSize: 20, MaxRetries: 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := glclient.LookupProfile(tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, profile)
+			require.NoError(t, profile.Validate())
+		})
+	}
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := glclient.LookupProfile("turbo")
+		require.ErrorIs(t, err, glclient.ErrUnknownProfile)
+		assert.Contains(t, err.Error(), "gentle, balanced, aggressive")
+	})
+}
+
+func TestProfile_Validate(t *testing.T) {
+	valid := glclient.DefaultProfile()
+
+	tests := []struct {
+		name   string
+		modify func(p *glclient.Profile)
+	}{
+		{name: "no concurrency", modify: func(p *glclient.Profile) { p.Concurrency = 0 }},
+		{name: "negative rate limit", modify: func(p *glclient.Profile) { p.RateLimit = -1 }},
+		{name: "empty pages", modify: func(p *glclient.Profile) { p.PageSize = 0 }},
+		{name: "pages over the API maximum", modify: func(p *glclient.Profile) { p.PageSize = 101 }},
+		{name: "negative retries", modify: func(p *glclient.Profile) { p.MaxRetries = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := valid
+			tt.modify(&profile)
+			require.ErrorIs(t, profile.Validate(), glclient.ErrInvalidProfile)
+		})
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	t.Run("lists pages of the profile's size", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false,
+			glclient.WithProfile(glclient.Profile{Concurrency: 2, PageSize: 20}))
+
+		mockClient.MockGroups.EXPECT().
+			ListGroups(&gitlab.ListGroupsOptions{
+				ListOptions: gitlab.ListOptions{PerPage: 20, Page: 1, Pagination: "keyset"},
+			}, gomock.Any()).
+			Return([]*gitlab.Group{{ID: 1, FullPath: "group1"}}, &gitlab.Response{}, nil)
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "")
+		require.NoError(t, err)
+		assert.Len(t, groups, 1)
+	})
+
+	t.Run("retries as often as the profile allows", func(t *testing.T) {
+		transport := newScriptedTransport(
+			scriptedResponse{status: http.StatusServiceUnavailable},
+			scriptedResponse{status: http.StatusServiceUnavailable},
+			scriptedResponse{status: http.StatusOK, body: versionBody},
+		)
+
+		client, err := glclient.NewClientWithTransport("token", transport, false,
+			glclient.WithBaseURL("https://gitlab.example.com"),
+			glclient.WithProfile(glclient.Profile{Concurrency: 1, RateLimit: 100, PageSize: 50, MaxRetries: 1}))
+		require.NoError(t, err)
+
+		_, err = client.ServerVersion(t.Context())
+		require.Error(t, err)
+		assert.Len(t, transport.requests(), 2)
+	})
+}
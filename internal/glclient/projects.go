@@ -0,0 +1,86 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetProjectAccessTokensForProjects fetches the access tokens of each given project and concatenates
+// them. A project listed more than once, by the same or a different ID or path, is reported once.
+func (c *Client) GetProjectAccessTokensForProjects(
+	ctx context.Context,
+	projectIDs []string,
+	state TokenState,
+) ([]*ProjectAccessTokenWithProject, error) {
+	return forEachProject(projectIDs,
+		func(projectID string) ([]*ProjectAccessTokenWithProject, error) {
+			return c.GetProjectAccessTokens(ctx, projectID, state)
+		},
+		func(t *ProjectAccessTokenWithProject) string { return fmt.Sprintf("%d/%d", t.ProjectID, t.ID) },
+	)
+}
+
+// GetPipelineTriggersForProjects fetches the pipeline triggers of each given project and concatenates
+// them. A project listed more than once, by the same or a different ID or path, is reported once.
+func (c *Client) GetPipelineTriggersForProjects(
+	ctx context.Context,
+	projectIDs []string,
+) ([]*PipelineTriggerWithProject, error) {
+	return forEachProject(projectIDs,
+		func(projectID string) ([]*PipelineTriggerWithProject, error) {
+			return c.GetPipelineTriggers(ctx, projectID)
+		},
+		func(t *PipelineTriggerWithProject) string { return fmt.Sprintf("%d/%d", t.ProjectID, t.ID) },
+	)
+}
+
+// GetProjectVariablesForProjects fetches the CI/CD variables of each given project and concatenates
+// them. A project listed more than once, by the same or a different ID or path, is reported once.
+func (c *Client) GetProjectVariablesForProjects(
+	ctx context.Context,
+	projectIDs []string,
+) ([]*ProjectVariableWithProject, error) {
+	return forEachProject(projectIDs,
+		func(projectID string) ([]*ProjectVariableWithProject, error) {
+			return c.GetProjectVariables(ctx, projectID)
+		},
+		func(v *ProjectVariableWithProject) string {
+			return fmt.Sprintf("%d/%s/%s", v.ProjectID, v.Key, v.EnvironmentScope)
+		},
+	)
+}
+
+// forEachProject calls fetch for every distinct project ID in turn and concatenates the results.
+// The same project given once by ID and once by path is only recognized after fetching it, so items
+// are also deduplicated by key.
+func forEachProject[T any](projectIDs []string, fetch func(projectID string) ([]T, error), key func(T) string) (
+	[]T, error,
+) {
+	var (
+		all         []T
+		seenIDs     = make(map[string]bool, len(projectIDs))
+		seenResults = make(map[string]bool)
+	)
+
+	for _, projectID := range projectIDs {
+		if seenIDs[projectID] {
+			continue
+		}
+
+		seenIDs[projectID] = true
+
+		items, err := fetch(projectID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			if k := key(item); !seenResults[k] {
+				seenResults[k] = true
+				all = append(all, item)
+			}
+		}
+	}
+
+	return all, nil
+}
@@ -0,0 +1,105 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectAccessTokensForProjects(t *testing.T) {
+	api := &gitlab.Project{
+		ID:                1,
+		Name:              "api",
+		PathWithNamespace: "group/api",
+		Namespace:         &gitlab.ProjectNamespace{FullPath: "group"},
+	}
+
+	web := &gitlab.Project{
+		ID:                2,
+		Name:              "web",
+		PathWithNamespace: "group/web",
+		Namespace:         &gitlab.ProjectNamespace{FullPath: "group"},
+	}
+
+	token := func(id int, name string) *gitlab.ProjectAccessToken {
+		return &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{ID: id, Name: name, Active: true}}
+	}
+
+	t.Run("concatenates the tokens of every project", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockProjects.EXPECT().
+			GetProject("1", nil, gomock.Any()).
+			Return(api, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectAccessTokens.EXPECT().
+			ListProjectAccessTokens("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectAccessToken{token(10, "api-deploy")}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjects.EXPECT().
+			GetProject("group/web", nil, gomock.Any()).
+			Return(web, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectAccessTokens.EXPECT().
+			ListProjectAccessTokens("group/web", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectAccessToken{token(20, "web-deploy"), token(21, "web-release")}, &gitlab.Response{}, nil)
+
+		tokens, err := client.GetProjectAccessTokensForProjects(t.Context(),
+			[]string{"1", "group/web"}, glclient.TokenStateActive)
+		require.NoError(t, err)
+
+		require.Len(t, tokens, 3)
+		assert.Equal(t, "api-deploy", tokens[0].Name)
+		assert.Equal(t, "group/api", tokens[0].ProjectPath)
+		assert.Equal(t, "web-deploy", tokens[1].Name)
+		assert.Equal(t, "web-release", tokens[2].Name)
+		assert.Equal(t, "group/web", tokens[2].ProjectPath)
+	})
+
+	t.Run("reports a project listed twice once", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		// the repeated "1" is not fetched again
+		mockClient.MockProjects.EXPECT().
+			GetProject("1", nil, gomock.Any()).
+			Return(api, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectAccessTokens.EXPECT().
+			ListProjectAccessTokens("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectAccessToken{token(10, "api-deploy")}, &gitlab.Response{}, nil)
+
+		// the same project by path is only recognized once fetched
+		mockClient.MockProjects.EXPECT().
+			GetProject("group/api", nil, gomock.Any()).
+			Return(api, &gitlab.Response{}, nil)
+
+		mockClient.MockProjectAccessTokens.EXPECT().
+			ListProjectAccessTokens("group/api", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProjectAccessToken{token(10, "api-deploy")}, &gitlab.Response{}, nil)
+
+		tokens, err := client.GetProjectAccessTokensForProjects(t.Context(),
+			[]string{"1", "group/api", "1"}, glclient.TokenStateActive)
+		require.NoError(t, err)
+
+		require.Len(t, tokens, 1)
+		assert.Equal(t, "api-deploy", tokens[0].Name)
+	})
+
+	t.Run("fails on a project that cannot be read", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockProjects.EXPECT().
+			GetProject("1", nil, gomock.Any()).
+			Return(nil, &gitlab.Response{}, errStatus(http.StatusNotFound))
+
+		_, err := client.GetProjectAccessTokensForProjects(t.Context(),
+			[]string{"1", "group/web"}, glclient.TokenStateActive)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get project 1")
+	})
+}
@@ -0,0 +1,200 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProductionEnvironment is the name of the environment GetUnprotectedProductionRecursively checks.
+const ProductionEnvironment = "production"
+
+// ProjectProtectedEnvironment represents a deployment environment of a project with its protection:
+// who may deploy to it and how many approvals a deployment needs.
+type ProjectProtectedEnvironment struct {
+	ReportType            string   `json:"report_type" csv:"-"`
+	Instance              string   `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID             int      `json:"project_id"`
+	ProjectName           string   `json:"project_name"`
+	ProjectPath           string   `json:"project_path"`
+	ProjectWebURL         string   `json:"project_web_url"`
+	Name                  string   `json:"name"`
+	Protected             bool     `json:"protected"`
+	DeployAccessLevels    []string `json:"deploy_access_levels"`
+	RequiredApprovalCount int      `json:"required_approval_count"` // of the environment and all approval rules
+	ApprovalRules         []string `json:"approval_rules"`
+}
+
+// GetProtectedEnvironmentsRecursively fetches the protected environments of all projects within a
+// group and its subgroups. Projects without protected environments contribute no entries. Reading
+// them requires at least the Maintainer role; other projects are reported as inaccessible.
+func (c *Client) GetProtectedEnvironmentsRecursively(
+	ctx context.Context,
+	groupID string,
+) ([]*ProjectProtectedEnvironment, error) {
+	return c.fetchProtectedEnvironmentsRecursively(ctx, groupID, "protected environments", c.listProtectedEnvironments)
+}
+
+// GetUnprotectedProductionRecursively finds the projects within a group and its subgroups that have
+// a production environment without protection, returning an unprotected entry for each of them.
+// Projects without a production environment are not reported.
+func (c *Client) GetUnprotectedProductionRecursively(
+	ctx context.Context,
+	groupID string,
+) ([]*ProjectProtectedEnvironment, error) {
+	return c.fetchProtectedEnvironmentsRecursively(ctx, groupID, "production protection", c.checkProductionProtection)
+}
+
+func (c *Client) fetchProtectedEnvironmentsRecursively(
+	ctx context.Context,
+	groupID, what string,
+	fetch func(ctx context.Context, projectID string) ([]*ProjectProtectedEnvironment, error),
+) ([]*ProjectProtectedEnvironment, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allEnvironments []*ProjectProtectedEnvironment
+		mu              sync.Mutex
+		wg              sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			environments, err := fetch(ctx, projectID)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, what, err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching %s for project %s: %v\n", what, projectID, err)
+				}
+
+				return
+			}
+
+			for _, environment := range environments {
+				environment.ProjectID = project.ID
+				environment.ProjectName = project.Name
+				environment.ProjectPath = project.PathWithNamespace
+				environment.ProjectWebURL = c.webURL(project.WebURL)
+			}
+
+			mu.Lock()
+			allEnvironments = append(allEnvironments, environments...)
+			mu.Unlock()
+			c.countItems(len(environments))
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, what+" fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProtectedEnvironments(allEnvironments)
+
+	return allEnvironments, nil
+}
+
+func (c *Client) listProtectedEnvironments(
+	ctx context.Context,
+	projectID string,
+) ([]*ProjectProtectedEnvironment, error) {
+	var allEnvironments []*ProjectProtectedEnvironment
+
+	opt := &gitlab.ListProtectedEnvironmentsOptions{
+		PerPage: c.pageSize,
+		Page:    1,
+	}
+
+	for {
+		environments, resp, err := c.client.ProtectedEnvironments.ListProtectedEnvironments(
+			projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list protected environments: %w", err)
+		}
+
+		for _, environment := range environments {
+			allEnvironments = append(allEnvironments, newProtectedEnvironment(environment))
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d protected environments for project %s\n", len(environments), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allEnvironments, nil
+}
+
+// checkProductionProtection returns an unprotected production entry if the project has a production
+// environment that is not protected, and nothing otherwise.
+func (c *Client) checkProductionProtection(
+	ctx context.Context,
+	projectID string,
+) ([]*ProjectProtectedEnvironment, error) {
+	environments, _, err := c.client.Environments.ListEnvironments(projectID, &gitlab.ListEnvironmentsOptions{
+		Name: gitlab.Ptr(ProductionEnvironment),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	if len(environments) == 0 {
+		return nil, nil
+	}
+
+	protected, err := c.listProtectedEnvironments(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, environment := range protected {
+		if environment.Name == ProductionEnvironment {
+			return nil, nil
+		}
+	}
+
+	return []*ProjectProtectedEnvironment{{
+		ReportType: ReportTypeProjectProtectedEnvironment,
+		Name:       ProductionEnvironment,
+	}}, nil
+}
+
+func newProtectedEnvironment(environment *gitlab.ProtectedEnvironment) *ProjectProtectedEnvironment {
+	wrapped := &ProjectProtectedEnvironment{
+		ReportType:            ReportTypeProjectProtectedEnvironment,
+		Name:                  environment.Name,
+		Protected:             true,
+		RequiredApprovalCount: environment.RequiredApprovalCount,
+	}
+
+	for _, access := range environment.DeployAccessLevels {
+		wrapped.DeployAccessLevels = append(wrapped.DeployAccessLevels, access.AccessLevelDescription)
+	}
+
+	for _, rule := range environment.ApprovalRules {
+		wrapped.RequiredApprovalCount += rule.RequiredApprovalCount
+		wrapped.ApprovalRules = append(wrapped.ApprovalRules,
+			fmt.Sprintf("%s: %d", rule.AccessLevelDescription, rule.RequiredApprovalCount))
+	}
+
+	return wrapped
+}
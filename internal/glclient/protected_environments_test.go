@@ -0,0 +1,149 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProtectedEnvironmentsRecursively(t *testing.T) {
+	t.Run("lists the protected environments of every project", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectTopicProjects(mockClient)
+
+		mockClient.MockProtectedEnvironments.EXPECT().
+			ListProtectedEnvironments("10", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProtectedEnvironment{
+				{
+					Name: "staging",
+					DeployAccessLevels: []*gitlab.EnvironmentAccessDescription{
+						{AccessLevel: gitlab.DeveloperPermissions, AccessLevelDescription: "Developers + Maintainers"},
+					},
+				},
+				{
+					Name:                  "production",
+					RequiredApprovalCount: 1,
+					DeployAccessLevels: []*gitlab.EnvironmentAccessDescription{
+						{AccessLevel: gitlab.MaintainerPermissions, AccessLevelDescription: "Maintainers"},
+						{GroupID: 5, AccessLevelDescription: "release-managers"},
+					},
+					ApprovalRules: []*gitlab.EnvironmentApprovalRule{
+						{GroupID: 6, AccessLevelDescription: "qa", RequiredApprovalCount: 2},
+					},
+				},
+			}, &gitlab.Response{}, nil)
+		// projects without protected environments contribute nothing
+		mockClient.MockProtectedEnvironments.EXPECT().
+			ListProtectedEnvironments(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProtectedEnvironment{}, &gitlab.Response{}, nil).
+			Times(3)
+
+		environments, err := client.GetProtectedEnvironmentsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []*glclient.ProjectProtectedEnvironment{
+			{
+				ReportType:            glclient.ReportTypeProjectProtectedEnvironment,
+				ProjectID:             10,
+				ProjectName:           "payments",
+				ProjectPath:           "root-group/payments",
+				Name:                  "production",
+				Protected:             true,
+				DeployAccessLevels:    []string{"Maintainers", "release-managers"},
+				RequiredApprovalCount: 3,
+				ApprovalRules:         []string{"qa: 2"},
+			},
+			{
+				ReportType:         glclient.ReportTypeProjectProtectedEnvironment,
+				ProjectID:          10,
+				ProjectName:        "payments",
+				ProjectPath:        "root-group/payments",
+				Name:               "staging",
+				Protected:          true,
+				DeployAccessLevels: []string{"Developers + Maintainers"},
+			},
+		}, environments)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("skips projects that fail", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expectTopicProjects(mockClient)
+
+		mockClient.MockProtectedEnvironments.EXPECT().
+			ListProtectedEnvironments("12", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+		mockClient.MockProtectedEnvironments.EXPECT().
+			ListProtectedEnvironments(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*gitlab.ProtectedEnvironment{}, &gitlab.Response{}, nil).
+			Times(3)
+
+		environments, err := client.GetProtectedEnvironmentsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Empty(t, environments)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "project", Path: "root-group/billing", Reason: "protected environments: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
+
+func TestGetUnprotectedProductionRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	expectTopicProjects(mockClient)
+
+	production := []*gitlab.Environment{{ID: 1, Name: glclient.ProductionEnvironment}}
+
+	// payments protects production, docs does not, billing protects only staging, and sandbox has no
+	// production environment at all
+	for _, projectID := range []string{"10", "11", "12"} {
+		mockClient.MockEnvironments.EXPECT().
+			ListEnvironments(projectID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ any, opt *gitlab.ListEnvironmentsOptions, _ ...gitlab.RequestOptionFunc,
+			) ([]*gitlab.Environment, *gitlab.Response, error) {
+				assert.Equal(t, glclient.ProductionEnvironment, *opt.Name)
+
+				return production, &gitlab.Response{}, nil
+			})
+	}
+
+	mockClient.MockEnvironments.EXPECT().
+		ListEnvironments("13", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Environment{}, &gitlab.Response{}, nil)
+
+	mockClient.MockProtectedEnvironments.EXPECT().
+		ListProtectedEnvironments("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.ProtectedEnvironment{{Name: "production"}}, &gitlab.Response{}, nil)
+	mockClient.MockProtectedEnvironments.EXPECT().
+		ListProtectedEnvironments("11", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.ProtectedEnvironment{}, &gitlab.Response{}, nil)
+	mockClient.MockProtectedEnvironments.EXPECT().
+		ListProtectedEnvironments("12", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.ProtectedEnvironment{{Name: "staging"}}, &gitlab.Response{}, nil)
+
+	environments, err := client.GetUnprotectedProductionRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, []*glclient.ProjectProtectedEnvironment{
+		{
+			ReportType:  glclient.ReportTypeProjectProtectedEnvironment,
+			ProjectID:   12,
+			ProjectName: "billing",
+			ProjectPath: "root-group/billing",
+			Name:        "production",
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectProtectedEnvironment,
+			ProjectID:   11,
+			ProjectName: "docs",
+			ProjectPath: "root-group/docs",
+			Name:        "production",
+		},
+	}, environments)
+}
@@ -0,0 +1,216 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ErrPushRulesUnavailable is returned when no group or project lets the token read its push rules.
+var ErrPushRulesUnavailable = errors.New(
+	"push rules are not available: they require GitLab Premium or Ultimate, " +
+		"and the token must be able to read the groups and projects")
+
+// PushRulesWithSource represents the push rules of a project or group with source identification.
+type PushRulesWithSource struct {
+	ReportType            string `json:"report_type" csv:"-"`
+	Instance              string `json:"instance,omitempty" csv:"omitempty"`
+	Source                string `json:"source"` // "project" or "group"
+	SourceID              int    `json:"source_id"`
+	SourceName            string `json:"source_name"`
+	SourcePath            string `json:"source_path"`
+	SourceWebURL          string `json:"source_web_url"`
+	Configured            bool   `json:"configured"` // false when no push rules are set
+	RejectUnsignedCommits bool   `json:"reject_unsigned_commits"`
+	PreventSecrets        bool   `json:"prevent_secrets"`
+	BranchNameRegex       string `json:"branch_name_regex"`
+	AuthorEmailRegex      string `json:"author_email_regex"`
+}
+
+// pushRulesResults collects the push rules fetched by concurrent workers.
+type pushRulesResults struct {
+	mu        sync.Mutex
+	rules     []*PushRulesWithSource
+	forbidden int
+}
+
+// GetPushRulesRecursively fetches the push rules of all groups and projects within a group and its
+// subgroups. Groups and projects without push rules are reported as not configured. Those that do not
+// let the token read their push rules, which requires at least the Maintainer role, are skipped and
+// reported by Inaccessible, unless none does, in which case ErrPushRulesUnavailable is returned.
+func (c *Client) GetPushRulesRecursively(ctx context.Context, groupID string) ([]*PushRulesWithSource, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive push rules fetch for group ID %s\n", groupID)
+	}
+
+	groups, err := c.GetGroupsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups recursively: %w", err)
+	}
+
+	projects := c.projectsForGroups(ctx, groups)
+	if err := c.interrupted(ctx, "project fetch"); err != nil {
+		return nil, err
+	}
+
+	var (
+		results pushRulesResults
+		wg      sync.WaitGroup
+	)
+
+	for _, group := range groups {
+		wg.Add(1)
+
+		groupID := strconv.Itoa(group.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			rules, err := c.getGroupPushRules(ctx, groupID, group)
+			c.addPushRules(ctx, &results, "group", group.FullPath, rules, err)
+		})
+	}
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			rules, err := c.getProjectPushRules(ctx, projectID, project)
+			c.addPushRules(ctx, &results, "project", project.PathWithNamespace, rules, err)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "push rules fetch"); err != nil {
+		return nil, err
+	}
+
+	// GitLab answers 403 for every group and project when push rules are not part of the license
+	if sources := len(groups) + len(projects); sources > 0 && results.forbidden == sources {
+		return nil, ErrPushRulesUnavailable
+	}
+
+	c.sortPushRules(results.rules)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive push rules fetch, found %d sources\n", len(results.rules))
+	}
+
+	return results.rules, nil
+}
+
+func (c *Client) getGroupPushRules(
+	ctx context.Context,
+	groupID string,
+	group *gitlab.Group,
+) (*PushRulesWithSource, error) {
+	wrapped := &PushRulesWithSource{
+		ReportType:   ReportTypeGroupPushRules,
+		Source:       "group",
+		SourceID:     group.ID,
+		SourceName:   group.Name,
+		SourcePath:   group.FullPath,
+		SourceWebURL: c.webURL(group.WebURL),
+	}
+
+	rules, _, err := c.client.Groups.GetGroupPushRules(groupID, gitlab.WithContext(ctx))
+	if err != nil {
+		// GitLab answers 404 for a group without push rules
+		if isNotFound(err) {
+			return wrapped, nil
+		}
+
+		return nil, fmt.Errorf("failed to get group push rules: %w", err)
+	}
+
+	if rules != nil && rules.ID != 0 {
+		wrapped.Configured = true
+		wrapped.RejectUnsignedCommits = rules.RejectUnsignedCommits
+		wrapped.PreventSecrets = rules.PreventSecrets
+		wrapped.BranchNameRegex = rules.BranchNameRegex
+		wrapped.AuthorEmailRegex = rules.AuthorEmailRegex
+	}
+
+	return wrapped, nil
+}
+
+func (c *Client) getProjectPushRules(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) (*PushRulesWithSource, error) {
+	wrapped := &PushRulesWithSource{
+		ReportType:   ReportTypeProjectPushRules,
+		Source:       "project",
+		SourceID:     project.ID,
+		SourceName:   project.Name,
+		SourcePath:   project.PathWithNamespace,
+		SourceWebURL: c.webURL(project.WebURL),
+	}
+
+	rules, _, err := c.client.Projects.GetProjectPushRules(projectID, gitlab.WithContext(ctx))
+	if err != nil {
+		if isNotFound(err) {
+			return wrapped, nil
+		}
+
+		return nil, fmt.Errorf("failed to get project push rules: %w", err)
+	}
+
+	// GitLab answers null for a project without push rules
+	if rules != nil && rules.ID != 0 {
+		wrapped.Configured = true
+		wrapped.RejectUnsignedCommits = rules.RejectUnsignedCommits
+		wrapped.PreventSecrets = rules.PreventSecrets
+		wrapped.BranchNameRegex = rules.BranchNameRegex
+		wrapped.AuthorEmailRegex = rules.AuthorEmailRegex
+	}
+
+	return wrapped, nil
+}
+
+// addPushRules adds the push rules of the group or project at path to results, or records why
+// they could not be read.
+func (c *Client) addPushRules(
+	ctx context.Context,
+	results *pushRulesResults,
+	kind, path string,
+	rules *PushRulesWithSource,
+	err error,
+) {
+	if err != nil {
+		if isForbidden(err) {
+			results.mu.Lock()
+			results.forbidden++
+			results.mu.Unlock()
+		}
+
+		c.recordInaccessible(ctx, kind, path, kind+" push rules", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching push rules for %s %s: %v\n", kind, path, err)
+		}
+
+		return
+	}
+
+	results.mu.Lock()
+	results.rules = append(results.rules, rules)
+	results.mu.Unlock()
+	c.countItems(1)
+}
+
+// isNotFound reports whether err is a 404 response of the GitLab API, which the client returns as its
+// ErrNotFound sentinel rather than an ErrorResponse.
+func isNotFound(err error) bool {
+	return errors.Is(err, gitlab.ErrNotFound)
+}
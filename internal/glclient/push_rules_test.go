@@ -0,0 +1,149 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetPushRulesRecursively(t *testing.T) {
+	rootGroup := &gitlab.Group{
+		ID:       1,
+		Name:     "root-group",
+		FullPath: "root-group",
+		WebURL:   "https://gitlab.com/groups/root-group",
+	}
+
+	projects := []*gitlab.Project{
+		{
+			ID:                10,
+			Name:              "api",
+			PathWithNamespace: "root-group/api",
+			WebURL:            "https://gitlab.com/root-group/api",
+		},
+		{ID: 11, Name: "docs", PathWithNamespace: "root-group/docs"},
+		{ID: 12, Name: "legacy", PathWithNamespace: "root-group/legacy"},
+		{ID: 13, Name: "secret", PathWithNamespace: "root-group/secret"},
+	}
+
+	t.Run("fetches group and project push rules", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroupPushRules("1", gomock.Any()).
+			Return(nil, &gitlab.Response{}, errStatus(http.StatusNotFound))
+
+		mockClient.MockProjects.EXPECT().
+			GetProjectPushRules("10", gomock.Any()).
+			Return(&gitlab.ProjectPushRules{
+				ID:                    5,
+				ProjectID:             10,
+				RejectUnsignedCommits: true,
+				PreventSecrets:        true,
+				BranchNameRegex:       `^(feat|fix)/`,
+				AuthorEmailRegex:      `@example\.com$`,
+			}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjects.EXPECT().
+			GetProjectPushRules("11", gomock.Any()).
+			Return(&gitlab.ProjectPushRules{}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjects.EXPECT().
+			GetProjectPushRules("12", gomock.Any()).
+			Return(&gitlab.ProjectPushRules{ID: 6, ProjectID: 12, DenyDeleteTag: true}, &gitlab.Response{}, nil)
+
+		mockClient.MockProjects.EXPECT().
+			GetProjectPushRules("13", gomock.Any()).
+			Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+		rules, err := client.GetPushRulesRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		assert.Equal(t, []*glclient.PushRulesWithSource{
+			{
+				ReportType:   glclient.ReportTypeGroupPushRules,
+				Source:       "group",
+				SourceID:     1,
+				SourceName:   "root-group",
+				SourcePath:   "root-group",
+				SourceWebURL: "https://gitlab.com/groups/root-group",
+			},
+			{
+				ReportType:            glclient.ReportTypeProjectPushRules,
+				Source:                "project",
+				SourceID:              10,
+				SourceName:            "api",
+				SourcePath:            "root-group/api",
+				SourceWebURL:          "https://gitlab.com/root-group/api",
+				Configured:            true,
+				RejectUnsignedCommits: true,
+				PreventSecrets:        true,
+				BranchNameRegex:       `^(feat|fix)/`,
+				AuthorEmailRegex:      `@example\.com$`,
+			},
+			{
+				ReportType: glclient.ReportTypeProjectPushRules,
+				Source:     "project",
+				SourceID:   11,
+				SourceName: "docs",
+				SourcePath: "root-group/docs",
+			},
+			{
+				ReportType: glclient.ReportTypeProjectPushRules,
+				Source:     "project",
+				SourceID:   12,
+				SourceName: "legacy",
+				SourcePath: "root-group/legacy",
+				Configured: true,
+			},
+		}, rules)
+
+		inaccessible := client.Inaccessible()
+		require.Len(t, inaccessible, 1)
+		assert.Equal(t, "root-group/secret", inaccessible[0].Path)
+	})
+
+	t.Run("fails when push rules are forbidden everywhere", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(rootGroup, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects[:1], &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroupPushRules("1", gomock.Any()).
+			Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+		mockClient.MockProjects.EXPECT().
+			GetProjectPushRules("10", gomock.Any()).
+			Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+		_, err := client.GetPushRulesRecursively(t.Context(), "1")
+		require.ErrorIs(t, err, glclient.ErrPushRulesUnavailable)
+	})
+}
@@ -0,0 +1,71 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var ErrRateLimitUnavailable = errors.New(
+	"the GitLab instance reports no rate limit headers, its rate limits may be disabled")
+
+// RateLimitStatus is the API request budget of the token, as reported by the RateLimit headers of a
+// response.
+type RateLimitStatus struct {
+	ReportType string `json:"report_type" csv:"-"`
+	Limit      int    `json:"limit"`
+	Remaining  int    `json:"remaining"`
+	// Observed is the number of requests counted in the current window.
+	Observed int        `json:"observed"`
+	ResetAt  *time.Time `json:"reset_at"`
+}
+
+// GetRateLimitStatus makes a single lightweight request, reading the version of the instance, and
+// returns the rate limit budget reported in its response headers. Instances that send no rate limit
+// headers are reported with ErrRateLimitUnavailable.
+func (c *Client) GetRateLimitStatus(ctx context.Context) (*RateLimitStatus, error) {
+	_, resp, err := c.client.Version.GetVersion(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab version: %w", err)
+	}
+
+	if resp == nil || resp.Response == nil {
+		return nil, ErrRateLimitUnavailable
+	}
+
+	return rateLimitStatus(resp.Header)
+}
+
+// rateLimitStatus parses the RateLimit headers of a response. The reset time is given as a Unix
+// timestamp.
+func rateLimitStatus(header http.Header) (*RateLimitStatus, error) {
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: RateLimit-Limit %q", ErrRateLimitUnavailable, header.Get("RateLimit-Limit"))
+	}
+
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: RateLimit-Remaining %q",
+			ErrRateLimitUnavailable, header.Get("RateLimit-Remaining"))
+	}
+
+	status := &RateLimitStatus{ReportType: ReportTypeRateLimit, Limit: limit, Remaining: remaining}
+
+	// the other headers are informative and skipped when missing
+	if observed, err := strconv.Atoi(header.Get("RateLimit-Observed")); err == nil {
+		status.Observed = observed
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		resetAt := time.Unix(reset, 0).UTC()
+		status.ResetAt = &resetAt
+	}
+
+	return status, nil
+}
@@ -0,0 +1,87 @@
+package glclient_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func rateLimitResponse(header http.Header) *gitlab.Response {
+	return &gitlab.Response{Response: &http.Response{StatusCode: http.StatusOK, Header: header}}
+}
+
+func TestGetRateLimitStatus(t *testing.T) {
+	t.Run("reports the rate limit headers", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		header := http.Header{}
+		header.Set("RateLimit-Limit", "2000")
+		header.Set("RateLimit-Remaining", "1998")
+		header.Set("RateLimit-Observed", "2")
+		header.Set("RateLimit-Reset", "1792152060")
+
+		mockClient.MockVersion.EXPECT().
+			GetVersion(gomock.Any()).
+			Return(&gitlab.Version{Version: "18.4.0"}, rateLimitResponse(header), nil)
+
+		status, err := client.GetRateLimitStatus(t.Context())
+		require.NoError(t, err)
+
+		resetAt := time.Unix(1792152060, 0).UTC()
+		assert.Equal(t, &glclient.RateLimitStatus{
+			ReportType: glclient.ReportTypeRateLimit,
+			Limit:      2000,
+			Remaining:  1998,
+			Observed:   2,
+			ResetAt:    &resetAt,
+		}, status)
+	})
+
+	t.Run("skips missing optional headers", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		header := http.Header{}
+		header.Set("RateLimit-Limit", "600")
+		header.Set("RateLimit-Remaining", "0")
+
+		mockClient.MockVersion.EXPECT().
+			GetVersion(gomock.Any()).
+			Return(&gitlab.Version{}, rateLimitResponse(header), nil)
+
+		status, err := client.GetRateLimitStatus(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 600, status.Limit)
+		assert.Zero(t, status.Remaining)
+		assert.Nil(t, status.ResetAt)
+	})
+
+	t.Run("reports an instance without rate limit headers", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockVersion.EXPECT().
+			GetVersion(gomock.Any()).
+			Return(&gitlab.Version{}, rateLimitResponse(http.Header{}), nil)
+
+		_, err := client.GetRateLimitStatus(t.Context())
+		require.ErrorIs(t, err, glclient.ErrRateLimitUnavailable)
+	})
+
+	t.Run("reports request errors", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockVersion.EXPECT().
+			GetVersion(gomock.Any()).
+			Return(nil, nil, errors.New("connection refused"))
+
+		_, err := client.GetRateLimitStatus(t.Context())
+		require.Error(t, err)
+		require.NotErrorIs(t, err, glclient.ErrRateLimitUnavailable)
+	})
+}
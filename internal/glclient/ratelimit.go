@@ -0,0 +1,107 @@
+package glclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxSecondaryRateLimitRetries bounds the retries of a single request rejected by the secondary rate limit.
+	maxSecondaryRateLimitRetries = 3
+	// maxSecondaryRateLimitWait is the longest Retry-After delay waited for; longer ones fail the request.
+	maxSecondaryRateLimitWait = time.Minute
+)
+
+// secondaryRateLimitTransport retries requests rejected by GitLab's secondary rate limit, which answers
+// with 403 Forbidden and a Retry-After header instead of 429 Too Many Requests. The GitLab client only
+// retries 429 and 5xx responses itself and would otherwise treat the group or project as inaccessible.
+type secondaryRateLimitTransport struct {
+	base  http.RoundTripper
+	debug bool
+}
+
+func (t *secondaryRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || attempt == maxSecondaryRateLimitRetries {
+			return resp, err
+		}
+
+		delay, ok := secondaryRateLimitDelay(resp, time.Now())
+		if !ok || delay > maxSecondaryRateLimitWait {
+			return resp, nil
+		}
+
+		retry, ok := rewindRequest(req)
+		if !ok {
+			return resp, nil
+		}
+
+		// the connection is only reused once the body was read to the end
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if t.debug {
+			fmt.Printf("DEBUG: secondary rate limit hit for %s, retrying in %s\n", req.URL.Path, delay)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req = retry
+	}
+}
+
+// secondaryRateLimitDelay returns the delay requested by a 403 response carrying a Retry-After header,
+// given either in seconds or as an HTTP date.
+func secondaryRateLimitDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// rewindRequest returns a copy of req that can be sent again, or false if its body cannot be replayed.
+func rewindRequest(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Body = body
+
+	return retry, true
+}
@@ -0,0 +1,87 @@
+package glclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitedServer serves a project whose first variables request is rejected with 403 Forbidden
+// and the given Retry-After header, if any. It returns the number of variables requests received.
+func rateLimitedServer(t *testing.T, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/10", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"id": 10, "path_with_namespace": "root-group/api", "namespace": {"full_path": "root-group"}
+		}`))
+	})
+	mux.HandleFunc("/api/v4/projects/10/variables", func(w http.ResponseWriter, _ *http.Request) {
+		if hits.Add(1) == 1 {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "403 Forbidden - retry later"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"key": "TOKEN"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, &hits
+}
+
+func TestClient_secondaryRateLimit(t *testing.T) {
+	t.Run("retries a 403 with Retry-After once after the delay", func(t *testing.T) {
+		server, hits := rateLimitedServer(t, "1")
+
+		client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+		require.NoError(t, err)
+
+		start := time.Now()
+
+		variables, err := client.GetProjectVariables(t.Context(), "10")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TOKEN"}, variableKeys(variables))
+
+		assert.Equal(t, int32(2), hits.Load())
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("fails fast on a 403 without Retry-After", func(t *testing.T) {
+		server, hits := rateLimitedServer(t, "")
+
+		client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+		require.NoError(t, err)
+
+		_, err = client.GetProjectVariables(t.Context(), "10")
+		require.Error(t, err)
+		assert.Equal(t, int32(1), hits.Load())
+	})
+
+	t.Run("fails fast when Retry-After is too long", func(t *testing.T) {
+		server, hits := rateLimitedServer(t, "3600")
+
+		client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+		require.NoError(t, err)
+
+		_, err = client.GetProjectVariables(t.Context(), "10")
+		require.Error(t, err)
+		assert.Equal(t, int32(1), hits.Load())
+	})
+}
@@ -0,0 +1,205 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectRegistry represents what a project publishes to the container and package registries: the
+// number of container repositories, the tags they hold in total, and the number of packages.
+type ProjectRegistry struct {
+	ReportType               string `json:"report_type" csv:"-"`
+	Instance                 string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID                int    `json:"project_id"`
+	ProjectName              string `json:"project_name"`
+	ProjectPath              string `json:"project_path"`
+	ProjectWebURL            string `json:"project_web_url"`
+	ContainerRegistryEnabled bool   `json:"container_registry_enabled"`
+	Repositories             int    `json:"repositories"`
+	Tags                     int    `json:"tags"`
+	PackageRegistryEnabled   bool   `json:"package_registry_enabled"`
+	Packages                 int    `json:"packages"`
+}
+
+// Empty reports whether the project holds neither container images nor packages.
+func (r *ProjectRegistry) Empty() bool {
+	return r.Repositories == 0 && r.Packages == 0
+}
+
+// GetProjectRegistriesRecursively counts the container repositories, tags, and packages of all
+// projects within a group and its subgroups. Registries disabled for a project, or for the whole
+// instance, are reported as disabled without being listed. Listing a registry requires at least the
+// Reporter role on private projects; other projects are reported as inaccessible.
+func (c *Client) GetProjectRegistriesRecursively(ctx context.Context, groupID string) ([]*ProjectRegistry, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allRegistries []*ProjectRegistry
+		mu            sync.Mutex
+		wg            sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			registry, what, err := c.countRegistryForProject(ctx, projectID, project)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, what, err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching %s for project %s: %v\n", what, projectID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allRegistries = append(allRegistries, registry)
+			mu.Unlock()
+			c.countItems(1)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "registry fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectRegistries(allRegistries)
+
+	return allRegistries, nil
+}
+
+// countRegistryForProject counts the registry contents of a project. On failure, it also returns
+// which registry could not be listed.
+func (c *Client) countRegistryForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) (*ProjectRegistry, string, error) {
+	registry := &ProjectRegistry{
+		ReportType:               ReportTypeProjectRegistry,
+		ProjectID:                project.ID,
+		ProjectName:              project.Name,
+		ProjectPath:              project.PathWithNamespace,
+		ProjectWebURL:            c.webURL(project.WebURL),
+		ContainerRegistryEnabled: project.ContainerRegistryAccessLevel != gitlab.DisabledAccessControl,
+		PackageRegistryEnabled:   project.PackagesEnabled,
+	}
+
+	if registry.ContainerRegistryEnabled {
+		repositories, tags, err := c.countContainerRepositories(ctx, projectID)
+
+		switch {
+		// GitLab answers 404 when the container registry is not configured for the instance
+		case isNotFound(err):
+			registry.ContainerRegistryEnabled = false
+		case err != nil:
+			return nil, "container registry", err
+		default:
+			registry.Repositories, registry.Tags = repositories, tags
+		}
+	}
+
+	if registry.PackageRegistryEnabled {
+		packages, err := c.countPackages(ctx, projectID)
+
+		switch {
+		case isNotFound(err):
+			registry.PackageRegistryEnabled = false
+		case err != nil:
+			return nil, "package registry", err
+		default:
+			registry.Packages = packages
+		}
+	}
+
+	return registry, "", nil
+}
+
+func (c *Client) countContainerRepositories(ctx context.Context, projectID string) (int, int, error) {
+	var repositories, tags int
+
+	opt := &gitlab.ListRegistryRepositoriesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+		TagsCount: gitlab.Ptr(true),
+	}
+
+	for {
+		page, resp, err := c.client.ContainerRegistry.ListProjectRegistryRepositories(projectID, opt,
+			gitlab.WithContext(ctx))
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to list container repositories: %w", err)
+		}
+
+		for _, repository := range page {
+			repositories++
+			tags += repository.TagsCount
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d container repositories for project %s\n", len(page), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return repositories, tags, nil
+}
+
+func (c *Client) countPackages(ctx context.Context, projectID string) (int, error) {
+	var packages int
+
+	opt := &gitlab.ListProjectPackagesOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		page, resp, err := c.client.Packages.ListProjectPackages(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return 0, fmt.Errorf("failed to list packages: %w", err)
+		}
+
+		// the total saves listing every page, but GitLab omits it for very large collections
+		if resp.TotalItems > 0 {
+			return resp.TotalItems, nil
+		}
+
+		packages += len(page)
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d packages for project %s\n", len(page), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return packages, nil
+}
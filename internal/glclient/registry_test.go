@@ -0,0 +1,103 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectRegistriesRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			{
+				ID: 10, Name: "api", PathWithNamespace: "root-group/api",
+				ContainerRegistryAccessLevel: gitlab.EnabledAccessControl, PackagesEnabled: true,
+			},
+			{
+				ID: 11, Name: "docs", PathWithNamespace: "root-group/docs",
+				ContainerRegistryAccessLevel: gitlab.DisabledAccessControl,
+			},
+			{
+				ID: 12, Name: "sdk", PathWithNamespace: "root-group/sdk",
+				ContainerRegistryAccessLevel: gitlab.PrivateAccessControl, PackagesEnabled: true,
+			},
+			{
+				ID: 13, Name: "secret", PathWithNamespace: "root-group/secret",
+				ContainerRegistryAccessLevel: gitlab.PrivateAccessControl,
+			},
+		}, &gitlab.Response{}, nil)
+
+	mockClient.MockContainerRegistry.EXPECT().
+		ListProjectRegistryRepositories("10", &gitlab.ListRegistryRepositoriesOptions{
+			ListOptions: gitlab.ListOptions{PerPage: 50, Page: 1},
+			TagsCount:   gitlab.Ptr(true),
+		}, gomock.Any()).
+		Return([]*gitlab.RegistryRepository{{ID: 1, TagsCount: 12}}, &gitlab.Response{NextPage: 2}, nil)
+	mockClient.MockContainerRegistry.EXPECT().
+		ListProjectRegistryRepositories("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.RegistryRepository{{ID: 2, TagsCount: 3}}, &gitlab.Response{}, nil)
+	mockClient.MockPackages.EXPECT().
+		ListProjectPackages("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Package{{ID: 1}}, &gitlab.Response{TotalItems: 7}, nil)
+
+	// the instance has no container registry
+	mockClient.MockContainerRegistry.EXPECT().
+		ListProjectRegistryRepositories("12", gomock.Any(), gomock.Any()).
+		Return(nil, nil, errStatus(http.StatusNotFound))
+	mockClient.MockPackages.EXPECT().
+		ListProjectPackages("12", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Package{{ID: 2}, {ID: 3}}, &gitlab.Response{}, nil)
+
+	mockClient.MockContainerRegistry.EXPECT().
+		ListProjectRegistryRepositories("13", gomock.Any(), gomock.Any()).
+		Return(nil, nil, errStatus(http.StatusForbidden))
+
+	registries, err := client.GetProjectRegistriesRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, []*glclient.ProjectRegistry{
+		{
+			ReportType:               glclient.ReportTypeProjectRegistry,
+			ProjectID:                10,
+			ProjectName:              "api",
+			ProjectPath:              "root-group/api",
+			ContainerRegistryEnabled: true,
+			Repositories:             2,
+			Tags:                     15,
+			PackageRegistryEnabled:   true,
+			Packages:                 7,
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectRegistry,
+			ProjectID:   11,
+			ProjectName: "docs",
+			ProjectPath: "root-group/docs",
+		},
+		{
+			ReportType:             glclient.ReportTypeProjectRegistry,
+			ProjectID:              12,
+			ProjectName:            "sdk",
+			ProjectPath:            "root-group/sdk",
+			PackageRegistryEnabled: true,
+			Packages:               2,
+		},
+	}, registries)
+	assert.Equal(t, []glclient.Inaccessible{
+		{Kind: "project", Path: "root-group/secret", Reason: "container registry: 403 Forbidden"},
+	}, client.Inaccessible())
+}
@@ -0,0 +1,41 @@
+package glclient
+
+// Report types identify the kind of a report item in its JSON output, so that items of different
+// reports can be told apart once they are merged into one stream. They are not written to CSV, where
+// every file holds a single report.
+const (
+	ReportTypeGroupAccessToken            = "group_access_token"
+	ReportTypeProjectAccessToken          = "project_access_token"
+	ReportTypeImpersonationToken          = "impersonation_token"
+	ReportTypePipelineTrigger             = "pipeline_trigger"
+	ReportTypeProjectVariable             = "project_variable"
+	ReportTypeGroupVariable               = "group_variable"
+	ReportTypeProjectAccessRequest        = "project_access_request"
+	ReportTypeGroupAccessRequest          = "group_access_request"
+	ReportTypeProjectBadge                = "project_badge"
+	ReportTypeGroupBadge                  = "group_badge"
+	ReportTypeProjectMilestone            = "project_milestone"
+	ReportTypeGroupMilestone              = "group_milestone"
+	ReportTypeGroupEpic                   = "group_epic"
+	ReportTypeProjectActivity             = "project_activity"
+	ReportTypeProjectCISettings           = "project_ci_settings"
+	ReportTypeProjectDefaultBranch        = "project_default_branch"
+	ReportTypeProjectFork                 = "project_fork"
+	ReportTypeProjectIntegration          = "project_integration"
+	ReportTypeProjectJobTokenScope        = "project_job_token_scope"
+	ReportTypeProjectProtectedEnvironment = "project_protected_environment"
+	ReportTypeProjectRunners              = "project_runners"
+	ReportTypeProjectRunnerSettings       = "project_runner_settings"
+	ReportTypeProjectClusterAgent         = "project_cluster_agent"
+	ReportTypeProjectSecurityConfig       = "project_security_config"
+	ReportTypeProjectComplianceFrameworks = "project_compliance_frameworks"
+	ReportTypeProjectStorage              = "project_storage"
+	ReportTypeProjectRegistry             = "project_registry"
+	ReportTypeProjectTopics               = "project_topics"
+	ReportTypeGroupTwoFactor              = "group_two_factor"
+	ReportTypeGroupComputeUsage           = "group_compute_usage"
+	ReportTypeProjectPushRules            = "project_push_rules"
+	ReportTypeGroupPushRules              = "group_push_rules"
+	ReportTypeInstanceTokenSettings       = "instance_token_settings"
+	ReportTypeRateLimit                   = "rate_limit"
+)
@@ -0,0 +1,55 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// GetGroup fetches a single group by numeric ID or full path. Groups that do not exist or cannot be
+// read are reported with ErrGroupNotFound or ErrAccessDenied.
+func (c *Client) GetGroup(ctx context.Context, groupID string) (*gitlab.Group, error) {
+	// the projects of the group are not needed to identify it
+	opt := &gitlab.GetGroupOptions{WithProjects: gitlab.Ptr(false)}
+
+	group, _, err := c.client.Groups.GetGroup(groupID, opt, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group %s: %w", groupID, groupLookupError(err))
+	}
+
+	stripWebURLs(c, []*gitlab.Group{group})
+
+	return group, nil
+}
+
+// rootGroupLookupError classifies an error returned when looking up the group given to start from
+// like groupLookupError. When the group is not found, the ID or path is looked up as a project, and
+// ErrGroupIsProject is returned if it names one, as a project path is easily passed for a group.
+func (c *Client) rootGroupLookupError(ctx context.Context, groupID string, err error) error {
+	err = groupLookupError(err)
+	if !errors.Is(err, ErrGroupNotFound) {
+		return err
+	}
+
+	project, _, projectErr := c.client.Projects.GetProject(groupID, nil, gitlab.WithContext(ctx))
+	if projectErr != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s is project %d, did you mean --project-id?", ErrGroupIsProject, groupID, project.ID)
+}
+
+// GetProject fetches a single project by numeric ID or full path. Projects that do not exist or cannot
+// be read are reported with ErrProjectNotFound or ErrAccessDenied.
+func (c *Client) GetProject(ctx context.Context, projectID string) (*gitlab.Project, error) {
+	project, _, err := c.client.Projects.GetProject(projectID, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, projectLookupError(err))
+	}
+
+	stripWebURLs(c, []*gitlab.Project{project})
+
+	return project, nil
+}
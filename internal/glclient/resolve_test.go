@@ -0,0 +1,194 @@
+package glclient_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetGroup(t *testing.T) {
+	team := &gitlab.Group{ID: 42, FullPath: "org/team", WebURL: "https://gitlab.com/groups/org/team?ref=1"}
+
+	for _, ref := range []string{"42", "org/team"} {
+		t.Run(ref, func(t *testing.T) {
+			mockClient := gitlabtesting.NewTestClient(t)
+			client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithStrippedQueryParams())
+
+			mockClient.MockGroups.EXPECT().
+				GetGroup(ref, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ any, opt *gitlab.GetGroupOptions, _ ...gitlab.RequestOptionFunc,
+				) (*gitlab.Group, *gitlab.Response, error) {
+					assert.False(t, *opt.WithProjects)
+
+					group := *team
+
+					return &group, &gitlab.Response{}, nil
+				})
+
+			group, err := client.GetGroup(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, 42, group.ID)
+			assert.Equal(t, "org/team", group.FullPath)
+			assert.Equal(t, "https://gitlab.com/groups/org/team", group.WebURL)
+		})
+	}
+}
+
+func TestGetProject(t *testing.T) {
+	for _, ref := range []string{"7", "org/team/app"} {
+		t.Run(ref, func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockProjects.EXPECT().
+				GetProject(ref, nil, gomock.Any()).
+				Return(&gitlab.Project{ID: 7, PathWithNamespace: "org/team/app"}, &gitlab.Response{}, nil)
+
+			project, err := client.GetProject(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, 7, project.ID)
+			assert.Equal(t, "org/team/app", project.PathWithNamespace)
+		})
+	}
+}
+
+func TestResolveLookupErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		group   error
+		project error
+	}{
+		{
+			name:    "not found",
+			err:     gitlab.ErrNotFound,
+			group:   glclient.ErrGroupNotFound,
+			project: glclient.ErrProjectNotFound,
+		},
+		{
+			name:    "forbidden",
+			err:     errStatus(http.StatusForbidden),
+			group:   glclient.ErrAccessDenied,
+			project: glclient.ErrAccessDenied,
+		},
+		{name: "other status", err: errStatus(http.StatusInternalServerError)},
+	}
+
+	sentinels := []error{glclient.ErrGroupNotFound, glclient.ErrProjectNotFound, glclient.ErrAccessDenied}
+
+	for _, tt := range tests {
+		t.Run("group/"+tt.name, func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockGroups.EXPECT().
+				GetGroup("org/missing", gomock.Any(), gomock.Any()).
+				Return(nil, nil, tt.err)
+
+			_, err := client.GetGroup(t.Context(), "org/missing")
+			require.Error(t, err)
+
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tt.group, errors.Is(err, sentinel), sentinel)
+			}
+		})
+
+		t.Run("project/"+tt.name, func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockProjects.EXPECT().
+				GetProject("org/missing", nil, gomock.Any()).
+				Return(nil, nil, tt.err)
+
+			_, err := client.GetProject(t.Context(), "org/missing")
+			require.Error(t, err)
+
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tt.project, errors.Is(err, sentinel), sentinel)
+			}
+		})
+	}
+}
+
+// gitLabServer serves the JSON bodies of the API paths in bodies and answers 404 Not Found to any
+// other request, as GitLab does for groups and projects that do not exist.
+func gitLabServer(t *testing.T, bodies map[string]string) *glclient.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"message":"404 Not Found"}`, http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestRootGroupLookup_notFound(t *testing.T) {
+	client := gitLabServer(t, map[string]string{
+		"/api/v4/projects/org/api": `{"id": 7, "path_with_namespace": "org/api"}`,
+	})
+
+	t.Run("project path", func(t *testing.T) {
+		_, err := client.GetGroupsRecursively(t.Context(), "org/api")
+		require.ErrorIs(t, err, glclient.ErrGroupIsProject)
+		assert.Contains(t, err.Error(), "org/api is project 7, did you mean --project-id?")
+	})
+
+	t.Run("missing group", func(t *testing.T) {
+		_, err := client.GetGroupsRecursively(t.Context(), "org/missing")
+		require.ErrorIs(t, err, glclient.ErrGroupNotFound)
+		assert.NotErrorIs(t, err, glclient.ErrGroupIsProject)
+	})
+}
+
+func TestProjectLookup_notFound(t *testing.T) {
+	client := gitLabServer(t, nil)
+
+	fetches := map[string]func() error{
+		"GetProject": func() error {
+			_, err := client.GetProject(t.Context(), "org/missing")
+
+			return err
+		},
+		"GetProjectAccessTokens": func() error {
+			_, err := client.GetProjectAccessTokens(t.Context(), "org/missing", glclient.TokenStateActive)
+
+			return err
+		},
+		"GetPipelineTriggers": func() error {
+			_, err := client.GetPipelineTriggers(t.Context(), "org/missing")
+
+			return err
+		},
+		"GetProjectVariables": func() error {
+			_, err := client.GetProjectVariables(t.Context(), "org/missing")
+
+			return err
+		},
+	}
+
+	for name, fetch := range fetches {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, fetch(), glclient.ErrProjectNotFound)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectRunnerSettings represents whether a project runs jobs on shared and group runners, next to
+// the shared runners setting of the group it belongs to, which new projects inherit.
+type ProjectRunnerSettings struct {
+	ReportType           string `json:"report_type" csv:"-"`
+	Instance             string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID            int    `json:"project_id"`
+	ProjectName          string `json:"project_name"`
+	ProjectPath          string `json:"project_path"`
+	ProjectWebURL        string `json:"project_web_url"`
+	SharedRunnersEnabled bool   `json:"shared_runners_enabled"`
+	GroupRunnersEnabled  bool   `json:"group_runners_enabled"`
+	// GroupSharedRunners is the shared_runners_setting of the project's group: enabled,
+	// disabled_and_overridable, or disabled_and_unoverridable. It is empty for projects in a personal
+	// namespace or whose group cannot be read.
+	GroupSharedRunners string `json:"group_shared_runners_setting"`
+}
+
+// groupLookup is the memoized result of looking up the group of a namespace.
+type groupLookup struct {
+	once  sync.Once
+	group *gitlab.Group
+	err   error
+}
+
+// GetRunnerSettingsRecursively fetches the runner settings of all projects within a group and its
+// subgroups. The project listing includes them; the group of each namespace is looked up once for
+// its shared runners setting.
+func (c *Client) GetRunnerSettingsRecursively(ctx context.Context, groupID string) ([]*ProjectRunnerSettings, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	settings := make([]*ProjectRunnerSettings, len(projects))
+
+	var wg sync.WaitGroup
+
+	for i, project := range projects {
+		settings[i] = &ProjectRunnerSettings{
+			ReportType:           ReportTypeProjectRunnerSettings,
+			ProjectID:            project.ID,
+			ProjectName:          project.Name,
+			ProjectPath:          project.PathWithNamespace,
+			ProjectWebURL:        c.webURL(project.WebURL),
+			SharedRunnersEnabled: project.SharedRunnersEnabled,
+			GroupRunnersEnabled:  project.GroupRunnersEnabled,
+		}
+
+		namespace := projectNamespace(project)
+		if namespace == "" || (project.Namespace != nil && project.Namespace.Kind == "user") {
+			continue
+		}
+
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			group, err := c.namespaceGroup(ctx, namespace)
+			if err != nil {
+				return
+			}
+
+			settings[i].GroupSharedRunners = string(group.SharedRunnersSetting)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "runner settings fetch"); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// namespaceGroup returns the group with the given full path. Each group is looked up at most once per
+// client, also when requested concurrently, so the projects of a group cost a single request. Groups
+// that cannot be read are reported as inaccessible.
+func (c *Client) namespaceGroup(ctx context.Context, path string) (*gitlab.Group, error) {
+	value, _ := c.namespaceGroups.LoadOrStore(path, &groupLookup{})
+	lookup, _ := value.(*groupLookup)
+
+	lookup.once.Do(func() {
+		lookup.group, lookup.err = c.GetGroup(ctx, path)
+		if lookup.err == nil {
+			return
+		}
+
+		c.recordInaccessible(ctx, "group", path, "runner settings", lookup.err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching group %s: %v\n", path, lookup.err)
+		}
+	})
+
+	return lookup.group, lookup.err
+}
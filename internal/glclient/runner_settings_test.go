@@ -0,0 +1,98 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRunnerSettingsRecursively(t *testing.T) {
+	namespace := &gitlab.ProjectNamespace{FullPath: "root-group", Kind: "group"}
+	projects := []*gitlab.Project{
+		{
+			ID:                   10,
+			Name:                 "api",
+			PathWithNamespace:    "root-group/api",
+			WebURL:               "https://gitlab.com/root-group/api",
+			Namespace:            namespace,
+			SharedRunnersEnabled: true,
+			GroupRunnersEnabled:  true,
+		},
+		{ID: 11, Name: "build", PathWithNamespace: "root-group/build", Namespace: namespace},
+	}
+
+	expectProjects := func(mockClient *gitlabtesting.TestClient) {
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return(projects, &gitlab.Response{}, nil)
+	}
+
+	t.Run("looks up the group of each namespace once", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("root-group", gomock.Any(), gomock.Any()).
+			Return(&gitlab.Group{
+				ID:                   1,
+				FullPath:             "root-group",
+				SharedRunnersSetting: gitlab.EnabledSharedRunnersSettingValue,
+			}, &gitlab.Response{}, nil).
+			Times(1)
+
+		settings, err := client.GetRunnerSettingsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		assert.Equal(t, []*glclient.ProjectRunnerSettings{
+			{
+				ReportType:           glclient.ReportTypeProjectRunnerSettings,
+				ProjectID:            10,
+				ProjectName:          "api",
+				ProjectPath:          "root-group/api",
+				ProjectWebURL:        "https://gitlab.com/root-group/api",
+				SharedRunnersEnabled: true,
+				GroupRunnersEnabled:  true,
+				GroupSharedRunners:   "enabled",
+			},
+			{
+				ReportType:         glclient.ReportTypeProjectRunnerSettings,
+				ProjectID:          11,
+				ProjectName:        "build",
+				ProjectPath:        "root-group/build",
+				GroupSharedRunners: "enabled",
+			},
+		}, settings)
+	})
+
+	t.Run("records groups the token cannot read", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		mockClient.MockGroups.EXPECT().
+			GetGroup("root-group", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		settings, err := client.GetRunnerSettingsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, settings, 2)
+		assert.Empty(t, settings[0].GroupSharedRunners)
+		assert.Empty(t, settings[1].GroupSharedRunners)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "group", Path: "root-group", Reason: "runner settings: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
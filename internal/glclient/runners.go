@@ -0,0 +1,183 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Runner types reported by GitLab.
+const (
+	RunnerTypeInstance = "instance_type"
+	RunnerTypeGroup    = "group_type"
+	RunnerTypeProject  = "project_type"
+)
+
+// Runner reliance of a project: whether its jobs can run on runners of its own, only on the shared
+// runners hosted for the whole instance, or on none at all.
+const (
+	RunnerRelianceSpecific = "specific"
+	RunnerRelianceShared   = "shared"
+	RunnerRelianceNone     = "none"
+)
+
+// ProjectRunners represents how many runners of each type are available to a project. Shared runners
+// belong to the instance, such as the GitLab-hosted runners on gitlab.com; group and project runners
+// are specific to the project and usually hosted by its owners.
+type ProjectRunners struct {
+	ReportType           string `json:"report_type" csv:"-"`
+	Instance             string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID            int    `json:"project_id"`
+	ProjectName          string `json:"project_name"`
+	ProjectPath          string `json:"project_path"`
+	ProjectWebURL        string `json:"project_web_url"`
+	SharedRunnersEnabled bool   `json:"shared_runners_enabled"`
+	SharedRunners        int    `json:"shared_runners"`
+	GroupRunners         int    `json:"group_runners"`
+	ProjectRunners       int    `json:"project_runners"`
+	Reliance             string `json:"reliance"` // specific, shared, or none
+}
+
+// ClassifyRunner returns the type of a runner. Responses of older GitLab versions without a runner
+// type are classified by whether the runner is shared, which cannot tell group and project runners
+// apart; such runners count as project runners.
+func ClassifyRunner(runner *gitlab.Runner) string {
+	switch runner.RunnerType {
+	case RunnerTypeInstance, RunnerTypeGroup, RunnerTypeProject:
+		return runner.RunnerType
+	}
+
+	if runner.IsShared {
+		return RunnerTypeInstance
+	}
+
+	return RunnerTypeProject
+}
+
+// RunnerReliance returns the reliance of a project with the given numbers of shared and specific
+// runners. Specific runners take precedence, since jobs without matching tags on them are not
+// necessarily picked up by shared runners.
+func RunnerReliance(shared, specific int) string {
+	switch {
+	case specific > 0:
+		return RunnerRelianceSpecific
+	case shared > 0:
+		return RunnerRelianceShared
+	default:
+		return RunnerRelianceNone
+	}
+}
+
+// GetProjectRunnersRecursively counts the runners available to all projects within a group and its
+// subgroups, including the runners of their ancestor groups and the shared runners they may use.
+// Paused runners are not counted. Listing the runners of a project requires at least the Maintainer
+// role; other projects are reported as inaccessible.
+func (c *Client) GetProjectRunnersRecursively(ctx context.Context, groupID string) ([]*ProjectRunners, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allRunners []*ProjectRunners
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			runners, err := c.countRunnersForProject(ctx, projectID, project)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, "project runners", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching runners for project %s: %v\n", projectID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allRunners = append(allRunners, runners)
+			mu.Unlock()
+			c.countItems(1)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "project runners fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectRunners(allRunners)
+
+	return allRunners, nil
+}
+
+func (c *Client) countRunnersForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) (*ProjectRunners, error) {
+	counts := &ProjectRunners{
+		ReportType:           ReportTypeProjectRunners,
+		ProjectID:            project.ID,
+		ProjectName:          project.Name,
+		ProjectPath:          project.PathWithNamespace,
+		ProjectWebURL:        c.webURL(project.WebURL),
+		SharedRunnersEnabled: project.SharedRunnersEnabled,
+	}
+
+	opt := &gitlab.ListProjectRunnersOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		runners, resp, err := c.client.Runners.ListProjectRunners(projectID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list project runners: %w", err)
+		}
+
+		for _, runner := range runners {
+			if runner.Paused {
+				continue
+			}
+
+			switch ClassifyRunner(runner) {
+			case RunnerTypeInstance:
+				counts.SharedRunners++
+			case RunnerTypeGroup:
+				counts.GroupRunners++
+			default:
+				counts.ProjectRunners++
+			}
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: fetched %d runners for project %s\n", len(runners), projectID)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	counts.Reliance = RunnerReliance(counts.SharedRunners, counts.GroupRunners+counts.ProjectRunners)
+
+	return counts, nil
+}
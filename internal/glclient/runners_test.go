@@ -0,0 +1,126 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClassifyRunner(t *testing.T) {
+	tests := []struct {
+		name   string
+		runner *gitlab.Runner
+		want   string
+	}{
+		{
+			name:   "instance runner",
+			runner: &gitlab.Runner{RunnerType: glclient.RunnerTypeInstance, IsShared: true},
+			want:   glclient.RunnerTypeInstance,
+		},
+		{
+			name:   "group runner",
+			runner: &gitlab.Runner{RunnerType: glclient.RunnerTypeGroup},
+			want:   glclient.RunnerTypeGroup,
+		},
+		{
+			name:   "project runner",
+			runner: &gitlab.Runner{RunnerType: glclient.RunnerTypeProject},
+			want:   glclient.RunnerTypeProject,
+		},
+		{
+			name:   "shared runner without a type",
+			runner: &gitlab.Runner{IsShared: true},
+			want:   glclient.RunnerTypeInstance,
+		},
+		{
+			name:   "specific runner without a type",
+			runner: &gitlab.Runner{},
+			want:   glclient.RunnerTypeProject,
+		},
+		{
+			name:   "unknown type falls back to is_shared",
+			runner: &gitlab.Runner{RunnerType: "cloud_type", IsShared: true},
+			want:   glclient.RunnerTypeInstance,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, glclient.ClassifyRunner(tt.runner))
+		})
+	}
+}
+
+func TestRunnerReliance(t *testing.T) {
+	assert.Equal(t, glclient.RunnerRelianceSpecific, glclient.RunnerReliance(3, 1))
+	assert.Equal(t, glclient.RunnerRelianceSpecific, glclient.RunnerReliance(0, 2))
+	assert.Equal(t, glclient.RunnerRelianceShared, glclient.RunnerReliance(5, 0))
+	assert.Equal(t, glclient.RunnerRelianceNone, glclient.RunnerReliance(0, 0))
+}
+
+func TestGetProjectRunnersRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	expectTopicProjects(mockClient)
+
+	shared := &gitlab.Runner{ID: 1, RunnerType: glclient.RunnerTypeInstance, IsShared: true}
+
+	mockClient.MockRunners.EXPECT().
+		ListProjectRunners("10", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Runner{
+			shared,
+			{ID: 2, RunnerType: glclient.RunnerTypeGroup},
+			{ID: 3, RunnerType: glclient.RunnerTypeProject},
+			{ID: 4, RunnerType: glclient.RunnerTypeProject, Paused: true},
+		}, &gitlab.Response{}, nil)
+	mockClient.MockRunners.EXPECT().
+		ListProjectRunners("11", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Runner{shared}, &gitlab.Response{NextPage: 2}, nil)
+	mockClient.MockRunners.EXPECT().
+		ListProjectRunners("11", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Runner{{ID: 5, IsShared: true}}, &gitlab.Response{}, nil)
+	mockClient.MockRunners.EXPECT().
+		ListProjectRunners("12", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Runner{}, &gitlab.Response{}, nil)
+	mockClient.MockRunners.EXPECT().
+		ListProjectRunners("13", gomock.Any(), gomock.Any()).
+		Return(nil, nil, errStatus(http.StatusForbidden))
+
+	runners, err := client.GetProjectRunnersRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, []*glclient.ProjectRunners{
+		{
+			ReportType:  glclient.ReportTypeProjectRunners,
+			ProjectID:   12,
+			ProjectName: "billing",
+			ProjectPath: "root-group/billing",
+			Reliance:    glclient.RunnerRelianceNone,
+		},
+		{
+			ReportType:    glclient.ReportTypeProjectRunners,
+			ProjectID:     11,
+			ProjectName:   "docs",
+			ProjectPath:   "root-group/docs",
+			SharedRunners: 2,
+			Reliance:      glclient.RunnerRelianceShared,
+		},
+		{
+			ReportType:     glclient.ReportTypeProjectRunners,
+			ProjectID:      10,
+			ProjectName:    "payments",
+			ProjectPath:    "root-group/payments",
+			SharedRunners:  1,
+			GroupRunners:   1,
+			ProjectRunners: 1,
+			Reliance:       glclient.RunnerRelianceSpecific,
+		},
+	}, runners)
+	assert.Equal(t, []glclient.Inaccessible{
+		{Kind: "project", Path: "root-group/sandbox", Reason: "project runners: 403 Forbidden"},
+	}, client.Inaccessible())
+}
@@ -0,0 +1,179 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Job artifact reports of the security scanners, which tell that a scanner ran in a pipeline.
+const (
+	artifactSAST               = "sast"
+	artifactDAST               = "dast"
+	artifactDependencyScanning = "dependency_scanning"
+)
+
+// ProjectSecurityConfig represents which security scanners run on the default branch of a project,
+// as shown by GitLab on its security configuration page, and whether secret push protection is on.
+type ProjectSecurityConfig struct {
+	ReportType    string `json:"report_type" csv:"-"`
+	Instance      string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int    `json:"project_id"`
+	ProjectName   string `json:"project_name"`
+	ProjectPath   string `json:"project_path"`
+	ProjectWebURL string `json:"project_web_url"`
+	// PipelineID is the latest pipeline of the default branch, 0 for projects without one.
+	PipelineID         int  `json:"pipeline_id"`
+	SAST               bool `json:"sast_enabled"`
+	DAST               bool `json:"dast_enabled"`
+	DependencyScanning bool `json:"dependency_scanning_enabled"`
+	// SecretPushProtection is nil where the security settings cannot be read, such as on instances
+	// without GitLab Ultimate.
+	SecretPushProtection *bool `json:"secret_push_protection_enabled"`
+}
+
+// GetSecurityConfigsRecursively fetches the security configuration of all projects within a group
+// and its subgroups. Like GitLab, a scanner counts as enabled when a job of the latest pipeline of the
+// default branch produced its report. Secret push protection is left empty where the security
+// settings are not licensed or not readable. Projects whose pipelines cannot be read are reported as
+// inaccessible.
+func (c *Client) GetSecurityConfigsRecursively(ctx context.Context, groupID string) ([]*ProjectSecurityConfig, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allConfigs []*ProjectSecurityConfig
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			config, err := c.getSecurityConfig(ctx, projectID, project)
+			if err != nil {
+				c.recordInaccessible(ctx, "project", project.PathWithNamespace, "security configuration", err)
+
+				if c.debug {
+					fmt.Printf("DEBUG: error fetching security configuration for project %s: %v\n", projectID, err)
+				}
+
+				return
+			}
+
+			mu.Lock()
+			allConfigs = append(allConfigs, config)
+			mu.Unlock()
+			c.countItems(1)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "security configuration fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortSecurityConfigs(allConfigs)
+
+	return allConfigs, nil
+}
+
+func (c *Client) getSecurityConfig(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+) (*ProjectSecurityConfig, error) {
+	config := &ProjectSecurityConfig{
+		ReportType:    ReportTypeProjectSecurityConfig,
+		ProjectID:     project.ID,
+		ProjectName:   project.Name,
+		ProjectPath:   project.PathWithNamespace,
+		ProjectWebURL: c.webURL(project.WebURL),
+	}
+
+	// projects without a repository or commits have no default branch to run pipelines on
+	if project.DefaultBranch != "" {
+		if err := c.detectScanners(ctx, projectID, project.DefaultBranch, config); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, _, err := c.client.ProjectSecuritySettings.ListProjectSecuritySettings(projectID, gitlab.WithContext(ctx))
+
+	switch {
+	case err == nil:
+		config.SecretPushProtection = gitlab.Ptr(settings.SecretPushProtectionEnabled)
+	// GitLab answers 403 without GitLab Ultimate or the role to read them, and 404 on older versions
+	case isForbidden(err) || isNotFound(err):
+		if c.debug {
+			fmt.Printf("DEBUG: security settings of project %s are not available: %v\n", projectID, err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to get security settings: %w", err)
+	}
+
+	return config, nil
+}
+
+// detectScanners sets the scanners of config whose reports a job of the latest pipeline of branch
+// produced.
+func (c *Client) detectScanners(ctx context.Context, projectID, branch string, config *ProjectSecurityConfig) error {
+	pipeline, _, err := c.client.Pipelines.GetLatestPipeline(projectID,
+		&gitlab.GetLatestPipelineOptions{Ref: gitlab.Ptr(branch)}, gitlab.WithContext(ctx))
+	if err != nil {
+		// GitLab answers 404 for a branch without pipelines
+		if isNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get latest pipeline: %w", err)
+	}
+
+	config.PipelineID = pipeline.ID
+
+	opt := &gitlab.ListJobsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: c.pageSize,
+			Page:    1,
+		},
+	}
+
+	for {
+		jobs, resp, err := c.client.Jobs.ListPipelineJobs(projectID, pipeline.ID, opt, gitlab.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to list jobs of pipeline %d: %w", pipeline.ID, err)
+		}
+
+		for _, job := range jobs {
+			for _, artifact := range job.Artifacts {
+				switch artifact.FileType {
+				case artifactSAST:
+					config.SAST = true
+				case artifactDAST:
+					config.DAST = true
+				case artifactDependencyScanning:
+					config.DependencyScanning = true
+				}
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return nil
+}
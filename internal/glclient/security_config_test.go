@@ -0,0 +1,143 @@
+package glclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// jobWithArtifacts returns a job with artifacts of the given file types, decoded like the API answer
+// since the client declares the artifacts as an anonymous struct.
+func jobWithArtifacts(t *testing.T, name string, fileTypes ...string) *gitlab.Job {
+	t.Helper()
+
+	artifacts := make([]map[string]string, 0, len(fileTypes))
+	for _, fileType := range fileTypes {
+		artifacts = append(artifacts, map[string]string{"file_type": fileType})
+	}
+
+	body, err := json.Marshal(map[string]any{"name": name, "artifacts": artifacts})
+	require.NoError(t, err)
+
+	var job gitlab.Job
+	require.NoError(t, json.Unmarshal(body, &job))
+
+	return &job
+}
+
+func TestGetSecurityConfigsRecursively(t *testing.T) {
+	expectProjects := func(mockClient *gitlabtesting.TestClient) {
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Project{
+				{ID: 10, Name: "api", PathWithNamespace: "root-group/api", DefaultBranch: "main"},
+				{ID: 11, Name: "web", PathWithNamespace: "root-group/web", DefaultBranch: "main"},
+				{ID: 12, Name: "empty", PathWithNamespace: "root-group/empty"},
+			}, &gitlab.Response{}, nil)
+	}
+
+	t.Run("detects scanners from the job reports of the latest pipeline", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		mockClient.MockPipelines.EXPECT().
+			GetLatestPipeline("10", &gitlab.GetLatestPipelineOptions{Ref: gitlab.Ptr("main")}, gomock.Any()).
+			Return(&gitlab.Pipeline{ID: 100}, &gitlab.Response{}, nil)
+		mockClient.MockJobs.EXPECT().
+			ListPipelineJobs("10", 100, gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Job{
+				jobWithArtifacts(t, "semgrep-sast", "trace", "sast"),
+				jobWithArtifacts(t, "build"),
+			}, &gitlab.Response{NextPage: 2}, nil)
+		mockClient.MockJobs.EXPECT().
+			ListPipelineJobs("10", 100, gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Job{
+				jobWithArtifacts(t, "gemnasium", "dependency_scanning"),
+			}, &gitlab.Response{}, nil)
+		// a branch without pipelines runs no scanners
+		mockClient.MockPipelines.EXPECT().
+			GetLatestPipeline("11", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusNotFound))
+
+		mockClient.MockProjectSecuritySettings.EXPECT().
+			ListProjectSecuritySettings("10", gomock.Any()).
+			Return(&gitlab.ProjectSecuritySettings{SecretPushProtectionEnabled: true}, &gitlab.Response{}, nil)
+		mockClient.MockProjectSecuritySettings.EXPECT().
+			ListProjectSecuritySettings("11", gomock.Any()).
+			Return(&gitlab.ProjectSecuritySettings{}, &gitlab.Response{}, nil)
+		// instances without GitLab Ultimate refuse the security settings
+		mockClient.MockProjectSecuritySettings.EXPECT().
+			ListProjectSecuritySettings("12", gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+
+		configs, err := client.GetSecurityConfigsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, []*glclient.ProjectSecurityConfig{
+			{
+				ReportType:           glclient.ReportTypeProjectSecurityConfig,
+				ProjectID:            10,
+				ProjectName:          "api",
+				ProjectPath:          "root-group/api",
+				PipelineID:           100,
+				SAST:                 true,
+				DependencyScanning:   true,
+				SecretPushProtection: gitlab.Ptr(true),
+			},
+			{
+				ReportType:  glclient.ReportTypeProjectSecurityConfig,
+				ProjectID:   12,
+				ProjectName: "empty",
+				ProjectPath: "root-group/empty",
+			},
+			{
+				ReportType:           glclient.ReportTypeProjectSecurityConfig,
+				ProjectID:            11,
+				ProjectName:          "web",
+				ProjectPath:          "root-group/web",
+				SecretPushProtection: gitlab.Ptr(false),
+			},
+		}, configs)
+		assert.Empty(t, client.Inaccessible())
+	})
+
+	t.Run("records projects whose pipelines cannot be read", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectProjects(mockClient)
+
+		mockClient.MockPipelines.EXPECT().
+			GetLatestPipeline("10", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden))
+		mockClient.MockPipelines.EXPECT().
+			GetLatestPipeline("11", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusNotFound))
+		mockClient.MockProjectSecuritySettings.EXPECT().
+			ListProjectSecuritySettings(gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusNotFound)).
+			Times(2)
+
+		configs, err := client.GetSecurityConfigsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+		require.Len(t, configs, 2)
+		assert.Equal(t, "root-group/empty", configs[0].ProjectPath)
+		assert.Nil(t, configs[0].SecretPushProtection)
+		assert.Equal(t, "root-group/web", configs[1].ProjectPath)
+		assert.Equal(t, []glclient.Inaccessible{
+			{Kind: "project", Path: "root-group/api", Reason: "security configuration: 403 Forbidden"},
+		}, client.Inaccessible())
+	})
+}
@@ -0,0 +1,122 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectStorage represents the storage used by a project.
+type ProjectStorage struct {
+	ReportType       string `json:"report_type" csv:"-"`
+	Instance         string `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID        int    `json:"project_id"`
+	ProjectName      string `json:"project_name"`
+	ProjectPath      string `json:"project_path"`
+	ProjectWebURL    string `json:"project_web_url"`
+	RepositorySize   int64  `json:"repository_size"`
+	LFSObjectsSize   int64  `json:"lfs_objects_size"`
+	JobArtifactsSize int64  `json:"job_artifacts_size"`
+	StorageSize      int64  `json:"storage_size"` // total, including wiki, packages, registry, snippets, and uploads
+}
+
+// GetProjectStorageRecursively fetches the storage statistics of all projects within a group and its
+// subgroups. GitLab only includes statistics for single projects, so each project is fetched again.
+// Projects whose statistics the token cannot read, which requires at least the Reporter role,
+// are reported as inaccessible.
+func (c *Client) GetProjectStorageRecursively(ctx context.Context, groupID string) ([]*ProjectStorage, error) {
+	if c.debug {
+		fmt.Printf("DEBUG: starting recursive storage fetch for group ID %s\n", groupID)
+	}
+
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	var (
+		allStorage []*ProjectStorage
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+	)
+
+	for _, project := range projects {
+		wg.Add(1)
+
+		projectID := strconv.Itoa(project.ID)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+			c.fetchStorageForProject(ctx, projectID, project, &allStorage, &mu)
+		})
+	}
+
+	wg.Wait()
+
+	if err := c.interrupted(ctx, "storage fetch"); err != nil {
+		return nil, err
+	}
+
+	c.sortProjectStorage(allStorage)
+
+	if c.debug {
+		fmt.Printf("DEBUG: completed recursive storage fetch, found %d projects\n", len(allStorage))
+	}
+
+	return allStorage, nil
+}
+
+func (c *Client) getStorageForProject(ctx context.Context, projectID string) (*gitlab.Statistics, error) {
+	opt := &gitlab.GetProjectOptions{Statistics: gitlab.Ptr(true)}
+
+	project, _, err := c.client.Projects.GetProject(projectID, opt, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project statistics: %w", err)
+	}
+
+	return project.Statistics, nil
+}
+
+func (c *Client) fetchStorageForProject(
+	ctx context.Context,
+	projectID string,
+	project *gitlab.Project,
+	storage *[]*ProjectStorage,
+	mu *sync.Mutex,
+) {
+	statistics, err := c.getStorageForProject(ctx, projectID)
+	if err != nil {
+		c.recordInaccessible(ctx, "project", project.PathWithNamespace, "statistics", err)
+
+		if c.debug {
+			fmt.Printf("DEBUG: error fetching statistics for project %s: %v\n", projectID, err)
+		}
+
+		return
+	}
+
+	// GitLab omits the statistics instead of failing when the token lacks the Reporter role
+	if statistics == nil {
+		c.addInaccessible("project", project.PathWithNamespace, "statistics: not available to the token")
+
+		return
+	}
+
+	mu.Lock()
+	*storage = append(*storage, &ProjectStorage{
+		ReportType:       ReportTypeProjectStorage,
+		ProjectID:        project.ID,
+		ProjectName:      project.Name,
+		ProjectPath:      project.PathWithNamespace,
+		ProjectWebURL:    c.webURL(project.WebURL),
+		RepositorySize:   statistics.RepositorySize,
+		LFSObjectsSize:   statistics.LFSObjectsSize,
+		JobArtifactsSize: statistics.JobArtifactsSize,
+		StorageSize:      statistics.StorageSize,
+	})
+	mu.Unlock()
+	c.countItems(1)
+}
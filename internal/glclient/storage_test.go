@@ -0,0 +1,80 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetProjectStorageRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	projects := []*gitlab.Project{
+		{
+			ID:                10,
+			Name:              "api",
+			PathWithNamespace: "root-group/api",
+			WebURL:            "https://gitlab.com/root-group/api",
+		},
+		{ID: 11, Name: "guest", PathWithNamespace: "root-group/guest"},
+		{ID: 12, Name: "secret", PathWithNamespace: "root-group/secret"},
+	}
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return(projects, &gitlab.Response{}, nil)
+
+	withStatistics := &gitlab.GetProjectOptions{Statistics: gitlab.Ptr(true)}
+
+	mockClient.MockProjects.EXPECT().
+		GetProject("10", withStatistics, gomock.Any()).
+		Return(&gitlab.Project{ID: 10, Statistics: &gitlab.Statistics{
+			RepositorySize:   100,
+			LFSObjectsSize:   200,
+			JobArtifactsSize: 300,
+			StorageSize:      650,
+		}}, &gitlab.Response{}, nil)
+
+	mockClient.MockProjects.EXPECT().
+		GetProject("11", withStatistics, gomock.Any()).
+		Return(&gitlab.Project{ID: 11}, &gitlab.Response{}, nil)
+
+	mockClient.MockProjects.EXPECT().
+		GetProject("12", withStatistics, gomock.Any()).
+		Return(nil, &gitlab.Response{}, errStatus(http.StatusForbidden))
+
+	storage, err := client.GetProjectStorageRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*glclient.ProjectStorage{
+		{
+			ReportType:       glclient.ReportTypeProjectStorage,
+			ProjectID:        10,
+			ProjectName:      "api",
+			ProjectPath:      "root-group/api",
+			ProjectWebURL:    "https://gitlab.com/root-group/api",
+			RepositorySize:   100,
+			LFSObjectsSize:   200,
+			JobArtifactsSize: 300,
+			StorageSize:      650,
+		},
+	}, storage)
+
+	assert.Equal(t, []glclient.Inaccessible{
+		{Kind: "project", Path: "root-group/guest", Reason: "statistics: not available to the token"},
+		{Kind: "project", Path: "root-group/secret", Reason: "statistics: 403 Forbidden"},
+	}, client.Inaccessible())
+}
@@ -0,0 +1,89 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// tokenProvenance is the part of an access token the client library does not decode: the IP
+// addresses it was last used from, reported by recent GitLab versions only.
+type tokenProvenance struct {
+	LastUsedIPs *[]string `json:"last_used_ips"`
+}
+
+// TokenLastUsedIPs returns the IP addresses the access token at path, such as
+// projects/10/access_tokens/1, was last used from. It returns nil without an error when GitLab does
+// not report them, and an empty slice for a token it saw no use of.
+func (c *Client) TokenLastUsedIPs(ctx context.Context, path string) ([]string, error) {
+	req, err := c.client.NewRequest(http.MethodGet, path, nil, []gitlab.RequestOptionFunc{gitlab.WithContext(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", path, err)
+	}
+
+	var provenance tokenProvenance
+	if _, err := c.client.Do(req, &provenance); err != nil {
+		return nil, fmt.Errorf("failed to get token %s: %w", path, err)
+	}
+
+	if provenance.LastUsedIPs == nil {
+		return nil, nil
+	}
+
+	return *provenance.LastUsedIPs, nil
+}
+
+// ResolveGroupTokenIPs sets the IP addresses each token was last used from, one request per token.
+// Tokens keep no addresses where GitLab does not report them or the token cannot be read.
+func (c *Client) ResolveGroupTokenIPs(ctx context.Context, tokens []*GroupAccessTokenWithGroup) {
+	resolveLastUsedIPs(ctx, c, tokens,
+		func(t *GroupAccessTokenWithGroup) string {
+			return fmt.Sprintf("groups/%d/access_tokens/%d", t.GroupID, t.ID)
+		},
+		func(t *GroupAccessTokenWithGroup, ips []string) { t.LastUsedIPs = ips })
+}
+
+// ResolveProjectTokenIPs sets the IP addresses each token was last used from, one request per
+// token. Tokens keep no addresses where GitLab does not report them or the token cannot be read.
+func (c *Client) ResolveProjectTokenIPs(ctx context.Context, tokens []*ProjectAccessTokenWithProject) {
+	resolveLastUsedIPs(ctx, c, tokens,
+		func(t *ProjectAccessTokenWithProject) string {
+			return fmt.Sprintf("projects/%d/access_tokens/%d", t.ProjectID, t.ID)
+		},
+		func(t *ProjectAccessTokenWithProject, ips []string) { t.LastUsedIPs = ips })
+}
+
+// resolveLastUsedIPs looks up the IP addresses the tokens of items were last used from concurrently.
+func resolveLastUsedIPs[T any](
+	ctx context.Context,
+	c *Client,
+	items []T,
+	path func(T) string,
+	setIPs func(T, []string),
+) {
+	var wg sync.WaitGroup
+
+	for _, item := range items {
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			ips, err := c.TokenLastUsedIPs(ctx, path(item))
+			if err != nil {
+				if c.debug {
+					fmt.Printf("DEBUG: error resolving last used IPs: %v\n", err)
+				}
+
+				return
+			}
+
+			setIPs(item, ips)
+		})
+	}
+
+	wg.Wait()
+}
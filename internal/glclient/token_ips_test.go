@@ -0,0 +1,62 @@
+package glclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestResolveTokenIPs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/10/access_tokens/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 1, "name": "deploy", "last_used_ips": ["203.0.113.9", "10.0.0.5"]}`))
+	})
+	mux.HandleFunc("/api/v4/projects/10/access_tokens/2", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 2, "name": "unused", "last_used_ips": []}`))
+	})
+	// older GitLab versions do not report the addresses
+	mux.HandleFunc("/api/v4/groups/1/access_tokens/3", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": 3, "name": "release"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := glclient.NewClient("token", false, glclient.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	t.Run("project tokens", func(t *testing.T) {
+		projectToken := func(id int) *glclient.ProjectAccessTokenWithProject {
+			return &glclient.ProjectAccessTokenWithProject{
+				ProjectAccessToken: &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{ID: id}},
+				ProjectID:          10,
+			}
+		}
+
+		// a token that cannot be read keeps no addresses
+		tokens := []*glclient.ProjectAccessTokenWithProject{projectToken(1), projectToken(2), projectToken(9)}
+
+		client.ResolveProjectTokenIPs(t.Context(), tokens)
+
+		assert.Equal(t, []string{"203.0.113.9", "10.0.0.5"}, tokens[0].LastUsedIPs)
+		assert.NotNil(t, tokens[1].LastUsedIPs)
+		assert.Empty(t, tokens[1].LastUsedIPs)
+		assert.Nil(t, tokens[2].LastUsedIPs)
+	})
+
+	t.Run("group tokens without the field", func(t *testing.T) {
+		tokens := []*glclient.GroupAccessTokenWithGroup{{
+			GroupAccessToken: &gitlab.GroupAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{ID: 3}},
+			GroupID:          1,
+		}}
+
+		client.ResolveGroupTokenIPs(t.Context(), tokens)
+
+		assert.Nil(t, tokens[0].LastUsedIPs)
+	})
+}
@@ -0,0 +1,37 @@
+package glclient
+
+import gitlab "gitlab.com/gitlab-org/api/client-go"
+
+// TokenState selects the group and project access tokens to fetch by whether they are active.
+type TokenState string
+
+const (
+	// TokenStateActive selects active tokens, which GitLab filters server-side.
+	TokenStateActive TokenState = "active"
+	// TokenStateInactive selects revoked and expired tokens. All tokens are fetched and the active ones
+	// left out.
+	TokenStateInactive TokenState = "inactive"
+	// TokenStateAll selects all tokens.
+	TokenStateAll TokenState = "all"
+)
+
+// accessTokenState returns the state option to list tokens in s with, or nil to list all tokens.
+func (s TokenState) accessTokenState() *gitlab.AccessTokenState {
+	if s == TokenStateActive {
+		return gitlab.Ptr(gitlab.AccessTokenStateActive)
+	}
+
+	return nil
+}
+
+// includes reports whether a token that is active or not is in s.
+func (s TokenState) includes(active bool) bool {
+	switch s {
+	case TokenStateActive:
+		return active
+	case TokenStateInactive:
+		return !active
+	default:
+		return true
+	}
+}
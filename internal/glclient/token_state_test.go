@@ -0,0 +1,94 @@
+package glclient_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccessTokens_state(t *testing.T) {
+	tests := []struct {
+		state glclient.TokenState
+		// option is the state GitLab is asked for, nil for all tokens
+		option *string
+		want   []string
+	}{
+		{state: glclient.TokenStateActive, option: gitlab.Ptr("active"), want: []string{"active-token"}},
+		{state: glclient.TokenStateInactive, want: []string{"revoked-token"}},
+		{state: glclient.TokenStateAll, want: []string{"active-token", "revoked-token"}},
+	}
+
+	active := gitlab.PersonalAccessToken{ID: 1, Name: "active-token", Active: true}
+	revoked := gitlab.PersonalAccessToken{ID: 2, Name: "revoked-token", Revoked: true}
+
+	for _, tt := range tests {
+		t.Run("group tokens "+string(tt.state), func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockGroups.EXPECT().
+				GetGroup("1", nil, gomock.Any()).
+				Return(&gitlab.Group{ID: 1, FullPath: "org"}, &gitlab.Response{}, nil)
+			mockClient.MockGroupAccessTokens.EXPECT().
+				ListGroupAccessTokens("1", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ any, opt *gitlab.ListGroupAccessTokensOptions, _ ...gitlab.RequestOptionFunc,
+				) ([]*gitlab.GroupAccessToken, *gitlab.Response, error) {
+					if tt.option == nil {
+						assert.Nil(t, opt.State)
+					} else {
+						require.NotNil(t, opt.State)
+						assert.Equal(t, *tt.option, string(*opt.State))
+					}
+
+					return []*gitlab.GroupAccessToken{
+						{PersonalAccessToken: active},
+						{PersonalAccessToken: revoked},
+					}, &gitlab.Response{}, nil
+				})
+
+			tokens, err := client.GetGroupAccessTokens(t.Context(), "1", tt.state)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(tokens))
+			for _, token := range tokens {
+				names = append(names, token.Name)
+			}
+
+			assert.Equal(t, tt.want, names)
+		})
+
+		t.Run("project tokens "+string(tt.state), func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockProjects.EXPECT().
+				GetProject("1", nil, gomock.Any()).
+				Return(&gitlab.Project{ID: 1, PathWithNamespace: "org/api"}, &gitlab.Response{}, nil)
+			mockClient.MockProjectAccessTokens.EXPECT().
+				ListProjectAccessTokens("1", gomock.Any(), gomock.Any()).
+				DoAndReturn(func(
+					_ any, opt *gitlab.ListProjectAccessTokensOptions, _ ...gitlab.RequestOptionFunc,
+				) ([]*gitlab.ProjectAccessToken, *gitlab.Response, error) {
+					assert.Equal(t, tt.option, opt.State)
+
+					return []*gitlab.ProjectAccessToken{
+						{PersonalAccessToken: active},
+						{PersonalAccessToken: revoked},
+					}, &gitlab.Response{}, nil
+				})
+
+			tokens, err := client.GetProjectAccessTokens(t.Context(), "1", tt.state)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(tokens))
+			for _, token := range tokens {
+				names = append(names, token.Name)
+			}
+
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// ProjectTopics represents the topics a project is classified with.
+type ProjectTopics struct {
+	ReportType    string   `json:"report_type" csv:"-"`
+	Instance      string   `json:"instance,omitempty" csv:"omitempty"`
+	ProjectID     int      `json:"project_id"`
+	ProjectName   string   `json:"project_name"`
+	ProjectPath   string   `json:"project_path"`
+	ProjectWebURL string   `json:"project_web_url"`
+	Topics        []string `json:"topics"`
+}
+
+// GetProjectTopicsRecursively fetches the topics of all projects within a group and its subgroups.
+// The project listing already includes them, so no further requests are made.
+func (c *Client) GetProjectTopicsRecursively(ctx context.Context, groupID string) ([]*ProjectTopics, error) {
+	projects, err := c.GetProjectsRecursively(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects recursively: %w", err)
+	}
+
+	topics := make([]*ProjectTopics, 0, len(projects))
+
+	for _, project := range projects {
+		topics = append(topics, &ProjectTopics{
+			ReportType:    ReportTypeProjectTopics,
+			ProjectID:     project.ID,
+			ProjectName:   project.Name,
+			ProjectPath:   project.PathWithNamespace,
+			ProjectWebURL: c.webURL(project.WebURL),
+			Topics:        project.Topics,
+		})
+	}
+
+	c.sortProjectTopics(topics)
+
+	return topics, nil
+}
+
+// filterByTopic returns the projects carrying the given topic, compared case-insensitively like
+// GitLab does. An empty topic keeps all projects.
+func filterByTopic(projects []*gitlab.Project, topic string) []*gitlab.Project {
+	if topic == "" {
+		return projects
+	}
+
+	return slices.DeleteFunc(projects, func(project *gitlab.Project) bool {
+		return !slices.ContainsFunc(project.Topics, func(t string) bool { return strings.EqualFold(t, topic) })
+	})
+}
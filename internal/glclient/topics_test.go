@@ -0,0 +1,101 @@
+package glclient_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+// expectTopicProjects sets up a root group whose projects carry various topics.
+func expectTopicProjects(mockClient *gitlabtesting.TestClient) {
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "root-group", FullPath: "root-group"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListGroupProjects("root-group", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Project{
+			{ID: 10, Name: "payments", PathWithNamespace: "root-group/payments", Topics: []string{"pci", "internal"}},
+			{ID: 11, Name: "docs", PathWithNamespace: "root-group/docs", Topics: []string{"internal"}},
+			{ID: 12, Name: "billing", PathWithNamespace: "root-group/billing", Topics: []string{"PCI"}},
+			{ID: 13, Name: "sandbox", PathWithNamespace: "root-group/sandbox"},
+		}, &gitlab.Response{}, nil)
+}
+
+func TestGetProjectsRecursively_topic(t *testing.T) {
+	mockClient := gitlabtesting.NewTestClient(t)
+	client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithTopic("pci"))
+
+	expectTopicProjects(mockClient)
+
+	projects, err := client.GetProjectsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	ids := make([]int, 0, len(projects))
+	for _, project := range projects {
+		ids = append(ids, project.ID)
+	}
+
+	assert.Equal(t, []int{10, 12}, ids)
+}
+
+func TestGetProjectsRecursively_topicComposesWithReports(t *testing.T) {
+	mockClient := gitlabtesting.NewTestClient(t)
+	client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithTopic("internal"))
+
+	expectTopicProjects(mockClient)
+
+	activity, err := client.GetProjectActivityRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	require.Len(t, activity, 2)
+	assert.Equal(t, "root-group/payments", activity[0].ProjectPath)
+	assert.Equal(t, "root-group/docs", activity[1].ProjectPath)
+}
+
+func TestGetProjectTopicsRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	expectTopicProjects(mockClient)
+
+	topics, err := client.GetProjectTopicsRecursively(t.Context(), "1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []*glclient.ProjectTopics{
+		{
+			ReportType:  glclient.ReportTypeProjectTopics,
+			ProjectID:   12,
+			ProjectName: "billing",
+			ProjectPath: "root-group/billing",
+			Topics:      []string{"PCI"},
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectTopics,
+			ProjectID:   11,
+			ProjectName: "docs",
+			ProjectPath: "root-group/docs",
+			Topics:      []string{"internal"},
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectTopics,
+			ProjectID:   10,
+			ProjectName: "payments",
+			ProjectPath: "root-group/payments",
+			Topics:      []string{"pci", "internal"},
+		},
+		{
+			ReportType:  glclient.ReportTypeProjectTopics,
+			ProjectID:   13,
+			ProjectName: "sandbox",
+			ProjectPath: "root-group/sandbox",
+		},
+	}, topics)
+}
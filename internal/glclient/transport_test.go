@@ -0,0 +1,202 @@
+package glclient_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnexpectedRequest = errors.New("unexpected request")
+
+// scriptedResponse is a response returned by scriptedTransport. A zero status blocks until the
+// request is canceled.
+type scriptedResponse struct {
+	status     int
+	retryAfter string
+	etag       string
+	body       string
+}
+
+// scriptedTransport answers requests with a fixed sequence of responses and records the paths
+// requested, along with the If-None-Match header sent.
+type scriptedTransport struct {
+	mu          sync.Mutex
+	responses   []scriptedResponse
+	paths       []string
+	ifNoneMatch []string
+}
+
+func newScriptedTransport(responses ...scriptedResponse) *scriptedTransport {
+	return &scriptedTransport{responses: responses}
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.paths = append(t.paths, req.URL.Path)
+	t.ifNoneMatch = append(t.ifNoneMatch, req.Header.Get("If-None-Match"))
+
+	if len(t.responses) == 0 {
+		t.mu.Unlock()
+
+		return nil, errUnexpectedRequest
+	}
+
+	next := t.responses[0]
+	t.responses = t.responses[1:]
+	t.mu.Unlock()
+
+	if next.status == 0 {
+		<-req.Context().Done()
+
+		return nil, req.Context().Err()
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if next.retryAfter != "" {
+		header.Set("Retry-After", next.retryAfter)
+	}
+
+	if next.etag != "" {
+		header.Set("ETag", next.etag)
+	}
+
+	return &http.Response{
+		StatusCode: next.status,
+		Status:     http.StatusText(next.status),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(next.body)),
+		Request:    req,
+	}, nil
+}
+
+func (t *scriptedTransport) requests() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.paths
+}
+
+func (t *scriptedTransport) conditions() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.ifNoneMatch
+}
+
+const versionBody = `{"version": "17.2.0", "revision": "abc"}`
+
+func TestNewClientWithTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []scriptedResponse
+		requests  int
+		wantErr   bool
+	}{
+		{
+			name:      "passes a successful response through",
+			responses: []scriptedResponse{{status: http.StatusOK, body: versionBody}},
+			requests:  1,
+		},
+		{
+			name: "retries a 403 with Retry-After",
+			responses: []scriptedResponse{
+				{status: http.StatusForbidden, retryAfter: "0", body: `{"message": "retry later"}`},
+				{status: http.StatusOK, body: versionBody},
+			},
+			requests: 2,
+		},
+		{
+			name: "fails on a 403 without Retry-After",
+			responses: []scriptedResponse{
+				{status: http.StatusForbidden, body: `{"message": "403 Forbidden"}`},
+			},
+			requests: 1,
+			wantErr:  true,
+		},
+		{
+			name: "gives up on a 403 after the retries",
+			responses: []scriptedResponse{
+				{status: http.StatusForbidden, retryAfter: "0"},
+				{status: http.StatusForbidden, retryAfter: "0"},
+				{status: http.StatusForbidden, retryAfter: "0"},
+				{status: http.StatusForbidden, retryAfter: "0", body: `{"message": "403 Forbidden"}`},
+			},
+			requests: 4,
+			wantErr:  true,
+		},
+		{
+			name: "retries 429 responses",
+			responses: []scriptedResponse{
+				{status: http.StatusTooManyRequests, retryAfter: "0"},
+				{status: http.StatusTooManyRequests, retryAfter: "0"},
+				{status: http.StatusOK, body: versionBody},
+			},
+			requests: 3,
+		},
+		{
+			name: "retries a 503 response",
+			responses: []scriptedResponse{
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusOK, body: versionBody},
+			},
+			requests: 2,
+		},
+		{
+			name: "mixes retries of both layers",
+			responses: []scriptedResponse{
+				{status: http.StatusForbidden, retryAfter: "0"},
+				{status: http.StatusTooManyRequests},
+				{status: http.StatusOK, body: versionBody},
+			},
+			requests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newScriptedTransport(tt.responses...)
+
+			client, err := glclient.NewClientWithTransport("token", transport, false,
+				glclient.WithBaseURL("https://gitlab.example.com"))
+			require.NoError(t, err)
+
+			version, err := client.ServerVersion(t.Context())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "17.2.0", version)
+			}
+
+			requests := transport.requests()
+			assert.Len(t, requests, tt.requests)
+
+			for _, path := range requests {
+				assert.Equal(t, "/api/v4/version", path)
+			}
+		})
+	}
+}
+
+func TestNewClientWithTransport_requestTimeout(t *testing.T) {
+	transport := newScriptedTransport(scriptedResponse{})
+
+	client, err := glclient.NewClientWithTransport("token", transport, false,
+		glclient.WithBaseURL("https://gitlab.example.com"), glclient.WithRequestTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	_, err = client.ServerVersion(t.Context())
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Len(t, transport.requests(), 1)
+}
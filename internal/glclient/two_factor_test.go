@@ -0,0 +1,76 @@
+package glclient_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTwoFactorStatusRecursively(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockGroups.EXPECT().
+		GetGroup("1", nil, gomock.Any()).
+		Return(&gitlab.Group{ID: 1, Name: "org", FullPath: "org"}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("1", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{
+			{ID: 2, Name: "secure", FullPath: "org/secure", ParentID: 1, RequireTwoFactorAuth: true, TwoFactorGracePeriod: 48},
+		}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("2", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{
+			{ID: 3, Name: "team", FullPath: "org/secure/team", ParentID: 2},
+		}, &gitlab.Response{}, nil)
+
+	mockClient.MockGroups.EXPECT().
+		ListSubGroups("3", gomock.Any(), gomock.Any()).
+		Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+
+	statuses, err := client.GetTwoFactorStatusRecursively(t.Context(), "1")
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	byPath := make(map[string]*glclient.GroupTwoFactor)
+	for _, status := range statuses {
+		byPath[status.GroupPath] = status
+	}
+
+	assert.False(t, byPath["org"].Enforced())
+	assert.False(t, byPath["org"].RequireTwoFactorAuthentication)
+
+	assert.True(t, byPath["org/secure"].Enforced())
+	assert.True(t, byPath["org/secure"].RequireTwoFactorAuthentication)
+	assert.Equal(t, 48, byPath["org/secure"].TwoFactorGracePeriod)
+	assert.Equal(t, "org/secure", byPath["org/secure"].EnforcedBy)
+
+	assert.True(t, byPath["org/secure/team"].Enforced())
+	assert.False(t, byPath["org/secure/team"].RequireTwoFactorAuthentication)
+	assert.Equal(t, "org/secure", byPath["org/secure/team"].EnforcedBy)
+}
+
+func TestTwoFactorStatus(t *testing.T) {
+	t.Run("unknown ancestors do not enforce", func(t *testing.T) {
+		statuses := glclient.TwoFactorStatus([]*gitlab.Group{{ID: 5, FullPath: "org/team", ParentID: 1}})
+
+		require.Len(t, statuses, 1)
+		assert.False(t, statuses[0].Enforced())
+	})
+
+	t.Run("cyclic parents terminate", func(t *testing.T) {
+		statuses := glclient.TwoFactorStatus([]*gitlab.Group{
+			{ID: 1, FullPath: "a", ParentID: 2},
+			{ID: 2, FullPath: "b", ParentID: 1},
+		})
+
+		require.Len(t, statuses, 2)
+		assert.False(t, statuses[0].Enforced())
+		assert.False(t, statuses[1].Enforced())
+	})
+}
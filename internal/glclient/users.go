@@ -0,0 +1,91 @@
+package glclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// userLookup is the memoized result of looking up a single user.
+type userLookup struct {
+	once     sync.Once
+	username string
+	err      error
+}
+
+// Username returns the username of the user with the given ID. Each user is looked up at most once
+// per client, also when requested concurrently, so resolving the users of many items costs one
+// request per distinct user.
+func (c *Client) Username(ctx context.Context, userID int) (string, error) {
+	value, _ := c.usernames.LoadOrStore(userID, &userLookup{})
+	lookup, _ := value.(*userLookup)
+
+	lookup.once.Do(func() {
+		user, _, err := c.client.Users.GetUser(userID, gitlab.GetUsersOptions{}, gitlab.WithContext(ctx))
+		if err != nil {
+			lookup.err = fmt.Errorf("failed to get user %d: %w", userID, err)
+
+			return
+		}
+
+		lookup.username = user.Username
+	})
+
+	return lookup.username, lookup.err
+}
+
+// ResolveGroupTokenUsers sets the username of each token's user. For group access tokens, this is
+// the bot user GitLab creates for the token.
+func (c *Client) ResolveGroupTokenUsers(ctx context.Context, tokens []*GroupAccessTokenWithGroup) {
+	resolveUsernames(ctx, c, tokens,
+		func(t *GroupAccessTokenWithGroup) int { return t.UserID },
+		func(t *GroupAccessTokenWithGroup, username string) { t.Username = username })
+}
+
+// ResolveProjectTokenUsers sets the username of each token's user. For project access tokens, this
+// is the bot user GitLab creates for the token.
+func (c *Client) ResolveProjectTokenUsers(ctx context.Context, tokens []*ProjectAccessTokenWithProject) {
+	resolveUsernames(ctx, c, tokens,
+		func(t *ProjectAccessTokenWithProject) int { return t.UserID },
+		func(t *ProjectAccessTokenWithProject, username string) { t.Username = username })
+}
+
+// resolveUsernames looks up the users of items concurrently. Items whose user cannot be looked up
+// keep an empty username.
+func resolveUsernames[T any](
+	ctx context.Context,
+	c *Client,
+	items []T,
+	userID func(T) int,
+	setUsername func(T, string),
+) {
+	var wg sync.WaitGroup
+
+	for _, item := range items {
+		id := userID(item)
+		if id == 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		c.pool.Submit(func() {
+			defer wg.Done()
+
+			username, err := c.Username(ctx, id)
+			if err != nil {
+				if c.debug {
+					fmt.Printf("DEBUG: error resolving user %d: %v\n", id, err)
+				}
+
+				return
+			}
+
+			setUsername(item, username)
+		})
+	}
+
+	wg.Wait()
+}
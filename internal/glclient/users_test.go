@@ -0,0 +1,125 @@
+package glclient_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsername(t *testing.T) {
+	t.Run("looks up each user once", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			GetUser(300, gomock.Any(), gomock.Any()).
+			Return(&gitlab.User{ID: 300, Username: "group_1_bot_abc"}, &gitlab.Response{}, nil).
+			Times(1)
+
+		var wg sync.WaitGroup
+
+		for range 5 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				username, err := client.Username(t.Context(), 300)
+				assert.NoError(t, err)
+				assert.Equal(t, "group_1_bot_abc", username)
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("remembers failed lookups", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			GetUser(404, gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusNotFound)).
+			Times(1)
+
+		for range 2 {
+			_, err := client.Username(t.Context(), 404)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to get user 404")
+		}
+	})
+}
+
+func TestResolveTokenUsers(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	groupToken := func(id, userID int) *glclient.GroupAccessTokenWithGroup {
+		return &glclient.GroupAccessTokenWithGroup{
+			GroupAccessToken: &gitlab.GroupAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{ID: id, UserID: userID, CreatedAt: &createdAt},
+			},
+		}
+	}
+
+	t.Run("resolves group token users", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			GetUser(300, gomock.Any(), gomock.Any()).
+			Return(&gitlab.User{ID: 300, Username: "group_1_bot_abc"}, &gitlab.Response{}, nil).
+			Times(1)
+		mockClient.MockUsers.EXPECT().
+			GetUser(301, gomock.Any(), gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusForbidden)).
+			Times(1)
+
+		// the token without a user is not looked up
+		tokens := []*glclient.GroupAccessTokenWithGroup{
+			groupToken(1, 300), groupToken(2, 300), groupToken(3, 301), groupToken(4, 0),
+		}
+
+		client.ResolveGroupTokenUsers(t.Context(), tokens)
+
+		assert.Equal(t, "group_1_bot_abc", tokens[0].Username)
+		assert.Equal(t, "group_1_bot_abc", tokens[1].Username)
+		assert.Empty(t, tokens[2].Username)
+		assert.Empty(t, tokens[3].Username)
+		assert.Equal(t, &createdAt, tokens[0].CreatedAt)
+		assert.Equal(t, 300, tokens[0].UserID)
+	})
+
+	t.Run("resolves project token users", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			GetUser(500, gomock.Any(), gomock.Any()).
+			Return(&gitlab.User{ID: 500, Username: "project_7_bot_def"}, &gitlab.Response{}, nil).
+			Times(1)
+
+		tokens := []*glclient.ProjectAccessTokenWithProject{
+			{
+				ProjectAccessToken: &gitlab.ProjectAccessToken{
+					PersonalAccessToken: gitlab.PersonalAccessToken{ID: 1, UserID: 500, CreatedAt: &createdAt},
+				},
+				ProjectPath: "org/app",
+			},
+			{
+				ProjectAccessToken: &gitlab.ProjectAccessToken{
+					PersonalAccessToken: gitlab.PersonalAccessToken{ID: 2, UserID: 500},
+				},
+				ProjectPath: "org/app",
+			},
+		}
+
+		client.ResolveProjectTokenUsers(t.Context(), tokens)
+
+		assert.Equal(t, "project_7_bot_def", tokens[0].Username)
+		assert.Equal(t, "project_7_bot_def", tokens[1].Username)
+		assert.Equal(t, &createdAt, tokens[0].CreatedAt)
+	})
+}
@@ -0,0 +1,99 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var ErrInvalidVersion = errors.New("invalid GitLab version")
+
+// VersionRequirement names a feature and the oldest GitLab version it works with, such as "16.1".
+type VersionRequirement struct {
+	Feature    string
+	MinVersion string
+}
+
+// serverVersion is the GitLab version reported by the instance, fetched once per client.
+type serverVersion struct {
+	version string
+	err     error
+}
+
+// ServerVersion returns the version of the GitLab instance, such as "17.2.1-ee".
+// The version is fetched once, and later calls return the same result.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	c.versionOnce.Do(func() {
+		version, _, err := c.client.Version.GetVersion(gitlab.WithContext(ctx))
+		if err != nil {
+			c.version.err = fmt.Errorf("failed to get GitLab version: %w", err)
+
+			return
+		}
+
+		c.version.version = version.Version
+	})
+
+	return c.version.version, c.version.err
+}
+
+// UnsupportedFeatures returns the version of the GitLab instance and the features of requirements
+// that need a newer one, in the order of requirements.
+func (c *Client) UnsupportedFeatures(
+	ctx context.Context,
+	requirements []VersionRequirement,
+) (string, []string, error) {
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	current, err := parseVersion(version)
+	if err != nil {
+		return version, nil, err
+	}
+
+	var unsupported []string
+
+	for _, requirement := range requirements {
+		minimum, err := parseVersion(requirement.MinVersion)
+		if err != nil {
+			return version, nil, err
+		}
+
+		if slices.Compare(current[:], minimum[:]) < 0 {
+			unsupported = append(unsupported, requirement.Feature)
+		}
+	}
+
+	return version, unsupported, nil
+}
+
+// parseVersion returns the major, minor, and patch numbers of a version such as "16.11.2-ee".
+// Missing minor and patch numbers are zero.
+func parseVersion(version string) ([3]int, error) {
+	var parts [3]int
+
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "-")
+
+	fields := strings.Split(core, ".")
+	if len(fields) > len(parts) {
+		return parts, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+
+		parts[i] = n
+	}
+
+	return parts, nil
+}
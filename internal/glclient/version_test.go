@@ -0,0 +1,88 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+var testRequirements = []glclient.VersionRequirement{
+	{Feature: "all commands", MinVersion: "15.0"},
+	{Feature: "whoami", MinVersion: "15.5"},
+	{Feature: "job-token-scope", MinVersion: "17.0"},
+}
+
+func TestUnsupportedFeatures(t *testing.T) {
+	tests := []struct {
+		version     string
+		unsupported []string
+	}{
+		{version: "17.2.1-ee", unsupported: nil},
+		{version: "17.0.0", unsupported: nil},
+		{version: "16.11.3-ee", unsupported: []string{"job-token-scope"}},
+		{version: "15.4.0", unsupported: []string{"whoami", "job-token-scope"}},
+		{version: "14.10.5", unsupported: []string{"all commands", "whoami", "job-token-scope"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			client, mockClient := testClient(t)
+
+			mockClient.MockVersion.EXPECT().
+				GetVersion(gomock.Any()).
+				Return(&gitlab.Version{Version: tt.version}, &gitlab.Response{}, nil)
+
+			version, unsupported, err := client.UnsupportedFeatures(t.Context(), testRequirements)
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+			assert.Equal(t, tt.unsupported, unsupported)
+		})
+	}
+}
+
+func TestUnsupportedFeatures_invalidVersion(t *testing.T) {
+	client, mockClient := testClient(t)
+
+	mockClient.MockVersion.EXPECT().
+		GetVersion(gomock.Any()).
+		Return(&gitlab.Version{Version: "unknown"}, &gitlab.Response{}, nil)
+
+	_, _, err := client.UnsupportedFeatures(t.Context(), testRequirements)
+	require.ErrorIs(t, err, glclient.ErrInvalidVersion)
+}
+
+func TestServerVersion_fetchedOnce(t *testing.T) {
+	t.Run("version", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockVersion.EXPECT().
+			GetVersion(gomock.Any()).
+			Return(&gitlab.Version{Version: "16.11.3-ee"}, &gitlab.Response{}, nil).
+			Times(1)
+
+		for range 3 {
+			version, err := client.ServerVersion(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, "16.11.3-ee", version)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockVersion.EXPECT().
+			GetVersion(gomock.Any()).
+			Return(nil, nil, errStatus(http.StatusUnauthorized)).
+			Times(1)
+
+		for range 2 {
+			_, err := client.ServerVersion(t.Context())
+			require.Error(t, err)
+		}
+	})
+}
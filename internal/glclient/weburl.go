@@ -0,0 +1,50 @@
+package glclient
+
+import (
+	"net/url"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// webURL returns the web URL of a group, project, or user as reported by the client: without its
+// query string and fragment when the client strips them, unchanged otherwise.
+func (c *Client) webURL(raw string) string {
+	if !c.stripQueryParams {
+		return raw
+	}
+
+	return stripQuery(raw)
+}
+
+// stripWebURLs removes the query string and fragment from the web URLs of the given groups or
+// projects in place, when the client strips them.
+func stripWebURLs[T gitlab.Group | gitlab.Project](c *Client, items []*T) {
+	if !c.stripQueryParams {
+		return
+	}
+
+	for _, item := range items {
+		switch item := any(item).(type) {
+		case *gitlab.Group:
+			item.WebURL = stripQuery(item.WebURL)
+		case *gitlab.Project:
+			item.WebURL = stripQuery(item.WebURL)
+		}
+	}
+}
+
+// stripQuery removes the query string and fragment from rawURL. Values that do not parse as URLs are
+// returned unchanged.
+func stripQuery(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String()
+}
@@ -0,0 +1,93 @@
+package glclient_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/testing"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWithStrippedQueryParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		webURL string
+		want   string
+	}{
+		{"query", "https://gitlab.example.com/org/api?utm_source=mail&ref=1", "https://gitlab.example.com/org/api"},
+		{"fragment", "https://gitlab.example.com/org/api#readme", "https://gitlab.example.com/org/api"},
+		{"query and fragment", "https://gitlab.example.com/org/api?ref=1#readme", "https://gitlab.example.com/org/api"},
+		{"empty query", "https://gitlab.example.com/org/api?", "https://gitlab.example.com/org/api"},
+		{"plain", "https://gitlab.example.com/org/api", "https://gitlab.example.com/org/api"},
+		{"escaped path", "https://gitlab.example.com/org/caf%C3%A9?ref=1", "https://gitlab.example.com/org/caf%C3%A9"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := gitlabtesting.NewTestClient(t)
+			client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithStrippedQueryParams())
+
+			project := &gitlab.Project{
+				ID:                1,
+				PathWithNamespace: "org/api",
+				Namespace:         &gitlab.ProjectNamespace{FullPath: "org"},
+				WebURL:            tt.webURL,
+			}
+
+			mockClient.MockProjects.EXPECT().
+				GetProject("1", nil, gomock.Any()).
+				Return(project, &gitlab.Response{}, nil)
+
+			mockClient.MockPipelineTriggers.EXPECT().
+				ListPipelineTriggers("1", gomock.Any(), gomock.Any()).
+				Return([]*gitlab.PipelineTrigger{{ID: 7}}, &gitlab.Response{}, nil)
+
+			triggers, err := client.GetPipelineTriggers(t.Context(), "1")
+			require.NoError(t, err)
+
+			require.Len(t, triggers, 1)
+			assert.Equal(t, tt.want, triggers[0].ProjectWebURL)
+		})
+	}
+}
+
+func TestGetGroupsRecursively_webURLs(t *testing.T) {
+	const webURL = "https://gitlab.example.com/org?utm_source=mail"
+
+	expectGroups := func(mockClient *gitlabtesting.TestClient) {
+		mockClient.MockGroups.EXPECT().
+			GetGroup("1", nil, gomock.Any()).
+			Return(&gitlab.Group{ID: 1, FullPath: "org", WebURL: webURL}, &gitlab.Response{}, nil)
+
+		mockClient.MockGroups.EXPECT().
+			ListSubGroups("1", gomock.Any(), gomock.Any()).
+			Return([]*gitlab.Group{}, &gitlab.Response{}, nil)
+	}
+
+	t.Run("keeps query parameters by default", func(t *testing.T) {
+		client, mockClient := testClient(t)
+		expectGroups(mockClient)
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		require.Len(t, groups, 1)
+		assert.Equal(t, webURL, groups[0].WebURL)
+	})
+
+	t.Run("strips query parameters when requested", func(t *testing.T) {
+		mockClient := gitlabtesting.NewTestClient(t)
+		client := glclient.NewClientWithGitLabClient(mockClient.Client, false, glclient.WithStrippedQueryParams())
+		expectGroups(mockClient)
+
+		groups, err := client.GetGroupsRecursively(t.Context(), "1")
+		require.NoError(t, err)
+
+		require.Len(t, groups, 1)
+		assert.Equal(t, "https://gitlab.example.com/org", groups[0].WebURL)
+	})
+}
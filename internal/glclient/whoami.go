@@ -0,0 +1,110 @@
+package glclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// tokenExpiryWarning is how long before expiry a token is reported as expiring soon.
+const tokenExpiryWarning = 7 * 24 * time.Hour
+
+// reportScopes are the token scopes that allow read access to the API used by the report commands.
+var reportScopes = []string{"read_api", "api"}
+
+// TokenInfo describes the authenticated user and the token used to access the API.
+type TokenInfo struct {
+	Username   string          `json:"username"`
+	Name       string          `json:"name"`
+	UserWebURL string          `json:"user_web_url"`
+	IsAdmin    bool            `json:"is_admin"`
+	TokenName  string          `json:"token_name"`
+	Scopes     []string        `json:"scopes"`
+	Active     bool            `json:"active"`
+	ExpiresAt  *gitlab.ISOTime `json:"expires_at"`
+	Warnings   []string        `json:"warnings"`
+}
+
+// GetTokenInfo fetches the authenticated user and the details of the token in use, and warns
+// about tokens that cannot be used by the report commands. Token details are only available
+// for personal, project, and group access tokens; for other tokens a warning is added instead.
+func (c *Client) GetTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	user, _, err := c.client.Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	info := &TokenInfo{
+		Username:   user.Username,
+		Name:       user.Name,
+		UserWebURL: c.webURL(user.WebURL),
+		IsAdmin:    user.IsAdmin,
+	}
+
+	token, _, err := c.client.PersonalAccessTokens.GetSinglePersonalAccessToken(gitlab.WithContext(ctx))
+	if err != nil {
+		if !isClientError(err) {
+			return nil, fmt.Errorf("failed to get token details: %w", err)
+		}
+
+		if c.debug {
+			fmt.Printf("DEBUG: token details unavailable: %v\n", err)
+		}
+
+		info.Warnings = append(info.Warnings,
+			"token details are unavailable, scopes and expiry cannot be checked (not an access token?)")
+
+		return info, nil
+	}
+
+	info.TokenName = token.Name
+	info.Scopes = token.Scopes
+	info.Active = token.Active
+	info.ExpiresAt = token.ExpiresAt
+	info.Warnings = tokenWarnings(token, time.Now())
+
+	return info, nil
+}
+
+// tokenWarnings lists the problems that prevent or will soon prevent the token from running reports.
+func tokenWarnings(token *gitlab.PersonalAccessToken, now time.Time) []string {
+	var warnings []string
+
+	if !slices.ContainsFunc(reportScopes, func(scope string) bool { return slices.Contains(token.Scopes, scope) }) {
+		warnings = append(warnings, "token has neither the read_api nor the api scope, report commands will fail")
+	}
+
+	if !token.Active || token.Revoked {
+		warnings = append(warnings, "token is not active")
+	}
+
+	if token.ExpiresAt != nil {
+		switch remaining := time.Time(*token.ExpiresAt).Sub(now); {
+		case remaining < 0:
+			warnings = append(warnings, fmt.Sprintf("token expired on %s", token.ExpiresAt))
+		case remaining < tokenExpiryWarning:
+			warnings = append(warnings, fmt.Sprintf("token expires soon, on %s", token.ExpiresAt))
+		}
+	}
+
+	return warnings
+}
+
+// isClientError reports whether the API rejected a request with a 4xx status.
+func isClientError(err error) bool {
+	if isNotFound(err) {
+		return true
+	}
+
+	var errResp *gitlab.ErrorResponse
+
+	return errors.As(err, &errResp) &&
+		errResp.Response != nil &&
+		errResp.Response.StatusCode >= http.StatusBadRequest &&
+		errResp.Response.StatusCode < http.StatusInternalServerError
+}
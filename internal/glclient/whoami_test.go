@@ -0,0 +1,128 @@
+package glclient_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetTokenInfo(t *testing.T) {
+	user := &gitlab.User{
+		Username: "jdoe",
+		Name:     "J. Doe",
+		WebURL:   "https://gitlab.com/jdoe",
+	}
+
+	isoDate := func(d time.Duration) *gitlab.ISOTime {
+		date := gitlab.ISOTime(time.Now().Add(d))
+
+		return &date
+	}
+
+	t.Run("reports user and token details", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		expiresAt := isoDate(90 * 24 * time.Hour)
+
+		mockClient.MockUsers.EXPECT().
+			CurrentUser(gomock.Any()).
+			Return(user, &gitlab.Response{}, nil)
+
+		mockClient.MockPersonalAccessTokens.EXPECT().
+			GetSinglePersonalAccessToken(gomock.Any()).
+			Return(&gitlab.PersonalAccessToken{
+				Name:      "audit",
+				Scopes:    []string{"read_api", "read_repository"},
+				Active:    true,
+				ExpiresAt: expiresAt,
+			}, &gitlab.Response{}, nil)
+
+		info, err := client.GetTokenInfo(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe", info.Username)
+		assert.Equal(t, "J. Doe", info.Name)
+		assert.Equal(t, "audit", info.TokenName)
+		assert.Equal(t, []string{"read_api", "read_repository"}, info.Scopes)
+		assert.True(t, info.Active)
+		assert.Equal(t, expiresAt, info.ExpiresAt)
+		assert.Empty(t, info.Warnings)
+	})
+
+	t.Run("warns about insufficient scopes and expiry", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			CurrentUser(gomock.Any()).
+			Return(user, &gitlab.Response{}, nil)
+
+		mockClient.MockPersonalAccessTokens.EXPECT().
+			GetSinglePersonalAccessToken(gomock.Any()).
+			Return(&gitlab.PersonalAccessToken{
+				Name:      "registry",
+				Scopes:    []string{"read_registry"},
+				Active:    true,
+				ExpiresAt: isoDate(2 * 24 * time.Hour),
+			}, &gitlab.Response{}, nil)
+
+		info, err := client.GetTokenInfo(t.Context())
+		require.NoError(t, err)
+		require.Len(t, info.Warnings, 2)
+		assert.Contains(t, info.Warnings[0], "read_api")
+		assert.Contains(t, info.Warnings[1], "expires soon")
+	})
+
+	t.Run("warns about expired and inactive tokens", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			CurrentUser(gomock.Any()).
+			Return(user, &gitlab.Response{}, nil)
+
+		mockClient.MockPersonalAccessTokens.EXPECT().
+			GetSinglePersonalAccessToken(gomock.Any()).
+			Return(&gitlab.PersonalAccessToken{
+				Scopes:    []string{"api"},
+				ExpiresAt: isoDate(-48 * time.Hour),
+			}, &gitlab.Response{}, nil)
+
+		info, err := client.GetTokenInfo(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"token is not active"}, info.Warnings[:1])
+		assert.Contains(t, info.Warnings[1], "token expired on")
+	})
+
+	t.Run("keeps user details when token details are unavailable", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			CurrentUser(gomock.Any()).
+			Return(user, &gitlab.Response{}, nil)
+
+		mockClient.MockPersonalAccessTokens.EXPECT().
+			GetSinglePersonalAccessToken(gomock.Any()).
+			Return(nil, nil, &gitlab.ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnauthorized}})
+
+		info, err := client.GetTokenInfo(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe", info.Username)
+		require.Len(t, info.Warnings, 1)
+		assert.Contains(t, info.Warnings[0], "token details are unavailable")
+	})
+
+	t.Run("returns error when current user fails", func(t *testing.T) {
+		client, mockClient := testClient(t)
+
+		mockClient.MockUsers.EXPECT().
+			CurrentUser(gomock.Any()).
+			Return(nil, nil, errAPI)
+
+		info, err := client.GetTokenInfo(t.Context())
+		require.ErrorIs(t, err, errAPI)
+		assert.Nil(t, info)
+	})
+}
@@ -0,0 +1,81 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	t := f.newTable()
+	header := append(table.Row{"Source"}, f.identifier(IDFormatPath, "ID", "Path")...)
+	header = append(header, "Username", "Name", "Requested At", "Pending")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, request := range requests {
+		requestedAt := defaultTextPlaceholder
+		if request.RequestedAt != nil {
+			requestedAt = f.formatTime(*request.RequestedAt)
+		}
+
+		target := accessRequestTarget(request)
+		pathLink := f.link(request.SourceWebURL, target, request.SourcePath)
+
+		row := append(table.Row{request.Source}, f.identifier(IDFormatPath, request.SourceID, pathLink)...)
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(row,
+			request.Username,
+			request.Name,
+			requestedAt,
+			fmt.Sprintf("%d days", request.PendingDays),
+		), request.SourceWebURL, target), request.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+// accessRequestTarget returns the access requests page of the group or project request was made for.
+func accessRequestTarget(request *glclient.AccessRequestWithSource) LinkTarget {
+	if request.Source == "group" {
+		return LinkGroupAccessRequests
+	}
+
+	return LinkProjectAccessRequests
+}
+
+func (f *JSONFormatter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	return f.encode(requests, len(requests), "access requests")
+}
+
+func (f *CSVFormatter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(requests[0]))); err != nil {
+		return err
+	}
+
+	for _, request := range requests {
+		row := f.withLinkStatus(f.row(request), request.SourceWebURL, accessRequestTarget(request))
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatAccessRequests(_ []*glclient.AccessRequestWithSource) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	return f.render("access requests", requests)
+}
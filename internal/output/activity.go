@@ -0,0 +1,66 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Last Activity", "Inactive")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range activity {
+		lastActivity, inactive := defaultTextPlaceholder, defaultTextPlaceholder
+		if project.LastActivityAt != nil {
+			lastActivity = f.formatTime(*project.LastActivityAt)
+			inactive = fmt.Sprintf("%d days", project.InactiveDays)
+		}
+
+		pathLink := f.link(project.ProjectWebURL, LinkActivity, project.ProjectPath)
+
+		row := append(f.identifier(IDFormatPath, project.ProjectID, pathLink), lastActivity, inactive)
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, project.ProjectWebURL, LinkActivity), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	return f.encode(activity, len(activity), "project activity")
+}
+
+func (f *CSVFormatter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	if len(activity) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(activity[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range activity {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkActivity)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectActivity(_ []*glclient.ProjectActivity) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	return f.render("project activity", activity)
+}
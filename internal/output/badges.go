@@ -0,0 +1,99 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	checked := false
+
+	for _, badge := range badges {
+		if badge.ImageStatus != 0 || badge.ImageError != "" {
+			checked = true
+
+			break
+		}
+	}
+
+	t := f.newTable()
+
+	header := append(table.Row{"Source"}, f.identifier(IDFormatPath, "ID", "Path")...)
+	header = append(header, "Name", "Link URL", "Image URL")
+	if checked {
+		header = append(header, "Image Status")
+	}
+
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, badge := range badges {
+		pathLink := f.link(badge.SourceWebURL, LinkBadges, badge.SourcePath)
+
+		row := append(table.Row{badge.Source}, f.identifier(IDFormatPath, badge.SourceID, pathLink)...)
+		row = append(row,
+			badge.Name,
+			renderedOrRaw(badge.RenderedLinkURL, badge.LinkURL),
+			renderedOrRaw(badge.RenderedImageURL, badge.ImageURL),
+		)
+		if checked {
+			row = append(row, badgeImageStatus(badge))
+		}
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, badge.SourceWebURL, LinkBadges), badge.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	return f.encode(badges, len(badges), "badges")
+}
+
+func (f *CSVFormatter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	if len(badges) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	headers := f.withLinkStatusHeaders(getCSVHeaders(badges[0]))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	for _, badge := range badges {
+		row := f.withLinkStatus(f.row(badge), badge.SourceWebURL, LinkBadges)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderedOrRaw prefers the URL rendered by GitLab and falls back to the raw templated URL.
+func renderedOrRaw(rendered, raw string) string {
+	if rendered != "" {
+		return rendered
+	}
+
+	return raw
+}
+
+func badgeImageStatus(badge *glclient.BadgeWithSource) string {
+	switch {
+	case badge.ImageStatus != 0:
+		return strconv.Itoa(badge.ImageStatus)
+	case badge.ImageError != "":
+		return "error"
+	default:
+		return defaultTextPlaceholder
+	}
+}
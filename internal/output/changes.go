@@ -0,0 +1,55 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+var ErrDotenvChanges = fmt.Errorf("%w: dotenv cannot show changes against a baseline", ErrUnsupportedFormat)
+
+func (f *TableFormatter) FormatChanges(changes []report.Change) error {
+	t := f.newTable()
+	t.AppendHeader(table.Row{"Change", "Item", "Changed Fields"})
+
+	for _, change := range changes {
+		t.AppendRow(table.Row{change.Change, change.Item, strings.Join(change.Fields, ", ")})
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatChanges(changes []report.Change) error {
+	return f.encode(changes, len(changes), "changes")
+}
+
+func (f *CSVFormatter) FormatChanges(changes []report.Change) error {
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, []string{"change", "item", "fields"}); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		row := []string{string(change.Change), change.Item, strings.Join(change.Fields, " ")}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatChanges(_ []report.Change) error {
+	return ErrDotenvChanges
+}
+
+func (f *TemplateFormatter) FormatChanges(changes []report.Change) error {
+	return f.render("changes", changes)
+}
@@ -0,0 +1,89 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"CI/CD", "Public Pipelines", "Git Strategy", "Git Depth", "Timeout", "Job Token Allowlist", "Job Token Push")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range settings {
+		row := f.identifier(IDFormatPath, project.ProjectID,
+			f.link(project.ProjectWebURL, LinkCICDSettings, project.ProjectPath))
+
+		// the remaining settings have no effect while CI/CD is disabled
+		if !project.CIEnabled {
+			row = append(row, "Disabled",
+				defaultTextPlaceholder, defaultTextPlaceholder, defaultTextPlaceholder,
+				defaultTextPlaceholder, defaultTextPlaceholder, defaultTextPlaceholder)
+			t.AppendRow(f.withInstance(f.withLinkStatus(row, project.ProjectWebURL, LinkCICDSettings), project.Instance))
+
+			continue
+		}
+
+		row = append(row, "Enabled",
+			project.PublicPipelines,
+			project.GitStrategy,
+			gitDepth(project.GitDepth),
+			(time.Duration(project.Timeout) * time.Second).String(),
+			project.JobTokenAllowlistEnabled,
+			project.JobTokenPushAllowed,
+		)
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, project.ProjectWebURL, LinkCICDSettings), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+// gitDepth returns the shallow clone depth, where 0 means a full clone.
+func gitDepth(depth int) string {
+	if depth == 0 {
+		return "Full"
+	}
+
+	return strconv.Itoa(depth)
+}
+
+func (f *JSONFormatter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	return f.encode(settings, len(settings), "CI/CD settings")
+}
+
+func (f *CSVFormatter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(settings[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range settings {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkCICDSettings)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatCISettings(_ []*glclient.ProjectCISettings) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	return f.render("CI/CD settings", settings)
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+// agentStatusText are the connection statuses of Kubernetes agents as shown in the table.
+var agentStatusText = map[string]string{
+	glclient.AgentConnected:      "Connected",
+	glclient.AgentNotConnected:   "Not connected",
+	glclient.AgentNeverConnected: "Never connected",
+}
+
+func (f *TableFormatter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Agent", "Config Project", "Status", "Last Contact", "Created At")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, agent := range agents {
+		lastContact := defaultLastUsedText
+		if agent.LastContactAt != nil {
+			lastContact = f.formatTime(*agent.LastContactAt)
+		}
+
+		pathLink := f.link(agent.ProjectWebURL, LinkClusterAgents, agent.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, agent.ProjectID, pathLink),
+			agent.Name,
+			textOrPlaceholder(agent.ConfigProject),
+			textOrPlaceholder(agentStatusText[agent.ConnectionStatus]),
+			lastContact,
+			f.tokenTime(agent.CreatedAt),
+		), agent.ProjectWebURL, LinkClusterAgents), agent.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	return f.encode(agents, len(agents), "cluster agents")
+}
+
+func (f *CSVFormatter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	if len(agents) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(agents[0]))); err != nil {
+		return err
+	}
+
+	for _, agent := range agents {
+		row := f.withLinkStatus(f.row(agent), agent.ProjectWebURL, LinkClusterAgents)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatClusterAgents(_ []*glclient.ProjectClusterAgent) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	return f.render("cluster agents", agents)
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"), "Compliance Frameworks")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range frameworks {
+		names := defaultTextPlaceholder
+		if project.FrameworksAvailable {
+			names = "None"
+			if len(project.Frameworks) > 0 {
+				names = strings.Join(project.Frameworks, ", ")
+			}
+		}
+
+		pathLink := f.link(project.ProjectWebURL, LinkComplianceFrameworks, project.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, project.ProjectID, pathLink),
+			names,
+		), project.ProjectWebURL, LinkComplianceFrameworks), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	return f.encode(frameworks, len(frameworks), "compliance frameworks")
+}
+
+func (f *CSVFormatter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	if len(frameworks) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(frameworks[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range frameworks {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkComplianceFrameworks)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatComplianceFrameworks(_ []*glclient.ProjectComplianceFrameworks) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	return f.render("compliance frameworks", frameworks)
+}
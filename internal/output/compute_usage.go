@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatBoth, "ID", "Group"), "Month", "Minutes Used", "Minutes Limit", "Over Limit")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, group := range usage {
+		limit := defaultTextPlaceholder
+		if group.MinutesLimit > 0 {
+			limit = strconv.Itoa(group.MinutesLimit)
+		}
+
+		groupLink := f.link(group.GroupWebURL, LinkUsageQuotas, group.GroupPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatBoth, group.GroupID, groupLink),
+			group.Month,
+			strconv.Itoa(group.MinutesUsed),
+			limit,
+			group.OverLimit,
+		), group.GroupWebURL, LinkUsageQuotas), group.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	return f.encode(usage, len(usage), "compute usage")
+}
+
+func (f *CSVFormatter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(usage[0]))); err != nil {
+		return err
+	}
+
+	for _, group := range usage {
+		row := f.withLinkStatus(f.row(group), group.GroupWebURL, LinkUsageQuotas)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatComputeUsage(_ []*glclient.GroupComputeUsage) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	return f.render("compute usage", usage)
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Default Branch")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range branches {
+		branch := project.DefaultBranch
+		if branch == "" {
+			branch = defaultTextPlaceholder
+		}
+
+		pathLink := f.link(project.ProjectWebURL, LinkDefaultBranch, project.ProjectPath)
+
+		row := append(f.identifier(IDFormatPath, project.ProjectID, pathLink), branch)
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, project.ProjectWebURL, LinkDefaultBranch), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	return f.encode(branches, len(branches), "default branches")
+}
+
+func (f *CSVFormatter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	if len(branches) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(branches[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range branches {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkDefaultBranch)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatDefaultBranches(_ []*glclient.ProjectDefaultBranch) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	return f.render("default branches", branches)
+}
@@ -0,0 +1,183 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var (
+	ErrDotenvVariablesOnly  = fmt.Errorf("%w: dotenv is only available for variable reports", ErrUnsupportedFormat)
+	ErrDotenvRequiresValues = errors.New("dotenv output exposes variable values and requires --include-values")
+
+	validDotenvKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+const (
+	dotenvDefaultEnvironment string = "*"
+	dotenvHiddenNote         string = "hidden variable, its value is not returned by the API"
+	dotenvFileNote           string = "file variable, in CI/CD its value is written to a temporary file"
+	dotenvInvalidKeyNote     string = "skipped, not a valid shell variable name"
+)
+
+// DotenvFormatter writes CI/CD variables as KEY=VALUE lines that can be sourced by a shell.
+// Only the variable reports are supported.
+type DotenvFormatter struct {
+	sink
+}
+
+// dotenvEntry is a variable in a form shared by project, group, and unified variables.
+type dotenvEntry struct {
+	key              string
+	value            string
+	variableType     string
+	environmentScope string
+	hidden           bool
+	source           string
+}
+
+func (f *DotenvFormatter) FormatGroups(_ []*gitlab.Group) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatProjects(_ []*gitlab.Project) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatGroupAccessTokens(_ []*glclient.GroupAccessTokenWithGroup) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatProjectAccessTokens(_ []*glclient.ProjectAccessTokenWithProject) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatPipelineTriggers(_ []*glclient.PipelineTriggerWithProject) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatBadges(_ []*glclient.BadgeWithSource) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatProjectVariables(
+	variables []*glclient.ProjectVariableWithProject,
+	includeValues bool,
+) error {
+	entries := make([]dotenvEntry, 0, len(variables))
+
+	for _, variable := range variables {
+		if variable.ProjectVariable == nil {
+			continue
+		}
+
+		entries = append(entries, dotenvEntry{
+			key:              variable.Key,
+			value:            variable.Value,
+			variableType:     string(variable.VariableType),
+			environmentScope: variable.EnvironmentScope,
+			hidden:           variable.Hidden,
+			source:           "project " + variable.ProjectPath,
+		})
+	}
+
+	return writeDotenv(f.writer(), entries, includeValues)
+}
+
+func (f *DotenvFormatter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error {
+	entries := make([]dotenvEntry, 0, len(variables))
+
+	for _, variable := range variables {
+		if variable.GroupVariable == nil {
+			continue
+		}
+
+		entries = append(entries, dotenvEntry{
+			key:              variable.Key,
+			value:            variable.Value,
+			variableType:     string(variable.VariableType),
+			environmentScope: variable.EnvironmentScope,
+			hidden:           variable.Hidden,
+			source:           "group " + variable.GroupFullPath,
+		})
+	}
+
+	return writeDotenv(f.writer(), entries, includeValues)
+}
+
+func (f *DotenvFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
+	entries := make([]dotenvEntry, 0, len(variables))
+
+	for _, variable := range variables {
+		entries = append(entries, dotenvEntry{
+			key:              variable.Key,
+			value:            variable.Value,
+			variableType:     variable.VariableType,
+			environmentScope: variable.EnvironmentScope,
+			hidden:           variable.Hidden,
+			source:           variable.Source + " " + variable.SourcePath,
+		})
+	}
+
+	return writeDotenv(f.writer(), entries, includeValues)
+}
+
+// writeDotenv prints the entries to w. A comment naming the source and environment scope
+// is written whenever either changes, so variables from different projects or scopes stay apart.
+func writeDotenv(w io.Writer, entries []dotenvEntry, includeValues bool) error {
+	if !includeValues {
+		return ErrDotenvRequiresValues
+	}
+
+	var (
+		b       strings.Builder
+		section string
+	)
+
+	for _, entry := range entries {
+		scope := entry.environmentScope
+		if scope == "" {
+			scope = dotenvDefaultEnvironment
+		}
+
+		if current := fmt.Sprintf("# %s (environment: %s)", entry.source, scope); current != section {
+			if section != "" {
+				b.WriteString("\n")
+			}
+
+			section = current
+			b.WriteString(section + "\n")
+		}
+
+		switch {
+		case !validDotenvKey.MatchString(entry.key):
+			fmt.Fprintf(&b, "# %q: %s\n", entry.key, dotenvInvalidKeyNote)
+		case entry.hidden:
+			fmt.Fprintf(&b, "# %s: %s\n", entry.key, dotenvHiddenNote)
+		default:
+			if entry.variableType == string(gitlab.FileVariableType) {
+				fmt.Fprintf(&b, "# %s: %s\n", entry.key, dotenvFileNote)
+			}
+
+			fmt.Fprintf(&b, "%s=%s\n", entry.key, shellQuote(entry.value))
+		}
+	}
+
+	if _, err := fmt.Fprint(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write dotenv output: %w", err)
+	}
+
+	return nil
+}
+
+// shellQuote wraps s in single quotes, which disable every kind of shell expansion.
+// Each embedded single quote becomes the four characters quote, backslash, quote, quote, which
+// close the quoted string, emit an escaped quote, and reopen it.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,196 @@
+package output_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// readStdout runs fn and returns everything it wrote to stdout.
+func readStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	old := os.Stdout
+	os.Stdout = w
+
+	fn()
+
+	os.Stdout = old
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(out)
+}
+
+func projectVariable(key, value string) *glclient.ProjectVariableWithProject {
+	return &glclient.ProjectVariableWithProject{
+		ProjectVariable: &gitlab.ProjectVariable{
+			Key:              key,
+			Value:            value,
+			VariableType:     gitlab.EnvVariableType,
+			EnvironmentScope: "*",
+		},
+		ProjectPath: "backend/api-service",
+	}
+}
+
+func TestDotenvFormatter_FormatProjectVariables(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain value", "secret123", "KEY='secret123'\n"},
+		{"empty value", "", "KEY=''\n"},
+		{"single quotes", "it's", `KEY='it'\''s'` + "\n"},
+		{"only a single quote", "'", `KEY=''\'''` + "\n"},
+		{"shell expansion", "$HOME `id` $(id) ${PATH}", "KEY='$HOME `id` $(id) ${PATH}'\n"},
+		{"double quotes and backslashes", `say "hi" \n`, `KEY='say "hi" \n'` + "\n"},
+		{"spaces and hash", "a b # not a comment", "KEY='a b # not a comment'\n"},
+		{"newlines", "line1\nline2", "KEY='line1\nline2'\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := output.NewFormatter(output.FormatDotenv)
+			require.NoError(t, err)
+
+			out := readStdout(t, func() {
+				err = formatter.FormatProjectVariables([]*glclient.ProjectVariableWithProject{
+					projectVariable("KEY", tt.value),
+				}, true)
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, "# project backend/api-service (environment: *)\n"+tt.want, out)
+		})
+	}
+
+	t.Run("notes file, hidden, and invalid variables", func(t *testing.T) {
+		fileVariable := projectVariable("CONFIG_FILE", "key: value")
+		fileVariable.VariableType = gitlab.FileVariableType
+
+		hiddenVariable := projectVariable("HIDDEN", "")
+		hiddenVariable.Hidden = true
+
+		formatter := &output.DotenvFormatter{}
+
+		var err error
+
+		out := readStdout(t, func() {
+			err = formatter.FormatProjectVariables([]*glclient.ProjectVariableWithProject{
+				fileVariable,
+				hiddenVariable,
+				projectVariable("BAD-KEY", "value"),
+			}, true)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "# project backend/api-service (environment: *)\n"+
+			"# CONFIG_FILE: file variable, in CI/CD its value is written to a temporary file\n"+
+			"CONFIG_FILE='key: value'\n"+
+			"# HIDDEN: hidden variable, its value is not returned by the API\n"+
+			"# \"BAD-KEY\": skipped, not a valid shell variable name\n", out)
+	})
+
+	t.Run("separates sources and environments", func(t *testing.T) {
+		production := projectVariable("DB_HOST", "db.prod")
+		production.EnvironmentScope = "production"
+
+		formatter := &output.DotenvFormatter{}
+
+		var err error
+
+		out := readStdout(t, func() {
+			err = formatter.FormatProjectVariables([]*glclient.ProjectVariableWithProject{
+				projectVariable("DB_HOST", "localhost"),
+				production,
+			}, true)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "# project backend/api-service (environment: *)\n"+
+			"DB_HOST='localhost'\n"+
+			"\n"+
+			"# project backend/api-service (environment: production)\n"+
+			"DB_HOST='db.prod'\n", out)
+	})
+
+	t.Run("requires values", func(t *testing.T) {
+		formatter := &output.DotenvFormatter{}
+
+		err := formatter.FormatProjectVariables([]*glclient.ProjectVariableWithProject{
+			projectVariable("KEY", "value"),
+		}, false)
+		require.ErrorIs(t, err, output.ErrDotenvRequiresValues)
+	})
+}
+
+func TestDotenvFormatter_FormatUnifiedVariables(t *testing.T) {
+	formatter := &output.DotenvFormatter{}
+
+	var err error
+
+	out := readStdout(t, func() {
+		err = formatter.FormatUnifiedVariables([]*glclient.VariableWithSource{
+			{Key: "GROUP_VAR", Value: "g", Source: "group", SourcePath: "backend"},
+			{Key: "PROJECT_VAR", Value: "p", Source: "project", SourcePath: "backend/api-service"},
+		}, true)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "# group backend (environment: *)\n"+
+		"GROUP_VAR='g'\n"+
+		"\n"+
+		"# project backend/api-service (environment: *)\n"+
+		"PROJECT_VAR='p'\n", out)
+}
+
+func TestDotenvFormatter_unsupportedReports(t *testing.T) {
+	formatter := &output.DotenvFormatter{}
+
+	require.ErrorIs(t, formatter.FormatGroups(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjects(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatGroupAccessTokens(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectAccessTokens(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatPipelineTriggers(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatBadges(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatPushRules(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatTwoFactor(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectStorage(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectRegistries(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatComputeUsage(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatCISettings(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatDefaultBranches(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectActivity(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatIntegrations(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatJobTokenScopes(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProtectedEnvironments(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatClusterAgents(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatSecurityConfigs(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatComplianceFrameworks(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectRunners(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatRunnerSettings(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectForks(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatImpersonationTokens(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectTopics(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatEpics(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatProjectMilestones(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatGroupMilestones(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatUnifiedMilestones(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatVariableDifferences(nil, true), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatSourceCounts(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatInstanceTokenSettings(nil), output.ErrUnsupportedFormat)
+	require.ErrorIs(t, formatter.FormatRateLimit(nil), output.ErrUnsupportedFormat)
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var ErrEnvelopeRequiresJSON = fmt.Errorf(
+	"%w: the envelope is only available with the json format", ErrUnsupportedFormat)
+
+// Metadata describes how a report was produced. It is filled in by the caller, which knows the
+// selection and the timing of the run.
+type Metadata struct {
+	GeneratedAt time.Time
+	GitLabURL   string
+	RootGroup   string
+}
+
+// envelope wraps the items of a report together with its metadata.
+type envelope struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	GitLabURL   string    `json:"gitlab_url"`
+	RootGroup   string    `json:"root_group"`
+	Total       int       `json:"total"`
+	Items       any       `json:"items"`
+}
+
+// WithEnvelope wraps JSON reports in an object carrying the given metadata and the number of items,
+// instead of writing a bare array.
+func WithEnvelope(meta Metadata) Option {
+	return WithEnvelopeFunc(func() Metadata { return meta })
+}
+
+// WithEnvelopeFunc wraps JSON reports like WithEnvelope, with the metadata returned by metadata when
+// each report is written. It suits callers that create the formatter before the data is fetched and
+// only then know the root group and when the data was gathered.
+func WithEnvelopeFunc(metadata func() Metadata) Option {
+	return func(o *options) {
+		o.envelope = metadata
+	}
+}
+
+// encode writes the items of a report as JSON, nested in a tree or flattened and wrapped in an
+// envelope when configured.
+// total is passed separately because filtered variables are converted to another type first.
+func (f *JSONFormatter) encode(items any, total int, what string) error {
+	switch {
+	case f.tree:
+		tree, err := treeItems(items, f.treeItem)
+		if err != nil {
+			return err
+		}
+
+		items = tree
+	case f.flatten:
+		items = flattenItems(items)
+	}
+
+	v := items
+	if f.envelope != nil {
+		meta := f.envelope()
+		v = envelope{
+			GeneratedAt: meta.GeneratedAt,
+			GitLabURL:   meta.GitLabURL,
+			RootGroup:   meta.RootGroup,
+			Total:       total,
+			Items:       items,
+		}
+	}
+
+	encoder := json.NewEncoder(f.writer())
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s as JSON: %w", what, err)
+	}
+
+	return nil
+}
+
+// treeItem returns the value a report item is written as in a tree, its flat object when flattening.
+func (f *JSONFormatter) treeItem(item reflect.Value) any {
+	if f.flatten {
+		return flattenItem(item)
+	}
+
+	return item.Interface()
+}
@@ -0,0 +1,111 @@
+package output_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestJSONFormatter_envelope(t *testing.T) {
+	meta := output.Metadata{
+		GeneratedAt: time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC),
+		GitLabURL:   "https://gitlab.example.com",
+		RootGroup:   "backend",
+	}
+
+	t.Run("wraps items with metadata", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithEnvelope(meta))
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			err = formatter.FormatGroups([]*gitlab.Group{{ID: 1, FullPath: "backend"}, {ID: 2, FullPath: "backend/api"}})
+		})
+		require.NoError(t, err)
+
+		var got struct {
+			GeneratedAt string           `json:"generated_at"`
+			GitLabURL   string           `json:"gitlab_url"`
+			RootGroup   string           `json:"root_group"`
+			Total       int              `json:"total"`
+			Items       []map[string]any `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &got))
+
+		assert.Equal(t, "2025-03-04T05:06:07Z", got.GeneratedAt)
+		assert.Equal(t, "https://gitlab.example.com", got.GitLabURL)
+		assert.Equal(t, "backend", got.RootGroup)
+		assert.Equal(t, 2, got.Total)
+		require.Len(t, got.Items, 2)
+		assert.Equal(t, "backend/api", got.Items[1]["full_path"])
+	})
+
+	t.Run("keeps values out of filtered variables", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithEnvelope(meta))
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			err = formatter.FormatProjectVariables(
+				[]*glclient.ProjectVariableWithProject{projectVariable("API_KEY", "secret")}, false)
+		})
+		require.NoError(t, err)
+		assert.Contains(t, out, `"total": 1`)
+		assert.Contains(t, out, `"key": "API_KEY"`)
+		assert.NotContains(t, out, "secret")
+	})
+
+	t.Run("reads the metadata when the report is written", func(t *testing.T) {
+		// the root group is only known once picked interactively, after the formatter is created
+		rootGroup := ""
+		calls := 0
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithEnvelopeFunc(func() output.Metadata {
+			calls++
+
+			return output.Metadata{GeneratedAt: meta.GeneratedAt, GitLabURL: meta.GitLabURL, RootGroup: rootGroup}
+		}))
+		require.NoError(t, err)
+		assert.Zero(t, calls)
+
+		rootGroup = "backend/api"
+
+		out := readStdout(t, func() {
+			err = formatter.FormatGroups([]*gitlab.Group{{ID: 2, FullPath: "backend/api"}})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+
+		var got struct {
+			RootGroup string `json:"root_group"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &got))
+		assert.Equal(t, "backend/api", got.RootGroup)
+	})
+
+	t.Run("writes a bare array by default", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatJSON)
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			err = formatter.FormatGroups([]*gitlab.Group{{ID: 1}})
+		})
+		require.NoError(t, err)
+
+		var groups []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(out), &groups))
+		assert.Len(t, groups, 1)
+	})
+
+	t.Run("is rejected by other formats", func(t *testing.T) {
+		for _, format := range []output.Format{output.FormatTable, output.FormatCSV, output.FormatDotenv} {
+			_, err := output.NewFormatter(format, output.WithEnvelope(meta))
+			require.ErrorIs(t, err, output.ErrEnvelopeRequiresJSON, format)
+			require.ErrorIs(t, err, output.ErrUnsupportedFormat, format)
+		}
+	})
+}
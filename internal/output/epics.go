@@ -0,0 +1,70 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatEpics(epics []*glclient.GroupEpic) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Group ID", "Group Path"),
+		"IID", "Title", "State", "Author", "Due Date")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, epic := range epics {
+		dueDate := defaultTextPlaceholder
+		if epic.DueDate != nil {
+			dueDate = epic.DueDate.Format(defaultDateFormat)
+		}
+
+		pathLink := f.link(epic.GroupWebURL, LinkEpics, epic.GroupPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, epic.GroupID, pathLink),
+			epic.IID,
+			epic.Title,
+			epic.State,
+			textOrPlaceholder(epic.AuthorUsername),
+			dueDate,
+		), epic.GroupWebURL, LinkEpics), epic.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatEpics(epics []*glclient.GroupEpic) error {
+	return f.encode(epics, len(epics), "epics")
+}
+
+func (f *CSVFormatter) FormatEpics(epics []*glclient.GroupEpic) error {
+	if len(epics) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(epics[0]))); err != nil {
+		return err
+	}
+
+	for _, epic := range epics {
+		row := f.withLinkStatus(f.row(epic), epic.GroupWebURL, LinkEpics)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatEpics(_ []*glclient.GroupEpic) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatEpics(epics []*glclient.GroupEpic) error {
+	return f.render("epics", epics)
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ErrorFormat selects how a failed command reports its error on standard error.
+type ErrorFormat string
+
+const (
+	// ErrorFormatText prints the error message alone on a line.
+	ErrorFormatText ErrorFormat = "text"
+	// ErrorFormatJSON prints a JSON object with the message, a stable code, and its context.
+	ErrorFormatJSON ErrorFormat = "json"
+)
+
+// CodeUnknown is the code of errors without a more specific one.
+const CodeUnknown = "error"
+
+var ErrInvalidErrorFormat = errors.New("invalid error format, must be text or json")
+
+// ErrorCode maps an error, and every error wrapping it, to a stable code.
+type ErrorCode struct {
+	Err  error
+	Code string
+}
+
+// CommandError is the JSON object reporting a failed command.
+type CommandError struct {
+	Error   string            `json:"error"`
+	Code    string            `json:"code"`
+	Context map[string]string `json:"context,omitempty"`
+}
+
+// ParseErrorFormat validates an error format given on the command line. An empty value selects text.
+func ParseErrorFormat(s string) (ErrorFormat, error) {
+	if s == "" {
+		return ErrorFormatText, nil
+	}
+
+	format := ErrorFormat(s)
+	if !slices.Contains([]ErrorFormat{ErrorFormatText, ErrorFormatJSON}, format) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidErrorFormat, s)
+	}
+
+	return format, nil
+}
+
+// ErrorCodeOf returns the code of the first entry of codes that err matches, or CodeUnknown.
+func ErrorCodeOf(err error, codes []ErrorCode) string {
+	for _, code := range codes {
+		if errors.Is(err, code.Err) {
+			return code.Code
+		}
+	}
+
+	return CodeUnknown
+}
+
+// WriteError reports err in the given format. Context entries with empty values are left out.
+func WriteError(w io.Writer, format ErrorFormat, err error, code string, context map[string]string) error {
+	if format != ErrorFormatJSON {
+		if _, writeErr := fmt.Fprintln(w, err); writeErr != nil {
+			return fmt.Errorf("failed to write error: %w", writeErr)
+		}
+
+		return nil
+	}
+
+	report := CommandError{Error: err.Error(), Code: code}
+
+	for key, value := range context {
+		if value == "" {
+			continue
+		}
+
+		if report.Context == nil {
+			report.Context = make(map[string]string, len(context))
+		}
+
+		report.Context[key] = value
+	}
+
+	if encodeErr := json.NewEncoder(w).Encode(report); encodeErr != nil {
+		return fmt.Errorf("failed to encode error: %w", encodeErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTokenRequired = errors.New("gitlab token is required")
+
+var testErrorCodes = []output.ErrorCode{
+	{Err: errTokenRequired, Code: "token_required"},
+	{Err: glclient.ErrGroupNotFound, Code: "group_not_found"},
+	{Err: glclient.ErrAccessDenied, Code: "access_denied"},
+}
+
+func TestParseErrorFormat(t *testing.T) {
+	for input, want := range map[string]output.ErrorFormat{
+		"":     output.ErrorFormatText,
+		"text": output.ErrorFormatText,
+		"json": output.ErrorFormatJSON,
+	} {
+		format, err := output.ParseErrorFormat(input)
+		require.NoError(t, err)
+		assert.Equal(t, want, format)
+	}
+
+	_, err := output.ParseErrorFormat("xml")
+	require.ErrorIs(t, err, output.ErrInvalidErrorFormat)
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		context map[string]string
+		want    output.CommandError
+	}{
+		{
+			name: "sentinel error",
+			err:  errTokenRequired,
+			want: output.CommandError{Error: "gitlab token is required", Code: "token_required"},
+		},
+		{
+			name:    "wrapped sentinel error with context",
+			err:     fmt.Errorf("failed to get group org/missing: %w", glclient.ErrGroupNotFound),
+			context: map[string]string{"command": "glreporter groups", "group_id": "org/missing", "project_id": ""},
+			want: output.CommandError{
+				Error:   "failed to get group org/missing: " + glclient.ErrGroupNotFound.Error(),
+				Code:    "group_not_found",
+				Context: map[string]string{"command": "glreporter groups", "group_id": "org/missing"},
+			},
+		},
+		{
+			name: "first matching code wins",
+			err:  errors.Join(glclient.ErrAccessDenied, glclient.ErrGroupNotFound),
+			want: output.CommandError{
+				Error: glclient.ErrAccessDenied.Error() + "\n" + glclient.ErrGroupNotFound.Error(),
+				Code:  "group_not_found",
+			},
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("boom"),
+			want: output.CommandError{Error: "boom", Code: output.CodeUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+
+			code := output.ErrorCodeOf(tt.err, testErrorCodes)
+			require.NoError(t, output.WriteError(&stderr, output.ErrorFormatJSON, tt.err, code, tt.context))
+
+			var got output.CommandError
+			require.NoError(t, json.Unmarshal(stderr.Bytes(), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("text format prints the message only", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		err := fmt.Errorf("lookup failed: %w", glclient.ErrAccessDenied)
+		require.NoError(t, output.WriteError(&stderr, output.ErrorFormatText, err, "access_denied",
+			map[string]string{"group_id": "org"}))
+		assert.Equal(t, "lookup failed: "+glclient.ErrAccessDenied.Error()+"\n", stderr.String())
+	})
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var ErrFlattenRequiresJSON = fmt.Errorf(
+	"%w: flattening is only available with the json format", ErrUnsupportedFormat)
+
+// flatField is a json-tagged field of a report item and its declared type. value is invalid for
+// the fields of a nil embedded pointer, which still contribute their columns. Fields tagged csv:"-" are kept out of
+// the CSV columns but not out of flattened JSON, and fields tagged csv:"omitempty" are left out of
+// both while they are empty.
+type flatField struct {
+	name    string
+	typ     reflect.Type
+	value   reflect.Value
+	skipCSV bool
+}
+
+// flatFields walks the json-tagged fields of the struct v points to, inlining the fields of
+// embedded structs in declaration order. The CSV columns and the keys of flattened JSON both come
+// from this walk, so the two describe the same fields apart from those tagged csv:"-".
+func flatFields(v any, includeValues ...bool) []flatField {
+	skipValue := len(includeValues) > 0 && !includeValues[0]
+
+	return appendFlatFields(nil, reflect.ValueOf(v).Elem(), true, skipValue)
+}
+
+// csvFields returns the flat fields of v that become CSV columns.
+func csvFields(v any, includeValues ...bool) []flatField {
+	return slices.DeleteFunc(flatFields(v, includeValues...), func(field flatField) bool {
+		return field.skipCSV
+	})
+}
+
+func appendFlatFields(fields []flatField, val reflect.Value, present, skipValue bool) []flatField {
+	typ := val.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		fieldValue := val.Field(i)
+
+		if field.Anonymous {
+			switch field.Type.Kind() {
+			case reflect.Ptr:
+				if !present || fieldValue.IsNil() {
+					// a zero value of the embedded type still yields its fields
+					fields = appendFlatFields(fields, reflect.New(field.Type.Elem()).Elem(), false, skipValue)
+				} else {
+					fields = appendFlatFields(fields, fieldValue.Elem(), true, skipValue)
+				}
+			case reflect.Struct:
+				fields = appendFlatFields(fields, fieldValue, present, skipValue)
+			default:
+			}
+
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+
+		if skipValue && name == excludedFieldName {
+			continue
+		}
+
+		csvTag := field.Tag.Get("csv")
+		if csvTag == "omitempty" && (!present || fieldValue.IsZero()) {
+			continue
+		}
+
+		flat := flatField{name: name, typ: field.Type, skipCSV: csvTag == "-"}
+		if present {
+			flat.value = fieldValue
+		}
+
+		fields = append(fields, flat)
+	}
+
+	return fields
+}
+
+// jsonName returns the key a field is encoded under, without options such as omitempty, or an
+// empty string for fields that are not encoded.
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	return name
+}
+
+// flatObject encodes the fields of an item as a single JSON object, keeping their order.
+type flatObject []flatField
+
+func (o flatObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field name %s: %w", field.name, err)
+		}
+
+		var value any
+		if field.value.IsValid() {
+			value = field.value.Interface()
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field %s: %w", field.name, err)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// flattenItems replaces the structs of a report, or a single struct, with flat objects. Anything
+// else is returned unchanged.
+func flattenItems(items any) any {
+	val := reflect.ValueOf(items)
+
+	switch val.Kind() {
+	case reflect.Slice:
+		flat := make([]any, 0, val.Len())
+		for i := range val.Len() {
+			flat = append(flat, flattenItem(val.Index(i)))
+		}
+
+		return flat
+	case reflect.Ptr:
+		return flattenItem(val)
+	default:
+		return items
+	}
+}
+
+func flattenItem(val reflect.Value) any {
+	switch {
+	case val.Kind() == reflect.Ptr && !val.IsNil() && val.Elem().Kind() == reflect.Struct:
+		return flatObject(flatFields(val.Interface()))
+	case val.Kind() == reflect.Struct && val.CanAddr():
+		return flatObject(flatFields(val.Addr().Interface()))
+	default:
+		return val.Interface()
+	}
+}
+
+// WithFlattening inlines the fields of embedded structs in JSON reports, so that each item is a
+// single flat object with the same keys as the columns of the CSV format, plus the report type.
+func WithFlattening() Option {
+	return func(o *options) {
+		o.flatten = true
+	}
+}
@@ -0,0 +1,147 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var flattenTokens = []*glclient.ProjectAccessTokenWithProject{
+	{
+		ProjectAccessToken: &gitlab.ProjectAccessToken{
+			PersonalAccessToken: gitlab.PersonalAccessToken{ID: 7, Name: "deploy", Scopes: []string{"read_api"}},
+		},
+		ProjectID:   42,
+		ProjectName: "api",
+		ProjectPath: "backend/api",
+	},
+	{ProjectID: 43, ProjectName: "web", ProjectPath: "backend/web"},
+}
+
+// objectKeys returns the keys of each object of a JSON array, in the order they were written.
+func objectKeys(t *testing.T, data []byte) [][]string {
+	t.Helper()
+
+	var objects []json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &objects))
+
+	keys := make([][]string, 0, len(objects))
+	for _, object := range objects {
+		decoder := json.NewDecoder(bytes.NewReader(object))
+
+		_, err := decoder.Token()
+		require.NoError(t, err)
+
+		var objectKeys []string
+		for decoder.More() {
+			key, err := decoder.Token()
+			require.NoError(t, err)
+
+			objectKeys = append(objectKeys, key.(string))
+
+			var value json.RawMessage
+			require.NoError(t, decoder.Decode(&value))
+		}
+
+		keys = append(keys, objectKeys)
+	}
+
+	return keys
+}
+
+func TestJSONFormatter_flattenMatchesCSVColumns(t *testing.T) {
+	var jsonOut, csvOut bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&jsonOut), output.WithFlattening())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectAccessTokens(flattenTokens))
+
+	formatter, err = output.NewFormatter(output.FormatCSV, output.WithWriter(&csvOut))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectAccessTokens(flattenTokens))
+
+	records, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	keys := objectKeys(t, jsonOut.Bytes())
+	require.Len(t, keys, len(flattenTokens))
+
+	// the report type is left out of CSV, where every file holds a single report
+	for _, objectKeys := range keys {
+		assert.Contains(t, objectKeys, "report_type")
+		assert.Equal(t, records[0], slices.DeleteFunc(objectKeys, func(key string) bool { return key == "report_type" }))
+	}
+}
+
+func TestJSONFormatter_flattenValues(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithFlattening())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectAccessTokens(flattenTokens))
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 2)
+
+	assert.InDelta(t, 7, got[0]["id"], 0)
+	assert.Equal(t, "deploy", got[0]["name"])
+	assert.Equal(t, []any{"read_api"}, got[0]["scopes"])
+	assert.InDelta(t, 42, got[0]["project_id"], 0)
+	assert.NotContains(t, got[0], "ProjectAccessToken")
+
+	// the fields of a missing token are still present, as null
+	assert.Contains(t, got[1], "name")
+	assert.Nil(t, got[1]["name"])
+	assert.Equal(t, "backend/web", got[1]["project_path"])
+}
+
+func TestJSONFormatter_flattenFilteredVariables(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithFlattening())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectVariables(
+		[]*glclient.ProjectVariableWithProject{projectVariable("API_KEY", "secret")}, false))
+
+	assert.Contains(t, buf.String(), `"key": "API_KEY"`)
+	assert.NotContains(t, buf.String(), "secret")
+}
+
+func TestJSONFormatter_flattenWithEnvelope(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON,
+		output.WithWriter(&buf), output.WithFlattening(), output.WithEnvelope(output.Metadata{RootGroup: "backend"}))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectAccessTokens(flattenTokens))
+
+	var got struct {
+		Total int              `json:"total"`
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+	assert.Equal(t, 2, got.Total)
+	require.Len(t, got.Items, 2)
+	assert.Equal(t, "deploy", got.Items[0]["name"])
+}
+
+func TestNewFormatter_flattenRequiresJSON(t *testing.T) {
+	for _, format := range []output.Format{output.FormatTable, output.FormatCSV, output.FormatTemplate} {
+		t.Run(string(format), func(t *testing.T) {
+			_, err := output.NewFormatter(format, output.WithFlattening())
+			require.ErrorIs(t, err, output.ErrFlattenRequiresJSON)
+			assert.ErrorIs(t, err, output.ErrUnsupportedFormat)
+		})
+	}
+}
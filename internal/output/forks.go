@@ -0,0 +1,80 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Fork Path", "Fork Namespace", "External", "Ahead", "Behind")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, fork := range forks {
+		external := "No"
+		if fork.External {
+			external = "Yes"
+		}
+
+		pathLink := f.link(fork.ProjectWebURL, LinkForks, fork.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, fork.ProjectID, pathLink),
+			fork.ForkPath,
+			textOrPlaceholder(fork.ForkNamespace),
+			external,
+			commitCount(fork.Ahead),
+			commitCount(fork.Behind),
+		), fork.ProjectWebURL, LinkForks), fork.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	return f.encode(forks, len(forks), "project forks")
+}
+
+func (f *CSVFormatter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	if len(forks) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(forks[0]))); err != nil {
+		return err
+	}
+
+	for _, fork := range forks {
+		row := f.withLinkStatus(f.row(fork), fork.ProjectWebURL, LinkForks)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectForks(_ []*glclient.ProjectFork) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	return f.render("project forks", forks)
+}
+
+// commitCount formats a number of commits, leaving unknown counts as a placeholder.
+func commitCount(count *int) string {
+	if count == nil {
+		return defaultTextPlaceholder
+	}
+
+	return strconv.Itoa(*count)
+}
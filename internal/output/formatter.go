@@ -3,14 +3,14 @@ package output
 
 import (
 	"encoding/csv"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -27,11 +27,19 @@ const (
 	FormatJSON Format = "json"
 	// FormatCSV represents CSV output format.
 	FormatCSV Format = "csv"
+	// FormatDotenv represents KEY=VALUE output format, supported by the variable reports only.
+	FormatDotenv Format = "dotenv"
+	// FormatTemplate represents output rendered by a user-provided Go text/template.
+	FormatTemplate Format = "template"
+	// FormatXLSX represents an Excel workbook with a worksheet per report, written to a Workbook.
+	FormatXLSX Format = "xlsx"
+	// FormatSQLite represents a SQLite database with a table per report, written to a Database.
+	FormatSQLite Format = "sqlite"
 
 	defaultExpiresAtText   string = "Never"
 	defaultLastUsedText    string = "Never"
 	defaultTextPlaceholder string = "N/A"
-	defaultTimeFormat      string = "2006-01-02 15:04:05Z"
+	defaultTimeFormat      string = "2006-01-02 15:04:05Z07:00"
 	excludedFieldName      string = "value"
 )
 
@@ -44,31 +52,223 @@ type Formatter interface {
 	FormatProjectVariables(variables []*glclient.ProjectVariableWithProject, includeValues bool) error
 	FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error
 	FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error
+	FormatBadges(badges []*glclient.BadgeWithSource) error
+	FormatPushRules(rules []*glclient.PushRulesWithSource) error
+	FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error
+	FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error
+	FormatProjectStorage(storage []*glclient.ProjectStorage) error
+	FormatProjectRegistries(registries []*glclient.ProjectRegistry) error
+	FormatComputeUsage(usage []*glclient.GroupComputeUsage) error
+	FormatCISettings(settings []*glclient.ProjectCISettings) error
+	FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error
+	FormatProjectActivity(activity []*glclient.ProjectActivity) error
+	FormatIntegrations(integrations []*glclient.ProjectIntegration) error
+	FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error
+	FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error
+	FormatProjectRunners(runners []*glclient.ProjectRunners) error
+	FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error
+	FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error
+	FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error
+	FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error
+	FormatProjectForks(forks []*glclient.ProjectFork) error
+	FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error
+	FormatProjectTopics(topics []*glclient.ProjectTopics) error
+	FormatEpics(epics []*glclient.GroupEpic) error
+	FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error
+	FormatGroupMilestones(milestones []*glclient.GroupMilestone) error
+	FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error
+	FormatTokenInfo(info *glclient.TokenInfo) error
+	FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error
+	FormatRateLimit(status *glclient.RateLimitStatus) error
+	FormatChanges(changes []report.Change) error
+	FormatVariableDifferences(differences []*report.VariableDifference, includeValues bool) error
+	FormatSourceCounts(counts []*report.SourceCount) error
 }
 
-func NewFormatter(format Format) (Formatter, error) {
+func NewFormatter(format Format, opts ...Option) (Formatter, error) {
+	o := newOptions(opts)
+
+	if o.envelope != nil && format != FormatJSON {
+		return nil, fmt.Errorf("%w: %s", ErrEnvelopeRequiresJSON, format)
+	}
+
+	if o.flatten && format != FormatJSON {
+		return nil, fmt.Errorf("%w: %s", ErrFlattenRequiresJSON, format)
+	}
+
+	if o.tree && format != FormatJSON {
+		return nil, fmt.Errorf("%w: %s", ErrTreeRequiresJSON, format)
+	}
+
+	if err := validateIDFormat(o.idFormat); err != nil {
+		return nil, err
+	}
+
+	var rewrites []rewriteFunc
+
+	// paths are normalized first so that equal paths are redacted alike
+	if o.normalizePaths {
+		rewrites = append(rewrites, normalizeField)
+	}
+
+	// timestamps are shown in UTC unless another time zone was selected
+	location := o.location
+	if location == nil {
+		location = time.UTC
+	}
+
+	if o.redact {
+		rewrites = append(rewrites, redactField(o.redactSalt))
+	}
+
+	if o.envelope != nil {
+		metadata := o.envelope
+		metadataRewrite := fieldRewrites{location: location}
+
+		if o.redact {
+			metadataRewrite.text = rewrites[len(rewrites)-1]
+		}
+
+		o.envelope = func() Metadata {
+			meta := metadata()
+			rewriteFields([]*Metadata{&meta}, metadataRewrite)
+
+			return meta
+		}
+	}
+
+	formatter, err := newFormatter(format, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewriteID rewriteIDFunc
+	if o.pseudonymizeIDs {
+		rewriteID = pseudonymizeID(o.redactSalt)
+	}
+
+	rewrite := fieldRewrites{id: rewriteID, location: location}
+	if len(rewrites) > 0 {
+		rewrite.text = chainRewrites(rewrites)
+	}
+
+	return &fieldRewriter{formatter: formatter, rewrite: rewrite}, nil
+}
+
+func newFormatter(format Format, o options) (Formatter, error) {
 	switch format {
 	case FormatTable:
-		return &TableFormatter{}, nil
+		suffixes, err := linkSuffixes(o)
+		if err != nil {
+			return nil, err
+		}
+
+		style, err := lookupTableStyle(o.tableStyle)
+		if err != nil {
+			return nil, err
+		}
+
+		return &TableFormatter{
+			sink:             sink{out: o.writer},
+			descriptions:     o.descriptions,
+			descriptionWidth: o.descriptionWidth,
+			idFormat:         o.idFormat,
+			linkSuffixes:     suffixes,
+			links:            newLinkChecker(o.verifyURL, suffixes),
+			instanceColumn:   o.instanceColumn,
+			instanceLabel:    o.instanceLabel,
+			style:            style,
+			maxColumnWidth:   o.maxColumnWidth,
+			truncateMiddle:   o.truncateMiddle,
+			timeFormat:       o.timeFormat,
+		}, nil
 	case FormatJSON:
-		return &JSONFormatter{}, nil
+		return &JSONFormatter{
+			sink:          sink{out: o.writer},
+			envelope:      o.envelope,
+			flatten:       o.flatten,
+			tree:          o.tree,
+			instanceLabel: o.instanceLabel,
+		}, nil
 	case FormatCSV:
-		return &CSVFormatter{}, nil
+		formatter := &CSVFormatter{
+			sink:          sink{out: o.writer},
+			noHeader:      o.noHeader,
+			timeFormat:    o.timeFormat,
+			instanceLabel: o.instanceLabel,
+		}
+		if o.verifyURL != nil {
+			suffixes, err := linkSuffixes(o)
+			if err != nil {
+				return nil, err
+			}
+
+			formatter.links = newLinkChecker(o.verifyURL, suffixes)
+		}
+
+		return formatter, nil
+	case FormatDotenv:
+		return &DotenvFormatter{sink: sink{out: o.writer}}, nil
+	case FormatTemplate:
+		return newTemplateFormatter(o)
+	case FormatXLSX:
+		workbook, ok := o.writer.(*Workbook)
+		if !ok {
+			return nil, ErrXLSXRequiresWorkbook
+		}
+
+		return &XLSXFormatter{workbook: workbook, instanceLabel: o.instanceLabel}, nil
+	case FormatSQLite:
+		database, ok := o.writer.(*Database)
+		if !ok {
+			return nil, ErrSQLiteRequiresDatabase
+		}
+
+		return &SQLiteFormatter{database: database, instanceLabel: o.instanceLabel}, nil
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
 	}
 }
 
-type TableFormatter struct{}
+type TableFormatter struct {
+	sink
+
+	descriptions     bool
+	descriptionWidth int
+	idFormat         IDFormat
+	linkSuffixes     map[LinkTarget]string
+	// links checks the linked settings pages for the Link Status column, nil unless links are verified
+	links *linkChecker
+	// instanceColumn adds the Instance column to reports fetched from several GitLab instances
+	instanceColumn bool
+	// instanceLabel returns the instance of groups and projects, nil unless set by WithInstanceLabels
+	instanceLabel func(item any) string
+	style         table.Style
+	// maxColumnWidth cuts wider cells with an ellipsis, at the end or in the middle with truncateMiddle
+	maxColumnWidth int
+	truncateMiddle bool
+	timeFormat     TimeFormat
+}
+
+// withDescription appends the Description column to row when descriptions are enabled.
+func (f *TableFormatter) withDescription(row table.Row, description string) table.Row {
+	if !f.descriptions {
+		return row
+	}
+
+	description = strings.Join(strings.Fields(description), " ")
+
+	return append(row, text.Snip(description, f.descriptionWidth, "…"))
+}
 
 func (f *TableFormatter) FormatGroups(groups []*gitlab.Group) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"ID", "Name", "Full Path"})
+	t := f.newTable()
+	t.AppendHeader(f.withInstanceHeader(f.withDescription(f.namedIdentifier("ID", "Name", "Full Path"), "Description")))
 
 	for _, group := range groups {
 		fullPathLink := text.Hyperlink(group.WebURL, group.FullPath)
-		t.AppendRow(table.Row{group.ID, group.Name, fullPathLink})
+		row := f.withDescription(f.namedIdentifier(group.ID, group.Name, fullPathLink), group.Description)
+		t.AppendRow(f.withInstance(row, f.instanceOf(group)))
 	}
 
 	t.Render()
@@ -77,13 +277,14 @@ func (f *TableFormatter) FormatGroups(groups []*gitlab.Group) error {
 }
 
 func (f *TableFormatter) FormatProjects(projects []*gitlab.Project) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"ID", "Name", "Path with Namespace"})
+	t := f.newTable()
+	header := f.withDescription(f.namedIdentifier("ID", "Name", "Path with Namespace"), "Description")
+	t.AppendHeader(f.withInstanceHeader(header))
 
 	for _, project := range projects {
 		pathLink := text.Hyperlink(project.WebURL, project.PathWithNamespace)
-		t.AppendRow(table.Row{project.ID, project.Name, pathLink})
+		row := f.withDescription(f.namedIdentifier(project.ID, project.Name, pathLink), project.Description)
+		t.AppendRow(f.withInstance(row, f.instanceOf(project)))
 	}
 
 	t.Render()
@@ -92,20 +293,34 @@ func (f *TableFormatter) FormatProjects(projects []*gitlab.Project) error {
 }
 
 func (f *TableFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Group Path", "Token Name", "Scopes", "Active", "Expires At"})
+	t := f.newTable()
+	withUsers := slices.ContainsFunc(tokens, func(t *glclient.GroupAccessTokenWithGroup) bool { return t.Username != "" })
+	withIPs := slices.ContainsFunc(tokens, func(t *glclient.GroupAccessTokenWithGroup) bool {
+		return t.LastUsedIPs != nil
+	})
+
+	header := tokenColumns(f.identifier(IDFormatPath, "Group ID", "Group Path"), withUsers, withIPs)
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
 
 	for _, token := range tokens {
 		expiresAt := defaultExpiresAtText
 		if token.ExpiresAt != nil {
-			expiresAt = time.Time(*token.ExpiresAt).UTC().Format(defaultTimeFormat)
+			expiresAt = f.formatTime(time.Time(*token.ExpiresAt))
+		}
+
+		groupPathLink := f.link(token.GroupWebURL, LinkGroupAccessTokens, token.GroupPath)
+
+		row := append(f.identifier(IDFormatPath, token.GroupID, groupPathLink),
+			token.Name, token.Scopes, token.Active, f.tokenTime(token.CreatedAt), expiresAt, f.tokenLastUsed(token.LastUsedAt))
+		if withUsers {
+			row = append(row, textOrPlaceholder(token.Username))
 		}
 
-		groupURL := token.GroupWebURL + "/-/settings/access_tokens"
-		groupPathLink := text.Hyperlink(groupURL, token.GroupPath)
+		if withIPs {
+			row = append(row, textOrPlaceholder(strings.Join(token.LastUsedIPs, ", ")))
+		}
 
-		t.AppendRow(table.Row{groupPathLink, token.Name, token.Scopes, token.Active, expiresAt})
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, token.GroupWebURL, LinkGroupAccessTokens), token.Instance))
 	}
 
 	t.Render()
@@ -114,20 +329,36 @@ func (f *TableFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessT
 }
 
 func (f *TableFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Project Path", "Token Name", "Scopes", "Active", "Expires At"})
+	t := f.newTable()
+	withUsers := slices.ContainsFunc(tokens, func(t *glclient.ProjectAccessTokenWithProject) bool {
+		return t.Username != ""
+	})
+	withIPs := slices.ContainsFunc(tokens, func(t *glclient.ProjectAccessTokenWithProject) bool {
+		return t.LastUsedIPs != nil
+	})
+
+	header := tokenColumns(f.identifier(IDFormatPath, "Project ID", "Project Path"), withUsers, withIPs)
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
 
 	for _, token := range tokens {
 		expiresAt := defaultExpiresAtText
 		if token.ExpiresAt != nil {
-			expiresAt = time.Time(*token.ExpiresAt).UTC().Format(defaultTimeFormat)
+			expiresAt = f.formatTime(time.Time(*token.ExpiresAt))
+		}
+
+		projectPathLink := f.link(token.ProjectWebURL, LinkProjectAccessTokens, token.ProjectPath)
+
+		row := append(f.identifier(IDFormatPath, token.ProjectID, projectPathLink),
+			token.Name, token.Scopes, token.Active, f.tokenTime(token.CreatedAt), expiresAt, f.tokenLastUsed(token.LastUsedAt))
+		if withUsers {
+			row = append(row, textOrPlaceholder(token.Username))
 		}
 
-		projectURL := token.ProjectWebURL + "/-/settings/access_tokens"
-		projectPathLink := text.Hyperlink(projectURL, token.ProjectPath)
+		if withIPs {
+			row = append(row, textOrPlaceholder(strings.Join(token.LastUsedIPs, ", ")))
+		}
 
-		t.AppendRow(table.Row{projectPathLink, token.Name, token.Scopes, token.Active, expiresAt})
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, token.ProjectWebURL, LinkProjectAccessTokens), token.Instance))
 	}
 
 	t.Render()
@@ -135,10 +366,48 @@ func (f *TableFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAcc
 	return nil
 }
 
+// tokenColumns returns the header of an access token table, with a column for the token's user
+// once the users were resolved, and for the addresses it was last used from where GitLab reported them.
+func tokenColumns(identifier table.Row, withUsers, withIPs bool) table.Row {
+	header := append(identifier, "Token Name", "Scopes", "Active", "Created At", "Expires At", "Last Used")
+	if withUsers {
+		header = append(header, "User")
+	}
+
+	if withIPs {
+		header = append(header, "Last Used IPs")
+	}
+
+	return header
+}
+
+// formatTime formats a timestamp in the selected time format.
+func (f *TableFormatter) formatTime(t time.Time) string {
+	return f.timeFormat.format(t)
+}
+
+// tokenTime formats when an access token was created, leaving unknown times as a placeholder.
+func (f *TableFormatter) tokenTime(t *time.Time) string {
+	if t == nil {
+		return defaultTextPlaceholder
+	}
+
+	return f.formatTime(*t)
+}
+
+// tokenLastUsed formats when an access token was last used.
+func (f *TableFormatter) tokenLastUsed(lastUsed *time.Time) string {
+	if lastUsed == nil {
+		return defaultLastUsedText
+	}
+
+	return f.formatTime(*lastUsed)
+}
+
 func (f *TableFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Project Path", "Description", "Owner", "Last Used"})
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"), "Description", "Owner", "Last Used")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
 
 	for _, trigger := range triggers {
 		owner := defaultTextPlaceholder
@@ -148,13 +417,13 @@ func (f *TableFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTri
 
 		lastUsed := defaultLastUsedText
 		if trigger.LastUsed != nil {
-			lastUsed = trigger.LastUsed.UTC().Format(defaultTimeFormat)
+			lastUsed = f.formatTime(*trigger.LastUsed)
 		}
 
-		projectURL := trigger.ProjectWebURL + "/-/settings/ci_cd#js-pipeline-triggers"
-		projectPathLink := text.Hyperlink(projectURL, trigger.ProjectPath)
+		projectPathLink := f.link(trigger.ProjectWebURL, LinkPipelineTriggers, trigger.ProjectPath)
 
-		t.AppendRow(table.Row{projectPathLink, trigger.Description, owner, lastUsed})
+		row := append(f.identifier(IDFormatPath, trigger.ProjectID, projectPathLink), trigger.Description, owner, lastUsed)
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, trigger.ProjectWebURL, LinkPipelineTriggers), trigger.Instance))
 	}
 
 	t.Render()
@@ -162,23 +431,26 @@ func (f *TableFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTri
 	return nil
 }
 
-func (f *TableFormatter) FormatProjectVariables(variables []*glclient.ProjectVariableWithProject, _ bool) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Project Path", "Key", "Type", "Protected", "Masked", "Environment"})
+func (f *TableFormatter) FormatProjectVariables(
+	variables []*glclient.ProjectVariableWithProject,
+	includeValues bool,
+) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"), variableKeyHeader(includeValues)...)
+	header = append(header, "Type", "Protected", "Masked", "Environment")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
 
 	for _, variable := range variables {
-		projectURL := variable.ProjectWebURL + "/-/settings/ci_cd#js-cicd-variables-settings"
-		projectPathLink := text.Hyperlink(projectURL, variable.ProjectPath)
+		projectPathLink := f.link(variable.ProjectWebURL, LinkProjectVariables, variable.ProjectPath)
 
-		t.AppendRow(table.Row{
-			projectPathLink,
-			variable.Key,
+		row := append(f.identifier(IDFormatPath, variable.ProjectID, projectPathLink),
+			variableKeyCells(variable.Key, variable.Value, variable.Hidden, includeValues)...)
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(row,
 			variable.VariableType,
 			variable.Protected,
 			variable.Masked,
 			variable.EnvironmentScope,
-		})
+		), variable.ProjectWebURL, LinkProjectVariables), variable.Instance))
 	}
 
 	t.Render()
@@ -186,23 +458,23 @@ func (f *TableFormatter) FormatProjectVariables(variables []*glclient.ProjectVar
 	return nil
 }
 
-func (f *TableFormatter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, _ bool) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Group Path", "Key", "Type", "Protected", "Masked", "Environment"})
+func (f *TableFormatter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Group ID", "Group Path"), variableKeyHeader(includeValues)...)
+	header = append(header, "Type", "Protected", "Masked", "Environment")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
 
 	for _, variable := range variables {
-		groupURL := variable.GroupWebURL + "/-/settings/ci_cd#ci-variables"
-		groupPathLink := text.Hyperlink(groupURL, variable.GroupFullPath)
+		groupPathLink := f.link(variable.GroupWebURL, LinkGroupVariables, variable.GroupFullPath)
 
-		t.AppendRow(table.Row{
-			groupPathLink,
-			variable.Key,
+		row := append(f.identifier(IDFormatPath, variable.GroupID, groupPathLink),
+			variableKeyCells(variable.Key, variable.Value, variable.Hidden, includeValues)...)
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(row,
 			variable.VariableType,
 			variable.Protected,
 			variable.Masked,
 			variable.EnvironmentScope,
-		})
+		), variable.GroupWebURL, LinkGroupVariables), variable.Instance))
 	}
 
 	t.Render()
@@ -210,29 +482,25 @@ func (f *TableFormatter) FormatGroupVariables(variables []*glclient.GroupVariabl
 	return nil
 }
 
-func (f *TableFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, _ bool) error {
-	t := table.NewWriter()
-	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"Source", "Path", "Key", "Type", "Protected", "Masked", "Environment"})
+func (f *TableFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
+	t := f.newTable()
+	header := append(append(table.Row{"Source"}, f.identifier(IDFormatPath, "ID", "Path")...),
+		variableKeyHeader(includeValues)...)
+	header = append(header, "Type", "Protected", "Masked", "Environment")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
 
 	for _, variable := range variables {
-		var url string
-		if variable.Source == "project" {
-			url = variable.SourceWebURL + "/-/settings/ci_cd#js-cicd-variables-settings"
-		} else {
-			url = variable.SourceWebURL + "/-/settings/ci_cd#ci-variables"
-		}
-		pathLink := text.Hyperlink(url, variable.SourcePath)
+		target := variableTarget(variable)
+		pathLink := f.link(variable.SourceWebURL, target, variable.SourcePath)
 
-		t.AppendRow(table.Row{
-			variable.Source,
-			pathLink,
-			variable.Key,
+		row := append(table.Row{variable.Source}, f.identifier(IDFormatPath, variable.SourceID, pathLink)...)
+		row = append(row, variableKeyCells(variable.Key, variable.Value, variable.Hidden, includeValues)...)
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(row,
 			variable.VariableType,
 			variable.Protected,
 			variable.Masked,
 			variable.EnvironmentScope,
-		})
+		), variable.SourceWebURL, target), variable.Instance))
 	}
 
 	t.Render()
@@ -240,89 +508,88 @@ func (f *TableFormatter) FormatUnifiedVariables(variables []*glclient.VariableWi
 	return nil
 }
 
-type JSONFormatter struct{}
-
-func (f *JSONFormatter) FormatGroups(groups []*gitlab.Group) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(groups); err != nil {
-		return fmt.Errorf("failed to encode groups as JSON: %w", err)
+// variableKeyHeader returns the Key column of the variable tables, followed by the Value column
+// when values are included.
+func variableKeyHeader(includeValues bool) table.Row {
+	if includeValues {
+		return table.Row{"Key", "Value"}
 	}
 
-	return nil
+	return table.Row{"Key"}
 }
 
-func (f *JSONFormatter) FormatProjects(projects []*gitlab.Project) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(projects); err != nil {
-		return fmt.Errorf("failed to encode projects as JSON: %w", err)
+// variableKeyCells returns the key of a variable, followed by its value when values are included.
+// Hidden variables, whose value GitLab never returns, are marked as such.
+func variableKeyCells(key, value string, hidden, includeValues bool) table.Row {
+	switch {
+	case !includeValues:
+		return table.Row{key}
+	case hidden:
+		return table.Row{key, "(hidden)"}
+	case value == "":
+		return table.Row{key, "(empty)"}
+	default:
+		return table.Row{key, value}
 	}
-
-	return nil
 }
 
-func (f *JSONFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
+type JSONFormatter struct {
+	sink
+
+	envelope func() Metadata
+	flatten  bool
+	// tree nests the items under their groups and projects
+	tree bool
+	// instanceLabel returns the instance of groups and projects, nil unless set by WithInstanceLabels
+	instanceLabel func(item any) string
+}
 
-	if err := encoder.Encode(tokens); err != nil {
-		return fmt.Errorf("failed to encode group access tokens as JSON: %w", err)
+func (f *JSONFormatter) FormatGroups(groups []*gitlab.Group) error {
+	if f.instanceLabel != nil {
+		return f.encode(labelGroups(groups, f.instanceLabel), len(groups), "groups")
 	}
 
-	return nil
+	return f.encode(groups, len(groups), "groups")
 }
 
-func (f *JSONFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(tokens); err != nil {
-		return fmt.Errorf("failed to encode project access tokens as JSON: %w", err)
+func (f *JSONFormatter) FormatProjects(projects []*gitlab.Project) error {
+	if f.instanceLabel != nil {
+		return f.encode(labelProjects(projects, f.instanceLabel), len(projects), "projects")
 	}
 
-	return nil
+	return f.encode(projects, len(projects), "projects")
 }
 
-func (f *JSONFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
+func (f *JSONFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
+	return f.encode(tokens, len(tokens), "group access tokens")
+}
 
-	if err := encoder.Encode(triggers); err != nil {
-		return fmt.Errorf("failed to encode pipeline triggers as JSON: %w", err)
-	}
+func (f *JSONFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
+	return f.encode(tokens, len(tokens), "project access tokens")
+}
 
-	return nil
+func (f *JSONFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
+	return f.encode(triggers, len(triggers), "pipeline triggers")
 }
 
 func (f *JSONFormatter) FormatProjectVariables(
 	variables []*glclient.ProjectVariableWithProject,
 	includeValues bool,
 ) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
 	if includeValues {
-		if err := encoder.Encode(variables); err != nil {
-			return fmt.Errorf("failed to encode project variables as JSON: %w", err)
-		}
-	} else {
-		// Convert to filtered structs without Value field
-		filtered := filterProjectVariables(variables)
-		if err := encoder.Encode(filtered); err != nil {
-			return fmt.Errorf("failed to encode project variables as JSON: %w", err)
-		}
+		return f.encode(variables, len(variables), "project variables")
 	}
 
-	return nil
+	// Convert to filtered structs without Value field
+	return f.encode(filterProjectVariables(variables), len(variables), "project variables")
 }
 
 func filterProjectVariables(variables []*glclient.ProjectVariableWithProject) any {
 	filtered := make([]*glclient.ProjectVariableWithProjectFiltered, len(variables))
 	for i, v := range variables {
 		filtered[i] = &glclient.ProjectVariableWithProjectFiltered{
+			ReportType:       v.ReportType,
+			Instance:         v.Instance,
 			Key:              v.Key,
 			VariableType:     v.VariableType,
 			Protected:        v.Protected,
@@ -331,6 +598,7 @@ func filterProjectVariables(variables []*glclient.ProjectVariableWithProject) an
 			Raw:              v.Raw,
 			EnvironmentScope: v.EnvironmentScope,
 			Description:      v.Description,
+			ProjectID:        v.ProjectID,
 			ProjectName:      v.ProjectName,
 			ProjectPath:      v.ProjectPath,
 			ProjectNamespace: v.ProjectNamespace,
@@ -342,28 +610,20 @@ func filterProjectVariables(variables []*glclient.ProjectVariableWithProject) an
 }
 
 func (f *JSONFormatter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
 	if includeValues {
-		if err := encoder.Encode(variables); err != nil {
-			return fmt.Errorf("failed to encode group variables as JSON: %w", err)
-		}
-	} else {
-		// Convert to filtered structs without Value field
-		filtered := filterGroupVariables(variables)
-		if err := encoder.Encode(filtered); err != nil {
-			return fmt.Errorf("failed to encode group variables as JSON: %w", err)
-		}
+		return f.encode(variables, len(variables), "group variables")
 	}
 
-	return nil
+	// Convert to filtered structs without Value field
+	return f.encode(filterGroupVariables(variables), len(variables), "group variables")
 }
 
 func filterGroupVariables(variables []*glclient.GroupVariableWithGroup) any {
 	filtered := make([]*glclient.GroupVariableWithGroupFiltered, len(variables))
 	for i, v := range variables {
 		filtered[i] = &glclient.GroupVariableWithGroupFiltered{
+			ReportType:       v.ReportType,
+			Instance:         v.Instance,
 			Key:              v.Key,
 			VariableType:     v.VariableType,
 			Protected:        v.Protected,
@@ -372,6 +632,7 @@ func filterGroupVariables(variables []*glclient.GroupVariableWithGroup) any {
 			Raw:              v.Raw,
 			EnvironmentScope: v.EnvironmentScope,
 			Description:      v.Description,
+			GroupID:          v.GroupID,
 			GroupName:        v.GroupName,
 			GroupPath:        v.GroupPath,
 			GroupWebURL:      v.GroupWebURL,
@@ -383,81 +644,105 @@ func filterGroupVariables(variables []*glclient.GroupVariableWithGroup) any {
 }
 
 func (f *JSONFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-
 	if includeValues {
-		if err := encoder.Encode(variables); err != nil {
-			return fmt.Errorf("failed to encode unified variables as JSON: %w", err)
-		}
-	} else {
-		// Convert to filtered structs without Value field
-		filtered := make([]*glclient.VariableWithSourceFiltered, len(variables))
-		for i, v := range variables {
-			filtered[i] = &glclient.VariableWithSourceFiltered{
-				Key:              v.Key,
-				VariableType:     v.VariableType,
-				Protected:        v.Protected,
-				Masked:           v.Masked,
-				Hidden:           v.Hidden,
-				Raw:              v.Raw,
-				EnvironmentScope: v.EnvironmentScope,
-				Description:      v.Description,
-				Source:           v.Source,
-				SourceName:       v.SourceName,
-				SourcePath:       v.SourcePath,
-				SourceWebURL:     v.SourceWebURL,
-				SourceNamespace:  v.SourceNamespace,
-			}
-		}
-		if err := encoder.Encode(filtered); err != nil {
-			return fmt.Errorf("failed to encode unified variables as JSON: %w", err)
+		return f.encode(variables, len(variables), "unified variables")
+	}
+
+	// Convert to filtered structs without Value field
+	return f.encode(filterUnifiedVariables(variables), len(variables), "unified variables")
+}
+
+func filterUnifiedVariables(variables []*glclient.VariableWithSource) any {
+	filtered := make([]*glclient.VariableWithSourceFiltered, len(variables))
+	for i, v := range variables {
+		filtered[i] = &glclient.VariableWithSourceFiltered{
+			ReportType:       v.ReportType,
+			Instance:         v.Instance,
+			Key:              v.Key,
+			VariableType:     v.VariableType,
+			Protected:        v.Protected,
+			Masked:           v.Masked,
+			Hidden:           v.Hidden,
+			Raw:              v.Raw,
+			EnvironmentScope: v.EnvironmentScope,
+			Description:      v.Description,
+			Source:           v.Source,
+			SourceID:         v.SourceID,
+			SourceName:       v.SourceName,
+			SourcePath:       v.SourcePath,
+			SourceWebURL:     v.SourceWebURL,
+			SourceNamespace:  v.SourceNamespace,
 		}
 	}
 
-	return nil
+	return filtered
 }
 
-type CSVFormatter struct{}
+// variableTarget returns the variables settings page of the group or project defining variable.
+func variableTarget(variable *glclient.VariableWithSource) LinkTarget {
+	if variable.Source == "project" {
+		return LinkProjectVariables
+	}
+
+	return LinkGroupVariables
+}
 
-func (f *CSVFormatter) FormatGroups(groups []*gitlab.Group) error {
-	if len(groups) == 0 {
+type CSVFormatter struct {
+	sink
+
+	noHeader   bool
+	timeFormat TimeFormat
+	// links checks the linked settings pages for the link_status column, nil unless links are verified
+	links *linkChecker
+	// instanceLabel returns the instance of groups and projects, nil unless set by WithInstanceLabels
+	instanceLabel func(item any) string
+}
+
+// writeHeaders writes the header row, unless the formatter leaves it out.
+func (f *CSVFormatter) writeHeaders(writer *csv.Writer, headers []string) error {
+	if f.noHeader {
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
-	defer writer.Flush()
-
-	headers := getCSVHeaders(groups[0])
 	if err := writer.Write(headers); err != nil {
 		return fmt.Errorf("failed to write CSV headers: %w", err)
 	}
 
-	for _, group := range groups {
-		row := getCSVRow(group)
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("failed to write CSV row: %w", err)
-		}
+	return nil
+}
+
+func (f *CSVFormatter) FormatGroups(groups []*gitlab.Group) error {
+	if f.instanceLabel != nil {
+		return writeCSVItems(f, labelGroups(groups, f.instanceLabel))
 	}
 
-	return nil
+	return writeCSVItems(f, groups)
 }
 
 func (f *CSVFormatter) FormatProjects(projects []*gitlab.Project) error {
-	if len(projects) == 0 {
+	if f.instanceLabel != nil {
+		return writeCSVItems(f, labelProjects(projects, f.instanceLabel))
+	}
+
+	return writeCSVItems(f, projects)
+}
+
+// writeCSVItems writes a row per item, with the columns of the first.
+func writeCSVItems[T any](f *CSVFormatter, items []*T) error {
+	if len(items) == 0 {
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(projects[0])
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := getCSVHeaders(items[0])
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
-	for _, project := range projects {
-		row := getCSVRow(project)
+	for _, item := range items {
+		row := f.row(item)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -471,16 +756,16 @@ func (f *CSVFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTok
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(tokens[0])
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := f.withLinkStatusHeaders(getCSVHeaders(tokens[0]))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
 	for _, token := range tokens {
-		row := getCSVRow(token)
+		row := f.withLinkStatus(f.row(token), token.GroupWebURL, LinkGroupAccessTokens)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -494,16 +779,16 @@ func (f *CSVFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAcces
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(tokens[0])
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := f.withLinkStatusHeaders(getCSVHeaders(tokens[0]))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
 	for _, token := range tokens {
-		row := getCSVRow(token)
+		row := f.withLinkStatus(f.row(token), token.ProjectWebURL, LinkProjectAccessTokens)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -519,16 +804,16 @@ func (f *CSVFormatter) FormatProjectVariables(
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(variables[0], includeValues)
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := f.withLinkStatusHeaders(getCSVHeaders(variables[0], includeValues))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
 	for _, variable := range variables {
-		row := getCSVRow(variable, includeValues)
+		row := f.withLinkStatus(f.row(variable, includeValues), variable.ProjectWebURL, LinkProjectVariables)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -542,16 +827,16 @@ func (f *CSVFormatter) FormatGroupVariables(variables []*glclient.GroupVariableW
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(variables[0], includeValues)
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := f.withLinkStatusHeaders(getCSVHeaders(variables[0], includeValues))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
 	for _, variable := range variables {
-		row := getCSVRow(variable, includeValues)
+		row := f.withLinkStatus(f.row(variable, includeValues), variable.GroupWebURL, LinkGroupVariables)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -565,16 +850,16 @@ func (f *CSVFormatter) FormatUnifiedVariables(variables []*glclient.VariableWith
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(variables[0], includeValues)
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := f.withLinkStatusHeaders(getCSVHeaders(variables[0], includeValues))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
 	for _, variable := range variables {
-		row := getCSVRow(variable, includeValues)
+		row := f.withLinkStatus(f.row(variable, includeValues), variable.SourceWebURL, variableTarget(variable))
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -584,94 +869,36 @@ func (f *CSVFormatter) FormatUnifiedVariables(variables []*glclient.VariableWith
 }
 
 func getCSVHeaders(v interface{}, includeValues ...bool) []string {
-	var headers []string
-	skipValue := len(includeValues) > 0 && !includeValues[0]
-
-	val := reflect.ValueOf(v).Elem()
-	typ := val.Type()
-
-	for i := range typ.NumField() {
-		field := typ.Field(i)
-		if field.Anonymous {
-			if field.Type.Kind() == reflect.Ptr {
-				// Create a zero value of the embedded type to extract headers
-				embeddedType := field.Type.Elem()
-				embeddedVal := reflect.New(embeddedType)
-				embeddedHeaders := getCSVHeaders(embeddedVal.Interface(), includeValues...)
-				headers = append(headers, embeddedHeaders...)
-			}
-			if field.Type.Kind() == reflect.Struct {
-				// Handle non-pointer embedded structs
-				embeddedVal := reflect.New(field.Type)
-				embeddedHeaders := getCSVHeaders(embeddedVal.Interface(), includeValues...)
-				headers = append(headers, embeddedHeaders...)
-			}
+	fields := csvFields(v, includeValues...)
 
-			continue
-		}
-		jsonTag := field.Tag.Get("json")
-		if jsonTag != "" && jsonTag != "-" {
-			// Skip value field if includeValues is false
-			if skipValue && jsonTag == excludedFieldName {
-				continue
-			}
-			headers = append(headers, jsonTag)
-		}
+	headers := make([]string, 0, len(fields))
+	for _, field := range fields {
+		headers = append(headers, field.name)
 	}
 
 	return headers
 }
 
-func getCSVRow(v interface{}, includeValues ...bool) []string {
-	var row []string
-	skipValue := len(includeValues) > 0 && !includeValues[0]
-
-	val := reflect.ValueOf(v).Elem()
-	typ := val.Type()
+// row returns the CSV values of v, with its timestamps in the selected time format.
+func (f *CSVFormatter) row(v interface{}, includeValues ...bool) []string {
+	fields := csvFields(v, includeValues...)
 
-	for i := range typ.NumField() {
-		field := typ.Field(i)
-		fieldValue := val.Field(i)
-		if field.Anonymous {
-			if field.Type.Kind() == reflect.Ptr {
-				// getEmbeddedCSVRow handles nil pointers correctly
-				row = append(row, getEmbeddedCSVRow(fieldValue, includeValues...)...)
-			}
-			if field.Type.Kind() == reflect.Struct {
-				row = append(row, getEmbeddedCSVRow(fieldValue.Addr(), includeValues...)...)
-			}
+	row := make([]string, 0, len(fields))
+	for _, field := range fields {
+		// fields of a nil embedded struct are left empty
+		if !field.value.IsValid() {
+			row = append(row, "")
 
 			continue
 		}
-		// Regular field
-		jsonTag := field.Tag.Get("json")
-		if jsonTag != "" && jsonTag != "-" {
-			// Skip value field if includeValues is false
-			if skipValue && jsonTag == excludedFieldName {
-				continue
-			}
-			row = append(row, fmt.Sprintf("%v", fieldValue.Interface()))
-		}
-	}
-
-	return row
-}
-
-func getEmbeddedCSVRow(fieldValue reflect.Value, includeValues ...bool) []string {
-	var row []string
 
-	if !fieldValue.IsNil() {
-		embeddedRow := getCSVRow(fieldValue.Interface(), includeValues...)
-		row = append(row, embeddedRow...)
-	} else {
-		// Count the number of fields in the embedded struct to add empty values
-		embeddedType := fieldValue.Type().Elem()
-		embeddedVal := reflect.New(embeddedType)
-		embeddedHeaders := getCSVHeaders(embeddedVal.Interface(), includeValues...)
+		if value, ok := f.timeFormat.formatCSVTime(field); ok {
+			row = append(row, value)
 
-		for range embeddedHeaders {
-			row = append(row, "")
+			continue
 		}
+
+		row = append(row, fmt.Sprintf("%v", field.value.Interface()))
 	}
 
 	return row
@@ -682,16 +909,16 @@ func (f *CSVFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTrigg
 		return nil
 	}
 
-	writer := csv.NewWriter(os.Stdout)
+	writer := csv.NewWriter(f.writer())
 	defer writer.Flush()
 
-	headers := getCSVHeaders(triggers[0])
-	if err := writer.Write(headers); err != nil {
-		return fmt.Errorf("failed to write CSV headers: %w", err)
+	headers := f.withLinkStatusHeaders(getCSVHeaders(triggers[0]))
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
 	}
 
 	for _, trigger := range triggers {
-		row := getCSVRow(trigger)
+		row := f.withLinkStatus(f.row(trigger), trigger.ProjectWebURL, LinkPipelineTriggers)
 		if err := writer.Write(row); err != nil {
 			return fmt.Errorf("failed to write CSV row: %w", err)
 		}
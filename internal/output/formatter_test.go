@@ -1,11 +1,16 @@
 package output_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/andreygrechin/glreporter/internal/glclient"
 	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
@@ -20,6 +25,7 @@ func TestNewFormatter(t *testing.T) {
 		{"Table format", output.FormatTable, false},
 		{"JSON format", output.FormatJSON, false},
 		{"CSV format", output.FormatCSV, false},
+		{"Dotenv format", output.FormatDotenv, false},
 		{"Invalid format", output.Format("invalid"), true},
 	}
 
@@ -149,3 +155,546 @@ func TestCSVFormatterHandlesNilEmbeddedStructs(t *testing.T) {
 	err = formatter.FormatProjectVariables(testVariables, true)
 	assert.NoError(t, err)
 }
+
+func TestTableFormatter_FormatProjects(t *testing.T) {
+	projects := []*gitlab.Project{
+		{
+			ID:                1,
+			Name:              "api",
+			PathWithNamespace: "backend/api",
+			Description:       "Public REST API\nserving the mobile apps and the partner integrations",
+		},
+	}
+
+	t.Run("description omitted by default", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatTable)
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			require.NoError(t, formatter.FormatProjects(projects))
+		})
+
+		assert.NotContains(t, out, "DESCRIPTION")
+		assert.NotContains(t, out, "Public REST API")
+	})
+
+	t.Run("description truncated to width", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithDescriptions(20))
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			require.NoError(t, formatter.FormatProjects(projects))
+		})
+
+		assert.Contains(t, out, "DESCRIPTION")
+		assert.Contains(t, out, "Public REST API ser…")
+		assert.NotContains(t, out, "partner")
+	})
+
+	t.Run("description kept whole without width", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithDescriptions(0))
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			require.NoError(t, formatter.FormatProjects(projects))
+		})
+
+		assert.Contains(t, out, "Public REST API serving the mobile apps and the partner integrations")
+	})
+}
+
+func TestTableFormatter_FormatGroups(t *testing.T) {
+	groups := []*gitlab.Group{{ID: 2, Name: "backend", FullPath: "org/backend", Description: "Backend services"}}
+
+	formatter, err := output.NewFormatter(output.FormatTable, output.WithDescriptions(60))
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatGroups(groups))
+	})
+
+	assert.Contains(t, out, "DESCRIPTION")
+	assert.Contains(t, out, "Backend services")
+}
+
+func TestTableFormatter_tokenLastUsed(t *testing.T) {
+	lastUsed := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{Name: "deploy", LastUsedAt: &lastUsed},
+			},
+			ProjectPath: "group/used",
+		},
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{Name: "spare"},
+			},
+			ProjectPath: "group/unused",
+		},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+	})
+
+	assert.Contains(t, out, "LAST USED")
+	assert.Contains(t, out, "2025-03-04 05:06:07Z")
+	// both tokens never expire and one was never used
+	assert.Equal(t, 3, strings.Count(out, "Never"))
+}
+
+func TestTableFormatter_tokenCreatedAtAndUser(t *testing.T) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tokens := []*glclient.GroupAccessTokenWithGroup{
+		{
+			GroupAccessToken: &gitlab.GroupAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{Name: "deploy", CreatedAt: &createdAt},
+			},
+			GroupPath: "org/platform",
+		},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatGroupAccessTokens(tokens))
+	})
+
+	assert.Contains(t, out, "CREATED AT")
+	assert.Contains(t, out, "2025-01-02 03:04:05Z")
+	assert.NotContains(t, out, "USER")
+
+	tokens[0].Username = "group_1_bot_abc"
+
+	out = readStdout(t, func() {
+		require.NoError(t, formatter.FormatGroupAccessTokens(tokens))
+	})
+
+	assert.Contains(t, out, "USER")
+	assert.Contains(t, out, "group_1_bot_abc")
+}
+
+func TestTableFormatter_tokenLastUsedIPs(t *testing.T) {
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{ProjectAccessToken: &gitlab.ProjectAccessToken{}, ProjectPath: "org/api"},
+		{ProjectAccessToken: &gitlab.ProjectAccessToken{}, ProjectPath: "org/web"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	// the column is left out where GitLab does not report the addresses
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+	})
+
+	assert.NotContains(t, out, "LAST USED IPS")
+
+	tokens[0].LastUsedIPs = []string{"203.0.113.9", "10.0.0.5"}
+	tokens[1].LastUsedIPs = []string{}
+
+	out = readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+	})
+
+	assert.Contains(t, out, "LAST USED IPS")
+	assert.Contains(t, out, "203.0.113.9, 10.0.0.5")
+	assert.Contains(t, out, "N/A")
+}
+
+func TestTableFormatter_FormatEpics(t *testing.T) {
+	dueDate := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	epics := []*glclient.GroupEpic{
+		{
+			GroupPath: "org/team", IID: 2, Title: "Migrate runners", State: "opened",
+			AuthorUsername: "alice", DueDate: &dueDate,
+		},
+		{GroupPath: "org/team", IID: 3, Title: "Retire legacy CI", State: "closed"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatEpics(epics))
+	})
+
+	for _, want := range []string{"DUE DATE", "Migrate runners", "alice", "2025-06-30", "closed", "N/A"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestTableFormatter_FormatUnifiedMilestones(t *testing.T) {
+	startDate := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	milestones := []*glclient.MilestoneWithSource{
+		{Source: "group", SourcePath: "org", IID: 4, Title: "Q2", State: "active", StartDate: &startDate, DueDate: &dueDate},
+		{Source: "project", SourcePath: "org/api", IID: 1, Title: "v1.0", State: "closed"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatUnifiedMilestones(milestones))
+	})
+
+	for _, want := range []string{"SOURCE", "START DATE", "DUE DATE", "Q2", "2025-06-01", "2025-06-30", "org/api", "N/A"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestCSVFormatter_FormatGroupMilestones(t *testing.T) {
+	dueDate := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroupMilestones([]*glclient.GroupMilestone{
+		{GroupID: 1, GroupPath: "org", ID: 301, IID: 4, Title: "Q2", State: "active", DueDate: &dueDate},
+	}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t,
+		"group_id,group_name,group_path,group_web_url,id,iid,title,state,start_date,due_date,web_url", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "1,,org,,301,4,Q2,active,"))
+}
+
+func TestTableFormatter_FormatProtectedEnvironments(t *testing.T) {
+	environments := []*glclient.ProjectProtectedEnvironment{
+		{
+			ProjectPath:           "org/api",
+			Name:                  "production",
+			Protected:             true,
+			DeployAccessLevels:    []string{"Maintainers"},
+			RequiredApprovalCount: 2,
+			ApprovalRules:         []string{"qa: 2"},
+		},
+		{ProjectPath: "org/web", Name: "production"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatProtectedEnvironments(environments))
+	})
+
+	for _, want := range []string{"ENVIRONMENT", "ALLOWED TO DEPLOY", "Maintainers", "qa: 2", "org/web", "No", "N/A"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestCSVFormatter_FormatProtectedEnvironments(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProtectedEnvironments([]*glclient.ProjectProtectedEnvironment{
+		{ProjectID: 10, ProjectPath: "org/api", Name: "production", Protected: true, RequiredApprovalCount: 1},
+	}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "project_id,project_name,project_path,project_web_url,name,protected,deploy_access_levels,"+
+		"required_approval_count,approval_rules", lines[0])
+	assert.Equal(t, "10,,org/api,,production,true,[],1,[]", lines[1])
+}
+
+func TestTableFormatter_FormatClusterAgents(t *testing.T) {
+	lastContact := time.Date(2026, 10, 16, 11, 58, 0, 0, time.UTC)
+	agents := []*glclient.ProjectClusterAgent{
+		{
+			ProjectPath:      "org/infra",
+			Name:             "production",
+			ConfigProject:    "org/infra",
+			LastContactAt:    &lastContact,
+			ConnectionStatus: glclient.AgentConnected,
+		},
+		{ProjectPath: "org/infra", Name: "sandbox", ConnectionStatus: glclient.AgentNeverConnected},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatClusterAgents(agents))
+	})
+
+	for _, want := range []string{
+		"CONFIG PROJECT", "LAST CONTACT", "Connected", "2026-10-16 11:58:00Z", "Never connected",
+	} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestCSVFormatter_FormatClusterAgents(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatClusterAgents([]*glclient.ProjectClusterAgent{
+		{
+			ProjectID:        10,
+			ProjectPath:      "org/infra",
+			AgentID:          1,
+			Name:             "staging",
+			ConfigProject:    "org/infra",
+			ConnectionStatus: glclient.AgentNotConnected,
+		},
+	}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "project_id,project_name,project_path,project_web_url,agent_id,name,config_project,"+
+		"created_at,last_contact_at,connection_status", lines[0])
+	assert.Equal(t, "10,,org/infra,,1,staging,org/infra,<nil>,<nil>,not_connected", lines[1])
+}
+
+func TestTableFormatter_FormatProjectRunners(t *testing.T) {
+	runners := []*glclient.ProjectRunners{
+		{ProjectPath: "org/api", SharedRunnersEnabled: true, SharedRunners: 3, Reliance: "shared"},
+		{ProjectPath: "org/build", GroupRunners: 1, ProjectRunners: 2, Reliance: "specific"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjectRunners(runners))
+	})
+
+	for _, want := range []string{"RUNS ON", "SHARED RUNNERS", "org/build", "specific", "shared", "Yes", "No"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestTableFormatter_FormatRunnerSettings(t *testing.T) {
+	settings := []*glclient.ProjectRunnerSettings{
+		{ProjectPath: "org/api", SharedRunnersEnabled: true, GroupRunnersEnabled: true, GroupSharedRunners: "enabled"},
+		{ProjectPath: "alice/sandbox"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatRunnerSettings(settings))
+	})
+
+	for _, want := range []string{"GROUP SHARED RUNNERS", "org/api", "enabled", "alice/sandbox", "Yes", "No", "N/A"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestTableFormatter_FormatSecurityConfigs(t *testing.T) {
+	configs := []*glclient.ProjectSecurityConfig{
+		{ProjectPath: "org/api", PipelineID: 100, SAST: true, SecretPushProtection: gitlab.Ptr(false)},
+		{ProjectPath: "org/empty"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatSecurityConfigs(configs))
+	})
+
+	for _, want := range []string{"SECRET PUSH PROTECTION", "org/api", "100", "org/empty", "Yes", "No", "N/A"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestTableFormatter_FormatComplianceFrameworks(t *testing.T) {
+	frameworks := []*glclient.ProjectComplianceFrameworks{
+		{ProjectPath: "org/api", Frameworks: []string{"SOC 2", "HIPAA"}, FrameworksAvailable: true},
+		{ProjectPath: "org/web", Frameworks: []string{}, FrameworksAvailable: true},
+		{ProjectPath: "org/legacy", Frameworks: []string{}},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatComplianceFrameworks(frameworks))
+	})
+
+	for _, want := range []string{"COMPLIANCE FRAMEWORKS", "SOC 2, HIPAA", "None", "org/legacy", "N/A"} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestTableFormatter_FormatProjectForks(t *testing.T) {
+	forks := []*glclient.ProjectFork{
+		{ProjectPath: "org/api", ForkPath: "alice/api", ForkNamespace: "alice", External: true, Ahead: gitlab.Ptr(3)},
+		{ProjectPath: "org/api", ForkPath: "org/sandbox/api", ForkNamespace: "org/sandbox"},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTable)
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjectForks(forks))
+	})
+
+	for _, want := range []string{
+		"FORK PATH", "EXTERNAL", "AHEAD", "BEHIND", "alice/api", "org/sandbox", "Yes", "No", "3",
+	} {
+		assert.Contains(t, out, want)
+	}
+}
+
+func TestFormatUnifiedVariables_reportType(t *testing.T) {
+	variables := []*glclient.VariableWithSource{
+		{ReportType: glclient.ReportTypeGroupVariable, Key: "GROUP_VAR", Source: "group", SourcePath: "org"},
+		{ReportType: glclient.ReportTypeProjectVariable, Key: "PROJECT_VAR", Source: "project", SourcePath: "org/api"},
+	}
+
+	for _, includeValues := range []bool{true, false} {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatUnifiedVariables(variables, includeValues))
+
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got, 2)
+		assert.Equal(t, "group_variable", got[0]["report_type"])
+		assert.Equal(t, "project_variable", got[1]["report_type"])
+	}
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatUnifiedVariables(variables, true))
+
+	header, _, _ := strings.Cut(buf.String(), "\n")
+	assert.True(t, strings.HasPrefix(header, "key,value,"))
+	assert.NotContains(t, buf.String(), "report_type")
+	assert.NotContains(t, buf.String(), "group_variable")
+}
+
+func TestFormatVariables_includeValues(t *testing.T) {
+	groupVariables := []*glclient.GroupVariableWithGroup{
+		{GroupVariable: &gitlab.GroupVariable{Key: "DB_PASSWORD", Value: "s3cr3t-group"}, GroupPath: "org"},
+	}
+	unifiedVariables := []*glclient.VariableWithSource{
+		{Key: "DB_PASSWORD", Value: "s3cr3t-group", Source: "group", SourcePath: "org"},
+		{Key: "API_TOKEN", Value: "s3cr3t-project", Source: "project", SourcePath: "org/api"},
+	}
+
+	tests := []struct {
+		name   string
+		format func(formatter output.Formatter, includeValues bool) error
+		values []string
+	}{
+		{
+			name: "group variables",
+			format: func(formatter output.Formatter, includeValues bool) error {
+				return formatter.FormatGroupVariables(groupVariables, includeValues)
+			},
+			values: []string{"s3cr3t-group"},
+		},
+		{
+			name: "unified variables",
+			format: func(formatter output.Formatter, includeValues bool) error {
+				return formatter.FormatUnifiedVariables(unifiedVariables, includeValues)
+			},
+			values: []string{"s3cr3t-group", "s3cr3t-project"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, format := range []output.Format{output.FormatJSON, output.FormatCSV} {
+			t.Run(tt.name+"/"+string(format), func(t *testing.T) {
+				render := func(includeValues bool) string {
+					var buf bytes.Buffer
+
+					formatter, err := output.NewFormatter(format, output.WithWriter(&buf))
+					require.NoError(t, err)
+					require.NoError(t, tt.format(formatter, includeValues))
+
+					return buf.String()
+				}
+
+				withValues, withoutValues := render(true), render(false)
+				for _, value := range tt.values {
+					assert.Contains(t, withValues, value)
+					assert.NotContains(t, withoutValues, value)
+				}
+
+				assert.Contains(t, withoutValues, "DB_PASSWORD")
+			})
+		}
+	}
+}
+
+func TestFormatVariableDifferences(t *testing.T) {
+	differences := []*report.VariableDifference{
+		{
+			Key:              "DB_URL",
+			EnvironmentScope: "*",
+			Difference:       report.VariableValue,
+			Left:             true,
+			Right:            true,
+			LeftValue:        "postgres://staging",
+			RightValue:       "postgres://production",
+		},
+		{Key: "ONLY_STAGING", EnvironmentScope: "*", Difference: report.VariableOnlyLeft, Left: true, LeftValue: ""},
+	}
+
+	render := func(t *testing.T, format output.Format, includeValues bool) string {
+		t.Helper()
+
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(format, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatVariableDifferences(differences, includeValues))
+
+		return buf.String()
+	}
+
+	t.Run("masks values by default", func(t *testing.T) {
+		for _, format := range []output.Format{output.FormatTable, output.FormatJSON, output.FormatCSV} {
+			assert.NotContains(t, render(t, format, false), "postgres://", format)
+		}
+
+		table := render(t, output.FormatTable, false)
+		assert.Contains(t, table, "set")
+		assert.Contains(t, table, "missing")
+
+		assert.Equal(t, "key,environment_scope,difference,left,right\n"+
+			"DB_URL,*,value,true,true\n"+
+			"ONLY_STAGING,*,only_left,true,false\n", render(t, output.FormatCSV, false))
+	})
+
+	t.Run("shows values when requested", func(t *testing.T) {
+		table := render(t, output.FormatTable, true)
+		assert.Contains(t, table, "postgres://staging")
+		assert.Contains(t, table, "postgres://production")
+		assert.Contains(t, table, "(empty)")
+
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(render(t, output.FormatJSON, true)), &got))
+		require.Len(t, got, 2)
+		assert.Equal(t, "postgres://production", got[0]["right_value"])
+
+		csvLines := strings.Split(render(t, output.FormatCSV, true), "\n")
+		assert.Equal(t, "key,environment_scope,difference,left,right,left_value,right_value", csvLines[0])
+		assert.Equal(t, "DB_URL,*,value,true,true,postgres://staging,postgres://production", csvLines[1])
+	})
+}
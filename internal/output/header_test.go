@@ -0,0 +1,57 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestWithoutHeader_csv(t *testing.T) {
+	var withHeader, withoutHeader bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&withHeader))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroups(writerGroups))
+
+	formatter, err = output.NewFormatter(output.FormatCSV, output.WithWriter(&withoutHeader), output.WithoutHeader())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroups(writerGroups))
+
+	lines := strings.SplitAfter(withHeader.String(), "\n")
+	require.Len(t, lines, len(writerGroups)+2)
+	assert.Equal(t, strings.Join(lines[1:], ""), withoutHeader.String())
+
+	rows := strings.Split(strings.TrimSuffix(withoutHeader.String(), "\n"), "\n")
+	require.Len(t, rows, len(writerGroups))
+	assert.True(t, strings.HasPrefix(rows[0], "1,"))
+	assert.True(t, strings.HasPrefix(rows[1], "2,"))
+}
+
+func TestWithoutHeader_empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf), output.WithoutHeader())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroups([]*gitlab.Group{}))
+
+	assert.Empty(t, buf.Bytes())
+}
+
+func TestWithoutHeader_otherFormats(t *testing.T) {
+	for _, format := range []output.Format{output.FormatJSON, output.FormatTable} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			formatter, err := output.NewFormatter(format, output.WithWriter(&buf), output.WithoutHeader())
+			require.NoError(t, err)
+			require.NoError(t, formatter.FormatGroups(writerGroups))
+
+			assert.Equal(t, expectedReport(t, format), buf.Bytes())
+		})
+	}
+}
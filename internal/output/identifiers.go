@@ -0,0 +1,87 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// IDFormat selects how the table output identifies the group or project each row belongs to.
+// Other formats always include both the numeric ID and the path.
+type IDFormat string
+
+const (
+	// IDFormatNumeric shows the numeric ID only.
+	IDFormatNumeric IDFormat = "numeric"
+	// IDFormatPath shows the path with namespace only.
+	IDFormatPath IDFormat = "path"
+	// IDFormatBoth shows the numeric ID and the path in two columns.
+	IDFormatBoth IDFormat = "both"
+)
+
+var ErrInvalidIDFormat = errors.New("invalid ID format, must be numeric, path, or both")
+
+// WithIDFormat sets how the table output identifies groups and projects. Without it, the groups,
+// projects, and two-factor tables show both the ID and the path and the other tables the path only.
+func WithIDFormat(idFormat IDFormat) Option {
+	return func(o *options) {
+		o.idFormat = idFormat
+	}
+}
+
+func validateIDFormat(idFormat IDFormat) error {
+	if idFormat != "" && !slices.Contains([]IDFormat{IDFormatNumeric, IDFormatPath, IDFormatBoth}, idFormat) {
+		return fmt.Errorf("%w: %s", ErrInvalidIDFormat, idFormat)
+	}
+
+	return nil
+}
+
+// idColumns reports whether the numeric ID and the path columns are shown, using def when no
+// ID format was chosen.
+func (f *TableFormatter) idColumns(def IDFormat) (bool, bool) {
+	idFormat := f.idFormat
+	if idFormat == "" {
+		idFormat = def
+	}
+
+	return idFormat != IDFormatPath, idFormat != IDFormatNumeric
+}
+
+// identifier returns the cells identifying a row's group or project, with def as the report's
+// default ID format. It is used for headers as well, with the column titles as id and path.
+func (f *TableFormatter) identifier(def IDFormat, id, path any) table.Row {
+	showID, showPath := f.idColumns(def)
+
+	var row table.Row
+	if showID {
+		row = append(row, id)
+	}
+
+	if showPath {
+		row = append(row, path)
+	}
+
+	return row
+}
+
+// namedIdentifier is like identifier with the name between the ID and the path, the layout of the
+// groups and projects tables, which show both by default.
+func (f *TableFormatter) namedIdentifier(id, name, path any) table.Row {
+	showID, showPath := f.idColumns(IDFormatBoth)
+
+	var row table.Row
+	if showID {
+		row = append(row, id)
+	}
+
+	row = append(row, name)
+
+	if showPath {
+		row = append(row, path)
+	}
+
+	return row
+}
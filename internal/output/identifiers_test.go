@@ -0,0 +1,97 @@
+package output_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestTableFormatter_identifier(t *testing.T) {
+	tokens := []*glclient.GroupAccessTokenWithGroup{
+		{
+			GroupAccessToken: &gitlab.GroupAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{Name: "deploy"}},
+			GroupID:          4242,
+			GroupPath:        "org/platform",
+		},
+	}
+
+	groups := []*gitlab.Group{{ID: 4242, Name: "platform", FullPath: "org/platform"}}
+
+	tests := []struct {
+		name       string
+		opts       []output.Option
+		wantTokens []string
+		skipTokens []string
+		wantGroups []string
+		skipGroups []string
+	}{
+		{
+			name:       "report defaults",
+			wantTokens: []string{"GROUP PATH", "org/platform"},
+			skipTokens: []string{"GROUP ID", "4242"},
+			wantGroups: []string{"ID", "4242", "FULL PATH", "org/platform"},
+		},
+		{
+			name:       "numeric",
+			opts:       []output.Option{output.WithIDFormat(output.IDFormatNumeric)},
+			wantTokens: []string{"GROUP ID", "4242"},
+			skipTokens: []string{"GROUP PATH", "org/platform"},
+			wantGroups: []string{"ID", "4242", "platform"},
+			skipGroups: []string{"FULL PATH", "org/platform"},
+		},
+		{
+			name:       "path",
+			opts:       []output.Option{output.WithIDFormat(output.IDFormatPath)},
+			wantTokens: []string{"GROUP PATH", "org/platform"},
+			skipTokens: []string{"GROUP ID", "4242"},
+			wantGroups: []string{"FULL PATH", "org/platform"},
+			skipGroups: []string{"4242"},
+		},
+		{
+			name:       "both",
+			opts:       []output.Option{output.WithIDFormat(output.IDFormatBoth)},
+			wantTokens: []string{"GROUP ID", "4242", "GROUP PATH", "org/platform"},
+			wantGroups: []string{"ID", "4242", "FULL PATH", "org/platform"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := output.NewFormatter(output.FormatTable, tt.opts...)
+			require.NoError(t, err)
+
+			out := readStdout(t, func() {
+				require.NoError(t, formatter.FormatGroupAccessTokens(tokens))
+			})
+
+			for _, want := range tt.wantTokens {
+				assert.Contains(t, out, want)
+			}
+
+			for _, skip := range tt.skipTokens {
+				assert.NotContains(t, out, skip)
+			}
+
+			out = readStdout(t, func() {
+				require.NoError(t, formatter.FormatGroups(groups))
+			})
+
+			for _, want := range tt.wantGroups {
+				assert.Contains(t, out, want)
+			}
+
+			for _, skip := range tt.skipGroups {
+				assert.NotContains(t, out, skip)
+			}
+		})
+	}
+}
+
+func TestNewFormatter_invalidIDFormat(t *testing.T) {
+	_, err := output.NewFormatter(output.FormatTable, output.WithIDFormat("slug"))
+	require.ErrorIs(t, err, output.ErrInvalidIDFormat)
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	t := f.newTable()
+	t.AppendHeader(table.Row{"Username", "Token Name", "Scopes", "Active", "Created At", "Expires At", "Last Used"})
+
+	for _, token := range tokens {
+		expiresAt := defaultExpiresAtText
+		if token.ExpiresAt != nil {
+			expiresAt = f.formatTime(time.Time(*token.ExpiresAt))
+		}
+
+		t.AppendRow(table.Row{
+			token.Username, token.Name, token.Scopes, token.Active,
+			f.tokenTime(token.CreatedAt), expiresAt, f.tokenLastUsed(token.LastUsedAt),
+		})
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	return f.encode(tokens, len(tokens), "impersonation tokens")
+}
+
+func (f *CSVFormatter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, getCSVHeaders(tokens[0])); err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if err := writer.Write(f.row(token)); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatImpersonationTokens(_ []*glclient.ImpersonationTokenWithUser) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	return f.render("impersonation tokens", tokens)
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	lifetime := "unlimited"
+	if settings.MaxPersonalAccessTokenLifetime > 0 {
+		lifetime = fmt.Sprintf("%d days", settings.MaxPersonalAccessTokenLifetime)
+	}
+
+	prefix := settings.PersonalAccessTokenPrefix
+	if prefix == "" {
+		prefix = defaultTextPlaceholder
+	}
+
+	t := f.newTable()
+	t.AppendRows([]table.Row{
+		{"Max Access Token Lifetime", lifetime},
+		{"Expiry Required", settings.RequirePersonalAccessTokenExpiry},
+		{"PAT Expiration Enforced", settings.EnforcePATExpiration},
+		{"Service Account Expiration Enforced", settings.ServiceAccessTokensExpirationEnforced},
+		{"Personal Access Tokens Disabled", settings.DisablePersonalAccessTokens},
+		{"Token Prefix", prefix},
+		{"Feed Token Disabled", settings.DisableFeedToken},
+		{"Runner Registration Tokens Allowed", settings.AllowRunnerRegistrationToken},
+		{"Job Token Allowlist Enforced", settings.EnforceCIInboundJobTokenScopeEnabled},
+	})
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	var v any = settings
+	if f.flatten {
+		v = flattenItems(settings)
+	}
+
+	encoder := json.NewEncoder(f.writer())
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode instance token settings as JSON: %w", err)
+	}
+
+	return nil
+}
+
+func (f *CSVFormatter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, getCSVHeaders(settings)); err != nil {
+		return err
+	}
+
+	if err := writer.Write(f.row(settings)); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatInstanceTokenSettings(_ *glclient.InstanceTokenSettings) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	return f.render("instance token settings", settings)
+}
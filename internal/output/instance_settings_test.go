@@ -0,0 +1,49 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatInstanceTokenSettings(t *testing.T) {
+	settings := &glclient.InstanceTokenSettings{
+		ReportType:                       glclient.ReportTypeInstanceTokenSettings,
+		MaxPersonalAccessTokenLifetime:   90,
+		RequirePersonalAccessTokenExpiry: true,
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatInstanceTokenSettings(settings))
+
+		assert.Contains(t, buf.String(), "90 days")
+		assert.Contains(t, buf.String(), "N/A")
+
+		buf.Reset()
+		require.NoError(t, formatter.FormatInstanceTokenSettings(&glclient.InstanceTokenSettings{}))
+		assert.Contains(t, buf.String(), "unlimited")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatInstanceTokenSettings(settings))
+
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, "instance_token_settings", got["report_type"])
+		assert.InDelta(t, 90, got["max_personal_access_token_lifetime"], 0)
+		assert.Equal(t, true, got["require_personal_access_token_expiry"])
+	})
+}
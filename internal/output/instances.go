@@ -0,0 +1,85 @@
+package output
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// WithInstanceColumn adds an Instance column to the table output of reports fetched from several
+// GitLab instances, holding the label of the instance each row comes from. The other formats carry
+// the label in the instance field of each item whenever it is set.
+func WithInstanceColumn() Option {
+	return func(o *options) {
+		o.instanceColumn = true
+	}
+}
+
+// WithInstanceLabels labels the groups and projects of reports fetched from several GitLab instances
+// with the instance label returns for each of them. Having no instance field of their own, they are
+// wrapped in items that carry one by every format but the table, which shows the Instance column.
+func WithInstanceLabels(label func(item any) string) Option {
+	return func(o *options) {
+		o.instanceLabel = label
+	}
+}
+
+// instanceGroup is a group labeled with the instance it was fetched from.
+type instanceGroup struct {
+	Instance string `json:"instance,omitempty" csv:"omitempty"`
+	*gitlab.Group
+}
+
+// instanceProject is a project labeled with the instance it was fetched from.
+type instanceProject struct {
+	Instance string `json:"instance,omitempty" csv:"omitempty"`
+	*gitlab.Project
+}
+
+// labelGroups wraps each of groups with the label of its instance.
+func labelGroups(groups []*gitlab.Group, label func(item any) string) []*instanceGroup {
+	labeled := make([]*instanceGroup, len(groups))
+	for i, group := range groups {
+		labeled[i] = &instanceGroup{Instance: label(group), Group: group}
+	}
+
+	return labeled
+}
+
+// labelProjects wraps each of projects with the label of its instance.
+func labelProjects(projects []*gitlab.Project, label func(item any) string) []*instanceProject {
+	labeled := make([]*instanceProject, len(projects))
+	for i, project := range projects {
+		labeled[i] = &instanceProject{Instance: label(project), Project: project}
+	}
+
+	return labeled
+}
+
+// withInstanceHeader prepends the Instance column to header when the report spans several instances.
+func (f *TableFormatter) withInstanceHeader(header table.Row) table.Row {
+	if !f.instanceColumn {
+		return header
+	}
+
+	return append(table.Row{"Instance"}, header...)
+}
+
+// withInstance prepends label, the instance a row comes from, to row when the report spans several
+// instances.
+func (f *TableFormatter) withInstance(row table.Row, label string) table.Row {
+	if !f.instanceColumn {
+		return row
+	}
+
+	return append(table.Row{label}, row...)
+}
+
+// instanceOf returns the label of the instance item, a group or project, was fetched from, or an
+// empty one without instance labels.
+func (f *TableFormatter) instanceOf(item any) string {
+	if f.instanceLabel == nil {
+		return ""
+	}
+
+	return f.instanceLabel(item)
+}
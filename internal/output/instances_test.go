@@ -0,0 +1,237 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func instanceTopics(labels ...string) []*glclient.ProjectTopics {
+	topics := make([]*glclient.ProjectTopics, 0, len(labels))
+	for _, label := range labels {
+		topics = append(topics, &glclient.ProjectTopics{Instance: label, ProjectPath: "org/api", Topics: []string{"pci"}})
+	}
+
+	return topics
+}
+
+func TestTableFormatter_instanceColumn(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf), output.WithInstanceColumn())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectTopics(instanceTopics("acme", "globex")))
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Regexp(t, `^\| INSTANCE +\| PROJECT PATH`, lines[1])
+	assert.Regexp(t, `^\| acme +\|`, lines[3])
+	assert.Regexp(t, `^\| globex +\|`, lines[4])
+
+	buf.Reset()
+
+	formatter, err = output.NewFormatter(output.FormatTable, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectTopics(instanceTopics("")))
+	assert.NotContains(t, buf.String(), "INSTANCE")
+}
+
+func TestCSVFormatter_instanceColumn(t *testing.T) {
+	tests := []struct {
+		name       string
+		topics     []*glclient.ProjectTopics
+		wantColumn bool
+	}{
+		{name: "labeled items", topics: instanceTopics("acme", "globex"), wantColumn: true},
+		{name: "unlabeled items", topics: instanceTopics("", "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+			require.NoError(t, err)
+			require.NoError(t, formatter.FormatProjectTopics(tt.topics))
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			require.NoError(t, err)
+
+			if !tt.wantColumn {
+				assert.NotContains(t, records[0], "instance")
+
+				return
+			}
+
+			assert.Equal(t, "instance", records[0][0])
+			assert.Equal(t, "acme", records[1][0])
+			assert.Equal(t, "globex", records[2][0])
+		})
+	}
+}
+
+func TestJSONFormatter_instanceField(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectTopics(instanceTopics("acme", "")))
+
+	out := buf.String()
+	assert.Contains(t, out, `"instance": "acme"`)
+	assert.Equal(t, 1, strings.Count(out, `"instance"`), "an unlabeled item has no instance field")
+}
+
+func TestTableFormatter_instanceColumnOfTokensAndVariables(t *testing.T) {
+	tests := []struct {
+		name   string
+		format func(formatter output.Formatter) error
+	}{
+		{
+			name: "group access tokens",
+			format: func(formatter output.Formatter) error {
+				return formatter.FormatGroupAccessTokens([]*glclient.GroupAccessTokenWithGroup{
+					{GroupAccessToken: &gitlab.GroupAccessToken{}, Instance: "acme", GroupPath: "org"},
+				})
+			},
+		},
+		{
+			name: "project access tokens",
+			format: func(formatter output.Formatter) error {
+				return formatter.FormatProjectAccessTokens([]*glclient.ProjectAccessTokenWithProject{
+					{ProjectAccessToken: &gitlab.ProjectAccessToken{}, Instance: "acme", ProjectPath: "org/api"},
+				})
+			},
+		},
+		{
+			name: "pipeline triggers",
+			format: func(formatter output.Formatter) error {
+				return formatter.FormatPipelineTriggers([]*glclient.PipelineTriggerWithProject{
+					{PipelineTrigger: &gitlab.PipelineTrigger{}, Instance: "acme", ProjectPath: "org/api"},
+				})
+			},
+		},
+		{
+			name: "project variables",
+			format: func(formatter output.Formatter) error {
+				return formatter.FormatProjectVariables([]*glclient.ProjectVariableWithProject{
+					{ProjectVariable: &gitlab.ProjectVariable{Key: "TOKEN"}, Instance: "acme", ProjectPath: "org/api"},
+				}, false)
+			},
+		},
+		{
+			name: "group variables",
+			format: func(formatter output.Formatter) error {
+				return formatter.FormatGroupVariables([]*glclient.GroupVariableWithGroup{
+					{GroupVariable: &gitlab.GroupVariable{Key: "TOKEN"}, Instance: "acme", GroupFullPath: "org"},
+				}, false)
+			},
+		},
+		{
+			name: "unified variables",
+			format: func(formatter output.Formatter) error {
+				return formatter.FormatUnifiedVariables([]*glclient.VariableWithSource{
+					{Instance: "acme", Key: "TOKEN", Source: "group", SourcePath: "org"},
+				}, false)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf), output.WithInstanceColumn())
+			require.NoError(t, err)
+			require.NoError(t, tt.format(formatter))
+
+			lines := strings.Split(buf.String(), "\n")
+			assert.Regexp(t, `^\| INSTANCE +\|`, lines[1])
+			assert.Regexp(t, `^\| acme +\|`, lines[3])
+		})
+	}
+}
+
+func TestCSVFormatter_instanceColumnOfVariables(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectVariables([]*glclient.ProjectVariableWithProject{
+		{ProjectVariable: &gitlab.ProjectVariable{Key: "TOKEN"}, Instance: "acme", ProjectPath: "org/api"},
+		{ProjectVariable: &gitlab.ProjectVariable{Key: "TOKEN"}, Instance: "globex", ProjectPath: "org/api"},
+	}, false))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	column := slices.Index(records[0], "instance")
+	require.NotEqual(t, -1, column)
+	assert.Equal(t, "acme", records[1][column])
+	assert.Equal(t, "globex", records[2][column])
+}
+
+func TestNewFormatter_instanceLabels(t *testing.T) {
+	groups := []*gitlab.Group{{ID: 1, FullPath: "org"}, {ID: 2, FullPath: "org"}}
+	projects := []*gitlab.Project{{ID: 3, PathWithNamespace: "org/api"}}
+	labels := map[any]string{groups[0]: "acme", groups[1]: "globex", projects[0]: "acme"}
+
+	label := func(item any) string { return labels[item] }
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf),
+			output.WithInstanceColumn(), output.WithInstanceLabels(label))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatGroups(groups))
+
+		lines := strings.Split(buf.String(), "\n")
+		assert.Regexp(t, `^\| INSTANCE +\| ID`, lines[1])
+		assert.Regexp(t, `^\| acme +\| +1 \|`, lines[3])
+		assert.Regexp(t, `^\| globex +\| +2 \|`, lines[4])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithInstanceLabels(label))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatProjects(projects))
+
+		assert.Contains(t, buf.String(), `"instance": "acme"`)
+		assert.Contains(t, buf.String(), `"path_with_namespace": "org/api"`)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf), output.WithInstanceLabels(label))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatGroups(groups))
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, "instance", records[0][0])
+		assert.Equal(t, "acme", records[1][0])
+		assert.Equal(t, "globex", records[2][0])
+	})
+
+	t.Run("template", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTemplate, output.WithWriter(&buf),
+			output.WithInstanceLabels(label), output.WithTemplate(`{{range .}}{{.Instance}}:{{.FullPath}} {{end}}`))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatGroups(groups))
+
+		assert.Equal(t, "acme:org globex:org ", buf.String())
+	})
+}
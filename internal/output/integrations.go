@@ -0,0 +1,85 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Type", "Name", "Active", "Endpoint", "Events")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, integration := range integrations {
+		target := integrationTarget(integration)
+		pathLink := f.link(integration.ProjectWebURL, target, integration.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, integration.ProjectID, pathLink),
+			integration.Type,
+			textOrPlaceholder(integration.Name),
+			integration.Active,
+			textOrPlaceholder(integration.EndpointURL),
+			textOrPlaceholder(strings.Join(integration.Events, ", ")),
+		), integration.ProjectWebURL, target), integration.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+// integrationTarget returns the settings page of the project listing integration.
+func integrationTarget(integration *glclient.ProjectIntegration) LinkTarget {
+	if integration.Type == glclient.IntegrationTypeWebhook {
+		return LinkWebhooks
+	}
+
+	return LinkIntegrations
+}
+
+// textOrPlaceholder returns s, or the placeholder when it is empty.
+func textOrPlaceholder(s string) string {
+	if s == "" {
+		return defaultTextPlaceholder
+	}
+
+	return s
+}
+
+func (f *JSONFormatter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	return f.encode(integrations, len(integrations), "integrations")
+}
+
+func (f *CSVFormatter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	if len(integrations) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(integrations[0]))); err != nil {
+		return err
+	}
+
+	for _, integration := range integrations {
+		row := f.withLinkStatus(f.row(integration), integration.ProjectWebURL, integrationTarget(integration))
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatIntegrations(_ []*glclient.ProjectIntegration) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	return f.render("integrations", integrations)
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Allowlist", "Allowed Projects", "Allowed Groups")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, scope := range scopes {
+		allowlist := "Disabled"
+		if scope.AllowlistEnabled {
+			allowlist = "Enforced"
+		}
+
+		pathLink := f.link(scope.ProjectWebURL, LinkCICDSettings, scope.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, scope.ProjectID, pathLink),
+			allowlist,
+			textOrPlaceholder(strings.Join(scope.AllowedProjectPaths, "\n")),
+			textOrPlaceholder(strings.Join(scope.AllowedGroupPaths, "\n")),
+		), scope.ProjectWebURL, LinkCICDSettings), scope.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	return f.encode(scopes, len(scopes), "job token scopes")
+}
+
+func (f *CSVFormatter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(scopes[0]))); err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		row := f.withLinkStatus(f.row(scope), scope.ProjectWebURL, LinkCICDSettings)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatJobTokenScopes(_ []*glclient.ProjectJobTokenScope) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	return f.render("job token scopes", scopes)
+}
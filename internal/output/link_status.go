@@ -0,0 +1,116 @@
+package output
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// URLVerifier returns the HTTP status code answering a HEAD request against url.
+type URLVerifier func(url string) (int, error)
+
+// WithURLVerifier adds the HTTP status of the settings page each row links to as an extra column of
+// the table and CSV reports, checking each page once with verify. Other formats are unaffected.
+func WithURLVerifier(verify URLVerifier) Option {
+	return func(o *options) {
+		o.verifyURL = verify
+	}
+}
+
+// linkStatus is the outcome of checking a link.
+type linkStatus struct {
+	code int
+	err  error
+}
+
+// linkChecker checks the settings pages the rows of a report link to, remembering the outcome for
+// each URL so that rows linking to the same page check it once.
+type linkChecker struct {
+	verify   URLVerifier
+	suffixes map[LinkTarget]string
+	checked  map[string]linkStatus
+}
+
+// newLinkChecker returns a linkChecker verifying links with verify, or nil if verify is nil.
+func newLinkChecker(verify URLVerifier, suffixes map[LinkTarget]string) *linkChecker {
+	if verify == nil {
+		return nil
+	}
+
+	return &linkChecker{verify: verify, suffixes: suffixes, checked: make(map[string]linkStatus)}
+}
+
+// check returns the URL of the target page of the group or project at webURL and the outcome of
+// checking it. Unknown or redacted web URLs have no page to check and return an empty URL.
+func (c *linkChecker) check(webURL string, target LinkTarget) (string, linkStatus) {
+	link := linkURL(webURL, c.suffixes[target])
+	if link == "" {
+		return "", linkStatus{}
+	}
+
+	status, ok := c.checked[link]
+	if !ok {
+		status.code, status.err = c.verify(link)
+		c.checked[link] = status
+	}
+
+	return link, status
+}
+
+// withLinkStatusHeader appends the Link Status column to header when links are verified.
+func (f *TableFormatter) withLinkStatusHeader(header table.Row) table.Row {
+	if f.links == nil {
+		return header
+	}
+
+	return append(header, "Link Status")
+}
+
+// withLinkStatus appends the status of the target page of the group or project at webURL to row
+// when links are verified. Missing pages stand out as "404 Not Found".
+func (f *TableFormatter) withLinkStatus(row table.Row, webURL string, target LinkTarget) table.Row {
+	if f.links == nil {
+		return row
+	}
+
+	link, status := f.links.check(webURL, target)
+
+	switch {
+	case link == "":
+		return append(row, defaultTextPlaceholder)
+	case status.err != nil:
+		return append(row, "error")
+	default:
+		return append(row, fmt.Sprintf("%d %s", status.code, http.StatusText(status.code)))
+	}
+}
+
+// withLinkStatusHeaders appends the link_url and link_status columns to headers when links are verified.
+func (f *CSVFormatter) withLinkStatusHeaders(headers []string) []string {
+	if f.links == nil {
+		return headers
+	}
+
+	return append(headers, "link_url", "link_status")
+}
+
+// withLinkStatus appends the URL of the target page of the group or project at webURL and its
+// status code to row when links are verified. The status is "error" if the page could not be reached.
+func (f *CSVFormatter) withLinkStatus(row []string, webURL string, target LinkTarget) []string {
+	if f.links == nil {
+		return row
+	}
+
+	link, status := f.links.check(webURL, target)
+
+	switch {
+	case link == "":
+		return append(row, "", "")
+	case status.err != nil:
+		return append(row, link, "error")
+	default:
+		return append(row, link, strconv.Itoa(status.code))
+	}
+}
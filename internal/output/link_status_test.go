@@ -0,0 +1,116 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVerifier answers link checks with the status mapped to each URL and counts the checks.
+type fakeVerifier struct {
+	statuses map[string]int
+	calls    map[string]int
+}
+
+var errUnreachable = errors.New("connection refused")
+
+func (v *fakeVerifier) verify(url string) (int, error) {
+	v.calls[url]++
+
+	status, ok := v.statuses[url]
+	if !ok {
+		return 0, errUnreachable
+	}
+
+	return status, nil
+}
+
+func linkStatusFixture() (*fakeVerifier, []*glclient.ProjectStorage) {
+	verifier := &fakeVerifier{
+		statuses: map[string]int{
+			"https://gitlab.example.com/org/api/-/usage_quotas":  http.StatusOK,
+			"https://gitlab.example.com/org/gone/-/usage_quotas": http.StatusNotFound,
+		},
+		calls: map[string]int{},
+	}
+
+	storage := []*glclient.ProjectStorage{
+		{ProjectPath: "org/api", ProjectWebURL: "https://gitlab.example.com/org/api"},
+		{ProjectPath: "org/api", ProjectWebURL: "https://gitlab.example.com/org/api"},
+		{ProjectPath: "org/gone", ProjectWebURL: "https://gitlab.example.com/org/gone"},
+		{ProjectPath: "org/down", ProjectWebURL: "https://gitlab.example.com/org/down"},
+		{ProjectPath: "org/redacted", ProjectWebURL: "5f2b9c0e"},
+	}
+
+	return verifier, storage
+}
+
+func TestTableFormatter_linkStatus(t *testing.T) {
+	t.Run("adds the status of each linked page", func(t *testing.T) {
+		verifier, storage := linkStatusFixture()
+
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTable,
+			output.WithWriter(&buf), output.WithURLVerifier(verifier.verify))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatProjectStorage(storage))
+
+		out := buf.String()
+		assert.Contains(t, out, "LINK STATUS")
+		assert.Contains(t, out, "200 OK")
+		assert.Contains(t, out, "404 Not Found")
+		assert.Contains(t, out, "error")
+
+		assert.Equal(t, map[string]int{
+			"https://gitlab.example.com/org/api/-/usage_quotas":  1,
+			"https://gitlab.example.com/org/gone/-/usage_quotas": 1,
+			"https://gitlab.example.com/org/down/-/usage_quotas": 1,
+		}, verifier.calls)
+	})
+
+	t.Run("leaves the column out without a verifier", func(t *testing.T) {
+		_, storage := linkStatusFixture()
+
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatProjectStorage(storage))
+
+		assert.NotContains(t, buf.String(), "LINK STATUS")
+	})
+}
+
+func TestCSVFormatter_linkStatus(t *testing.T) {
+	verifier, storage := linkStatusFixture()
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV,
+		output.WithWriter(&buf), output.WithURLVerifier(verifier.verify))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectStorage(storage))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(storage)+1)
+
+	tail := func(record []string) []string { return record[len(record)-2:] }
+
+	assert.Equal(t, []string{"link_url", "link_status"}, tail(records[0]))
+	assert.Equal(t, []string{"https://gitlab.example.com/org/api/-/usage_quotas", "200"}, tail(records[1]))
+	assert.Equal(t, []string{"https://gitlab.example.com/org/api/-/usage_quotas", "200"}, tail(records[2]))
+	assert.Equal(t, []string{"https://gitlab.example.com/org/gone/-/usage_quotas", "404"}, tail(records[3]))
+	assert.Equal(t, []string{"https://gitlab.example.com/org/down/-/usage_quotas", "error"}, tail(records[4]))
+	assert.Equal(t, []string{"", ""}, tail(records[5]))
+
+	assert.Equal(t, 1, verifier.calls["https://gitlab.example.com/org/api/-/usage_quotas"])
+}
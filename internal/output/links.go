@@ -0,0 +1,178 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// LinkTarget names a GitLab page the table output links group and project paths to.
+type LinkTarget string
+
+const (
+	// LinkGroupAccessTokens is the access tokens settings page of a group.
+	LinkGroupAccessTokens LinkTarget = "group-access-tokens"
+	// LinkProjectAccessTokens is the access tokens settings page of a project.
+	LinkProjectAccessTokens LinkTarget = "project-access-tokens"
+	// LinkPipelineTriggers is the pipeline trigger tokens section of a project's CI/CD settings.
+	LinkPipelineTriggers LinkTarget = "pipeline-triggers"
+	// LinkGroupVariables is the variables section of a group's CI/CD settings.
+	LinkGroupVariables LinkTarget = "group-variables"
+	// LinkProjectVariables is the variables section of a project's CI/CD settings.
+	LinkProjectVariables LinkTarget = "project-variables"
+	// LinkBadges is the badges section of a group's or project's general settings.
+	LinkBadges LinkTarget = "badges"
+	// LinkGroupAccessRequests is the access requests tab of a group's members page.
+	LinkGroupAccessRequests LinkTarget = "group-access-requests"
+	// LinkProjectAccessRequests is the access requests tab of a project's members page.
+	LinkProjectAccessRequests LinkTarget = "project-access-requests"
+	// LinkUsageQuotas is the usage quotas page of a project.
+	LinkUsageQuotas LinkTarget = "usage-quotas"
+	// LinkCICDSettings is the CI/CD settings page of a project.
+	LinkCICDSettings LinkTarget = "ci-cd-settings"
+	// LinkDefaultBranch is the default branch section of a project's repository settings.
+	LinkDefaultBranch LinkTarget = "default-branch"
+	// LinkActivity is the activity page of a project.
+	LinkActivity LinkTarget = "activity"
+	// LinkIntegrations is the integrations settings page of a project.
+	LinkIntegrations LinkTarget = "integrations"
+	// LinkWebhooks is the webhooks settings page of a project.
+	LinkWebhooks LinkTarget = "webhooks"
+	// LinkTopics is the general settings page of a project, where its topics are edited.
+	LinkTopics LinkTarget = "topics"
+	// LinkEpics is the epics page of a group.
+	LinkEpics LinkTarget = "epics"
+	// LinkMilestones is the milestones page of a group or project.
+	LinkMilestones LinkTarget = "milestones"
+	// LinkProtectedEnvironments is the protected environments section of a project's CI/CD settings.
+	LinkProtectedEnvironments LinkTarget = "protected-environments"
+	// LinkRunners is the runners section of a project's CI/CD settings.
+	LinkRunners LinkTarget = "runners"
+	// LinkForks is the forks page of a project.
+	LinkForks LinkTarget = "forks"
+	// LinkRegistry is the container registry page of a project.
+	LinkRegistry LinkTarget = "registry"
+	// LinkPushRules is the push rules section of a group's or project's repository settings.
+	LinkPushRules LinkTarget = "push-rules"
+	// LinkClusterAgents is the Kubernetes clusters page of a project, listing its agents.
+	LinkClusterAgents LinkTarget = "cluster-agents"
+	// LinkSecurityConfiguration is the security configuration page of a project.
+	LinkSecurityConfiguration LinkTarget = "security-configuration"
+	// LinkComplianceFrameworks is the general settings page of a project, where its compliance
+	// frameworks are applied.
+	LinkComplianceFrameworks LinkTarget = "compliance-frameworks"
+)
+
+// defaultLinkSuffixes are appended to the web URL of a group or project to link to each target,
+// matching the pages of current GitLab versions.
+var defaultLinkSuffixes = map[LinkTarget]string{
+	LinkGroupAccessTokens:     "/-/settings/access_tokens",
+	LinkProjectAccessTokens:   "/-/settings/access_tokens",
+	LinkPipelineTriggers:      "/-/settings/ci_cd#js-pipeline-triggers",
+	LinkGroupVariables:        "/-/settings/ci_cd#ci-variables",
+	LinkProjectVariables:      "/-/settings/ci_cd#js-cicd-variables-settings",
+	LinkBadges:                "/-/settings/general#js-badges-settings",
+	LinkGroupAccessRequests:   "/-/group_members?tab=access_requests",
+	LinkProjectAccessRequests: "/-/project_members?tab=access_requests",
+	LinkUsageQuotas:           "/-/usage_quotas",
+	LinkCICDSettings:          "/-/settings/ci_cd",
+	LinkDefaultBranch:         "/-/settings/repository#branch-defaults-settings",
+	LinkActivity:              "/activity",
+	LinkIntegrations:          "/-/settings/integrations",
+	LinkWebhooks:              "/-/hooks",
+	LinkTopics:                "/edit",
+	LinkEpics:                 "/-/epics",
+	LinkMilestones:            "/-/milestones",
+	LinkProtectedEnvironments: "/-/settings/ci_cd#js-protected-environments-settings",
+	LinkRunners:               "/-/settings/ci_cd#js-runners-settings",
+	LinkForks:                 "/-/forks",
+	LinkRegistry:              "/container_registry",
+	LinkPushRules:             "/-/settings/repository#js-push-rules",
+	LinkClusterAgents:         "/-/clusters",
+	LinkSecurityConfiguration: "/-/security/configuration",
+	LinkComplianceFrameworks:  "/edit",
+}
+
+var ErrUnknownLinkTarget = errors.New("unknown link target")
+
+// LinkTargets returns the names of all link targets, sorted.
+func LinkTargets() []LinkTarget {
+	return slices.Sorted(maps.Keys(defaultLinkSuffixes))
+}
+
+// WithLinkSuffixes overrides the suffixes appended to web URLs for the given link targets,
+// for GitLab versions whose settings pages live elsewhere. An empty suffix links to the group
+// or project page itself.
+func WithLinkSuffixes(suffixes map[LinkTarget]string) Option {
+	return func(o *options) {
+		if o.linkSuffixes == nil {
+			o.linkSuffixes = make(map[LinkTarget]string, len(suffixes))
+		}
+
+		maps.Copy(o.linkSuffixes, suffixes)
+	}
+}
+
+// WithoutLinkSuffixes links paths in the table output to the group or project page itself
+// instead of one of its settings pages.
+func WithoutLinkSuffixes() Option {
+	return func(o *options) {
+		o.noLinkSuffixes = true
+	}
+}
+
+// linkSuffixes returns the suffix of every link target after applying the options.
+func linkSuffixes(o options) (map[LinkTarget]string, error) {
+	suffixes := maps.Clone(defaultLinkSuffixes)
+
+	for target, suffix := range o.linkSuffixes {
+		if _, ok := suffixes[target]; !ok {
+			return nil, fmt.Errorf("%w %q, use one of: %s", ErrUnknownLinkTarget, target, joinTargets(LinkTargets()))
+		}
+
+		suffixes[target] = suffix
+	}
+
+	if o.noLinkSuffixes {
+		for target := range suffixes {
+			suffixes[target] = ""
+		}
+	}
+
+	return suffixes, nil
+}
+
+func joinTargets(targets []LinkTarget) string {
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		names[i] = string(target)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// link returns label linked to the given target page of the group or project at webURL,
+// or label alone if the web URL is unknown.
+func (f *TableFormatter) link(webURL string, target LinkTarget, label string) string {
+	link := linkURL(webURL, f.linkSuffixes[target])
+	if link == "" {
+		return label
+	}
+
+	return text.Hyperlink(link, label)
+}
+
+// linkURL returns webURL with suffix appended, or an empty string if the web URL is unknown.
+func linkURL(webURL, suffix string) string {
+	// redacted web URLs are no longer URLs
+	if u, err := url.Parse(webURL); webURL == "" || err != nil || !u.IsAbs() {
+		return ""
+	}
+
+	return strings.TrimSuffix(webURL, "/") + suffix
+}
@@ -0,0 +1,80 @@
+package output_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// hyperlink returns the terminal escape sequence linking label to url.
+func hyperlink(url, label string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + label + "\x1b]8;;\x1b\\"
+}
+
+func TestTableFormatter_link(t *testing.T) {
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{Name: "ci"}},
+			ProjectPath:        "org/api",
+			ProjectWebURL:      "https://gitlab.example.com/org/api",
+		},
+	}
+
+	variables := []*glclient.VariableWithSource{
+		{Key: "TOKEN", Source: "group", SourcePath: "org", SourceWebURL: "https://gitlab.example.com/groups/org"},
+	}
+
+	tests := []struct {
+		name         string
+		opts         []output.Option
+		wantToken    string
+		wantVariable string
+	}{
+		{
+			name:         "default suffixes",
+			wantToken:    "https://gitlab.example.com/org/api/-/settings/access_tokens",
+			wantVariable: "https://gitlab.example.com/groups/org/-/settings/ci_cd#ci-variables",
+		},
+		{
+			name: "overridden suffix",
+			opts: []output.Option{output.WithLinkSuffixes(map[output.LinkTarget]string{
+				output.LinkProjectAccessTokens: "/-/profile/personal_access_tokens",
+			})},
+			wantToken:    "https://gitlab.example.com/org/api/-/profile/personal_access_tokens",
+			wantVariable: "https://gitlab.example.com/groups/org/-/settings/ci_cd#ci-variables",
+		},
+		{
+			name:         "suffixes disabled",
+			opts:         []output.Option{output.WithoutLinkSuffixes()},
+			wantToken:    "https://gitlab.example.com/org/api",
+			wantVariable: "https://gitlab.example.com/groups/org",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := output.NewFormatter(output.FormatTable, tt.opts...)
+			require.NoError(t, err)
+
+			out := readStdout(t, func() {
+				require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+			})
+			assert.Contains(t, out, hyperlink(tt.wantToken, "org/api"))
+
+			out = readStdout(t, func() {
+				require.NoError(t, formatter.FormatUnifiedVariables(variables, false))
+			})
+			assert.Contains(t, out, hyperlink(tt.wantVariable, "org"))
+		})
+	}
+
+	t.Run("unknown target", func(t *testing.T) {
+		_, err := output.NewFormatter(output.FormatTable,
+			output.WithLinkSuffixes(map[output.LinkTarget]string{"settings": "/-/settings"}))
+		require.ErrorIs(t, err, output.ErrUnknownLinkTarget)
+	})
+}
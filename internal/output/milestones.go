@@ -0,0 +1,161 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// milestoneDate formats a milestone start or due date, or returns the placeholder when it is not set.
+func milestoneDate(date *time.Time) string {
+	if date == nil {
+		return defaultTextPlaceholder
+	}
+
+	return date.Format(defaultDateFormat)
+}
+
+func (f *TableFormatter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"IID", "Title", "State", "Start Date", "Due Date")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, milestone := range milestones {
+		pathLink := f.link(milestone.ProjectWebURL, LinkMilestones, milestone.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, milestone.ProjectID, pathLink),
+			milestone.IID,
+			milestone.Title,
+			milestone.State,
+			milestoneDate(milestone.StartDate),
+			milestoneDate(milestone.DueDate),
+		), milestone.ProjectWebURL, LinkMilestones), milestone.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *TableFormatter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Group ID", "Group Path"),
+		"IID", "Title", "State", "Start Date", "Due Date")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, milestone := range milestones {
+		pathLink := f.link(milestone.GroupWebURL, LinkMilestones, milestone.GroupPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, milestone.GroupID, pathLink),
+			milestone.IID,
+			milestone.Title,
+			milestone.State,
+			milestoneDate(milestone.StartDate),
+			milestoneDate(milestone.DueDate),
+		), milestone.GroupWebURL, LinkMilestones), milestone.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *TableFormatter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	t := f.newTable()
+	header := append(append(table.Row{"Source"}, f.identifier(IDFormatPath, "ID", "Path")...),
+		"IID", "Title", "State", "Start Date", "Due Date")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, milestone := range milestones {
+		pathLink := f.link(milestone.SourceWebURL, LinkMilestones, milestone.SourcePath)
+
+		row := append(table.Row{milestone.Source}, f.identifier(IDFormatPath, milestone.SourceID, pathLink)...)
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(row,
+			milestone.IID,
+			milestone.Title,
+			milestone.State,
+			milestoneDate(milestone.StartDate),
+			milestoneDate(milestone.DueDate),
+		), milestone.SourceWebURL, LinkMilestones), milestone.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	return f.encode(milestones, len(milestones), "project milestones")
+}
+
+func (f *JSONFormatter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	return f.encode(milestones, len(milestones), "group milestones")
+}
+
+func (f *JSONFormatter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	return f.encode(milestones, len(milestones), "milestones")
+}
+
+func (f *CSVFormatter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	return writeMilestonesCSV(f, milestones, func(m *glclient.ProjectMilestone) string { return m.ProjectWebURL })
+}
+
+func (f *CSVFormatter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	return writeMilestonesCSV(f, milestones, func(m *glclient.GroupMilestone) string { return m.GroupWebURL })
+}
+
+func (f *CSVFormatter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	return writeMilestonesCSV(f, milestones, func(m *glclient.MilestoneWithSource) string { return m.SourceWebURL })
+}
+
+// writeMilestonesCSV writes milestones as CSV. webURL returns the web URL of the group or project
+// defining a milestone, whose milestones page is checked when links are verified.
+func writeMilestonesCSV[T any](f *CSVFormatter, milestones []*T, webURL func(*T) string) error {
+	if len(milestones) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(milestones[0]))); err != nil {
+		return err
+	}
+
+	for _, milestone := range milestones {
+		row := f.withLinkStatus(f.row(milestone), webURL(milestone), LinkMilestones)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectMilestones(_ []*glclient.ProjectMilestone) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatGroupMilestones(_ []*glclient.GroupMilestone) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *DotenvFormatter) FormatUnifiedMilestones(_ []*glclient.MilestoneWithSource) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	return f.render("project milestones", milestones)
+}
+
+func (f *TemplateFormatter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	return f.render("group milestones", milestones)
+}
+
+func (f *TemplateFormatter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	return f.render("milestones", milestones)
+}
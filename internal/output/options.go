@@ -0,0 +1,112 @@
+package output
+
+import (
+	"io"
+	"time"
+)
+
+// Option configures a Formatter created by NewFormatter.
+type Option func(*options)
+
+type options struct {
+	template     string
+	templateFile string
+	envelope     func() Metadata
+	writer       io.Writer
+	noHeader     bool
+	flatten      bool
+	tree         bool
+
+	descriptions     bool
+	descriptionWidth int
+
+	normalizePaths bool
+	idFormat       IDFormat
+
+	redact          bool
+	redactSalt      string
+	pseudonymizeIDs bool
+
+	linkSuffixes   map[LinkTarget]string
+	noLinkSuffixes bool
+	verifyURL      URLVerifier
+	instanceColumn bool
+	instanceLabel  func(item any) string
+
+	tableStyle     string
+	maxColumnWidth int
+	truncateMiddle bool
+
+	location   *time.Location
+	timeFormat TimeFormat
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithTemplate sets the inline Go text/template used by the template format.
+func WithTemplate(text string) Option {
+	return func(o *options) {
+		o.template = text
+	}
+}
+
+// WithTemplateFile sets the file holding the Go text/template used by the template format.
+func WithTemplateFile(path string) Option {
+	return func(o *options) {
+		o.templateFile = path
+	}
+}
+
+// WithDescriptions adds a Description column to the group and project tables. Descriptions
+// longer than width are truncated with an ellipsis; a width of 0 or less keeps them whole.
+// Other formats always include the description.
+func WithDescriptions(width int) Option {
+	return func(o *options) {
+		o.descriptions = true
+		o.descriptionWidth = width
+	}
+}
+
+// WithNormalizedPaths makes every format emit group and project paths with forward slashes only and
+// web URLs with consistently escaped path components, including the settings links derived from them.
+func WithNormalizedPaths() Option {
+	return func(o *options) {
+		o.normalizePaths = true
+	}
+}
+
+// WithRedaction makes every format replace identifying fields, such as the names, paths, and web URLs
+// of groups, projects, and users, with hashes salted with salt. Equal values hash alike, so items
+// can still be correlated across the report. An empty salt is replaced with a random one.
+func WithRedaction(salt string) Option {
+	return func(o *options) {
+		o.redact = true
+		o.redactSalt = salt
+	}
+}
+
+// WithPseudonymousIDs makes every format replace the numeric IDs of groups, projects, users,
+// tokens, and other resources with integers derived from hashes salted with salt. The same ID
+// always gets the same pseudonym and distinct IDs distinct ones, so items can still be joined on
+// their IDs. An empty salt is replaced with a random one; WithRedaction shares the same salt.
+func WithPseudonymousIDs(salt string) Option {
+	return func(o *options) {
+		o.pseudonymizeIDs = true
+		o.redactSalt = salt
+	}
+}
+
+// WithoutHeader leaves the header row out of CSV reports, for appending them to an existing file.
+// Other formats are not affected.
+func WithoutHeader() Option {
+	return func(o *options) {
+		o.noHeader = true
+	}
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeField rewrites the fields named *Path, *Paths, or PathWithNamespace with normalizePath
+// and those named *WebURL with normalizeURL.
+func normalizeField(field, value string) string {
+	switch {
+	case strings.HasSuffix(field, "Path") || strings.HasSuffix(field, "Paths") || field == "PathWithNamespace":
+		return normalizePath(value)
+	case strings.HasSuffix(field, "WebURL"):
+		return normalizeURL(value)
+	default:
+		return value
+	}
+}
+
+// normalizePath returns p in Unicode NFC with forward slashes only, without empty segments
+// and surrounding slashes.
+func normalizePath(p string) string {
+	p = norm.NFC.String(strings.ReplaceAll(p, `\`, "/"))
+
+	segments := strings.FieldsFunc(p, func(r rune) bool { return r == '/' })
+
+	return strings.Join(segments, "/")
+}
+
+// normalizeURL returns rawURL with its path normalized by normalizePath and each path
+// segment escaped once. Values that are not absolute URLs are returned unchanged.
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(strings.ReplaceAll(rawURL, `\`, "/"))
+	if err != nil || !u.IsAbs() {
+		return rawURL
+	}
+
+	segments := strings.Split(normalizePath(u.Path), "/")
+	escaped := make([]string, len(segments))
+
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+
+	u.Path = "/" + strings.Join(segments, "/")
+	u.RawPath = "/" + strings.Join(escaped, "/")
+
+	return u.String()
+}
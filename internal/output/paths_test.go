@@ -0,0 +1,120 @@
+package output_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestPathNormalizer_FormatProjectAccessTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		webURL   string
+		wantPath string
+		wantURL  string
+	}{
+		{
+			name:     "backslashes",
+			path:     `org\team\api`,
+			webURL:   `https://gitlab.example.com/org\team\api`,
+			wantPath: "org/team/api",
+			wantURL:  "https://gitlab.example.com/org/team/api",
+		},
+		{
+			name:     "duplicate and surrounding slashes",
+			path:     "/org//api/",
+			webURL:   "https://gitlab.example.com/org//api/",
+			wantPath: "org/api",
+			wantURL:  "https://gitlab.example.com/org/api",
+		},
+		{
+			name:     "spaces",
+			path:     "org/my project",
+			webURL:   "https://gitlab.example.com/org/my project",
+			wantPath: "org/my project",
+			wantURL:  "https://gitlab.example.com/org/my%20project",
+		},
+		{
+			name:     "already escaped URL is not escaped twice",
+			path:     "org/my project",
+			webURL:   "https://gitlab.example.com/org/my%20project",
+			wantPath: "org/my project",
+			wantURL:  "https://gitlab.example.com/org/my%20project",
+		},
+		{
+			name:     "decomposed unicode",
+			path:     "org/cafe\u0301",
+			webURL:   "https://gitlab.example.com/org/cafe\u0301",
+			wantPath: "org/caf\u00e9",
+			wantURL:  "https://gitlab.example.com/org/caf%C3%A9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter, err := output.NewFormatter(output.FormatJSON, output.WithNormalizedPaths())
+			require.NoError(t, err)
+
+			tokens := []*glclient.ProjectAccessTokenWithProject{
+				{
+					ProjectAccessToken: &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{Name: "ci"}},
+					ProjectPath:        tt.path,
+					ProjectWebURL:      tt.webURL,
+				},
+			}
+
+			out := readStdout(t, func() {
+				require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+			})
+
+			var got []map[string]any
+			require.NoError(t, json.Unmarshal([]byte(out), &got))
+			require.Len(t, got, 1)
+
+			assert.Equal(t, tt.wantPath, got[0]["project_path"])
+			assert.Equal(t, tt.wantURL, got[0]["project_web_url"])
+		})
+	}
+}
+
+func TestPathNormalizer_FormatProjects(t *testing.T) {
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithNormalizedPaths())
+	require.NoError(t, err)
+
+	projects := []*gitlab.Project{
+		{
+			ID:                1,
+			Path:              "api",
+			PathWithNamespace: `org\api`,
+			Namespace:         &gitlab.ProjectNamespace{FullPath: `org\`},
+			WebURL:            `https://gitlab.example.com/org\api`,
+		},
+	}
+
+	readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjects(projects))
+	})
+
+	assert.Equal(t, "org/api", projects[0].PathWithNamespace)
+	assert.Equal(t, "org", projects[0].Namespace.FullPath)
+	assert.Equal(t, "https://gitlab.example.com/org/api", projects[0].WebURL)
+}
+
+func TestPathNormalizer_disabled(t *testing.T) {
+	formatter, err := output.NewFormatter(output.FormatCSV)
+	require.NoError(t, err)
+
+	projects := []*gitlab.Project{{ID: 1, PathWithNamespace: `org\api`}}
+
+	readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjects(projects))
+	})
+
+	assert.Equal(t, `org\api`, projects[0].PathWithNamespace)
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Environment", "Protected", "Allowed to Deploy", "Approvals", "Approval Rules")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, environment := range environments {
+		protected := "No"
+		if environment.Protected {
+			protected = "Yes"
+		}
+
+		pathLink := f.link(environment.ProjectWebURL, LinkProtectedEnvironments, environment.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, environment.ProjectID, pathLink),
+			environment.Name,
+			protected,
+			textOrPlaceholder(strings.Join(environment.DeployAccessLevels, "\n")),
+			strconv.Itoa(environment.RequiredApprovalCount),
+			textOrPlaceholder(strings.Join(environment.ApprovalRules, "\n")),
+		), environment.ProjectWebURL, LinkProtectedEnvironments), environment.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	return f.encode(environments, len(environments), "protected environments")
+}
+
+func (f *CSVFormatter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	if len(environments) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(environments[0]))); err != nil {
+		return err
+	}
+
+	for _, environment := range environments {
+		row := f.withLinkStatus(f.row(environment), environment.ProjectWebURL, LinkProtectedEnvironments)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProtectedEnvironments(_ []*glclient.ProjectProtectedEnvironment) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	return f.render("protected environments", environments)
+}
@@ -0,0 +1,67 @@
+package output
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+// pseudonymBits limits pseudonymous IDs to integers that JSON consumers parsing numbers as
+// doubles, such as JavaScript, still read exactly.
+const pseudonymBits = 53
+
+// pseudonymizeID returns a rewriteIDFunc replacing numeric IDs with integers derived from an HMAC
+// salted with salt, or with a random salt when it is empty. The same ID always gets the same
+// pseudonym, whichever field holds it, so items can still be joined on their IDs. Distinct IDs get
+// distinct pseudonyms: on the rare collision of two hashes, the later ID is hashed again.
+func pseudonymizeID(salt string) rewriteIDFunc {
+	if salt == "" {
+		salt = rand.Text()
+	}
+
+	pseudonyms := make(map[int64]int64)
+	taken := make(map[int64]bool)
+
+	hash := func(id int64, attempt int) int64 {
+		mac := hmac.New(sha256.New, []byte(salt))
+		mac.Write([]byte(strconv.FormatInt(id, 10)))
+
+		if attempt > 0 {
+			mac.Write([]byte("#" + strconv.Itoa(attempt)))
+		}
+
+		sum := binary.BigEndian.Uint64(mac.Sum(nil))
+
+		// zero is left for IDs that are not set
+		return int64(sum>>(64-pseudonymBits)) | 1
+	}
+
+	return func(field string, id int64) int64 {
+		if id == 0 || !isNumericID(field) {
+			return id
+		}
+
+		if pseudonym, ok := pseudonyms[id]; ok {
+			return pseudonym
+		}
+
+		pseudonym := hash(id, 0)
+		for attempt := 1; taken[pseudonym]; attempt++ {
+			pseudonym = hash(id, attempt)
+		}
+
+		pseudonyms[id] = pseudonym
+		taken[pseudonym] = true
+
+		return pseudonym
+	}
+}
+
+// isNumericID reports whether the field with the given name holds the GitLab-wide numeric ID of a
+// resource. IIDs are numbered within their project or group and are kept.
+func isNumericID(field string) bool {
+	return strings.HasSuffix(field, "ID") && !strings.HasSuffix(field, "IID")
+}
@@ -0,0 +1,136 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func pseudonymizedTokens(t *testing.T, opts ...output.Option) []map[string]any {
+	t.Helper()
+
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{ID: 7, UserID: 300, Name: "deploy"},
+			},
+			ProjectID:   42,
+			ProjectPath: "org/api",
+		},
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{ID: 8, UserID: 301, Name: "release"},
+			},
+			ProjectID:   42,
+			ProjectPath: "org/api",
+		},
+	}
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, append(opts, output.WithWriter(&buf))...)
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 2)
+
+	return got
+}
+
+func TestWithPseudonymousIDs(t *testing.T) {
+	t.Run("replaces IDs and keeps other fields", func(t *testing.T) {
+		got := pseudonymizedTokens(t, output.WithPseudonymousIDs("salt"))
+
+		assert.NotEqual(t, float64(7), got[0]["id"])
+		assert.NotEqual(t, float64(300), got[0]["user_id"])
+		assert.NotEqual(t, float64(42), got[0]["project_id"])
+		assert.Equal(t, "deploy", got[0]["name"])
+		assert.Equal(t, "org/api", got[0]["project_path"])
+	})
+
+	t.Run("maps equal IDs alike and distinct IDs apart", func(t *testing.T) {
+		got := pseudonymizedTokens(t, output.WithPseudonymousIDs("salt"))
+
+		assert.Equal(t, got[0]["project_id"], got[1]["project_id"])
+		assert.NotEqual(t, got[0]["id"], got[1]["id"])
+		assert.NotEqual(t, got[0]["user_id"], got[1]["user_id"])
+	})
+
+	t.Run("is stable for a salt", func(t *testing.T) {
+		first := pseudonymizedTokens(t, output.WithPseudonymousIDs("salt"))
+		second := pseudonymizedTokens(t, output.WithPseudonymousIDs("salt"))
+		other := pseudonymizedTokens(t, output.WithPseudonymousIDs("pepper"))
+
+		assert.Equal(t, first, second)
+		assert.NotEqual(t, first[0]["id"], other[0]["id"])
+	})
+
+	t.Run("keeps unset IDs", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON,
+			output.WithWriter(&buf), output.WithPseudonymousIDs("salt"))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatGroups([]*gitlab.Group{{ID: 5, ParentID: 0, FullPath: "org"}}))
+
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got, 1)
+		assert.InDelta(t, 0, got[0]["parent_id"], 0)
+		assert.NotEqual(t, float64(5), got[0]["id"])
+	})
+
+	t.Run("keeps IIDs", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON,
+			output.WithWriter(&buf), output.WithPseudonymousIDs("salt"))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatEpics([]*glclient.GroupEpic{{GroupID: 1, ID: 101, IID: 3}}))
+
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got, 1)
+		assert.InDelta(t, 3, got[0]["iid"], 0)
+		assert.NotEqual(t, float64(101), got[0]["id"])
+	})
+
+	t.Run("composes with redaction", func(t *testing.T) {
+		got := pseudonymizedTokens(t, output.WithRedaction("salt"), output.WithPseudonymousIDs("salt"))
+
+		assert.NotEqual(t, float64(7), got[0]["id"])
+		assert.NotEqual(t, "org/api", got[0]["project_path"])
+	})
+}
+
+func TestWithPseudonymousIDs_collisionFree(t *testing.T) {
+	const count = 20000
+
+	groups := make([]*gitlab.Group, 0, count)
+	for id := 1; id <= count; id++ {
+		groups = append(groups, &gitlab.Group{ID: id})
+	}
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV,
+		output.WithWriter(&buf), output.WithPseudonymousIDs("salt"), output.WithoutHeader())
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroups(groups))
+
+	seen := make(map[int]bool, count)
+	for _, group := range groups {
+		require.True(t, group.ID > 0 && group.ID <= 1<<53, "pseudonym %d out of range", group.ID)
+		seen[group.ID] = true
+	}
+
+	assert.Len(t, seen, count)
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	t := f.newTable()
+
+	header := append(table.Row{"Source"}, f.identifier(IDFormatPath, "ID", "Path")...)
+	header = append(header, "Push Rules", "Signed Commits", "Reject Secrets", "Branch Name Regex", "Author Email Regex")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, rule := range rules {
+		pathLink := f.link(rule.SourceWebURL, LinkPushRules, rule.SourcePath)
+		row := append(table.Row{rule.Source}, f.identifier(IDFormatPath, rule.SourceID, pathLink)...)
+
+		// a group or project without push rules enforces none of them
+		if !rule.Configured {
+			row = append(row, "Not configured",
+				defaultTextPlaceholder, defaultTextPlaceholder, defaultTextPlaceholder, defaultTextPlaceholder)
+			t.AppendRow(f.withInstance(f.withLinkStatus(row, rule.SourceWebURL, LinkPushRules), rule.Instance))
+
+			continue
+		}
+
+		row = append(row, "Configured",
+			rule.RejectUnsignedCommits,
+			rule.PreventSecrets,
+			textOrPlaceholder(rule.BranchNameRegex),
+			textOrPlaceholder(rule.AuthorEmailRegex),
+		)
+		t.AppendRow(f.withInstance(f.withLinkStatus(row, rule.SourceWebURL, LinkPushRules), rule.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	return f.encode(rules, len(rules), "push rules")
+}
+
+func (f *CSVFormatter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(rules[0]))); err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		row := f.withLinkStatus(f.row(rule), rule.SourceWebURL, LinkPushRules)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatPushRules(_ []*glclient.PushRulesWithSource) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	return f.render("push rules", rules)
+}
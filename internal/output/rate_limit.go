@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	resetAt := defaultTextPlaceholder
+	if status.ResetAt != nil {
+		resetAt = f.formatTime(*status.ResetAt)
+	}
+
+	t := f.newTable()
+	t.AppendRows([]table.Row{
+		{"Limit", status.Limit},
+		{"Remaining", status.Remaining},
+		{"Observed", status.Observed},
+		{"Resets At", resetAt},
+	})
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	var v any = status
+	if f.flatten {
+		v = flattenItems(status)
+	}
+
+	encoder := json.NewEncoder(f.writer())
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode rate limit as JSON: %w", err)
+	}
+
+	return nil
+}
+
+func (f *CSVFormatter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, getCSVHeaders(status)); err != nil {
+		return err
+	}
+
+	if err := writer.Write(f.row(status)); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatRateLimit(_ *glclient.RateLimitStatus) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	return f.render("rate limit", status)
+}
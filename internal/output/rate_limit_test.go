@@ -0,0 +1,54 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatRateLimit(t *testing.T) {
+	resetAt := time.Date(2026, 10, 16, 12, 1, 0, 0, time.UTC)
+	status := &glclient.RateLimitStatus{
+		ReportType: glclient.ReportTypeRateLimit,
+		Limit:      2000,
+		Remaining:  1998,
+		Observed:   2,
+		ResetAt:    &resetAt,
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatRateLimit(status))
+
+		assert.Contains(t, buf.String(), "1998")
+		assert.Contains(t, buf.String(), "2026-10-16 12:01:00Z")
+
+		buf.Reset()
+		require.NoError(t, formatter.FormatRateLimit(&glclient.RateLimitStatus{Limit: 2000}))
+		assert.Contains(t, buf.String(), "N/A")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatRateLimit(status))
+
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, "rate_limit", got["report_type"])
+		assert.InDelta(t, 2000, got["limit"], 0)
+		assert.InDelta(t, 1998, got["remaining"], 0)
+		assert.Equal(t, "2026-10-16T12:01:00Z", got["reset_at"])
+	})
+}
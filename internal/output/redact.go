@@ -0,0 +1,72 @@
+package output
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// redactedHashLength is the number of hex digits kept from each salted hash.
+const redactedHashLength = 12
+
+// redactField returns a rewriteFunc replacing identifying fields with hashes salted with salt, or
+// with a random salt when it is empty. Paths are hashed segment by segment so that the hierarchy of
+// groups and projects can still be followed; other identifying fields are hashed whole.
+func redactField(salt string) rewriteFunc {
+	if salt == "" {
+		salt = rand.Text()
+	}
+
+	hash := func(value string) string {
+		mac := hmac.New(sha256.New, []byte(salt))
+		mac.Write([]byte(value))
+
+		return hex.EncodeToString(mac.Sum(nil))[:redactedHashLength]
+	}
+
+	return func(field, value string) string {
+		switch {
+		case value == "":
+			return value
+		case isPath(field):
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				segments[i] = hash(segment)
+			}
+
+			return strings.Join(segments, "/")
+		case isIdentifying(field):
+			return hash(value)
+		default:
+			return value
+		}
+	}
+}
+
+// isPath reports whether the field with the given name holds the path of a group or project, or a
+// list of them.
+func isPath(field string) bool {
+	switch field {
+	case "PathWithNamespace", "ProjectNamespace", "ForkNamespace", "RootGroup":
+		return true
+	}
+
+	return strings.HasSuffix(field, "Path") || strings.HasSuffix(field, "Paths")
+}
+
+// isIdentifying reports whether the field with the given name identifies a group, project, user,
+// or a report item by name.
+func isIdentifying(field string) bool {
+	switch field {
+	case "NameWithNamespace", "Item":
+		return true
+	}
+
+	return strings.HasSuffix(field, "Name") ||
+		strings.HasSuffix(field, "Username") ||
+		strings.HasSuffix(field, "URL") ||
+		strings.HasSuffix(field, "URLToRepo") ||
+		strings.HasSuffix(field, "Email")
+}
@@ -0,0 +1,145 @@
+package output_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func redactedProjects(t *testing.T, formatter output.Formatter) []*gitlab.Project {
+	t.Helper()
+
+	projects := []*gitlab.Project{
+		{
+			ID:                1,
+			Name:              "API",
+			Path:              "api",
+			PathWithNamespace: "org/team/api",
+			WebURL:            "https://gitlab.example.com/org/team/api",
+			Namespace:         &gitlab.ProjectNamespace{Name: "Team", FullPath: "org/team"},
+			StarCount:         3,
+		},
+		{
+			ID:                2,
+			Name:              "API",
+			Path:              "api",
+			PathWithNamespace: "org/other/api",
+			WebURL:            "https://gitlab.example.com/org/other/api",
+			Namespace:         &gitlab.ProjectNamespace{Name: "Other", FullPath: "org/other"},
+		},
+	}
+
+	readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjects(projects))
+	})
+
+	return projects
+}
+
+func TestRedaction_FormatProjects(t *testing.T) {
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithRedaction(""))
+	require.NoError(t, err)
+
+	projects := redactedProjects(t, formatter)
+
+	for _, project := range projects {
+		assert.NotContains(t, project.Name, "API")
+		assert.NotContains(t, project.PathWithNamespace, "api")
+		assert.NotContains(t, project.WebURL, "gitlab.example.com")
+	}
+
+	// identical inputs hash identically within a run
+	assert.Equal(t, projects[0].Name, projects[1].Name)
+	assert.Equal(t, projects[0].Path, projects[1].Path)
+	assert.NotEqual(t, projects[0].PathWithNamespace, projects[1].PathWithNamespace)
+	assert.NotEqual(t, projects[0].WebURL, projects[1].WebURL)
+
+	// paths keep their hierarchy
+	assert.Equal(t, projects[0].Namespace.FullPath+"/"+projects[0].Path, projects[0].PathWithNamespace)
+	assert.True(t, strings.HasPrefix(projects[1].PathWithNamespace, strings.Split(projects[0].PathWithNamespace, "/")[0]))
+
+	// counts and structural fields are kept
+	assert.Equal(t, 1, projects[0].ID)
+	assert.Equal(t, 3, projects[0].StarCount)
+}
+
+func TestRedaction_salt(t *testing.T) {
+	hashes := func(salt string) string {
+		formatter, err := output.NewFormatter(output.FormatCSV, output.WithRedaction(salt))
+		require.NoError(t, err)
+
+		return redactedProjects(t, formatter)[0].PathWithNamespace
+	}
+
+	assert.Equal(t, hashes("s3cret"), hashes("s3cret"))
+	assert.NotEqual(t, hashes("s3cret"), hashes("other"))
+	assert.NotEqual(t, hashes(""), hashes(""))
+}
+
+func TestRedaction_acrossReports(t *testing.T) {
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithRedaction(""))
+	require.NoError(t, err)
+
+	projects := redactedProjects(t, formatter)
+
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{Name: "deploy"}},
+			ProjectPath:        "org/team/api",
+			ProjectNamespace:   "org/team",
+			ProjectWebURL:      "https://gitlab.example.com/org/team/api",
+		},
+	}
+
+	out := readStdout(t, func() {
+		require.NoError(t, formatter.FormatProjectAccessTokens(tokens))
+	})
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	require.Len(t, got, 1)
+
+	assert.Equal(t, projects[0].PathWithNamespace, got[0]["project_path"])
+	assert.Equal(t, projects[0].WebURL, got[0]["project_web_url"])
+	assert.Equal(t, projects[0].Namespace.FullPath, got[0]["project_namespace"])
+	assert.NotEqual(t, "deploy", got[0]["name"])
+}
+
+func TestRedaction_FormatChanges(t *testing.T) {
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithRedaction(""))
+	require.NoError(t, err)
+
+	changes := []report.Change{{Change: report.Added, Item: "org/team/api"}}
+
+	readStdout(t, func() {
+		require.NoError(t, formatter.FormatChanges(changes))
+	})
+
+	assert.Equal(t, report.Added, changes[0].Change)
+	assert.NotContains(t, changes[0].Item, "api")
+}
+
+func TestRedaction_pathLists(t *testing.T) {
+	formatter, err := output.NewFormatter(output.FormatCSV, output.WithRedaction(""))
+	require.NoError(t, err)
+
+	projects := redactedProjects(t, formatter)
+
+	scopes := []*glclient.ProjectJobTokenScope{
+		{ProjectPath: "org/team/api", AllowedProjectPaths: []string{"org/team/api", "org/other/api"}},
+	}
+
+	readStdout(t, func() {
+		require.NoError(t, formatter.FormatJobTokenScopes(scopes))
+	})
+
+	assert.Equal(t, []string{projects[0].PathWithNamespace, projects[1].PathWithNamespace},
+		scopes[0].AllowedProjectPaths)
+}
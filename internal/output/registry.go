@@ -0,0 +1,74 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Container Registry", "Repositories", "Tags", "Package Registry", "Packages")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range registries {
+		pathLink := f.link(project.ProjectWebURL, LinkRegistry, project.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, project.ProjectID, pathLink),
+			registryState(project.ContainerRegistryEnabled),
+			strconv.Itoa(project.Repositories),
+			strconv.Itoa(project.Tags),
+			registryState(project.PackageRegistryEnabled),
+			strconv.Itoa(project.Packages),
+		), project.ProjectWebURL, LinkRegistry), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func registryState(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+
+	return "Disabled"
+}
+
+func (f *JSONFormatter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	return f.encode(registries, len(registries), "project registries")
+}
+
+func (f *CSVFormatter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	if len(registries) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(registries[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range registries {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkRegistry)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectRegistries(_ []*glclient.ProjectRegistry) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	return f.render("project registries", registries)
+}
@@ -0,0 +1,357 @@
+package output
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// rewriteFunc returns the new value of the string field with the given name.
+type rewriteFunc func(field, value string) string
+
+// chainRewrites returns a rewriteFunc applying each of rewrites in order.
+func chainRewrites(rewrites []rewriteFunc) rewriteFunc {
+	return func(field, value string) string {
+		for _, rewrite := range rewrites {
+			value = rewrite(field, value)
+		}
+
+		return value
+	}
+}
+
+// rewriteIDFunc returns the new value of the integer field with the given name.
+type rewriteIDFunc func(field string, id int64) int64
+
+// fieldRewrites holds the rewrites of string fields and of integer fields, and the time zone
+// timestamps are converted to. Any of them may be nil.
+type fieldRewrites struct {
+	text     rewriteFunc
+	id       rewriteIDFunc
+	location *time.Location
+}
+
+// fieldRewriter rewrites the string, integer, and timestamp fields of the reported items in place
+// before passing them to the wrapped formatter.
+type fieldRewriter struct {
+	formatter Formatter
+	rewrite   fieldRewrites
+}
+
+func (f *fieldRewriter) FormatGroups(groups []*gitlab.Group) error {
+	rewriteFields(groups, f.rewrite)
+
+	return f.formatter.FormatGroups(groups)
+}
+
+func (f *fieldRewriter) FormatProjects(projects []*gitlab.Project) error {
+	rewriteFields(projects, f.rewrite)
+
+	return f.formatter.FormatProjects(projects)
+}
+
+func (f *fieldRewriter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
+	rewriteFields(tokens, f.rewrite)
+
+	return f.formatter.FormatGroupAccessTokens(tokens)
+}
+
+func (f *fieldRewriter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
+	rewriteFields(tokens, f.rewrite)
+
+	return f.formatter.FormatProjectAccessTokens(tokens)
+}
+
+func (f *fieldRewriter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
+	rewriteFields(triggers, f.rewrite)
+
+	return f.formatter.FormatPipelineTriggers(triggers)
+}
+
+func (f *fieldRewriter) FormatProjectVariables(
+	variables []*glclient.ProjectVariableWithProject,
+	includeValues bool,
+) error {
+	rewriteFields(variables, f.rewrite)
+
+	return f.formatter.FormatProjectVariables(variables, includeValues)
+}
+
+func (f *fieldRewriter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error {
+	rewriteFields(variables, f.rewrite)
+
+	return f.formatter.FormatGroupVariables(variables, includeValues)
+}
+
+func (f *fieldRewriter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
+	rewriteFields(variables, f.rewrite)
+
+	return f.formatter.FormatUnifiedVariables(variables, includeValues)
+}
+
+func (f *fieldRewriter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	rewriteFields(badges, f.rewrite)
+
+	return f.formatter.FormatBadges(badges)
+}
+
+func (f *fieldRewriter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	rewriteFields(rules, f.rewrite)
+
+	return f.formatter.FormatPushRules(rules)
+}
+
+func (f *fieldRewriter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	rewriteFields(requests, f.rewrite)
+
+	return f.formatter.FormatAccessRequests(requests)
+}
+
+func (f *fieldRewriter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	rewriteFields(statuses, f.rewrite)
+
+	return f.formatter.FormatTwoFactor(statuses)
+}
+
+func (f *fieldRewriter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	rewriteFields(storage, f.rewrite)
+
+	return f.formatter.FormatProjectStorage(storage)
+}
+
+func (f *fieldRewriter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	rewriteFields(registries, f.rewrite)
+
+	return f.formatter.FormatProjectRegistries(registries)
+}
+
+func (f *fieldRewriter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	rewriteFields(usage, f.rewrite)
+
+	return f.formatter.FormatComputeUsage(usage)
+}
+
+func (f *fieldRewriter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	rewriteFields(settings, f.rewrite)
+
+	return f.formatter.FormatCISettings(settings)
+}
+
+func (f *fieldRewriter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	rewriteFields(branches, f.rewrite)
+
+	return f.formatter.FormatDefaultBranches(branches)
+}
+
+func (f *fieldRewriter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	rewriteFields(activity, f.rewrite)
+
+	return f.formatter.FormatProjectActivity(activity)
+}
+
+func (f *fieldRewriter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	rewriteFields(integrations, f.rewrite)
+
+	return f.formatter.FormatIntegrations(integrations)
+}
+
+func (f *fieldRewriter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	rewriteFields(scopes, f.rewrite)
+
+	return f.formatter.FormatJobTokenScopes(scopes)
+}
+
+func (f *fieldRewriter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	rewriteFields(environments, f.rewrite)
+
+	return f.formatter.FormatProtectedEnvironments(environments)
+}
+
+func (f *fieldRewriter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	rewriteFields(runners, f.rewrite)
+
+	return f.formatter.FormatProjectRunners(runners)
+}
+
+func (f *fieldRewriter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	rewriteFields(settings, f.rewrite)
+
+	return f.formatter.FormatRunnerSettings(settings)
+}
+
+func (f *fieldRewriter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	rewriteFields(agents, f.rewrite)
+
+	return f.formatter.FormatClusterAgents(agents)
+}
+
+func (f *fieldRewriter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	rewriteFields(configs, f.rewrite)
+
+	return f.formatter.FormatSecurityConfigs(configs)
+}
+
+func (f *fieldRewriter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	rewriteFields(frameworks, f.rewrite)
+
+	return f.formatter.FormatComplianceFrameworks(frameworks)
+}
+
+func (f *fieldRewriter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	rewriteFields(forks, f.rewrite)
+
+	return f.formatter.FormatProjectForks(forks)
+}
+
+func (f *fieldRewriter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	rewriteFields(tokens, f.rewrite)
+
+	return f.formatter.FormatImpersonationTokens(tokens)
+}
+
+func (f *fieldRewriter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	rewriteFields(topics, f.rewrite)
+
+	return f.formatter.FormatProjectTopics(topics)
+}
+
+func (f *fieldRewriter) FormatEpics(epics []*glclient.GroupEpic) error {
+	rewriteFields(epics, f.rewrite)
+
+	return f.formatter.FormatEpics(epics)
+}
+
+func (f *fieldRewriter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	rewriteFields(milestones, f.rewrite)
+
+	return f.formatter.FormatProjectMilestones(milestones)
+}
+
+func (f *fieldRewriter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	rewriteFields(milestones, f.rewrite)
+
+	return f.formatter.FormatGroupMilestones(milestones)
+}
+
+func (f *fieldRewriter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	rewriteFields(milestones, f.rewrite)
+
+	return f.formatter.FormatUnifiedMilestones(milestones)
+}
+
+func (f *fieldRewriter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	rewriteFields([]*glclient.TokenInfo{info}, f.rewrite)
+
+	return f.formatter.FormatTokenInfo(info)
+}
+
+func (f *fieldRewriter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	rewriteFields([]*glclient.InstanceTokenSettings{settings}, f.rewrite)
+
+	return f.formatter.FormatInstanceTokenSettings(settings)
+}
+
+func (f *fieldRewriter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	rewriteFields([]*glclient.RateLimitStatus{status}, f.rewrite)
+
+	return f.formatter.FormatRateLimit(status)
+}
+
+func (f *fieldRewriter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	rewriteFields(differences, f.rewrite)
+
+	return f.formatter.FormatVariableDifferences(differences, includeValues)
+}
+
+func (f *fieldRewriter) FormatSourceCounts(counts []*report.SourceCount) error {
+	rewriteFields(counts, f.rewrite)
+
+	return f.formatter.FormatSourceCounts(counts)
+}
+
+func (f *fieldRewriter) FormatChanges(changes []report.Change) error {
+	if f.rewrite.text != nil {
+		for i := range changes {
+			changes[i].Item = f.rewrite.text("Item", changes[i].Item)
+		}
+	}
+
+	return f.formatter.FormatChanges(changes)
+}
+
+// rewriteFields rewrites the exported string and integer fields and string slices in every element
+// of items and the structs they embed, point to, or hold in slices. Slice elements are rewritten as
+// fields of the slice's name.
+func rewriteFields[T any](items []*T, rewrite fieldRewrites) {
+	visited := make(map[visit]bool)
+
+	for _, item := range items {
+		rewriteStruct(reflect.ValueOf(item), rewrite, visited)
+	}
+}
+
+// visit identifies a struct already rewritten. The type is part of it because a struct shares its
+// address with the struct embedded as its first field.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func rewriteStruct(v reflect.Value, rewrite fieldRewrites, visited map[visit]bool) {
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if visited[key] {
+		return
+	}
+
+	visited[key] = true
+
+	v = v.Elem()
+	typ := v.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		value := v.Field(i)
+
+		if !field.IsExported() || rewriteTime(field, value, rewrite.location) {
+			continue
+		}
+
+		switch value.Kind() {
+		case reflect.Ptr:
+			rewriteStruct(value, rewrite, visited)
+		case reflect.Struct:
+			rewriteStruct(value.Addr(), rewrite, visited)
+		case reflect.Slice:
+			for j := range value.Len() {
+				switch elem := value.Index(j); elem.Kind() {
+				case reflect.Ptr:
+					rewriteStruct(elem, rewrite, visited)
+				case reflect.Struct:
+					rewriteStruct(elem.Addr(), rewrite, visited)
+				case reflect.String:
+					if rewrite.text != nil {
+						elem.SetString(rewrite.text(field.Name, elem.String()))
+					}
+				}
+			}
+		case reflect.String:
+			if rewrite.text != nil {
+				value.SetString(rewrite.text(field.Name, value.String()))
+			}
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			if rewrite.id != nil {
+				value.SetInt(rewrite.id(field.Name, value.Int()))
+			}
+		}
+	}
+}
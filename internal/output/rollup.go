@@ -0,0 +1,55 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func (f *TableFormatter) FormatSourceCounts(counts []*report.SourceCount) error {
+	t := f.newTable()
+	t.AppendHeader(f.withInstanceHeader(table.Row{"Source", "Path", "Count"}))
+
+	for _, count := range counts {
+		t.AppendRow(f.withInstance(table.Row{count.Source, count.SourcePath, count.Count}, count.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatSourceCounts(counts []*report.SourceCount) error {
+	return f.encode(counts, len(counts), "source counts")
+}
+
+func (f *CSVFormatter) FormatSourceCounts(counts []*report.SourceCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, getCSVHeaders(counts[0])); err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		if err := writer.Write(f.row(count)); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatSourceCounts(_ []*report.SourceCount) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatSourceCounts(counts []*report.SourceCount) error {
+	return f.render("source counts", counts)
+}
@@ -0,0 +1,39 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSourceCounts(t *testing.T) {
+	counts := []*report.SourceCount{
+		{ReportType: "project_access_token", Source: "project", SourcePath: "org/api", Count: 1},
+		{ReportType: "project_access_token", Source: "project", SourcePath: "org/web", Count: 3},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatSourceCounts(counts))
+
+		assert.Contains(t, buf.String(), "COUNT")
+		assert.Regexp(t, `org/web\s+\S*\s*3`, buf.String())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatCSV, output.WithWriter(&buf))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatSourceCounts(counts))
+
+		assert.Equal(t, "source,source_path,count\nproject,org/api,1\nproject,org/web,3\n", buf.String())
+	})
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Shared Runners", "Group Runners", "Group Shared Runners")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range settings {
+		sharedEnabled := "No"
+		if project.SharedRunnersEnabled {
+			sharedEnabled = "Yes"
+		}
+
+		groupEnabled := "No"
+		if project.GroupRunnersEnabled {
+			groupEnabled = "Yes"
+		}
+
+		groupSetting := project.GroupSharedRunners
+		if groupSetting == "" {
+			groupSetting = defaultTextPlaceholder
+		}
+
+		pathLink := f.link(project.ProjectWebURL, LinkRunners, project.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, project.ProjectID, pathLink),
+			sharedEnabled,
+			groupEnabled,
+			groupSetting,
+		), project.ProjectWebURL, LinkRunners), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	return f.encode(settings, len(settings), "runner settings")
+}
+
+func (f *CSVFormatter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(settings[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range settings {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkRunners)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatRunnerSettings(_ []*glclient.ProjectRunnerSettings) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	return f.render("runner settings", settings)
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Runs On", "Shared Runners", "Group Runners", "Project Runners", "Shared Enabled")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range runners {
+		sharedEnabled := "No"
+		if project.SharedRunnersEnabled {
+			sharedEnabled = "Yes"
+		}
+
+		pathLink := f.link(project.ProjectWebURL, LinkRunners, project.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, project.ProjectID, pathLink),
+			project.Reliance,
+			strconv.Itoa(project.SharedRunners),
+			strconv.Itoa(project.GroupRunners),
+			strconv.Itoa(project.ProjectRunners),
+			sharedEnabled,
+		), project.ProjectWebURL, LinkRunners), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	return f.encode(runners, len(runners), "project runners")
+}
+
+func (f *CSVFormatter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	if len(runners) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(runners[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range runners {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkRunners)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectRunners(_ []*glclient.ProjectRunners) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	return f.render("project runners", runners)
+}
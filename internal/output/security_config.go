@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Pipeline", "SAST", "DAST", "Dependency Scanning", "Secret Push Protection")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, config := range configs {
+		pipeline := defaultTextPlaceholder
+		if config.PipelineID != 0 {
+			pipeline = strconv.Itoa(config.PipelineID)
+		}
+
+		sast := "No"
+		if config.SAST {
+			sast = "Yes"
+		}
+
+		dast := "No"
+		if config.DAST {
+			dast = "Yes"
+		}
+
+		dependencyScanning := "No"
+		if config.DependencyScanning {
+			dependencyScanning = "Yes"
+		}
+
+		secretPushProtection := defaultTextPlaceholder
+		if config.SecretPushProtection != nil {
+			secretPushProtection = "No"
+			if *config.SecretPushProtection {
+				secretPushProtection = "Yes"
+			}
+		}
+
+		pathLink := f.link(config.ProjectWebURL, LinkSecurityConfiguration, config.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, config.ProjectID, pathLink),
+			pipeline,
+			sast,
+			dast,
+			dependencyScanning,
+			secretPushProtection,
+		), config.ProjectWebURL, LinkSecurityConfiguration), config.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	return f.encode(configs, len(configs), "security configurations")
+}
+
+func (f *CSVFormatter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(configs[0]))); err != nil {
+		return err
+	}
+
+	for _, config := range configs {
+		row := f.withLinkStatus(f.row(config), config.ProjectWebURL, LinkSecurityConfiguration)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatSecurityConfigs(_ []*glclient.ProjectSecurityConfig) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	return f.render("security configurations", configs)
+}
@@ -0,0 +1,513 @@
+package output
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	// registers the pure Go "sqlite" driver, which needs no C toolchain
+	_ "modernc.org/sqlite"
+)
+
+var (
+	ErrSQLiteRequiresDatabase = errors.New("sqlite output must be written to a database, use --sqlite")
+	ErrDatabaseText           = errors.New("a database holds reports only, not text")
+)
+
+// secretTokenField is the column of the secret of a token, such as a pipeline trigger token, which is
+// never written to a database.
+const secretTokenField = "token"
+
+// Database is a SQLite database the sqlite format writes each report to as a table named after it.
+// Tables are created if absent, and a report replaces the rows of its table, so that a database
+// collects the latest run of several reports to join them.
+type Database struct {
+	db *sql.DB
+}
+
+// OpenDatabase opens the SQLite database at path, creating it if absent.
+func OpenDatabase(path string) (*Database, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+
+	if err := db.PingContext(context.Background()); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to open database %s: %w", path, err), db.Close())
+	}
+
+	return &Database{db: db}, nil
+}
+
+// Write rejects text written directly to the database, which only holds tables.
+func (d *Database) Write(_ []byte) (int, error) {
+	return 0, ErrDatabaseText
+}
+
+// Close closes the database.
+func (d *Database) Close() error {
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	return nil
+}
+
+// sqlColumn is a column of a report table with its SQLite type.
+type sqlColumn struct {
+	name string
+	typ  string
+}
+
+// replaceTable replaces the rows of the named table with rows in a single transaction, creating the
+// table or adding the columns it lacks first.
+func (d *Database) replaceTable(name string, columns []sqlColumn, rows [][]any) error {
+	ctx := context.Background()
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to write table %s: %w", name, err)
+	}
+
+	if err := writeTable(ctx, tx, name, columns, rows); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to write table %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func writeTable(ctx context.Context, tx *sql.Tx, name string, columns []sqlColumn, rows [][]any) error {
+	if err := createTable(ctx, tx, name, columns); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+quoteIdentifier(name)); err != nil {
+		return fmt.Errorf("failed to clear table %s: %w", name, err)
+	}
+
+	names := make([]string, 0, len(columns))
+	placeholders := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		names = append(names, quoteIdentifier(column.name))
+		placeholders = append(placeholders, "?")
+	}
+
+	insert, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(name), strings.Join(names, ", "), strings.Join(placeholders, ", ")))
+	if err != nil {
+		return fmt.Errorf("failed to write table %s: %w", name, err)
+	}
+
+	for _, row := range rows {
+		if _, err := insert.ExecContext(ctx, row...); err != nil {
+			return errors.Join(fmt.Errorf("failed to write table %s: %w", name, err), insert.Close())
+		}
+	}
+
+	if err := insert.Close(); err != nil {
+		return fmt.Errorf("failed to write table %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// createTable creates the named table if absent, and adds the columns that a table written by an
+// earlier run lacks, such as the instance column of a report fetched from several instances.
+func createTable(ctx context.Context, tx *sql.Tx, name string, columns []sqlColumn) error {
+	definitions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		definitions = append(definitions, quoteIdentifier(column.name)+" "+column.typ)
+	}
+
+	statement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)",
+		quoteIdentifier(name), strings.Join(definitions, ", "))
+	if _, err := tx.ExecContext(ctx, statement); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", name, err)
+	}
+
+	existing, err := tableColumns(ctx, tx, name)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range columns {
+		if existing[column.name] {
+			continue
+		}
+
+		statement := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s",
+			quoteIdentifier(name), quoteIdentifier(column.name), column.typ)
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to add column %s to table %s: %w", column.name, name, err)
+		}
+	}
+
+	return nil
+}
+
+// tableColumns returns the names of the columns of the named table.
+func tableColumns(ctx context.Context, tx *sql.Tx, name string) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name FROM pragma_table_info(?)", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of table %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, fmt.Errorf("failed to read columns of table %s: %w", name, err)
+		}
+
+		columns[column] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read columns of table %s: %w", name, err)
+	}
+
+	return columns, nil
+}
+
+// quoteIdentifier quotes a table or column name for use in a statement.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// SQLiteFormatter writes each report as a table of a Database.
+type SQLiteFormatter struct {
+	database *Database
+	// instanceLabel returns the instance of groups and projects, nil unless set by WithInstanceLabels
+	instanceLabel func(item any) string
+}
+
+// insertItems replaces the rows of the named table with a row per item, with a column per field of
+// the CSV format apart from token secrets. Columns are typed after the fields of the item type, so
+// that an empty report still creates its table.
+func insertItems[T any](f *SQLiteFormatter, name string, items []*T, includeValues ...bool) error {
+	var (
+		columns []sqlColumn
+		indexes = make(map[string]int)
+		records = make([]map[string]any, 0, len(items))
+	)
+
+	addColumns := func(fields []flatField) {
+		for _, field := range fields {
+			if _, ok := indexes[field.name]; ok || field.name == secretTokenField {
+				continue
+			}
+
+			indexes[field.name] = len(columns)
+			columns = append(columns, sqlColumn{name: field.name, typ: sqlType(field.typ)})
+		}
+	}
+
+	// the columns of an empty report are taken from a zero item
+	addColumns(csvFields(new(T), includeValues...))
+
+	for _, item := range items {
+		fields := csvFields(item, includeValues...)
+		addColumns(fields)
+
+		record := make(map[string]any, len(fields))
+
+		for _, field := range fields {
+			// a field named like one before it, such as of an embedded struct, is left out like its column
+			if _, ok := record[field.name]; ok {
+				continue
+			}
+
+			value, err := sqlValue(field.value)
+			if err != nil {
+				return fmt.Errorf("failed to write table %s: %w", name, err)
+			}
+
+			record[field.name] = value
+		}
+
+		records = append(records, record)
+	}
+
+	rows := make([][]any, 0, len(records))
+
+	for _, record := range records {
+		row := make([]any, len(columns))
+		for column, index := range indexes {
+			row[index] = record[column]
+		}
+
+		rows = append(rows, row)
+	}
+
+	return f.database.replaceTable(name, columns, rows)
+}
+
+// sqlType returns the SQLite type of the column of a field of type t.
+func sqlType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// sqlValue returns the value stored for a field. Empty pointers are stored as NULL, and values other
+// than numbers, booleans, and strings as their JSON encoding, so that timestamps are RFC 3339 text
+// and lists can be queried with the JSON functions of SQLite.
+func sqlValue(value reflect.Value) (any, error) {
+	// fields of a nil embedded struct are left empty
+	if !value.IsValid() {
+		return sql.NullString{}, nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return sql.NullString{}, nil
+		}
+
+		return sqlValue(value.Elem())
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return value.String(), nil
+	default:
+		encoded, err := json.Marshal(value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value: %w", err)
+		}
+
+		// values encoded as a JSON string, such as timestamps, are stored as plain text
+		var text string
+		if json.Unmarshal(encoded, &text) == nil {
+			return text, nil
+		}
+
+		return string(encoded), nil
+	}
+}
+
+func (f *SQLiteFormatter) FormatGroups(groups []*gitlab.Group) error {
+	if f.instanceLabel != nil {
+		return insertItems(f, "groups", labelGroups(groups, f.instanceLabel))
+	}
+
+	return insertItems(f, "groups", groups)
+}
+
+func (f *SQLiteFormatter) FormatProjects(projects []*gitlab.Project) error {
+	if f.instanceLabel != nil {
+		return insertItems(f, "projects", labelProjects(projects, f.instanceLabel))
+	}
+
+	return insertItems(f, "projects", projects)
+}
+
+func (f *SQLiteFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
+	return insertItems(f, "group_access_tokens", tokens)
+}
+
+func (f *SQLiteFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
+	return insertItems(f, "project_access_tokens", tokens)
+}
+
+func (f *SQLiteFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
+	return insertItems(f, "pipeline_triggers", triggers)
+}
+
+func (f *SQLiteFormatter) FormatProjectVariables(
+	variables []*glclient.ProjectVariableWithProject, includeValues bool,
+) error {
+	return insertItems(f, "project_variables", variables, includeValues)
+}
+
+func (f *SQLiteFormatter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error {
+	return insertItems(f, "group_variables", variables, includeValues)
+}
+
+func (f *SQLiteFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
+	return insertItems(f, "variables", variables, includeValues)
+}
+
+func (f *SQLiteFormatter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	return insertItems(f, "badges", badges)
+}
+
+func (f *SQLiteFormatter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	return insertItems(f, "push_rules", rules)
+}
+
+func (f *SQLiteFormatter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	return insertItems(f, "access_requests", requests)
+}
+
+func (f *SQLiteFormatter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	return insertItems(f, "two_factor", statuses)
+}
+
+func (f *SQLiteFormatter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	return insertItems(f, "storage", storage)
+}
+
+func (f *SQLiteFormatter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	return insertItems(f, "registries", registries)
+}
+
+func (f *SQLiteFormatter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	return insertItems(f, "compute_usage", usage)
+}
+
+func (f *SQLiteFormatter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	return insertItems(f, "ci_settings", settings)
+}
+
+func (f *SQLiteFormatter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	return insertItems(f, "default_branches", branches)
+}
+
+func (f *SQLiteFormatter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	return insertItems(f, "activity", activity)
+}
+
+func (f *SQLiteFormatter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	return insertItems(f, "integrations", integrations)
+}
+
+func (f *SQLiteFormatter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	return insertItems(f, "job_token_scopes", scopes)
+}
+
+func (f *SQLiteFormatter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	return insertItems(f, "protected_environments", environments)
+}
+
+func (f *SQLiteFormatter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	return insertItems(f, "runners", runners)
+}
+
+func (f *SQLiteFormatter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	return insertItems(f, "runner_settings", settings)
+}
+
+func (f *SQLiteFormatter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	return insertItems(f, "cluster_agents", agents)
+}
+
+func (f *SQLiteFormatter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	return insertItems(f, "security_config", configs)
+}
+
+func (f *SQLiteFormatter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	return insertItems(f, "compliance_frameworks", frameworks)
+}
+
+func (f *SQLiteFormatter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	return insertItems(f, "forks", forks)
+}
+
+func (f *SQLiteFormatter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	return insertItems(f, "impersonation_tokens", tokens)
+}
+
+func (f *SQLiteFormatter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	return insertItems(f, "topics", topics)
+}
+
+func (f *SQLiteFormatter) FormatEpics(epics []*glclient.GroupEpic) error {
+	return insertItems(f, "epics", epics)
+}
+
+func (f *SQLiteFormatter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	return insertItems(f, "project_milestones", milestones)
+}
+
+func (f *SQLiteFormatter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	return insertItems(f, "group_milestones", milestones)
+}
+
+func (f *SQLiteFormatter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	return insertItems(f, "milestones", milestones)
+}
+
+func (f *SQLiteFormatter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	return insertItems(f, "token", []*glclient.TokenInfo{info})
+}
+
+func (f *SQLiteFormatter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	return insertItems(f, "instance_settings", []*glclient.InstanceTokenSettings{settings})
+}
+
+func (f *SQLiteFormatter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	return insertItems(f, "rate_limit", []*glclient.RateLimitStatus{status})
+}
+
+func (f *SQLiteFormatter) FormatChanges(changes []report.Change) error {
+	rows := make([][]any, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []any{string(change.Change), change.Item, strings.Join(change.Fields, " ")})
+	}
+
+	columns := []sqlColumn{{name: "change", typ: "TEXT"}, {name: "item", typ: "TEXT"}, {name: "fields", typ: "TEXT"}}
+
+	return f.database.replaceTable("changes", columns, rows)
+}
+
+func (f *SQLiteFormatter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	names := []string{"key", "environment_scope", "difference", "left", "right"}
+	if includeValues {
+		names = append(names, "left_value", "right_value")
+	}
+
+	columns := make([]sqlColumn, 0, len(names))
+	for _, name := range names {
+		columns = append(columns, sqlColumn{name: name, typ: "TEXT"})
+	}
+
+	rows := make([][]any, 0, len(differences))
+
+	for _, d := range differences {
+		row := []any{d.Key, d.EnvironmentScope, d.Difference, d.Left, d.Right}
+		if includeValues {
+			row = append(row, d.LeftValue, d.RightValue)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return f.database.replaceTable("variable_differences", columns, rows)
+}
+
+func (f *SQLiteFormatter) FormatSourceCounts(counts []*report.SourceCount) error {
+	return insertItems(f, "rollup", counts)
+}
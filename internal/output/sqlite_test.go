@@ -0,0 +1,138 @@
+package output_test
+
+import (
+	"bytes"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func writeDatabase(t *testing.T, path string, write func(formatter output.Formatter)) {
+	t.Helper()
+
+	database, err := output.OpenDatabase(path)
+	require.NoError(t, err)
+
+	formatter, err := output.NewFormatter(output.FormatSQLite, output.WithWriter(database))
+	require.NoError(t, err)
+
+	write(formatter)
+	require.NoError(t, database.Close())
+}
+
+func TestSQLiteFormatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.db")
+	expiresAt := gitlab.ISOTime(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	writeDatabase(t, path, func(formatter output.Formatter) {
+		require.NoError(t, formatter.FormatProjects([]*gitlab.Project{
+			{ID: 10, Name: "api", PathWithNamespace: "org/api"},
+			{ID: 11, Name: "web", PathWithNamespace: "org/web", Archived: true},
+		}))
+		require.NoError(t, formatter.FormatProjectAccessTokens([]*glclient.ProjectAccessTokenWithProject{
+			{
+				ProjectAccessToken: &gitlab.ProjectAccessToken{
+					PersonalAccessToken: gitlab.PersonalAccessToken{
+						ID: 1, Name: "deploy", Scopes: []string{"api", "read_repository"}, ExpiresAt: &expiresAt,
+					},
+				},
+				ProjectID:   11,
+				ProjectPath: "org/web",
+			},
+		}))
+		require.NoError(t, formatter.FormatPipelineTriggers([]*glclient.PipelineTriggerWithProject{
+			{PipelineTrigger: &gitlab.PipelineTrigger{ID: 2, Token: "glptt-s3cret"}, ProjectID: 10},
+		}))
+		require.NoError(t, formatter.FormatUnifiedVariables([]*glclient.VariableWithSource{
+			{Key: "DB_PASSWORD", Value: "s3cret", Source: "project", SourcePath: "org/api"},
+		}, false))
+		require.NoError(t, formatter.FormatGroups(nil))
+	})
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	// tokens can be joined with the projects they belong to
+	var (
+		name     string
+		archived bool
+		scopes   string
+		expires  string
+	)
+
+	require.NoError(t, db.QueryRowContext(t.Context(), `
+		SELECT t.name, p.archived, t.scopes, t.expires_at
+		FROM project_access_tokens t JOIN projects p ON p.id = t.project_id`).
+		Scan(&name, &archived, &scopes, &expires))
+	assert.Equal(t, "deploy", name)
+	assert.True(t, archived)
+	assert.JSONEq(t, `["api", "read_repository"]`, scopes)
+	assert.Equal(t, "2025-03-01", expires)
+
+	// secrets are left out
+	var columns int
+
+	require.NoError(t, db.QueryRowContext(t.Context(),
+		`SELECT count(*) FROM pragma_table_info('pipeline_triggers') WHERE name = 'token'`).Scan(&columns))
+	assert.Zero(t, columns)
+
+	require.NoError(t, db.QueryRowContext(t.Context(),
+		`SELECT count(*) FROM pragma_table_info('variables') WHERE name = 'value'`).Scan(&columns))
+	assert.Zero(t, columns)
+
+	// an empty report still creates its table
+	var groups int
+
+	require.NoError(t, db.QueryRowContext(t.Context(), `SELECT count(*) FROM groups`).Scan(&groups))
+	assert.Zero(t, groups)
+}
+
+func TestSQLiteFormatter_replacesEarlierRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.db")
+
+	writeDatabase(t, path, func(formatter output.Formatter) {
+		require.NoError(t, formatter.FormatUnifiedVariables([]*glclient.VariableWithSource{
+			{Key: "A", Value: "1", Source: "project", SourcePath: "org/api"},
+			{Key: "B", Value: "2", Source: "project", SourcePath: "org/api"},
+		}, false))
+	})
+
+	writeDatabase(t, path, func(formatter output.Formatter) {
+		require.NoError(t, formatter.FormatUnifiedVariables([]*glclient.VariableWithSource{
+			{Key: "C", Value: "3", Source: "project", SourcePath: "org/api"},
+		}, true))
+	})
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	// the value column missing from the earlier run is added
+	var key, value string
+
+	require.NoError(t, db.QueryRowContext(t.Context(), `SELECT key, value FROM variables`).Scan(&key, &value))
+	assert.Equal(t, "C", key)
+	assert.Equal(t, "3", value)
+}
+
+func TestSQLiteFormatter_requiresDatabase(t *testing.T) {
+	_, err := output.NewFormatter(output.FormatSQLite, output.WithWriter(&bytes.Buffer{}))
+	require.ErrorIs(t, err, output.ErrSQLiteRequiresDatabase)
+
+	database, err := output.OpenDatabase(filepath.Join(t.TempDir(), "ids.db"))
+	require.NoError(t, err)
+
+	_, err = database.Write([]byte("42\n"))
+	require.ErrorIs(t, err, output.ErrDatabaseText)
+	require.NoError(t, database.Close())
+}
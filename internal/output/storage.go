@@ -0,0 +1,65 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+)
+
+func (f *TableFormatter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"),
+		"Repository", "LFS", "Artifacts", "Total")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range storage {
+		pathLink := f.link(project.ProjectWebURL, LinkUsageQuotas, project.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, project.ProjectID, pathLink),
+			report.FormatSize(project.RepositorySize),
+			report.FormatSize(project.LFSObjectsSize),
+			report.FormatSize(project.JobArtifactsSize),
+			report.FormatSize(project.StorageSize),
+		), project.ProjectWebURL, LinkUsageQuotas), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	return f.encode(storage, len(storage), "project storage")
+}
+
+func (f *CSVFormatter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	if len(storage) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(storage[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range storage {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkUsageQuotas)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectStorage(_ []*glclient.ProjectStorage) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	return f.render("project storage", storage)
+}
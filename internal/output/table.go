@@ -0,0 +1,166 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+var ErrUnknownTableStyle = errors.New("unknown table style")
+
+// tableStyles are the go-pretty styles the table output can be drawn in, by name.
+var tableStyles = map[string]table.Style{
+	"default": table.StyleDefault,
+	"light":   table.StyleLight,
+	"bold":    table.StyleBold,
+	"double":  table.StyleDouble,
+	"rounded": table.StyleRounded,
+}
+
+const (
+	ellipsis = "…"
+
+	hyperlinkStart = "\x1b]8;;"
+	hyperlinkEnd   = "\x1b\\"
+)
+
+// TableStyles returns the names of the table styles, sorted.
+func TableStyles() []string {
+	return slices.Sorted(maps.Keys(tableStyles))
+}
+
+// WithTableStyle draws the table output in the named style, one of TableStyles. Other formats are
+// not affected.
+func WithTableStyle(name string) Option {
+	return func(o *options) {
+		o.tableStyle = name
+	}
+}
+
+// WithMaxColumnWidth cuts table cells wider than width characters with an ellipsis at the end, or in
+// the middle when middle is set, which keeps both the top-level group and the name of long paths
+// visible. Linked cells stay linked. Other formats are not affected.
+func WithMaxColumnWidth(width int, middle bool) Option {
+	return func(o *options) {
+		o.maxColumnWidth = width
+		o.truncateMiddle = middle
+	}
+}
+
+func lookupTableStyle(name string) (table.Style, error) {
+	if name == "" {
+		return table.StyleDefault, nil
+	}
+
+	style, ok := tableStyles[name]
+	if !ok {
+		return table.Style{}, fmt.Errorf("%w %q, use one of %s", ErrUnknownTableStyle, name,
+			strings.Join(TableStyles(), ", "))
+	}
+
+	return style, nil
+}
+
+// newTable returns a table writing to the formatter's output, drawn in the selected style, with its
+// columns limited to the selected width.
+func (f *TableFormatter) newTable() table.Writer {
+	var t table.Writer = table.NewWriter()
+	if f.maxColumnWidth > 0 {
+		t = &widthLimitedTable{Writer: t, width: f.maxColumnWidth, middle: f.truncateMiddle}
+	}
+
+	t.SetOutputMirror(f.writer())
+	t.SetStyle(f.style)
+
+	return t
+}
+
+// widthLimitedTable limits each column of a table, whose number is known once the header is added.
+type widthLimitedTable struct {
+	table.Writer
+
+	width  int
+	middle bool
+}
+
+func (t *widthLimitedTable) AppendHeader(row table.Row, configs ...table.RowConfig) {
+	columns := make([]table.ColumnConfig, len(row))
+	for i := range row {
+		columns[i] = table.ColumnConfig{Number: i + 1, WidthMax: t.width, WidthMaxEnforcer: t.truncate}
+	}
+
+	t.SetColumnConfigs(columns)
+	t.Writer.AppendHeader(row, configs...)
+}
+
+// truncate cuts each line of a cell to width, keeping the link of a linked cell.
+func (t *widthLimitedTable) truncate(cell string, width int) string {
+	lines := strings.Split(cell, "\n")
+	for i, line := range lines {
+		lines[i] = t.truncateLine(line, width)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (t *widthLimitedTable) truncateLine(line string, width int) string {
+	if text.StringWidthWithoutEscSequences(line) <= width {
+		return line
+	}
+
+	if url, label, ok := splitHyperlink(line); ok {
+		return text.Hyperlink(url, t.truncateLine(label, width))
+	}
+
+	// other escape sequences are only kept intact by cutting the end
+	if !t.middle || strings.Contains(line, "\x1b") {
+		return text.Snip(line, width, ellipsis)
+	}
+
+	return ellipsizeMiddle(line, width)
+}
+
+// splitHyperlink returns the URL and the label of a cell linked with text.Hyperlink.
+func splitHyperlink(cell string) (string, string, bool) {
+	if !strings.HasPrefix(cell, hyperlinkStart) || !strings.HasSuffix(cell, hyperlinkStart+hyperlinkEnd) {
+		return "", "", false
+	}
+
+	inner := strings.TrimSuffix(strings.TrimPrefix(cell, hyperlinkStart), hyperlinkStart+hyperlinkEnd)
+
+	url, label, ok := strings.Cut(inner, hyperlinkEnd)
+
+	return url, label, ok
+}
+
+// ellipsizeMiddle replaces the middle of s with an ellipsis so that it is width characters wide,
+// keeping one more character of the start than of the end when they cannot be split evenly.
+func ellipsizeMiddle(s string, width int) string {
+	runes := []rune(s)
+	if width <= 1 {
+		return ellipsis
+	}
+
+	available := width - text.StringWidthWithoutEscSequences(ellipsis)
+	headWidth := (available + 1) / 2
+	tailWidth := available - headWidth
+
+	head, used := 0, 0
+	for head < len(runes) && used+text.RuneWidth(runes[head]) <= headWidth {
+		used += text.RuneWidth(runes[head])
+		head++
+	}
+
+	tail, used := len(runes), 0
+	for tail > head && used+text.RuneWidth(runes[tail-1]) <= tailWidth {
+		used += text.RuneWidth(runes[tail-1])
+		tail--
+	}
+
+	return string(runes[:head]) + ellipsis + string(runes[tail:])
+}
@@ -0,0 +1,142 @@
+package output_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestTableFormatter_maxColumnWidth(t *testing.T) {
+	storage := []*glclient.ProjectStorage{{
+		ProjectID:     1,
+		ProjectPath:   "platform/infrastructure/terraform-modules",
+		ProjectWebURL: "https://gitlab.example.com/platform/infrastructure/terraform-modules",
+	}}
+
+	tests := []struct {
+		name string
+		opts []output.Option
+		want string
+	}{
+		{
+			name: "whole path without a limit",
+			want: "platform/infrastructure/terraform-modules",
+		},
+		{
+			name: "cuts the end",
+			opts: []output.Option{output.WithMaxColumnWidth(16, false)},
+			want: "platform/infras…",
+		},
+		{
+			name: "cuts the middle",
+			opts: []output.Option{output.WithMaxColumnWidth(16, true)},
+			want: "platform…modules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			formatter, err := output.NewFormatter(output.FormatTable, append(tt.opts, output.WithWriter(&out))...)
+			require.NoError(t, err)
+			require.NoError(t, formatter.FormatProjectStorage(storage))
+
+			link := storage[0].ProjectWebURL + "/-/usage_quotas"
+			assert.Contains(t, out.String(), "| "+hyperlink(link, tt.want)+" |", "truncated paths stay linked")
+		})
+	}
+}
+
+func TestTableFormatter_style(t *testing.T) {
+	storage := []*glclient.ProjectStorage{{ProjectID: 1, ProjectPath: "org/api"}}
+
+	t.Run("draws the selected style", func(t *testing.T) {
+		var out strings.Builder
+
+		formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&out), output.WithTableStyle("light"))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatProjectStorage(storage))
+
+		assert.True(t, strings.HasPrefix(out.String(), "┌"), out.String())
+	})
+
+	t.Run("rejects an unknown style", func(t *testing.T) {
+		_, err := output.NewFormatter(output.FormatTable, output.WithTableStyle("neon"))
+		require.ErrorIs(t, err, output.ErrUnknownTableStyle)
+		assert.Contains(t, err.Error(), "bold, default, double, light, rounded")
+	})
+}
+
+func TestTableFormatter_variableValues(t *testing.T) {
+	projectVariables := []*glclient.ProjectVariableWithProject{
+		projectVariable("DB_PASSWORD", "secret123"),
+		projectVariable("EMPTY", ""),
+	}
+	projectVariables[1].Hidden = true
+
+	groupVariables := []*glclient.GroupVariableWithGroup{{
+		GroupVariable: &gitlab.GroupVariable{Key: "REGION", Value: "eu-west-1", EnvironmentScope: "*"},
+		GroupFullPath: "backend",
+	}}
+
+	unifiedVariables := []*glclient.VariableWithSource{
+		{Key: "REGION", Value: "eu-west-1", Source: "group", SourcePath: "backend"},
+		{Key: "UNSET", Source: "project", SourcePath: "backend/api"},
+	}
+
+	tests := []struct {
+		name   string
+		format func(formatter output.Formatter, includeValues bool) error
+		values []string
+	}{
+		{
+			name: "project variables",
+			format: func(formatter output.Formatter, includeValues bool) error {
+				return formatter.FormatProjectVariables(projectVariables, includeValues)
+			},
+			values: []string{"secret123", "(hidden)"},
+		},
+		{
+			name: "group variables",
+			format: func(formatter output.Formatter, includeValues bool) error {
+				return formatter.FormatGroupVariables(groupVariables, includeValues)
+			},
+			values: []string{"eu-west-1"},
+		},
+		{
+			name: "unified variables",
+			format: func(formatter output.Formatter, includeValues bool) error {
+				return formatter.FormatUnifiedVariables(unifiedVariables, includeValues)
+			},
+			values: []string{"eu-west-1", "(empty)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var withValues, withoutValues strings.Builder
+
+			formatter, err := output.NewFormatter(output.FormatTable, output.WithWriter(&withValues))
+			require.NoError(t, err)
+			require.NoError(t, tt.format(formatter, true))
+
+			formatter, err = output.NewFormatter(output.FormatTable, output.WithWriter(&withoutValues))
+			require.NoError(t, err)
+			require.NoError(t, tt.format(formatter, false))
+
+			assert.Contains(t, withValues.String(), "| VALUE")
+			assert.NotContains(t, withoutValues.String(), "VALUE")
+
+			for _, value := range tt.values {
+				assert.Contains(t, withValues.String(), "| "+value)
+				assert.NotContains(t, withoutValues.String(), value)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var ErrTemplateRequired = errors.New("template format requires --template or --template-string")
+
+const defaultDateFormat string = "2006-01-02"
+
+// TemplateFormatter renders each report with a user-provided Go text/template.
+// The template is executed once with the whole slice of wrapped structs as its data.
+type TemplateFormatter struct {
+	sink
+
+	tmpl *template.Template
+	// instanceLabel returns the instance of groups and projects, nil unless set by WithInstanceLabels
+	instanceLabel func(item any) string
+}
+
+// templateFuncs are the helper functions available to user templates, besides formatTime, which
+// depends on the selected time format.
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": templateJSON,
+}
+
+func newTemplateFormatter(o options) (*TemplateFormatter, error) {
+	text, name := o.template, "template"
+
+	if o.templateFile != "" {
+		data, err := os.ReadFile(o.templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+
+		text, name = string(data), filepath.Base(o.templateFile)
+	}
+
+	if text == "" {
+		return nil, ErrTemplateRequired
+	}
+
+	tmpl, err := template.New(name).
+		Funcs(templateFuncs).
+		Funcs(template.FuncMap{"formatTime": o.timeFormat.formatTemplateTime}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	return &TemplateFormatter{sink: sink{out: o.writer}, tmpl: tmpl, instanceLabel: o.instanceLabel}, nil
+}
+
+func (f *TemplateFormatter) FormatGroups(groups []*gitlab.Group) error {
+	if f.instanceLabel != nil {
+		return f.render("groups", labelGroups(groups, f.instanceLabel))
+	}
+
+	return f.render("groups", groups)
+}
+
+func (f *TemplateFormatter) FormatProjects(projects []*gitlab.Project) error {
+	if f.instanceLabel != nil {
+		return f.render("projects", labelProjects(projects, f.instanceLabel))
+	}
+
+	return f.render("projects", projects)
+}
+
+func (f *TemplateFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
+	return f.render("group access tokens", tokens)
+}
+
+func (f *TemplateFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
+	return f.render("project access tokens", tokens)
+}
+
+func (f *TemplateFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
+	return f.render("pipeline triggers", triggers)
+}
+
+func (f *TemplateFormatter) FormatProjectVariables(
+	variables []*glclient.ProjectVariableWithProject,
+	includeValues bool,
+) error {
+	if includeValues {
+		return f.render("project variables", variables)
+	}
+
+	return f.render("project variables", filterProjectVariables(variables))
+}
+
+func (f *TemplateFormatter) FormatGroupVariables(
+	variables []*glclient.GroupVariableWithGroup,
+	includeValues bool,
+) error {
+	if includeValues {
+		return f.render("group variables", variables)
+	}
+
+	return f.render("group variables", filterGroupVariables(variables))
+}
+
+func (f *TemplateFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
+	if includeValues {
+		return f.render("unified variables", variables)
+	}
+
+	return f.render("unified variables", filterUnifiedVariables(variables))
+}
+
+func (f *TemplateFormatter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	return f.render("badges", badges)
+}
+
+func (f *TemplateFormatter) render(report string, data any) error {
+	if err := f.tmpl.Execute(f.writer(), data); err != nil {
+		return fmt.Errorf("failed to render %s with template: %w", report, err)
+	}
+
+	return nil
+}
+
+// formatTemplateTime formats the time types found in the wrapped structs, timestamps in tf and
+// dates as such. Nil values are rendered as the default placeholder.
+func (tf TimeFormat) formatTemplateTime(v any) string {
+	switch t := v.(type) {
+	case time.Time:
+		return tf.format(t)
+	case *time.Time:
+		if t != nil {
+			return tf.format(*t)
+		}
+	case gitlab.ISOTime:
+		return time.Time(t).Format(defaultDateFormat)
+	case *gitlab.ISOTime:
+		if t != nil {
+			return time.Time(*t).Format(defaultDateFormat)
+		}
+	}
+
+	return defaultTextPlaceholder
+}
+
+func templateJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode value as JSON: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,117 @@
+package output_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestTemplateFormatter_FormatProjectAccessTokens(t *testing.T) {
+	expiresAt := gitlab.ISOTime(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC))
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{
+					Name:      "deploy",
+					Scopes:    []string{"read_api", "read_repository"},
+					ExpiresAt: &expiresAt,
+					CreatedAt: &createdAt,
+				},
+			},
+			ProjectPath: "backend/api-service",
+		},
+		{
+			ProjectAccessToken: &gitlab.ProjectAccessToken{
+				PersonalAccessToken: gitlab.PersonalAccessToken{Name: "forever"},
+			},
+			ProjectPath: "frontend/web-app",
+		},
+	}
+
+	formatter, err := output.NewFormatter(output.FormatTemplate, output.WithTemplate(
+		`{{range .}}{{.ProjectPath}};{{.Name}};{{join .Scopes ","}};{{formatTime .ExpiresAt}};`+
+			`{{formatTime .CreatedAt}}{{"\n"}}{{end}}total={{len .}}`))
+	require.NoError(t, err)
+
+	out := readStdout(t, func() {
+		err = formatter.FormatProjectAccessTokens(tokens)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "backend/api-service;deploy;read_api,read_repository;2025-06-30;2025-01-02 03:04:05Z\n"+
+		"frontend/web-app;forever;;N/A;N/A\n"+
+		"total=2", out)
+}
+
+func TestTemplateFormatter_FormatProjectVariables(t *testing.T) {
+	variables := []*glclient.ProjectVariableWithProject{projectVariable("API_KEY", "secret")}
+
+	formatter, err := output.NewFormatter(output.FormatTemplate, output.WithTemplate(
+		`{{range .}}{{json .}}{{end}}`))
+	require.NoError(t, err)
+
+	withValues := readStdout(t, func() {
+		err = formatter.FormatProjectVariables(variables, true)
+	})
+	require.NoError(t, err)
+	assert.Contains(t, withValues, `"value":"secret"`)
+
+	withoutValues := readStdout(t, func() {
+		err = formatter.FormatProjectVariables(variables, false)
+	})
+	require.NoError(t, err)
+	assert.Contains(t, withoutValues, `"key":"API_KEY"`)
+	assert.NotContains(t, withoutValues, "secret")
+}
+
+func TestTemplateFormatter_errors(t *testing.T) {
+	t.Run("requires a template", func(t *testing.T) {
+		_, err := output.NewFormatter(output.FormatTemplate)
+		require.ErrorIs(t, err, output.ErrTemplateRequired)
+	})
+
+	t.Run("reports parse errors", func(t *testing.T) {
+		_, err := output.NewFormatter(output.FormatTemplate, output.WithTemplate("{{range .}"))
+		require.ErrorContains(t, err, "failed to parse template")
+	})
+
+	t.Run("reports missing template files", func(t *testing.T) {
+		_, err := output.NewFormatter(output.FormatTemplate,
+			output.WithTemplateFile(filepath.Join(t.TempDir(), "missing.tmpl")))
+		require.ErrorContains(t, err, "failed to read template")
+	})
+
+	t.Run("reports execution errors", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatTemplate, output.WithTemplate("{{range .}}{{.Missing}}{{end}}"))
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			err = formatter.FormatGroups([]*gitlab.Group{{ID: 1}})
+		})
+		require.ErrorContains(t, err, "failed to render groups with template")
+		assert.Empty(t, out)
+	})
+
+	t.Run("reads the template from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "groups.tmpl")
+		require.NoError(t, os.WriteFile(path, []byte("{{range .}}{{.FullPath}} {{end}}"), 0o600))
+
+		formatter, err := output.NewFormatter(output.FormatTemplate, output.WithTemplateFile(path))
+		require.NoError(t, err)
+
+		out := readStdout(t, func() {
+			err = formatter.FormatGroups([]*gitlab.Group{{FullPath: "a"}, {FullPath: "a/b"}})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "a a/b ", out)
+	})
+}
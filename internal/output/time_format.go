@@ -0,0 +1,81 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTimeFormat = errors.New("invalid time format, use custom, rfc3339, or unix")
+
+// TimeFormat selects how the table, template, and CSV formats render timestamps.
+type TimeFormat string
+
+const (
+	// TimeFormatCustom renders timestamps as 2006-01-02 15:04:05Z07:00 in tables and templates and
+	// keeps the CSV values as they were.
+	TimeFormatCustom TimeFormat = "custom"
+	// TimeFormatRFC3339 renders timestamps as RFC 3339, such as 2024-05-01T13:04:05Z.
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+	// TimeFormatUnix renders timestamps as seconds since the Unix epoch.
+	TimeFormatUnix TimeFormat = "unix"
+)
+
+// ParseTimeFormat returns the time format with the given name. An empty name selects the custom one.
+func ParseTimeFormat(name string) (TimeFormat, error) {
+	switch format := TimeFormat(strings.ToLower(name)); format {
+	case "", TimeFormatCustom:
+		return TimeFormatCustom, nil
+	case TimeFormatRFC3339, TimeFormatUnix:
+		return format, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidTimeFormat, name)
+	}
+}
+
+// WithTimeFormat renders the timestamps of the table, template, and CSV formats in the given format.
+// JSON always encodes them as RFC 3339.
+func WithTimeFormat(format TimeFormat) Option {
+	return func(o *options) {
+		o.timeFormat = format
+	}
+}
+
+// format formats a timestamp. Timestamps are converted to the selected time zone before formatting,
+// so UTC ones end with Z and others with their offset, apart from Unix seconds.
+func (tf TimeFormat) format(t time.Time) string {
+	switch tf {
+	case TimeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	case TimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(defaultTimeFormat)
+	}
+}
+
+// formatCSVTime formats a timestamp field of a CSV row, reporting whether it did. The custom format
+// leaves the values to the default formatting, so the CSV output stays as before. Dates, named
+// *_date by GitLab, and nil timestamps are not formatted either.
+func (tf TimeFormat) formatCSVTime(field flatField) (string, bool) {
+	if tf != TimeFormatRFC3339 && tf != TimeFormatUnix {
+		return "", false
+	}
+
+	if strings.HasSuffix(field.name, "_date") {
+		return "", false
+	}
+
+	switch t := field.value.Interface().(type) {
+	case time.Time:
+		return tf.format(t), true
+	case *time.Time:
+		if t != nil {
+			return tf.format(*t), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,147 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFormatter_timeFormat(t *testing.T) {
+	berlin, err := output.LoadTimezone("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		format     output.Format
+		timeFormat output.TimeFormat
+		opts       []output.Option
+		expected   string
+	}{
+		{
+			name:       "table custom",
+			format:     output.FormatTable,
+			timeFormat: output.TimeFormatCustom,
+			expected:   "2024-07-01 22:30:00Z",
+		},
+		{
+			name:       "table rfc3339",
+			format:     output.FormatTable,
+			timeFormat: output.TimeFormatRFC3339,
+			expected:   "2024-07-01T22:30:00Z",
+		},
+		{
+			name:       "table unix",
+			format:     output.FormatTable,
+			timeFormat: output.TimeFormatUnix,
+			expected:   "1719873000",
+		},
+		{
+			name:       "table rfc3339 in named zone",
+			format:     output.FormatTable,
+			timeFormat: output.TimeFormatRFC3339,
+			opts:       []output.Option{output.WithTimezone(berlin)},
+			expected:   "2024-07-02T00:30:00+02:00",
+		},
+		{
+			name:       "table unix ignores the zone",
+			format:     output.FormatTable,
+			timeFormat: output.TimeFormatUnix,
+			opts:       []output.Option{output.WithTimezone(berlin)},
+			expected:   "1719873000",
+		},
+		{
+			name:       "csv custom",
+			format:     output.FormatCSV,
+			timeFormat: output.TimeFormatCustom,
+			expected:   "2024-07-01 22:30:00 +0000 UTC",
+		},
+		{
+			name:       "csv rfc3339",
+			format:     output.FormatCSV,
+			timeFormat: output.TimeFormatRFC3339,
+			expected:   ",2024-07-01T22:30:00Z,",
+		},
+		{
+			name:       "csv unix",
+			format:     output.FormatCSV,
+			timeFormat: output.TimeFormatUnix,
+			expected:   ",1719873000,",
+		},
+		{
+			name:       "template custom",
+			format:     output.FormatTemplate,
+			timeFormat: output.TimeFormatCustom,
+			opts:       []output.Option{output.WithTemplate(`{{range .}}{{formatTime .CreatedAt}}{{end}}`)},
+			expected:   "2024-07-01 22:30:00Z",
+		},
+		{
+			name:       "template rfc3339",
+			format:     output.FormatTemplate,
+			timeFormat: output.TimeFormatRFC3339,
+			opts:       []output.Option{output.WithTemplate(`{{range .}}{{formatTime .CreatedAt}}{{end}}`)},
+			expected:   "2024-07-01T22:30:00Z",
+		},
+		{
+			name:       "template unix",
+			format:     output.FormatTemplate,
+			timeFormat: output.TimeFormatUnix,
+			opts:       []output.Option{output.WithTemplate(`{{range .}}{{formatTime .CreatedAt}}{{end}}`)},
+			expected:   "1719873000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			opts := append(tt.opts, output.WithWriter(&buf), output.WithTimeFormat(tt.timeFormat))
+			formatter, err := output.NewFormatter(tt.format, opts...)
+			require.NoError(t, err)
+
+			require.NoError(t, formatter.FormatProjectAccessTokens(timezoneTokens()))
+			assert.Contains(t, buf.String(), tt.expected)
+		})
+	}
+}
+
+func TestNewFormatter_timeFormatKeepsCSVDates(t *testing.T) {
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatCSV,
+		output.WithWriter(&buf), output.WithTimeFormat(output.TimeFormatUnix))
+	require.NoError(t, err)
+
+	dueDate := time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, formatter.FormatProjectMilestones([]*glclient.ProjectMilestone{
+		{ID: 1, Title: "v1.0", DueDate: &dueDate},
+	}))
+
+	assert.NotContains(t, buf.String(), "1727654400")
+}
+
+func TestParseTimeFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected output.TimeFormat
+	}{
+		{name: "", expected: output.TimeFormatCustom},
+		{name: "custom", expected: output.TimeFormatCustom},
+		{name: "RFC3339", expected: output.TimeFormatRFC3339},
+		{name: "unix", expected: output.TimeFormatUnix},
+	}
+
+	for _, tt := range tests {
+		format, err := output.ParseTimeFormat(tt.name)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, format)
+	}
+
+	_, err := output.ParseTimeFormat("iso")
+	require.ErrorIs(t, err, output.ErrInvalidTimeFormat)
+	assert.Contains(t, err.Error(), "iso")
+}
@@ -0,0 +1,60 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTimezone = errors.New("invalid time zone, use an IANA name such as Europe/Berlin")
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	timePtrType = reflect.TypeFor[*time.Time]()
+)
+
+// LoadTimezone returns the time zone with the given IANA name, such as Europe/Berlin. UTC and Local
+// are accepted as well, and an empty name selects UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+
+	return location, nil
+}
+
+// WithTimezone renders the timestamps of every report in the given time zone instead of UTC.
+func WithTimezone(location *time.Location) Option {
+	return func(o *options) {
+		o.location = location
+	}
+}
+
+// rewriteTime converts a time.Time or *time.Time field to location, reporting whether the field
+// holds a time at all. Dates, named *_date by GitLab, are kept as they are, since they denote a
+// calendar day rather than an instant. Pointers are replaced rather than updated, as the time they
+// point to may be shared with other items.
+func rewriteTime(field reflect.StructField, value reflect.Value, location *time.Location) bool {
+	if value.Type() != timeType && value.Type() != timePtrType {
+		return false
+	}
+
+	if location == nil || strings.HasSuffix(jsonName(field), "_date") {
+		return true
+	}
+
+	switch t := value.Interface().(type) {
+	case time.Time:
+		value.Set(reflect.ValueOf(t.In(location)))
+	case *time.Time:
+		if t != nil {
+			converted := t.In(location)
+			value.Set(reflect.ValueOf(&converted))
+		}
+	}
+
+	return true
+}
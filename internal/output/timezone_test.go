@@ -0,0 +1,110 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func timezoneTokens() []*glclient.ProjectAccessTokenWithProject {
+	createdAt := time.Date(2024, 7, 1, 22, 30, 0, 0, time.UTC)
+
+	return []*glclient.ProjectAccessTokenWithProject{{
+		ProjectAccessToken: &gitlab.ProjectAccessToken{
+			PersonalAccessToken: gitlab.PersonalAccessToken{ID: 1, Name: "deploy", CreatedAt: &createdAt},
+		},
+		ProjectPath: "org/api",
+	}}
+}
+
+func TestNewFormatter_timezone(t *testing.T) {
+	berlin, err := output.LoadTimezone("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		format   output.Format
+		opts     []output.Option
+		expected string
+	}{
+		{name: "table defaults to UTC", format: output.FormatTable, expected: "2024-07-01 22:30:00Z"},
+		{
+			name:     "table in named zone",
+			format:   output.FormatTable,
+			opts:     []output.Option{output.WithTimezone(berlin)},
+			expected: "2024-07-02 00:30:00+02:00",
+		},
+		{name: "csv defaults to UTC", format: output.FormatCSV, expected: "2024-07-01 22:30:00 +0000 UTC"},
+		{
+			name:     "csv in named zone",
+			format:   output.FormatCSV,
+			opts:     []output.Option{output.WithTimezone(berlin)},
+			expected: "2024-07-02 00:30:00 +0200 CEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			formatter, err := output.NewFormatter(tt.format, append(tt.opts, output.WithWriter(&buf))...)
+			require.NoError(t, err)
+
+			require.NoError(t, formatter.FormatProjectAccessTokens(timezoneTokens()))
+			assert.Contains(t, buf.String(), tt.expected)
+		})
+	}
+}
+
+func TestNewFormatter_timezoneJSON(t *testing.T) {
+	tokyo, err := output.LoadTimezone("Asia/Tokyo")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithTimezone(tokyo))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatProjectAccessTokens(timezoneTokens()))
+
+	var tokens []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &tokens))
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "2024-07-02T07:30:00+09:00", tokens[0]["created_at"])
+}
+
+func TestNewFormatter_timezoneKeepsDates(t *testing.T) {
+	tokyo, err := output.LoadTimezone("Asia/Tokyo")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithTimezone(tokyo))
+	require.NoError(t, err)
+
+	dueDate := time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, formatter.FormatProjectMilestones([]*glclient.ProjectMilestone{
+		{ID: 1, Title: "v1.0", DueDate: &dueDate},
+	}))
+
+	var milestones []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &milestones))
+	require.Len(t, milestones, 1)
+	assert.Equal(t, "2024-09-30T00:00:00Z", milestones[0]["due_date"])
+}
+
+func TestLoadTimezone(t *testing.T) {
+	location, err := output.LoadTimezone("UTC")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, location)
+
+	_, err = output.LoadTimezone("Mars/Olympus_Mons")
+	require.ErrorIs(t, err, output.ErrInvalidTimezone)
+	assert.Contains(t, err.Error(), "Mars/Olympus_Mons")
+}
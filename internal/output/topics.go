@@ -0,0 +1,60 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+func (f *TableFormatter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatPath, "Project ID", "Project Path"), "Topics")
+	t.AppendHeader(f.withInstanceHeader(f.withLinkStatusHeader(header)))
+
+	for _, project := range topics {
+		pathLink := f.link(project.ProjectWebURL, LinkTopics, project.ProjectPath)
+
+		t.AppendRow(f.withInstance(f.withLinkStatus(append(f.identifier(IDFormatPath, project.ProjectID, pathLink),
+			textOrPlaceholder(strings.Join(project.Topics, ", "))), project.ProjectWebURL, LinkTopics), project.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	return f.encode(topics, len(topics), "project topics")
+}
+
+func (f *CSVFormatter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	if len(topics) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, f.withLinkStatusHeaders(getCSVHeaders(topics[0]))); err != nil {
+		return err
+	}
+
+	for _, project := range topics {
+		row := f.withLinkStatus(f.row(project), project.ProjectWebURL, LinkTopics)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatProjectTopics(_ []*glclient.ProjectTopics) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	return f.render("project topics", topics)
+}
@@ -0,0 +1,187 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrTreeRequiresJSON = fmt.Errorf(
+		"%w: the tree is only available with the json format", ErrUnsupportedFormat)
+	ErrTreeUnsupported = fmt.Errorf(
+		"%w: the tree needs the group or project path of every report item", ErrUnsupportedFormat)
+)
+
+// treeNode is a group or project of a report tree, holding the report items of its own and the
+// nodes of its subgroups and projects.
+type treeNode struct {
+	Path     string      `json:"path"`
+	Items    []any       `json:"items,omitempty"`
+	Groups   []*treeNode `json:"groups,omitempty"`
+	Projects []*treeNode `json:"projects,omitempty"`
+}
+
+// treeLocation tells whether a report item belongs to a group or a project, and its full path.
+type treeLocation struct {
+	project bool
+	path    string
+}
+
+// groupPathFields and projectPathFields are the JSON keys holding the full path of the group or
+// project an item belongs to, checked in order.
+var (
+	groupPathFields   = []string{"group_full_path", "full_path", "group_path"}
+	projectPathFields = []string{"project_path", "path_with_namespace"}
+)
+
+// itemLocation returns the group or project the report item v points to belongs to, found by the
+// json tags of its path fields.
+func itemLocation(v any) (treeLocation, bool) {
+	values := map[string]string{}
+
+	for _, field := range flatFields(v) {
+		if field.value.IsValid() && field.value.Kind() == reflect.String {
+			values[field.name] = field.value.String()
+		}
+	}
+
+	for _, name := range projectPathFields {
+		if values[name] != "" {
+			return treeLocation{project: true, path: values[name]}, true
+		}
+	}
+
+	for _, name := range groupPathFields {
+		if values[name] != "" {
+			return treeLocation{path: values[name]}, true
+		}
+	}
+
+	// items of mixed reports carry their source
+	if path := values["source_path"]; path != "" {
+		switch values["source"] {
+		case "project":
+			return treeLocation{project: true, path: path}, true
+		case "group":
+			return treeLocation{path: path}, true
+		}
+	}
+
+	return treeLocation{}, false
+}
+
+// treeBuilder nests report items under the groups and projects their paths name, creating the
+// nodes of parent groups on the way.
+type treeBuilder struct {
+	groups   map[string]*treeNode
+	projects map[string]*treeNode
+	roots    []*treeNode
+}
+
+// group returns the node of the group with the given full path, attached to its parent group.
+func (b *treeBuilder) group(path string) *treeNode {
+	if node, ok := b.groups[path]; ok {
+		return node
+	}
+
+	node := &treeNode{Path: path}
+	b.groups[path] = node
+
+	if parent, ok := parentPath(path); ok {
+		parentNode := b.group(parent)
+		parentNode.Groups = append(parentNode.Groups, node)
+	} else {
+		b.roots = append(b.roots, node)
+	}
+
+	return node
+}
+
+// project returns the node of the project with the given full path, attached to its namespace.
+// The namespace of a personal project becomes a node like any group.
+func (b *treeBuilder) project(path string) *treeNode {
+	if node, ok := b.projects[path]; ok {
+		return node
+	}
+
+	node := &treeNode{Path: path}
+	b.projects[path] = node
+
+	if parent, ok := parentPath(path); ok {
+		parentNode := b.group(parent)
+		parentNode.Projects = append(parentNode.Projects, node)
+	} else {
+		b.roots = append(b.roots, node)
+	}
+
+	return node
+}
+
+// parentPath returns the full path of the group holding the group or project at path, unless it is
+// top-level.
+func parentPath(path string) (string, bool) {
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return "", false
+	}
+
+	return path[:i], true
+}
+
+// treeItems nests the report items of a slice under their groups and projects and returns the
+// top-level nodes. The items keep their order within a node, and nodes are sorted by path. encoded
+// returns the value written for an item, such as its flat object.
+func treeItems(items any, encoded func(reflect.Value) any) ([]*treeNode, error) {
+	val := reflect.ValueOf(items)
+	if val.Kind() != reflect.Slice {
+		return nil, ErrTreeUnsupported
+	}
+
+	builder := &treeBuilder{groups: map[string]*treeNode{}, projects: map[string]*treeNode{}}
+
+	for i := range val.Len() {
+		item := val.Index(i)
+		if item.Kind() != reflect.Ptr || item.IsNil() || item.Elem().Kind() != reflect.Struct {
+			return nil, ErrTreeUnsupported
+		}
+
+		location, ok := itemLocation(item.Interface())
+		if !ok {
+			return nil, fmt.Errorf("%w: item %d has no path", ErrTreeUnsupported, i+1)
+		}
+
+		var node *treeNode
+		if location.project {
+			node = builder.project(location.path)
+		} else {
+			node = builder.group(location.path)
+		}
+
+		node.Items = append(node.Items, encoded(item))
+	}
+
+	sortTree(builder.roots)
+
+	return builder.roots, nil
+}
+
+func sortTree(nodes []*treeNode) {
+	slices.SortFunc(nodes, func(a, b *treeNode) int { return cmp.Compare(a.Path, b.Path) })
+
+	for _, node := range nodes {
+		sortTree(node.Groups)
+		sortTree(node.Projects)
+	}
+}
+
+// WithTree nests the items of JSON reports under the groups and projects they belong to, following
+// their paths, instead of writing a flat array. Groups hold their subgroups and projects, and each
+// node holds its own items.
+func WithTree() Option {
+	return func(o *options) {
+		o.tree = true
+	}
+}
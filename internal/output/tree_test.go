@@ -0,0 +1,130 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// treeNode mirrors a node of the tree output.
+type treeNode struct {
+	Path     string           `json:"path"`
+	Items    []map[string]any `json:"items"`
+	Groups   []treeNode       `json:"groups"`
+	Projects []treeNode       `json:"projects"`
+}
+
+// itemKeys returns the key of each variable item of a node.
+func itemKeys(node treeNode) []any {
+	keys := make([]any, 0, len(node.Items))
+	for _, item := range node.Items {
+		keys = append(keys, item["key"])
+	}
+
+	return keys
+}
+
+var treeVariables = []*glclient.VariableWithSource{
+	{Key: "WEB_URL", Source: "project", SourcePath: "backend/team/web"},
+	{Key: "REGION", Source: "group", SourcePath: "backend"},
+	{Key: "TEAM_CHANNEL", Source: "group", SourcePath: "backend/team"},
+	{Key: "API_PORT", Source: "project", SourcePath: "backend/api"},
+	{Key: "WEB_PORT", Source: "project", SourcePath: "backend/team/web"},
+}
+
+func TestJSONFormatter_tree(t *testing.T) {
+	t.Run("nests a two-level hierarchy", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithTree())
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatUnifiedVariables(treeVariables, false))
+
+		var roots []treeNode
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &roots))
+		require.Len(t, roots, 1)
+
+		root := roots[0]
+		assert.Equal(t, "backend", root.Path)
+		assert.Equal(t, []any{"REGION"}, itemKeys(root))
+
+		require.Len(t, root.Projects, 1)
+		assert.Equal(t, "backend/api", root.Projects[0].Path)
+		assert.Equal(t, []any{"API_PORT"}, itemKeys(root.Projects[0]))
+
+		require.Len(t, root.Groups, 1)
+		team := root.Groups[0]
+		assert.Equal(t, "backend/team", team.Path)
+		assert.Equal(t, []any{"TEAM_CHANNEL"}, itemKeys(team))
+		assert.Empty(t, team.Groups)
+
+		require.Len(t, team.Projects, 1)
+		assert.Equal(t, "backend/team/web", team.Projects[0].Path)
+		assert.Equal(t, []any{"WEB_URL", "WEB_PORT"}, itemKeys(team.Projects[0]))
+	})
+
+	t.Run("creates the nodes of parent groups without items", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithTree())
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatProjectAccessTokens(flattenTokens))
+
+		var roots []treeNode
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &roots))
+		require.Len(t, roots, 1)
+		assert.Equal(t, "backend", roots[0].Path)
+		assert.Empty(t, roots[0].Items)
+		require.Len(t, roots[0].Projects, 2)
+		assert.Equal(t, "backend/api", roots[0].Projects[0].Path)
+		assert.Equal(t, "backend/web", roots[0].Projects[1].Path)
+	})
+
+	t.Run("nests groups by their full path", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&buf), output.WithTree(),
+			output.WithFlattening(), output.WithEnvelope(output.Metadata{RootGroup: "backend"}))
+		require.NoError(t, err)
+		require.NoError(t, formatter.FormatGroups([]*gitlab.Group{
+			{ID: 2, FullPath: "backend/api"}, {ID: 1, FullPath: "backend"},
+		}))
+
+		var got struct {
+			Total int        `json:"total"`
+			Items []treeNode `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+
+		assert.Equal(t, 2, got.Total)
+		require.Len(t, got.Items, 1)
+		require.Len(t, got.Items[0].Items, 1)
+		assert.InDelta(t, 1, got.Items[0].Items[0]["id"], 0)
+		require.Len(t, got.Items[0].Groups, 1)
+		assert.Equal(t, "backend/api", got.Items[0].Groups[0].Path)
+	})
+
+	t.Run("rejects items without a path", func(t *testing.T) {
+		formatter, err := output.NewFormatter(output.FormatJSON, output.WithWriter(&bytes.Buffer{}), output.WithTree())
+		require.NoError(t, err)
+
+		err = formatter.FormatImpersonationTokens([]*glclient.ImpersonationTokenWithUser{{Username: "alice"}})
+		require.ErrorIs(t, err, output.ErrTreeUnsupported)
+	})
+}
+
+func TestNewFormatter_treeRequiresJSON(t *testing.T) {
+	for _, format := range []output.Format{output.FormatTable, output.FormatCSV, output.FormatTemplate} {
+		t.Run(string(format), func(t *testing.T) {
+			_, err := output.NewFormatter(format, output.WithTree())
+			require.ErrorIs(t, err, output.ErrTreeRequiresJSON)
+			assert.ErrorIs(t, err, output.ErrUnsupportedFormat)
+		})
+	}
+}
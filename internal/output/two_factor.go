@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func (f *TableFormatter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	t := f.newTable()
+	header := append(f.identifier(IDFormatBoth, "ID", "Group"), "2FA Required", "Grace Period", "Enforced By")
+	t.AppendHeader(f.withInstanceHeader(header))
+
+	for _, status := range statuses {
+		enforcedBy := defaultTextPlaceholder
+		if status.Enforced() {
+			enforcedBy = status.EnforcedBy
+		}
+
+		groupLink := text.Hyperlink(status.GroupWebURL, status.GroupPath)
+
+		t.AppendRow(f.withInstance(append(f.identifier(IDFormatBoth, status.GroupID, groupLink),
+			status.RequireTwoFactorAuthentication,
+			fmt.Sprintf("%dh", status.TwoFactorGracePeriod),
+			enforcedBy,
+		), status.Instance))
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	return f.encode(statuses, len(statuses), "two-factor status")
+}
+
+func (f *CSVFormatter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, getCSVHeaders(statuses[0])); err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		if err := writer.Write(f.row(status)); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatTwoFactor(_ []*glclient.GroupTwoFactor) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	return f.render("two-factor status", statuses)
+}
@@ -0,0 +1,136 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+var ErrDotenvVariableDifferences = fmt.Errorf("%w: dotenv cannot show a comparison of variables",
+	ErrUnsupportedFormat)
+
+// maskedVariableDifference is a variable difference without the values of either side.
+type maskedVariableDifference struct {
+	Key              string `json:"key"`
+	EnvironmentScope string `json:"environment_scope"`
+	Difference       string `json:"difference"`
+	Left             bool   `json:"left"`
+	Right            bool   `json:"right"`
+}
+
+func maskVariableDifferences(differences []*report.VariableDifference) []*maskedVariableDifference {
+	masked := make([]*maskedVariableDifference, len(differences))
+	for i, d := range differences {
+		masked[i] = &maskedVariableDifference{
+			Key:              d.Key,
+			EnvironmentScope: d.EnvironmentScope,
+			Difference:       d.Difference,
+			Left:             d.Left,
+			Right:            d.Right,
+		}
+	}
+
+	return masked
+}
+
+// variableSide describes a variable on one side of a comparison: its value, or only whether it is
+// set when values are not shown.
+func variableSide(present bool, value string, includeValues bool) string {
+	switch {
+	case !present:
+		return "missing"
+	case !includeValues:
+		return "set"
+	case value == "":
+		return "(empty)"
+	default:
+		return value
+	}
+}
+
+func (f *TableFormatter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	t := f.newTable()
+	t.AppendHeader(table.Row{"Key", "Environment Scope", "Difference", "Left", "Right"})
+
+	for _, d := range differences {
+		t.AppendRow(table.Row{
+			d.Key,
+			d.EnvironmentScope,
+			d.Difference,
+			variableSide(d.Left, d.LeftValue, includeValues),
+			variableSide(d.Right, d.RightValue, includeValues),
+		})
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	if includeValues {
+		return f.encode(differences, len(differences), "variable differences")
+	}
+
+	return f.encode(maskVariableDifferences(differences), len(differences), "variable differences")
+}
+
+func (f *CSVFormatter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	headers := []string{"key", "environment_scope", "difference", "left", "right"}
+	if includeValues {
+		headers = append(headers, "left_value", "right_value")
+	}
+
+	if err := f.writeHeaders(writer, headers); err != nil {
+		return err
+	}
+
+	for _, d := range differences {
+		row := []string{
+			d.Key,
+			d.EnvironmentScope,
+			d.Difference,
+			strconv.FormatBool(d.Left),
+			strconv.FormatBool(d.Right),
+		}
+		if includeValues {
+			row = append(row, d.LeftValue, d.RightValue)
+		}
+
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatVariableDifferences(_ []*report.VariableDifference, _ bool) error {
+	return ErrDotenvVariableDifferences
+}
+
+func (f *TemplateFormatter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	if includeValues {
+		return f.render("variable differences", differences)
+	}
+
+	return f.render("variable differences", maskVariableDifferences(differences))
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func (f *TableFormatter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	expiresAt := defaultExpiresAtText
+	if info.ExpiresAt != nil {
+		expiresAt = time.Time(*info.ExpiresAt).Format(defaultDateFormat)
+	}
+
+	tokenName := info.TokenName
+	if tokenName == "" {
+		tokenName = defaultTextPlaceholder
+	}
+
+	t := f.newTable()
+	t.AppendRows([]table.Row{
+		{"Username", text.Hyperlink(info.UserWebURL, info.Username)},
+		{"Name", info.Name},
+		{"Administrator", info.IsAdmin},
+		{"Token Name", tokenName},
+		{"Scopes", strings.Join(info.Scopes, ", ")},
+		{"Active", info.Active},
+		{"Expires At", expiresAt},
+	})
+
+	for _, warning := range info.Warnings {
+		t.AppendRow(table.Row{"Warning", warning})
+	}
+
+	t.Render()
+
+	return nil
+}
+
+func (f *JSONFormatter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	var v any = info
+	if f.flatten {
+		v = flattenItems(info)
+	}
+
+	encoder := json.NewEncoder(f.writer())
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode token info as JSON: %w", err)
+	}
+
+	return nil
+}
+
+func (f *CSVFormatter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	writer := csv.NewWriter(f.writer())
+	defer writer.Flush()
+
+	if err := f.writeHeaders(writer, getCSVHeaders(info)); err != nil {
+		return err
+	}
+
+	if err := writer.Write(f.row(info)); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	return nil
+}
+
+func (f *DotenvFormatter) FormatTokenInfo(_ *glclient.TokenInfo) error {
+	return ErrDotenvVariablesOnly
+}
+
+func (f *TemplateFormatter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	return f.render("token info", info)
+}
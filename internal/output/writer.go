@@ -0,0 +1,128 @@
+package output
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sink is embedded by the formatters to tell where they write the report.
+type sink struct {
+	out io.Writer
+}
+
+// writer returns the writer set by WithWriter, or standard output at the time of the call.
+func (s sink) writer() io.Writer {
+	if s.out == nil {
+		return os.Stdout
+	}
+
+	return s.out
+}
+
+// WithWriter makes the formatter write reports to w instead of standard output.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.writer = w
+	}
+}
+
+// fileWriter writes to a file, optionally through a gzip writer.
+type fileWriter struct {
+	io.Writer
+
+	file *os.File
+	gzip *gzip.Writer
+}
+
+// Close flushes and closes the gzip writer, if any, then the file. The file is closed even when
+// flushing fails.
+func (w *fileWriter) Close() error {
+	var gzipErr error
+	if w.gzip != nil {
+		gzipErr = w.gzip.Close()
+	}
+
+	if err := errors.Join(gzipErr, w.file.Close()); err != nil {
+		return fmt.Errorf("failed to close %s: %w", w.file.Name(), err)
+	}
+
+	return nil
+}
+
+// fileFormats are the formats inferred from the extension of an output file.
+var fileFormats = map[string]Format{
+	".json": FormatJSON,
+	".csv":  FormatCSV,
+	".env":  FormatDotenv,
+	".xlsx": FormatXLSX,
+}
+
+// FormatForFile returns the format of a report written to the file at path. A format given
+// explicitly is kept; otherwise the format is inferred from the extension of the file, ignoring a .gz
+// extension. Files without a known extension are written in the given format.
+func FormatForFile(format Format, explicit bool, path string) Format {
+	if explicit || path == "" {
+		return format
+	}
+
+	ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(path, ".gz")))
+	if inferred, ok := fileFormats[ext]; ok {
+		return inferred
+	}
+
+	return format
+}
+
+// CreateFile creates or truncates the file at path for a report. With compress, the report is
+// gzip-compressed and .gz is appended to path unless it already ends with it. The returned name is
+// the path of the created file; the caller must close the writer to complete the file.
+func CreateFile(path string, compress bool) (io.WriteCloser, string, error) {
+	w, name, err := openFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, compress)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	return w, name, nil
+}
+
+// AppendFile opens the file at path for appending a report, creating it if it does not exist, and
+// reports whether it already held data, so that the caller can leave out the CSV header. Compressed
+// reports are appended as another gzip member, which gzip readers decompress as one stream.
+// Otherwise it works like CreateFile.
+func AppendFile(path string, compress bool) (io.WriteCloser, string, bool, error) {
+	w, name, err := openFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, compress)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to open output file: %w", err)
+	}
+
+	info, err := w.file.Stat()
+	if err != nil {
+		return nil, "", false, errors.Join(fmt.Errorf("failed to open output file: %w", err), w.file.Close())
+	}
+
+	return w, name, info.Size() > 0, nil
+}
+
+func openFile(path string, flag int, compress bool) (*fileWriter, string, error) {
+	if compress && filepath.Ext(path) != ".gz" {
+		path += ".gz"
+	}
+
+	file, err := os.OpenFile(path, flag, 0o666)
+	if err != nil {
+		return nil, "", err
+	}
+
+	w := &fileWriter{Writer: file, file: file}
+	if compress {
+		w.gzip = gzip.NewWriter(file)
+		w.Writer = w.gzip
+	}
+
+	return w, path, nil
+}
@@ -0,0 +1,196 @@
+package output_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var writerGroups = []*gitlab.Group{
+	{ID: 1, Name: "root", FullPath: "root"},
+	{ID: 2, Name: "team", FullPath: "root/team"},
+}
+
+// expectedReport returns the report written by a formatter of the given format.
+func expectedReport(t *testing.T, format output.Format) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	formatter, err := output.NewFormatter(format, output.WithWriter(&buf))
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroups(writerGroups))
+
+	return buf.Bytes()
+}
+
+func TestCreateFile_gzip(t *testing.T) {
+	for _, format := range []output.Format{output.FormatJSON, output.FormatCSV, output.FormatTable} {
+		t.Run(string(format), func(t *testing.T) {
+			w, name, err := output.CreateFile(filepath.Join(t.TempDir(), "report"), true)
+			require.NoError(t, err)
+			assert.Equal(t, ".gz", filepath.Ext(name))
+
+			formatter, err := output.NewFormatter(format, output.WithWriter(w))
+			require.NoError(t, err)
+			require.NoError(t, formatter.FormatGroups(writerGroups))
+			require.NoError(t, w.Close())
+
+			file, err := os.Open(name)
+			require.NoError(t, err)
+
+			defer file.Close()
+
+			reader, err := gzip.NewReader(file)
+			require.NoError(t, err)
+
+			content, err := io.ReadAll(reader)
+			require.NoError(t, err)
+
+			assert.Equal(t, expectedReport(t, format), content)
+		})
+	}
+}
+
+func TestCreateFile_names(t *testing.T) {
+	dir := t.TempDir()
+
+	w, name, err := output.CreateFile(filepath.Join(dir, "report.json.gz"), true)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, filepath.Join(dir, "report.json.gz"), name)
+
+	w, name, err = output.CreateFile(filepath.Join(dir, "report.json"), false)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("[]\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	content, err := os.ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(content))
+}
+
+func TestCreateFile_missingDirectory(t *testing.T) {
+	_, _, err := output.CreateFile(filepath.Join(t.TempDir(), "missing", "report"), true)
+	require.Error(t, err)
+}
+
+// appendReport appends the CSV report of writerGroups to the file at path like --append does,
+// leaving out the header when the file already holds data.
+func appendReport(t *testing.T, path string, compress bool) {
+	t.Helper()
+
+	w, _, existing, err := output.AppendFile(path, compress)
+	require.NoError(t, err)
+
+	opts := []output.Option{output.WithWriter(w)}
+	if existing {
+		opts = append(opts, output.WithoutHeader())
+	}
+
+	formatter, err := output.NewFormatter(output.FormatCSV, opts...)
+	require.NoError(t, err)
+	require.NoError(t, formatter.FormatGroups(writerGroups))
+	require.NoError(t, w.Close())
+}
+
+func TestAppendFile(t *testing.T) {
+	report := string(expectedReport(t, output.FormatCSV))
+	header, rows, _ := strings.Cut(report, "\n")
+	header += "\n"
+
+	t.Run("new file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "groups.csv")
+
+		appendReport(t, path, false)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, report, string(content))
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "groups.csv")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+		appendReport(t, path, false)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, report, string(content))
+	})
+
+	t.Run("existing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "groups.csv")
+
+		appendReport(t, path, false)
+		appendReport(t, path, false)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, header+rows+rows, string(content))
+	})
+
+	t.Run("existing gzip file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "groups.csv.gz")
+
+		appendReport(t, path, true)
+		appendReport(t, path, true)
+
+		file, err := os.Open(path)
+		require.NoError(t, err)
+
+		defer file.Close()
+
+		reader, err := gzip.NewReader(file)
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, header+rows+rows, string(content))
+	})
+}
+
+func TestFormatForFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   output.Format
+		explicit bool
+		path     string
+		want     output.Format
+	}{
+		{name: "json", format: output.FormatTable, path: "report.json", want: output.FormatJSON},
+		{name: "csv", format: output.FormatTable, path: "out/report.CSV", want: output.FormatCSV},
+		{name: "dotenv", format: output.FormatTable, path: ".env", want: output.FormatDotenv},
+		{name: "xlsx", format: output.FormatTable, path: "audit.xlsx", want: output.FormatXLSX},
+		{name: "compressed", format: output.FormatTable, path: "report.csv.gz", want: output.FormatCSV},
+		{name: "unknown extension", format: output.FormatTable, path: "report.yaml", want: output.FormatTable},
+		{name: "no extension", format: output.FormatTable, path: "report", want: output.FormatTable},
+		{name: "no file", format: output.FormatTable, want: output.FormatTable},
+		{
+			name: "explicit format wins", format: output.FormatCSV, explicit: true, path: "report.json",
+			want: output.FormatCSV,
+		},
+		{
+			name: "explicit table wins", format: output.FormatTable, explicit: true, path: "report.json",
+			want: output.FormatTable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, output.FormatForFile(tt.format, tt.explicit, tt.path))
+		})
+	}
+}
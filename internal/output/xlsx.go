@@ -0,0 +1,367 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/xuri/excelize/v2"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var (
+	ErrXLSXRequiresWorkbook = errors.New("xlsx output must be written to a workbook file")
+	ErrWorkbookText         = errors.New("a workbook holds reports only, not text")
+)
+
+// maxSheetName is the longest worksheet name Excel accepts.
+const maxSheetName = 31
+
+// Workbook collects the worksheets written by the xlsx format and writes them to the wrapped file
+// as a single workbook once it is closed. A report written to it adds a worksheet named after its
+// report type.
+type Workbook struct {
+	file  *excelize.File
+	out   io.WriteCloser
+	bold  int
+	named bool
+}
+
+// NewWorkbook returns an empty workbook that is written to out when closed.
+func NewWorkbook(out io.WriteCloser) *Workbook {
+	return &Workbook{file: excelize.NewFile(), out: out}
+}
+
+// Write rejects text written directly to the workbook, which only holds worksheets.
+func (w *Workbook) Write(_ []byte) (int, error) {
+	return 0, ErrWorkbookText
+}
+
+// Close writes the workbook to the wrapped file and closes it. The file is closed even when writing
+// fails.
+func (w *Workbook) Close() error {
+	var writeErr error
+	if _, err := w.file.WriteTo(w.out); err != nil {
+		writeErr = fmt.Errorf("failed to write workbook: %w", err)
+	}
+
+	return errors.Join(writeErr, w.file.Close(), w.out.Close())
+}
+
+// addSheet adds a worksheet with a bold header row followed by rows. The first worksheet replaces the
+// default one of a new workbook; a name already taken is numbered.
+func (w *Workbook) addSheet(name string, headers []string, rows [][]any) error {
+	name = w.sheetName(name)
+
+	if w.named {
+		if _, err := w.file.NewSheet(name); err != nil {
+			return fmt.Errorf("failed to add worksheet %s: %w", name, err)
+		}
+	} else {
+		if err := w.file.SetSheetName(w.file.GetSheetName(0), name); err != nil {
+			return fmt.Errorf("failed to add worksheet %s: %w", name, err)
+		}
+
+		bold, err := w.file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+		if err != nil {
+			return fmt.Errorf("failed to add header style: %w", err)
+		}
+
+		w.bold = bold
+		w.named = true
+	}
+
+	header := make([]any, 0, len(headers))
+	for _, h := range headers {
+		header = append(header, h)
+	}
+
+	if err := w.file.SetSheetRow(name, "A1", &header); err != nil {
+		return fmt.Errorf("failed to write worksheet %s: %w", name, err)
+	}
+
+	if err := w.file.SetRowStyle(name, 1, 1, w.bold); err != nil {
+		return fmt.Errorf("failed to write worksheet %s: %w", name, err)
+	}
+
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return fmt.Errorf("failed to write worksheet %s: %w", name, err)
+		}
+
+		if err := w.file.SetSheetRow(name, cell, &row); err != nil {
+			return fmt.Errorf("failed to write worksheet %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// sheetName returns name, numbered when a worksheet of that name already exists.
+func (w *Workbook) sheetName(name string) string {
+	if !w.named {
+		return name
+	}
+
+	candidate := name
+	for n := 2; ; n++ {
+		if index, _ := w.file.GetSheetIndex(candidate); index < 0 {
+			return candidate
+		}
+
+		suffix := fmt.Sprintf(" (%d)", n)
+		candidate = name[:min(len(name), maxSheetName-len(suffix))] + suffix
+	}
+}
+
+// XLSXFormatter writes each report as a worksheet of a Workbook.
+type XLSXFormatter struct {
+	workbook *Workbook
+	// instanceLabel returns the instance of groups and projects, nil unless set by WithInstanceLabels
+	instanceLabel func(item any) string
+}
+
+// writeSheet adds a worksheet with a row per item, with the columns of the CSV format.
+func writeSheet[T any](f *XLSXFormatter, name string, items []*T, includeValues ...bool) error {
+	// the columns of an empty report are taken from a zero item
+	first := new(T)
+	if len(items) > 0 {
+		first = items[0]
+	}
+
+	rows := make([][]any, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, xlsxRow(item, includeValues...))
+	}
+
+	return f.workbook.addSheet(name, getCSVHeaders(first, includeValues...), rows)
+}
+
+// xlsxRow returns the cells of the CSV columns of v, keeping numbers and booleans as such so that
+// spreadsheets can sort and sum them.
+func xlsxRow(v any, includeValues ...bool) []any {
+	fields := csvFields(v, includeValues...)
+
+	row := make([]any, 0, len(fields))
+	for _, field := range fields {
+		row = append(row, cellValue(field.value))
+	}
+
+	return row
+}
+
+func cellValue(value reflect.Value) any {
+	// fields of a nil embedded struct are left empty
+	if !value.IsValid() {
+		return ""
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint()
+	case reflect.Float32, reflect.Float64:
+		return value.Float()
+	case reflect.String:
+		return value.String()
+	default:
+		return fmt.Sprintf("%v", value.Interface())
+	}
+}
+
+func (f *XLSXFormatter) FormatGroups(groups []*gitlab.Group) error {
+	if f.instanceLabel != nil {
+		return writeSheet(f, "Groups", labelGroups(groups, f.instanceLabel))
+	}
+
+	return writeSheet(f, "Groups", groups)
+}
+
+func (f *XLSXFormatter) FormatProjects(projects []*gitlab.Project) error {
+	if f.instanceLabel != nil {
+		return writeSheet(f, "Projects", labelProjects(projects, f.instanceLabel))
+	}
+
+	return writeSheet(f, "Projects", projects)
+}
+
+func (f *XLSXFormatter) FormatGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup) error {
+	return writeSheet(f, "Group Tokens", tokens)
+}
+
+func (f *XLSXFormatter) FormatProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject) error {
+	return writeSheet(f, "Project Tokens", tokens)
+}
+
+func (f *XLSXFormatter) FormatPipelineTriggers(triggers []*glclient.PipelineTriggerWithProject) error {
+	return writeSheet(f, "Pipeline Triggers", triggers)
+}
+
+func (f *XLSXFormatter) FormatProjectVariables(
+	variables []*glclient.ProjectVariableWithProject, includeValues bool,
+) error {
+	return writeSheet(f, "Project Variables", variables, includeValues)
+}
+
+func (f *XLSXFormatter) FormatGroupVariables(variables []*glclient.GroupVariableWithGroup, includeValues bool) error {
+	return writeSheet(f, "Group Variables", variables, includeValues)
+}
+
+func (f *XLSXFormatter) FormatUnifiedVariables(variables []*glclient.VariableWithSource, includeValues bool) error {
+	return writeSheet(f, "Variables", variables, includeValues)
+}
+
+func (f *XLSXFormatter) FormatBadges(badges []*glclient.BadgeWithSource) error {
+	return writeSheet(f, "Badges", badges)
+}
+
+func (f *XLSXFormatter) FormatPushRules(rules []*glclient.PushRulesWithSource) error {
+	return writeSheet(f, "Push Rules", rules)
+}
+
+func (f *XLSXFormatter) FormatAccessRequests(requests []*glclient.AccessRequestWithSource) error {
+	return writeSheet(f, "Access Requests", requests)
+}
+
+func (f *XLSXFormatter) FormatTwoFactor(statuses []*glclient.GroupTwoFactor) error {
+	return writeSheet(f, "Two-Factor", statuses)
+}
+
+func (f *XLSXFormatter) FormatProjectStorage(storage []*glclient.ProjectStorage) error {
+	return writeSheet(f, "Storage", storage)
+}
+
+func (f *XLSXFormatter) FormatProjectRegistries(registries []*glclient.ProjectRegistry) error {
+	return writeSheet(f, "Registries", registries)
+}
+
+func (f *XLSXFormatter) FormatComputeUsage(usage []*glclient.GroupComputeUsage) error {
+	return writeSheet(f, "Compute Usage", usage)
+}
+
+func (f *XLSXFormatter) FormatCISettings(settings []*glclient.ProjectCISettings) error {
+	return writeSheet(f, "CI Settings", settings)
+}
+
+func (f *XLSXFormatter) FormatDefaultBranches(branches []*glclient.ProjectDefaultBranch) error {
+	return writeSheet(f, "Default Branches", branches)
+}
+
+func (f *XLSXFormatter) FormatProjectActivity(activity []*glclient.ProjectActivity) error {
+	return writeSheet(f, "Activity", activity)
+}
+
+func (f *XLSXFormatter) FormatIntegrations(integrations []*glclient.ProjectIntegration) error {
+	return writeSheet(f, "Integrations", integrations)
+}
+
+func (f *XLSXFormatter) FormatJobTokenScopes(scopes []*glclient.ProjectJobTokenScope) error {
+	return writeSheet(f, "Job Token Scopes", scopes)
+}
+
+func (f *XLSXFormatter) FormatProtectedEnvironments(environments []*glclient.ProjectProtectedEnvironment) error {
+	return writeSheet(f, "Protected Environments", environments)
+}
+
+func (f *XLSXFormatter) FormatProjectRunners(runners []*glclient.ProjectRunners) error {
+	return writeSheet(f, "Runners", runners)
+}
+
+func (f *XLSXFormatter) FormatRunnerSettings(settings []*glclient.ProjectRunnerSettings) error {
+	return writeSheet(f, "Runner Settings", settings)
+}
+
+func (f *XLSXFormatter) FormatClusterAgents(agents []*glclient.ProjectClusterAgent) error {
+	return writeSheet(f, "Cluster Agents", agents)
+}
+
+func (f *XLSXFormatter) FormatSecurityConfigs(configs []*glclient.ProjectSecurityConfig) error {
+	return writeSheet(f, "Security Configuration", configs)
+}
+
+func (f *XLSXFormatter) FormatComplianceFrameworks(frameworks []*glclient.ProjectComplianceFrameworks) error {
+	return writeSheet(f, "Compliance Frameworks", frameworks)
+}
+
+func (f *XLSXFormatter) FormatProjectForks(forks []*glclient.ProjectFork) error {
+	return writeSheet(f, "Forks", forks)
+}
+
+func (f *XLSXFormatter) FormatImpersonationTokens(tokens []*glclient.ImpersonationTokenWithUser) error {
+	return writeSheet(f, "Impersonation Tokens", tokens)
+}
+
+func (f *XLSXFormatter) FormatProjectTopics(topics []*glclient.ProjectTopics) error {
+	return writeSheet(f, "Topics", topics)
+}
+
+func (f *XLSXFormatter) FormatEpics(epics []*glclient.GroupEpic) error {
+	return writeSheet(f, "Epics", epics)
+}
+
+func (f *XLSXFormatter) FormatProjectMilestones(milestones []*glclient.ProjectMilestone) error {
+	return writeSheet(f, "Project Milestones", milestones)
+}
+
+func (f *XLSXFormatter) FormatGroupMilestones(milestones []*glclient.GroupMilestone) error {
+	return writeSheet(f, "Group Milestones", milestones)
+}
+
+func (f *XLSXFormatter) FormatUnifiedMilestones(milestones []*glclient.MilestoneWithSource) error {
+	return writeSheet(f, "Milestones", milestones)
+}
+
+func (f *XLSXFormatter) FormatTokenInfo(info *glclient.TokenInfo) error {
+	return writeSheet(f, "Token", []*glclient.TokenInfo{info})
+}
+
+func (f *XLSXFormatter) FormatInstanceTokenSettings(settings *glclient.InstanceTokenSettings) error {
+	return writeSheet(f, "Instance Settings", []*glclient.InstanceTokenSettings{settings})
+}
+
+func (f *XLSXFormatter) FormatRateLimit(status *glclient.RateLimitStatus) error {
+	return writeSheet(f, "Rate Limit", []*glclient.RateLimitStatus{status})
+}
+
+func (f *XLSXFormatter) FormatChanges(changes []report.Change) error {
+	rows := make([][]any, 0, len(changes))
+	for _, change := range changes {
+		rows = append(rows, []any{string(change.Change), change.Item, strings.Join(change.Fields, " ")})
+	}
+
+	return f.workbook.addSheet("Changes", []string{"change", "item", "fields"}, rows)
+}
+
+func (f *XLSXFormatter) FormatVariableDifferences(
+	differences []*report.VariableDifference,
+	includeValues bool,
+) error {
+	headers := []string{"key", "environment_scope", "difference", "left", "right"}
+	if includeValues {
+		headers = append(headers, "left_value", "right_value")
+	}
+
+	rows := make([][]any, 0, len(differences))
+	for _, d := range differences {
+		row := []any{d.Key, d.EnvironmentScope, d.Difference, d.Left, d.Right}
+		if includeValues {
+			row = append(row, d.LeftValue, d.RightValue)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return f.workbook.addSheet("Variable Differences", headers, rows)
+}
+
+func (f *XLSXFormatter) FormatSourceCounts(counts []*report.SourceCount) error {
+	return writeSheet(f, "Rollup", counts)
+}
@@ -0,0 +1,85 @@
+package output_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestXLSXFormatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.xlsx")
+
+	file, _, err := output.CreateFile(path, false)
+	require.NoError(t, err)
+
+	workbook := output.NewWorkbook(file)
+
+	formatter, err := output.NewFormatter(output.FormatXLSX, output.WithWriter(workbook))
+	require.NoError(t, err)
+
+	require.NoError(t, formatter.FormatGroups([]*gitlab.Group{{ID: 1, Name: "org", FullPath: "org"}}))
+	require.NoError(t, formatter.FormatProjects([]*gitlab.Project{
+		{ID: 10, Name: "api", PathWithNamespace: "org/api"},
+		{ID: 11, Name: "web", PathWithNamespace: "org/web"},
+	}))
+	require.NoError(t, formatter.FormatUnifiedVariables([]*glclient.VariableWithSource{
+		{Key: "DB_PASSWORD", Value: "s3cret", Source: "project", SourcePath: "org/api"},
+	}, false))
+	require.NoError(t, formatter.FormatProjectAccessTokens(nil))
+	require.NoError(t, workbook.Close())
+
+	xlsx, err := excelize.OpenFile(path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, xlsx.Close()) })
+
+	assert.Equal(t, []string{"Groups", "Projects", "Variables", "Project Tokens"}, xlsx.GetSheetList())
+
+	rows, err := xlsx.GetRows("Projects")
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Contains(t, rows[0], "path_with_namespace")
+	assert.Contains(t, rows[2], "org/web")
+
+	style, err := xlsx.GetCellStyle("Projects", "A1")
+	require.NoError(t, err)
+
+	header, err := xlsx.GetStyle(style)
+	require.NoError(t, err)
+	require.NotNil(t, header.Font)
+	assert.True(t, header.Font.Bold)
+
+	rows, err = xlsx.GetRows("Variables")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Contains(t, rows[0], "key")
+	assert.NotContains(t, rows[0], "value")
+	assert.NotContains(t, rows[1], "s3cret")
+
+	// an empty report still gets a worksheet with its header
+	rows, err = xlsx.GetRows("Project Tokens")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Contains(t, rows[0], "project_path")
+}
+
+func TestXLSXFormatter_requiresWorkbook(t *testing.T) {
+	_, err := output.NewFormatter(output.FormatXLSX, output.WithWriter(&bytes.Buffer{}))
+	require.ErrorIs(t, err, output.ErrXLSXRequiresWorkbook)
+
+	file, _, err := output.CreateFile(filepath.Join(t.TempDir(), "ids.xlsx"), false)
+	require.NoError(t, err)
+
+	workbook := output.NewWorkbook(file)
+
+	_, err = workbook.Write([]byte("42\n"))
+	require.ErrorIs(t, err, output.ErrWorkbookText)
+	require.NoError(t, workbook.Close())
+}
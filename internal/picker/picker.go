@@ -0,0 +1,153 @@
+// Package picker lets the user choose the group a report starts from and confirm printing secrets.
+package picker
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var (
+	ErrNoGroups     = errors.New("no accessible top-level groups")
+	ErrNoSelection  = errors.New("no option selected")
+	ErrNotConfirmed = errors.New("printing secrets to the terminal was not confirmed")
+)
+
+// GroupLister lists the groups accessible to the token.
+type GroupLister interface {
+	GetAllGroups(ctx context.Context) ([]*gitlab.Group, error)
+}
+
+// Prompt asks the user to choose one of options and returns the index of the choice.
+type Prompt func(options []string) (int, error)
+
+// SelectGroup lists the accessible top-level groups, sorted by path, and returns the full path of
+// the one chosen with prompt.
+func SelectGroup(ctx context.Context, lister GroupLister, prompt Prompt) (string, error) {
+	groups, err := lister.GetAllGroups(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	var paths []string
+
+	for _, group := range groups {
+		if group.ParentID == 0 {
+			paths = append(paths, group.FullPath)
+		}
+	}
+
+	if len(paths) == 0 {
+		return "", ErrNoGroups
+	}
+
+	slices.Sort(paths)
+
+	choice, err := prompt(paths)
+	if err != nil {
+		return "", err
+	}
+
+	if choice < 0 || choice >= len(paths) {
+		return "", fmt.Errorf("%w: choice %d out of range", ErrNoSelection, choice)
+	}
+
+	return paths[choice], nil
+}
+
+// NewLinePrompt returns a Prompt that prints the numbered options to out and reads the choice from
+// in. Entering a number selects that option; any other text narrows the list to the options
+// containing it, ignoring case, and an empty line shows all options again.
+func NewLinePrompt(in io.Reader, out io.Writer) Prompt {
+	scanner := bufio.NewScanner(in)
+
+	return func(options []string) (int, error) {
+		filter := ""
+
+		for {
+			matches := filterOptions(options, filter)
+			if len(matches) == 0 {
+				fmt.Fprintf(out, "No options match %q\n", filter)
+
+				filter = ""
+
+				continue
+			}
+
+			for i, index := range matches {
+				fmt.Fprintf(out, "%3d) %s\n", i+1, options[index])
+			}
+
+			fmt.Fprint(out, "Enter a number to select, or text to filter: ")
+
+			if !scanner.Scan() {
+				fmt.Fprintln(out)
+
+				if err := scanner.Err(); err != nil {
+					return 0, fmt.Errorf("failed to read selection: %w", err)
+				}
+
+				return 0, ErrNoSelection
+			}
+
+			input := strings.TrimSpace(scanner.Text())
+
+			if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(matches) {
+				return matches[n-1], nil
+			}
+
+			filter = input
+		}
+	}
+}
+
+// filterOptions returns the indexes of the options containing filter, ignoring case.
+func filterOptions(options []string, filter string) []int {
+	filter = strings.ToLower(filter)
+
+	var matches []int
+
+	for i, option := range options {
+		if strings.Contains(strings.ToLower(option), filter) {
+			matches = append(matches, i)
+		}
+	}
+
+	return matches
+}
+
+// ConfirmSecrets asks on out whether to print secret values to the terminal and returns
+// ErrNotConfirmed unless the answer read from in is y or yes. It asks nothing when assumeYes is set
+// or the report is not printed to a terminal, as when it is redirected or written to a file.
+func ConfirmSecrets(in io.Reader, out io.Writer, terminal, assumeYes bool) error {
+	if assumeYes || !terminal {
+		return nil
+	}
+
+	fmt.Fprint(out, "This will print secrets to your terminal, continue? [y/N] ")
+
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() {
+		fmt.Fprintln(out)
+
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+
+		return ErrNotConfirmed
+	}
+
+	switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+	case "y", "yes":
+		return nil
+	default:
+		return ErrNotConfirmed
+	}
+}
@@ -0,0 +1,140 @@
+package picker_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/picker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+type stubLister struct {
+	groups []*gitlab.Group
+	err    error
+}
+
+func (l stubLister) GetAllGroups(context.Context) ([]*gitlab.Group, error) {
+	return l.groups, l.err
+}
+
+func TestSelectGroup(t *testing.T) {
+	lister := stubLister{groups: []*gitlab.Group{
+		{ID: 3, FullPath: "platform"},
+		{ID: 4, FullPath: "platform/infra", ParentID: 3},
+		{ID: 1, FullPath: "backend"},
+	}}
+
+	t.Run("returns the path of the chosen top-level group", func(t *testing.T) {
+		var offered []string
+
+		groupID, err := picker.SelectGroup(t.Context(), lister, func(options []string) (int, error) {
+			offered = options
+
+			return 1, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"backend", "platform"}, offered)
+		assert.Equal(t, "platform", groupID)
+	})
+
+	t.Run("passes on prompt errors", func(t *testing.T) {
+		_, err := picker.SelectGroup(t.Context(), lister, func([]string) (int, error) {
+			return 0, picker.ErrNoSelection
+		})
+		require.ErrorIs(t, err, picker.ErrNoSelection)
+	})
+
+	t.Run("rejects a choice out of range", func(t *testing.T) {
+		_, err := picker.SelectGroup(t.Context(), lister, func([]string) (int, error) { return 2, nil })
+		require.ErrorIs(t, err, picker.ErrNoSelection)
+	})
+
+	t.Run("fails without top-level groups", func(t *testing.T) {
+		_, err := picker.SelectGroup(t.Context(), stubLister{}, func([]string) (int, error) { return 0, nil })
+		require.ErrorIs(t, err, picker.ErrNoGroups)
+	})
+
+	t.Run("fails when the groups cannot be listed", func(t *testing.T) {
+		listErr := errors.New("unauthorized")
+
+		_, err := picker.SelectGroup(t.Context(), stubLister{err: listErr}, func([]string) (int, error) { return 0, nil })
+		require.ErrorIs(t, err, listErr)
+	})
+}
+
+func TestNewLinePrompt(t *testing.T) {
+	options := []string{"backend", "platform", "platform-tools"}
+
+	t.Run("selects by number", func(t *testing.T) {
+		var out strings.Builder
+
+		choice, err := picker.NewLinePrompt(strings.NewReader("2\n"), &out)(options)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, choice)
+		assert.Contains(t, out.String(), "  3) platform-tools\n")
+	})
+
+	t.Run("numbers refer to the filtered list", func(t *testing.T) {
+		var out strings.Builder
+
+		choice, err := picker.NewLinePrompt(strings.NewReader("TOOLS\n1\n"), &out)(options)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, choice)
+	})
+
+	t.Run("shows all options again when nothing matches", func(t *testing.T) {
+		var out strings.Builder
+
+		choice, err := picker.NewLinePrompt(strings.NewReader("frontend\n1\n"), &out)(options)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, choice)
+		assert.Contains(t, out.String(), `No options match "frontend"`)
+	})
+
+	t.Run("fails at the end of input", func(t *testing.T) {
+		var out strings.Builder
+
+		_, err := picker.NewLinePrompt(strings.NewReader("back\n"), &out)(options)
+		require.ErrorIs(t, err, picker.ErrNoSelection)
+	})
+}
+
+func TestConfirmSecrets(t *testing.T) {
+	t.Run("skips the prompt with --yes", func(t *testing.T) {
+		var out strings.Builder
+
+		require.NoError(t, picker.ConfirmSecrets(strings.NewReader(""), &out, true, true))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("skips the prompt when not printing to a terminal", func(t *testing.T) {
+		var out strings.Builder
+
+		require.NoError(t, picker.ConfirmSecrets(strings.NewReader(""), &out, false, false))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("continues when confirmed", func(t *testing.T) {
+		var out strings.Builder
+
+		require.NoError(t, picker.ConfirmSecrets(strings.NewReader("Yes\n"), &out, true, false))
+		assert.Equal(t, "This will print secrets to your terminal, continue? [y/N] ", out.String())
+	})
+
+	t.Run("fails by default", func(t *testing.T) {
+		for _, answer := range []string{"\n", "n\n", "sure\n", ""} {
+			var out strings.Builder
+
+			err := picker.ConfirmSecrets(strings.NewReader(answer), &out, true, false)
+			require.ErrorIs(t, err, picker.ErrNotConfirmed, "answer %q", answer)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+var ErrInvalidAccessLevel = errors.New(
+	"invalid access level, use a numeric level or guest, planner, reporter, developer, maintainer, or owner")
+
+// accessLevels maps role names to the access levels GitLab grants them.
+var accessLevels = map[string]gitlab.AccessLevelValue{
+	"none":       gitlab.NoPermissions,
+	"minimal":    gitlab.MinimalAccessPermissions,
+	"guest":      gitlab.GuestPermissions,
+	"planner":    gitlab.PlannerPermissions,
+	"reporter":   gitlab.ReporterPermissions,
+	"developer":  gitlab.DeveloperPermissions,
+	"maintainer": gitlab.MaintainerPermissions,
+	"owner":      gitlab.OwnerPermissions,
+	"admin":      gitlab.AdminPermissions,
+}
+
+// ParseAccessLevel returns the access level of a role name, such as maintainer, or of a numeric level.
+func ParseAccessLevel(s string) (gitlab.AccessLevelValue, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if level, ok := accessLevels[s]; ok {
+		return level, nil
+	}
+
+	if level, err := strconv.Atoi(s); err == nil && level >= 0 {
+		return gitlab.AccessLevelValue(level), nil
+	}
+
+	return gitlab.NoPermissions, fmt.Errorf("%w: %q", ErrInvalidAccessLevel, s)
+}
+
+// FilterByAccessLevel returns the items whose access level is at least minLevel, preserving their order.
+func FilterByAccessLevel[T any](
+	items []T,
+	minLevel gitlab.AccessLevelValue,
+	level func(T) gitlab.AccessLevelValue,
+) []T {
+	if minLevel <= gitlab.NoPermissions {
+		return items
+	}
+
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if level(item) >= minLevel {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
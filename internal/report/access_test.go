@@ -0,0 +1,95 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestParseAccessLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  gitlab.AccessLevelValue
+	}{
+		{input: "guest", want: gitlab.GuestPermissions},
+		{input: "planner", want: gitlab.PlannerPermissions},
+		{input: "reporter", want: gitlab.ReporterPermissions},
+		{input: "developer", want: gitlab.DeveloperPermissions},
+		{input: "Maintainer", want: gitlab.MaintainerPermissions},
+		{input: " owner ", want: gitlab.OwnerPermissions},
+		{input: "40", want: gitlab.MaintainerPermissions},
+		{input: "0", want: gitlab.NoPermissions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := report.ParseAccessLevel(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+
+	for _, input := range []string{"superuser", "-10", ""} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := report.ParseAccessLevel(input)
+			require.ErrorIs(t, err, report.ErrInvalidAccessLevel)
+		})
+	}
+}
+
+func TestFilterByAccessLevel(t *testing.T) {
+	levels := []gitlab.AccessLevelValue{
+		gitlab.OwnerPermissions,
+		gitlab.GuestPermissions,
+		gitlab.MaintainerPermissions,
+		gitlab.ReporterPermissions,
+		gitlab.DeveloperPermissions,
+		gitlab.PlannerPermissions,
+	}
+
+	level := func(l gitlab.AccessLevelValue) gitlab.AccessLevelValue { return l }
+
+	tests := []struct {
+		name     string
+		minLevel gitlab.AccessLevelValue
+		want     []gitlab.AccessLevelValue
+	}{
+		{name: "no minimum", minLevel: gitlab.NoPermissions, want: levels},
+		{name: "guest", minLevel: gitlab.GuestPermissions, want: levels},
+		{
+			name:     "reporter",
+			minLevel: gitlab.ReporterPermissions,
+			want: []gitlab.AccessLevelValue{
+				gitlab.OwnerPermissions,
+				gitlab.MaintainerPermissions,
+				gitlab.ReporterPermissions,
+				gitlab.DeveloperPermissions,
+			},
+		},
+		{
+			name:     "developer",
+			minLevel: gitlab.DeveloperPermissions,
+			want: []gitlab.AccessLevelValue{
+				gitlab.OwnerPermissions,
+				gitlab.MaintainerPermissions,
+				gitlab.DeveloperPermissions,
+			},
+		},
+		{
+			name:     "maintainer",
+			minLevel: gitlab.MaintainerPermissions,
+			want:     []gitlab.AccessLevelValue{gitlab.OwnerPermissions, gitlab.MaintainerPermissions},
+		},
+		{name: "owner", minLevel: gitlab.OwnerPermissions, want: []gitlab.AccessLevelValue{gitlab.OwnerPermissions}},
+		{name: "above owner", minLevel: gitlab.AdminPermissions, want: []gitlab.AccessLevelValue{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, report.FilterByAccessLevel(levels, tt.minLevel, level))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// DefaultBranchMismatches returns the projects whose default branch is not expected, including
+// projects without a default branch.
+func DefaultBranchMismatches(
+	branches []*glclient.ProjectDefaultBranch,
+	expected string,
+) []*glclient.ProjectDefaultBranch {
+	mismatches := make([]*glclient.ProjectDefaultBranch, 0, len(branches))
+
+	for _, project := range branches {
+		if project.DefaultBranch != expected {
+			mismatches = append(mismatches, project)
+		}
+	}
+
+	return mismatches
+}
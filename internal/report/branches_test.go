@@ -0,0 +1,21 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultBranchMismatches(t *testing.T) {
+	branches := []*glclient.ProjectDefaultBranch{
+		{ProjectPath: "org/api", DefaultBranch: "main"},
+		{ProjectPath: "org/legacy", DefaultBranch: "master"},
+		{ProjectPath: "org/empty"},
+	}
+
+	mismatches := report.DefaultBranchMismatches(branches, "main")
+
+	assert.Equal(t, []*glclient.ProjectDefaultBranch{branches[1], branches[2]}, mismatches)
+}
@@ -0,0 +1,17 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// FilterDisconnectedAgents returns the Kubernetes agents that are not connected, including those
+// that never connected, preserving their order.
+func FilterDisconnectedAgents(agents []*glclient.ProjectClusterAgent) []*glclient.ProjectClusterAgent {
+	filtered := make([]*glclient.ProjectClusterAgent, 0, len(agents))
+
+	for _, agent := range agents {
+		if agent.ConnectionStatus != glclient.AgentConnected {
+			filtered = append(filtered, agent)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,20 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterDisconnectedAgents(t *testing.T) {
+	agents := []*glclient.ProjectClusterAgent{
+		{ProjectPath: "org/infra", Name: "production", ConnectionStatus: glclient.AgentConnected},
+		{ProjectPath: "org/infra", Name: "staging", ConnectionStatus: glclient.AgentNotConnected},
+		{ProjectPath: "org/sandbox", Name: "dev", ConnectionStatus: glclient.AgentNeverConnected},
+	}
+
+	assert.Equal(t, agents[1:], report.FilterDisconnectedAgents(agents))
+	assert.Empty(t, report.FilterDisconnectedAgents(agents[:1]))
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+// valueField is the JSON field holding a variable's value, which is only compared when values are shown.
+const valueField = "value"
+
+// GroupAccessTokens matches group access tokens by ID. Last use and the numeric group ID,
+// which only duplicates the group path, are not compared.
+var GroupAccessTokens = Comparison[*glclient.GroupAccessTokenWithGroup]{
+	Key: func(t *glclient.GroupAccessTokenWithGroup) string { return strconv.Itoa(t.ID) },
+	Name: func(t *glclient.GroupAccessTokenWithGroup) string {
+		return fmt.Sprintf("%s: %s (ID %d)", t.GroupPath, t.Name, t.ID)
+	},
+	Ignore: []string{"last_used_at", "group_id"},
+}
+
+// ProjectAccessTokens matches project access tokens by ID. Last use and the numeric project ID,
+// which only duplicates the project path, are not compared.
+var ProjectAccessTokens = Comparison[*glclient.ProjectAccessTokenWithProject]{
+	Key: func(t *glclient.ProjectAccessTokenWithProject) string { return strconv.Itoa(t.ID) },
+	Name: func(t *glclient.ProjectAccessTokenWithProject) string {
+		return fmt.Sprintf("%s: %s (ID %d)", t.ProjectPath, t.Name, t.ID)
+	},
+	Ignore: []string{"last_used_at", "project_id"},
+}
+
+// PipelineTriggers matches pipeline trigger tokens by ID. Last use and the numeric project ID,
+// which only duplicates the project path, are not compared.
+var PipelineTriggers = Comparison[*glclient.PipelineTriggerWithProject]{
+	Key: func(t *glclient.PipelineTriggerWithProject) string { return strconv.Itoa(t.ID) },
+	Name: func(t *glclient.PipelineTriggerWithProject) string {
+		return fmt.Sprintf("%s: %s (ID %d)", t.ProjectPath, t.Description, t.ID)
+	},
+	Ignore: []string{"last_used", "project_id"},
+}
+
+// ImpersonationTokens matches impersonation tokens by ID. Last use and the numeric user ID, which
+// only duplicates the username, are not compared.
+var ImpersonationTokens = Comparison[*glclient.ImpersonationTokenWithUser]{
+	Key: func(t *glclient.ImpersonationTokenWithUser) string { return strconv.Itoa(t.ID) },
+	Name: func(t *glclient.ImpersonationTokenWithUser) string {
+		return fmt.Sprintf("%s: %s (ID %d)", t.Username, t.Name, t.ID)
+	},
+	Ignore: []string{"last_used_at", "user_id"},
+}
+
+// ProjectVariables matches project variables by project path, key, and environment scope.
+// Values are compared only when includeValues is set.
+func ProjectVariables(includeValues bool) Comparison[*glclient.ProjectVariableWithProject] {
+	name := func(v *glclient.ProjectVariableWithProject) string {
+		return variableName(v.ProjectPath, v.Key, v.EnvironmentScope)
+	}
+
+	return Comparison[*glclient.ProjectVariableWithProject]{
+		Key:    name,
+		Name:   name,
+		Ignore: variableIgnore(includeValues, "project_id"),
+	}
+}
+
+// GroupVariables matches group variables by group path, key, and environment scope.
+// Values are compared only when includeValues is set.
+func GroupVariables(includeValues bool) Comparison[*glclient.GroupVariableWithGroup] {
+	name := func(v *glclient.GroupVariableWithGroup) string {
+		return variableName(v.GroupFullPath, v.Key, v.EnvironmentScope)
+	}
+
+	return Comparison[*glclient.GroupVariableWithGroup]{
+		Key:    name,
+		Name:   name,
+		Ignore: variableIgnore(includeValues, "group_id"),
+	}
+}
+
+// UnifiedVariables matches project and group variables by source, path, key, and environment scope.
+// Values are compared only when includeValues is set.
+func UnifiedVariables(includeValues bool) Comparison[*glclient.VariableWithSource] {
+	return Comparison[*glclient.VariableWithSource]{
+		Key: func(v *glclient.VariableWithSource) string {
+			return v.Source + " " + variableName(v.SourcePath, v.Key, v.EnvironmentScope)
+		},
+		Name: func(v *glclient.VariableWithSource) string {
+			return variableName(v.SourcePath, v.Key, v.EnvironmentScope)
+		},
+		Ignore: variableIgnore(includeValues, "source_id"),
+	}
+}
+
+func variableName(path, key, scope string) string {
+	return fmt.Sprintf("%s: %s (environment: %s)", path, key, scope)
+}
+
+// variableIgnore returns the fields of a variable that are not compared: the numeric ID of its group
+// or project, which only duplicates the path, and the value unless values are shown.
+func variableIgnore(includeValues bool, idField string) []string {
+	if includeValues {
+		return []string{idField}
+	}
+
+	return []string{idField, valueField}
+}
@@ -0,0 +1,17 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// FilterUnframed returns the projects without a compliance framework, preserving their order.
+// Projects whose frameworks could not be read are left out, since they may have one.
+func FilterUnframed(frameworks []*glclient.ProjectComplianceFrameworks) []*glclient.ProjectComplianceFrameworks {
+	filtered := make([]*glclient.ProjectComplianceFrameworks, 0, len(frameworks))
+
+	for _, project := range frameworks {
+		if project.FrameworksAvailable && len(project.Frameworks) == 0 {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,20 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterUnframed(t *testing.T) {
+	frameworks := []*glclient.ProjectComplianceFrameworks{
+		{ProjectPath: "org/api", Frameworks: []string{"SOC 2"}, FrameworksAvailable: true},
+		{ProjectPath: "org/web", Frameworks: []string{}, FrameworksAvailable: true},
+		{ProjectPath: "org/legacy", Frameworks: []string{}},
+	}
+
+	assert.Equal(t, []*glclient.ProjectComplianceFrameworks{frameworks[1]}, report.FilterUnframed(frameworks))
+	assert.Empty(t, report.FilterUnframed(nil))
+}
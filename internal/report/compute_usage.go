@@ -0,0 +1,17 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// OverComputeLimit returns the groups that used more compute minutes than their quota, preserving
+// their order.
+func OverComputeLimit(usage []*glclient.GroupComputeUsage) []*glclient.GroupComputeUsage {
+	filtered := make([]*glclient.GroupComputeUsage, 0, len(usage))
+
+	for _, group := range usage {
+		if group.OverLimit {
+			filtered = append(filtered, group)
+		}
+	}
+
+	return filtered
+}
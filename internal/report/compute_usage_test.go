@@ -0,0 +1,20 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverComputeLimit(t *testing.T) {
+	usage := []*glclient.GroupComputeUsage{
+		{GroupPath: "acme", MinutesUsed: 420, MinutesLimit: 400, OverLimit: true},
+		{GroupPath: "beta", MinutesUsed: 120, MinutesLimit: 400},
+		{GroupPath: "gamma", MinutesUsed: 9000},
+	}
+
+	assert.Equal(t, []*glclient.GroupComputeUsage{usage[0]}, report.OverComputeLimit(usage))
+	assert.Empty(t, report.OverComputeLimit(nil))
+}
@@ -0,0 +1,167 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ChangeType tells how an item differs between a baseline report and the current one.
+type ChangeType string
+
+const (
+	// Added marks items that are only in the current report.
+	Added ChangeType = "added"
+	// Removed marks items that are only in the baseline report.
+	Removed ChangeType = "removed"
+	// Changed marks items that are in both reports with different fields.
+	Changed ChangeType = "changed"
+)
+
+var ErrInvalidBaseline = errors.New("invalid baseline report")
+
+// Change describes one item that differs between a baseline report and the current one.
+// Only the names of changed fields are kept, so that values never end up in the output.
+type Change struct {
+	Change ChangeType `json:"change"`
+	Item   string     `json:"item"`
+	Fields []string   `json:"fields,omitempty"`
+}
+
+// Comparison tells how the items of one report type are matched and compared.
+type Comparison[T any] struct {
+	// Key identifies an item across runs.
+	Key func(T) string
+	// Name describes an item to the reader.
+	Name func(T) string
+	// Ignore lists JSON fields that are not compared, such as timestamps that change on every use.
+	Ignore []string
+}
+
+// Diff compares the current items with the baseline ones, matching them by key and comparing
+// their JSON fields. Changes are sorted by item name, then by change type.
+func Diff[T any](baseline, current []T, cmp Comparison[T]) ([]Change, error) {
+	before := make(map[string]T, len(baseline))
+	for _, item := range baseline {
+		before[cmp.Key(item)] = item
+	}
+
+	var changes []Change
+
+	seen := make(map[string]bool, len(current))
+
+	for _, item := range current {
+		key := cmp.Key(item)
+		seen[key] = true
+
+		old, ok := before[key]
+		if !ok {
+			changes = append(changes, Change{Change: Added, Item: cmp.Name(item)})
+
+			continue
+		}
+
+		fields, err := changedFields(old, item, cmp.Ignore)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(fields) > 0 {
+			changes = append(changes, Change{Change: Changed, Item: cmp.Name(item), Fields: fields})
+		}
+	}
+
+	for _, item := range baseline {
+		if !seen[cmp.Key(item)] {
+			changes = append(changes, Change{Change: Removed, Item: cmp.Name(item)})
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b Change) int {
+		if c := strings.Compare(a.Item, b.Item); c != 0 {
+			return c
+		}
+
+		return strings.Compare(string(a.Change), string(b.Change))
+	})
+
+	return changes, nil
+}
+
+// reportTypeField is the JSON field naming the report type, which is the same for all items of a
+// comparison but missing from baselines saved before it was introduced.
+const reportTypeField = "report_type"
+
+// changedFields returns the sorted names of the JSON fields that differ between two items.
+func changedFields[T any](before, after T, ignore []string) ([]string, error) {
+	ignore = append(slices.Clip(ignore), reportTypeField)
+
+	a, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+
+	for name, value := range b {
+		if !slices.Contains(ignore, name) && !reflect.DeepEqual(a[name], value) {
+			fields = append(fields, name)
+		}
+	}
+
+	for name := range a {
+		if _, ok := b[name]; !ok && !slices.Contains(ignore, name) {
+			fields = append(fields, name)
+		}
+	}
+
+	slices.Sort(fields)
+
+	return fields, nil
+}
+
+func jsonFields(item any) (map[string]any, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode item for comparison: %w", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode item for comparison: %w", err)
+	}
+
+	return fields, nil
+}
+
+// LoadBaseline reads a JSON report saved by an earlier run. Both bare arrays and reports wrapped
+// in an envelope with an items field are accepted.
+func LoadBaseline[T any](path string) ([]T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var items []T
+	if err := json.Unmarshal(data, &items); err == nil {
+		return items, nil
+	}
+
+	var wrapped struct {
+		Items *[]T `json:"items"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil || wrapped.Items == nil {
+		return nil, fmt.Errorf("%w: %s is not a JSON report of this type", ErrInvalidBaseline, path)
+	}
+
+	return *wrapped.Items, nil
+}
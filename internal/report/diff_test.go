@@ -0,0 +1,186 @@
+package report_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func groupToken(id int, name string, scopes ...string) *glclient.GroupAccessTokenWithGroup {
+	return &glclient.GroupAccessTokenWithGroup{
+		GroupAccessToken: &gitlab.GroupAccessToken{
+			PersonalAccessToken: gitlab.PersonalAccessToken{ID: id, Name: name, Scopes: scopes, Active: true},
+		},
+		GroupPath: "backend",
+	}
+}
+
+func groupVariable(key, scope, value string) *glclient.GroupVariableWithGroup {
+	return &glclient.GroupVariableWithGroup{
+		GroupVariable: &gitlab.GroupVariable{
+			Key:              key,
+			Value:            value,
+			EnvironmentScope: scope,
+		},
+		GroupFullPath: "backend",
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("reports additions, removals, and field changes", func(t *testing.T) {
+		baseline := []*glclient.GroupAccessTokenWithGroup{
+			groupToken(1, "deploy", "read_api"),
+			groupToken(2, "old", "api"),
+			groupToken(3, "stable", "read_api"),
+		}
+		current := []*glclient.GroupAccessTokenWithGroup{
+			groupToken(1, "deploy", "api"),
+			groupToken(3, "stable", "read_api"),
+			groupToken(4, "new", "read_api"),
+		}
+
+		changes, err := report.Diff(baseline, current, report.GroupAccessTokens)
+		require.NoError(t, err)
+
+		assert.Equal(t, []report.Change{
+			{Change: report.Changed, Item: "backend: deploy (ID 1)", Fields: []string{"scopes"}},
+			{Change: report.Added, Item: "backend: new (ID 4)"},
+			{Change: report.Removed, Item: "backend: old (ID 2)"},
+		}, changes)
+	})
+
+	t.Run("ignores the report type missing from older baselines", func(t *testing.T) {
+		current := groupToken(1, "deploy", "read_api")
+		current.ReportType = glclient.ReportTypeGroupAccessToken
+
+		changes, err := report.Diff(
+			[]*glclient.GroupAccessTokenWithGroup{groupToken(1, "deploy", "read_api")},
+			[]*glclient.GroupAccessTokenWithGroup{current},
+			report.GroupAccessTokens,
+		)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("matches tokens by ID across renames", func(t *testing.T) {
+		changes, err := report.Diff(
+			[]*glclient.GroupAccessTokenWithGroup{groupToken(1, "deploy", "api")},
+			[]*glclient.GroupAccessTokenWithGroup{groupToken(1, "deploy-renamed", "api")},
+			report.GroupAccessTokens)
+		require.NoError(t, err)
+
+		require.Len(t, changes, 1)
+		assert.Equal(t, report.Changed, changes[0].Change)
+		assert.Equal(t, []string{"name"}, changes[0].Fields)
+	})
+
+	t.Run("ignores last use of tokens", func(t *testing.T) {
+		used := groupToken(1, "deploy", "api")
+		lastUsed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		used.LastUsedAt = &lastUsed
+
+		changes, err := report.Diff(
+			[]*glclient.GroupAccessTokenWithGroup{groupToken(1, "deploy", "api")},
+			[]*glclient.GroupAccessTokenWithGroup{used},
+			report.GroupAccessTokens)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("matches variables by key, scope, and path", func(t *testing.T) {
+		baseline := []*glclient.GroupVariableWithGroup{
+			groupVariable("TOKEN", "*", "old"),
+			groupVariable("TOKEN", "production", "same"),
+		}
+		current := []*glclient.GroupVariableWithGroup{
+			groupVariable("TOKEN", "*", "new"),
+			groupVariable("TOKEN", "staging", "same"),
+		}
+
+		changes, err := report.Diff(baseline, current, report.GroupVariables(true))
+		require.NoError(t, err)
+
+		assert.Equal(t, []report.Change{
+			{Change: report.Changed, Item: "backend: TOKEN (environment: *)", Fields: []string{"value"}},
+			{Change: report.Removed, Item: "backend: TOKEN (environment: production)"},
+			{Change: report.Added, Item: "backend: TOKEN (environment: staging)"},
+		}, changes)
+	})
+
+	t.Run("ignores values unless they are included", func(t *testing.T) {
+		changes, err := report.Diff(
+			[]*glclient.GroupVariableWithGroup{groupVariable("TOKEN", "*", "old")},
+			[]*glclient.GroupVariableWithGroup{groupVariable("TOKEN", "*", "new")},
+			report.GroupVariables(false))
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("ignores numeric group and project IDs", func(t *testing.T) {
+		token := groupToken(1, "deploy", "api")
+		token.GroupID = 42
+
+		changes, err := report.Diff(
+			[]*glclient.GroupAccessTokenWithGroup{groupToken(1, "deploy", "api")},
+			[]*glclient.GroupAccessTokenWithGroup{token},
+			report.GroupAccessTokens)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+
+		variable := groupVariable("TOKEN", "*", "same")
+		variable.GroupID = 42
+
+		changes, err = report.Diff(
+			[]*glclient.GroupVariableWithGroup{groupVariable("TOKEN", "*", "same")},
+			[]*glclient.GroupVariableWithGroup{variable},
+			report.GroupVariables(true))
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
+func TestLoadBaseline(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "baseline.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	t.Run("reads a bare array", func(t *testing.T) {
+		tokens, err := report.LoadBaseline[*glclient.GroupAccessTokenWithGroup](
+			write(t, `[{"id": 1, "name": "deploy", "group_path": "backend"}]`))
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, 1, tokens[0].ID)
+		assert.Equal(t, "backend", tokens[0].GroupPath)
+	})
+
+	t.Run("reads an envelope", func(t *testing.T) {
+		tokens, err := report.LoadBaseline[*glclient.GroupAccessTokenWithGroup](
+			write(t, `{"total": 1, "items": [{"id": 7, "name": "deploy"}]}`))
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, 7, tokens[0].ID)
+	})
+
+	t.Run("rejects other content", func(t *testing.T) {
+		_, err := report.LoadBaseline[*glclient.GroupAccessTokenWithGroup](write(t, `{"id": 1}`))
+		require.ErrorIs(t, err, report.ErrInvalidBaseline)
+	})
+
+	t.Run("reports missing files", func(t *testing.T) {
+		_, err := report.LoadBaseline[*glclient.GroupAccessTokenWithGroup](
+			filepath.Join(t.TempDir(), "missing.json"))
+		require.ErrorContains(t, err, "failed to read baseline")
+	})
+}
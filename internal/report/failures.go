@@ -0,0 +1,70 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+// FailureSummary holds the failed fetches of one kind of resource, "group" or "project", and how
+// many resources of that kind were fetched.
+type FailureSummary struct {
+	Kind      string
+	Attempted int
+	Failures  []glclient.Failure
+}
+
+// SummarizeFailures groups failures by kind, sorted by kind, with the number of resources attempted
+// of each kind.
+func SummarizeFailures(failures []glclient.Failure, attempted map[string]int) []FailureSummary {
+	var summaries []FailureSummary
+
+	for _, failure := range failures {
+		i := slices.IndexFunc(summaries, func(s FailureSummary) bool { return s.Kind == failure.Kind })
+		if i < 0 {
+			summaries = append(summaries, FailureSummary{Kind: failure.Kind, Attempted: attempted[failure.Kind]})
+			i = len(summaries) - 1
+		}
+
+		summaries[i].Failures = append(summaries[i].Failures, failure)
+	}
+
+	slices.SortFunc(summaries, func(a, b FailureSummary) int { return strings.Compare(a.Kind, b.Kind) })
+
+	return summaries
+}
+
+// Truncated reports whether Format leaves out some of the failures when listing at most limit.
+func (s FailureSummary) Truncated(limit int) bool {
+	return limit > 0 && len(s.Failures) > limit
+}
+
+// Format renders the summary as a line such as "2 of 40 projects failed:", followed by one indented
+// line with the path and reason of each failure, at most limit of them unless limit is 0.
+func (s FailureSummary) Format(limit int) string {
+	noun := s.Kind + "s"
+	if s.Attempted == 1 {
+		noun = s.Kind
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d of %d %s failed:\n", len(s.Failures), max(s.Attempted, len(s.Failures)), noun)
+
+	shown := s.Failures
+	if s.Truncated(limit) {
+		shown = shown[:limit]
+	}
+
+	for _, failure := range shown {
+		fmt.Fprintf(&b, "  %s (%s)\n", failure.Path, failure.Reason)
+	}
+
+	if s.Truncated(limit) {
+		fmt.Fprintf(&b, "  and %d more\n", len(s.Failures)-limit)
+	}
+
+	return b.String()
+}
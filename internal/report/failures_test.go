@@ -0,0 +1,56 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeFailures(t *testing.T) {
+	failures := []glclient.Failure{
+		{Kind: "project", Path: "org/api", Reason: "project variables: 500 Internal Server Error"},
+		{Kind: "group", Path: "org", Reason: "group variables: 502 Bad Gateway"},
+		{Kind: "project", Path: "org/web", Reason: "project variables: 503 Service Unavailable"},
+		{Kind: "project", Path: "org/worker", Reason: "project variables: connection reset by peer"},
+	}
+
+	summaries := report.SummarizeFailures(failures, map[string]int{"group": 1, "project": 40})
+	require.Len(t, summaries, 2)
+
+	t.Run("groups the failures by kind", func(t *testing.T) {
+		assert.Equal(t, "group", summaries[0].Kind)
+		assert.Equal(t, 1, summaries[0].Attempted)
+		assert.Len(t, summaries[0].Failures, 1)
+
+		assert.Equal(t, "project", summaries[1].Kind)
+		assert.Equal(t, 40, summaries[1].Attempted)
+		assert.Len(t, summaries[1].Failures, 3)
+	})
+
+	t.Run("lists the first failures", func(t *testing.T) {
+		assert.True(t, summaries[1].Truncated(2))
+		assert.Equal(t, "3 of 40 projects failed:\n"+
+			"  org/api (project variables: 500 Internal Server Error)\n"+
+			"  org/web (project variables: 503 Service Unavailable)\n"+
+			"  and 1 more\n", summaries[1].Format(2))
+	})
+
+	t.Run("lists every failure without a limit", func(t *testing.T) {
+		assert.False(t, summaries[1].Truncated(0))
+		assert.Equal(t, "3 of 40 projects failed:\n"+
+			"  org/api (project variables: 500 Internal Server Error)\n"+
+			"  org/web (project variables: 503 Service Unavailable)\n"+
+			"  org/worker (project variables: connection reset by peer)\n", summaries[1].Format(0))
+	})
+
+	t.Run("uses the singular for a single resource", func(t *testing.T) {
+		assert.Equal(t, "1 of 1 group failed:\n  org (group variables: 502 Bad Gateway)\n", summaries[0].Format(5))
+	})
+
+	t.Run("is empty without failures", func(t *testing.T) {
+		assert.Empty(t, report.SummarizeFailures(nil, map[string]int{"project": 40}))
+	})
+}
@@ -0,0 +1,38 @@
+// Package report holds post-fetch helpers shared by the report commands.
+package report
+
+// ValueFilter selects CI/CD variables by whether their value is empty.
+type ValueFilter int
+
+const (
+	// AllValues keeps every variable.
+	AllValues ValueFilter = iota
+	// OnlyEmptyValues keeps variables with an empty value.
+	OnlyEmptyValues
+	// OnlyWithValue keeps variables with a non-empty value.
+	OnlyWithValue
+)
+
+// FilterByValue returns the items matching filter, preserving their order.
+// The value func returns an item's value and whether it is known. Items with an unknown value,
+// such as hidden variables whose value GitLab never returns, are dropped by both value filters.
+func FilterByValue[T any](items []T, filter ValueFilter, value func(T) (string, bool)) []T {
+	if filter == AllValues {
+		return items
+	}
+
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		v, ok := value(item)
+		if !ok {
+			continue
+		}
+
+		if (filter == OnlyEmptyValues) == (v == "") {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,69 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+type variable struct {
+	key    string
+	value  string
+	hidden bool
+}
+
+func variableValue(v variable) (string, bool) {
+	return v.value, !v.hidden
+}
+
+func keys(variables []variable) []string {
+	result := make([]string, 0, len(variables))
+	for _, v := range variables {
+		result = append(result, v.key)
+	}
+
+	return result
+}
+
+func TestFilterByValue(t *testing.T) {
+	variables := []variable{
+		{key: "EMPTY"},
+		{key: "SET", value: "secret"},
+		{key: "HIDDEN", hidden: true},
+		{key: "ALSO_EMPTY", value: ""},
+		{key: "WHITESPACE", value: " "},
+	}
+
+	tests := []struct {
+		name   string
+		filter report.ValueFilter
+		want   []string
+	}{
+		{
+			name:   "keeps everything without a filter",
+			filter: report.AllValues,
+			want:   []string{"EMPTY", "SET", "HIDDEN", "ALSO_EMPTY", "WHITESPACE"},
+		},
+		{
+			name:   "keeps only empty values",
+			filter: report.OnlyEmptyValues,
+			want:   []string{"EMPTY", "ALSO_EMPTY"},
+		},
+		{
+			name:   "keeps only non-empty values",
+			filter: report.OnlyWithValue,
+			want:   []string{"SET", "WHITESPACE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, keys(report.FilterByValue(variables, tt.filter, variableValue)))
+		})
+	}
+
+	t.Run("handles empty input", func(t *testing.T) {
+		assert.Empty(t, report.FilterByValue(nil, report.OnlyEmptyValues, variableValue))
+	})
+}
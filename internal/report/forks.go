@@ -0,0 +1,17 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// FilterExternalForks returns the forks outside the top-level group of their source project,
+// preserving their order.
+func FilterExternalForks(forks []*glclient.ProjectFork) []*glclient.ProjectFork {
+	filtered := make([]*glclient.ProjectFork, 0, len(forks))
+
+	for _, fork := range forks {
+		if fork.External {
+			filtered = append(filtered, fork)
+		}
+	}
+
+	return filtered
+}
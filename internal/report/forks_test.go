@@ -0,0 +1,20 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterExternalForks(t *testing.T) {
+	forks := []*glclient.ProjectFork{
+		{ForkPath: "alice/api", External: true},
+		{ForkPath: "org/sandbox/api"},
+		{ForkPath: "partner/web", External: true},
+	}
+
+	assert.Equal(t, []*glclient.ProjectFork{forks[0], forks[2]}, report.FilterExternalForks(forks))
+	assert.Empty(t, report.FilterExternalForks(nil))
+}
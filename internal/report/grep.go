@@ -0,0 +1,52 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+var ErrInvalidValueRegex = errors.New("invalid value regex")
+
+// ValueMatcher reports whether a variable value matches a search.
+type ValueMatcher func(value string) bool
+
+// ValueContains returns a ValueMatcher matching values that contain substr.
+func ValueContains(substr string) ValueMatcher {
+	return func(value string) bool {
+		return strings.Contains(value, substr)
+	}
+}
+
+// ValueRegex returns a ValueMatcher matching values that match the regular expression pattern.
+func ValueRegex(pattern string) (ValueMatcher, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValueRegex, err)
+	}
+
+	return re.MatchString, nil
+}
+
+// GrepVariables returns the variables whose value matches, preserving their order. The returned
+// variables are copies with the value removed, so that a matched secret is never printed: only the
+// key, environment scope, and source of each match are reported. Hidden variables, whose values
+// GitLab never returns, never match.
+func GrepVariables(variables []*glclient.VariableWithSource, match ValueMatcher) []*glclient.VariableWithSource {
+	matches := make([]*glclient.VariableWithSource, 0, len(variables))
+
+	for _, variable := range variables {
+		if variable.Hidden || !match(variable.Value) {
+			continue
+		}
+
+		redacted := *variable
+		redacted.Value = ""
+		matches = append(matches, &redacted)
+	}
+
+	return matches
+}
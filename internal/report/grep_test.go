@@ -0,0 +1,74 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grepFixture() []*glclient.VariableWithSource {
+	return []*glclient.VariableWithSource{
+		{Key: "DEPLOY_TOKEN", Value: "glpat-leaked123", EnvironmentScope: "*", Source: "project", SourcePath: "org/api"},
+		{
+			Key: "DB_URL", Value: "postgres://app:glpat-leaked123@db", EnvironmentScope: "production",
+			Source: "project", SourcePath: "org/web",
+		},
+		{Key: "REGION", Value: "eu-west-1", EnvironmentScope: "*", Source: "group", SourcePath: "org"},
+		{Key: "OLD_TOKEN", Value: "glpat-rotated999", EnvironmentScope: "*", Source: "group", SourcePath: "org"},
+		{Key: "SECRET", Hidden: true, EnvironmentScope: "*", Source: "group", SourcePath: "org"},
+	}
+}
+
+func keysAndValues(variables []*glclient.VariableWithSource) ([]string, []string) {
+	keys := make([]string, 0, len(variables))
+	values := make([]string, 0, len(variables))
+
+	for _, v := range variables {
+		keys = append(keys, v.Key)
+		values = append(values, v.Value)
+	}
+
+	return keys, values
+}
+
+func TestGrepVariables(t *testing.T) {
+	t.Run("matches a substring and redacts the values", func(t *testing.T) {
+		variables := grepFixture()
+
+		matches := report.GrepVariables(variables, report.ValueContains("glpat-leaked123"))
+
+		keys, values := keysAndValues(matches)
+		assert.Equal(t, []string{"DEPLOY_TOKEN", "DB_URL"}, keys)
+		assert.Equal(t, []string{"", ""}, values)
+		assert.Equal(t, "production", matches[1].EnvironmentScope)
+		assert.Equal(t, "org/web", matches[1].SourcePath)
+
+		assert.Equal(t, "glpat-leaked123", variables[0].Value, "the fetched variables are left unchanged")
+	})
+
+	t.Run("matches a regex and redacts the values", func(t *testing.T) {
+		match, err := report.ValueRegex(`glpat-[a-z]+\d{3}`)
+		require.NoError(t, err)
+
+		matches := report.GrepVariables(grepFixture(), match)
+
+		keys, values := keysAndValues(matches)
+		assert.Equal(t, []string{"DEPLOY_TOKEN", "DB_URL", "OLD_TOKEN"}, keys)
+		assert.Equal(t, []string{"", "", ""}, values)
+	})
+
+	t.Run("never matches hidden variables", func(t *testing.T) {
+		matches := report.GrepVariables(grepFixture(), func(string) bool { return true })
+
+		keys, _ := keysAndValues(matches)
+		assert.NotContains(t, keys, "SECRET")
+	})
+
+	t.Run("rejects an invalid regex", func(t *testing.T) {
+		_, err := report.ValueRegex(`glpat-(`)
+		require.ErrorIs(t, err, report.ErrInvalidValueRegex)
+	})
+}
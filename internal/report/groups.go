@@ -0,0 +1,25 @@
+package report
+
+import gitlab "gitlab.com/gitlab-org/api/client-go"
+
+// FilterTopLevelGroups returns the groups without a parent group, preserving their order.
+func FilterTopLevelGroups(groups []*gitlab.Group) []*gitlab.Group {
+	return filterGroups(groups, true)
+}
+
+// FilterSubgroups returns the groups nested in a parent group, preserving their order.
+func FilterSubgroups(groups []*gitlab.Group) []*gitlab.Group {
+	return filterGroups(groups, false)
+}
+
+func filterGroups(groups []*gitlab.Group, topLevel bool) []*gitlab.Group {
+	filtered := make([]*gitlab.Group, 0, len(groups))
+
+	for _, group := range groups {
+		if (group.ParentID == 0) == topLevel {
+			filtered = append(filtered, group)
+		}
+	}
+
+	return filtered
+}
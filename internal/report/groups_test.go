@@ -0,0 +1,24 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestFilterGroupsByKind(t *testing.T) {
+	groups := []*gitlab.Group{
+		{ID: 1, FullPath: "org"},
+		{ID: 2, FullPath: "org/platform", ParentID: 1},
+		{ID: 3, FullPath: "org/platform/infra", ParentID: 2},
+		{ID: 4, FullPath: "tools"},
+	}
+
+	assert.Equal(t, []*gitlab.Group{groups[0], groups[3]}, report.FilterTopLevelGroups(groups))
+	assert.Equal(t, []*gitlab.Group{groups[1], groups[2]}, report.FilterSubgroups(groups))
+
+	assert.Empty(t, report.FilterTopLevelGroups(nil))
+	assert.Empty(t, report.FilterSubgroups(nil))
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
+)
+
+// instanceField is the name of the field of report items holding the label of their instance.
+const instanceField = "Instance"
+
+// Instance is a GitLab instance a report is run against, as listed in an instances file.
+type Instance struct {
+	URL   string `yaml:"url"`
+	Token string `yaml:"token"`
+	Label string `yaml:"label"`
+}
+
+var ErrInvalidInstances = errors.New("invalid instances file")
+
+// LoadInstances reads a YAML list of GitLab instances, each with a url, a token, and a label. Labels
+// must be unique, since they tell the instances apart in the report. The token may be left out
+// for the caller to supply a default.
+func LoadInstances(path string) ([]Instance, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read instances file: %w", err)
+	}
+
+	var instances []Instance
+	if err := yaml.Unmarshal(data, &instances); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidInstances, path, err)
+	}
+
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("%w: %s lists no instances", ErrInvalidInstances, path)
+	}
+
+	labels := make(map[string]bool, len(instances))
+
+	for i, instance := range instances {
+		switch {
+		case instance.URL == "":
+			return nil, fmt.Errorf("%w: instance %d has no url", ErrInvalidInstances, i+1)
+		case instance.Label == "":
+			return nil, fmt.Errorf("%w: instance %d has no label", ErrInvalidInstances, i+1)
+		case labels[instance.Label]:
+			return nil, fmt.Errorf("%w: label %q is used more than once", ErrInvalidInstances, instance.Label)
+		}
+
+		labels[instance.Label] = true
+	}
+
+	return instances, nil
+}
+
+// FetchInstances runs fetch against all instances concurrently and returns the items of every
+// instance in the order the instances are listed, each tagged with the label of its instance.
+// The first failing instance cancels the others and fails the whole fetch.
+func FetchInstances[T any](
+	ctx context.Context,
+	instances []Instance,
+	fetch func(ctx context.Context, instance Instance) ([]T, error),
+) ([]T, error) {
+	results := make([][]T, len(instances))
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	for i, instance := range instances {
+		g.Go(func() error {
+			items, err := fetch(gctx, instance)
+			if err != nil {
+				return fmt.Errorf("instance %s: %w", instance.Label, err)
+			}
+
+			TagInstance(items, instance.Label)
+			results[i] = items
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var all []T
+	for _, items := range results {
+		all = append(all, items...)
+	}
+
+	return all, nil
+}
+
+// TagInstance sets the Instance field of each item to label. Items without a string Instance
+// field are left unchanged.
+func TagInstance[T any](items []T, label string) {
+	for _, item := range items {
+		val := reflect.ValueOf(item)
+		if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		field := val.Elem().FieldByName(instanceField)
+		if field.IsValid() && field.Kind() == reflect.String && field.CanSet() {
+			field.SetString(label)
+		}
+	}
+}
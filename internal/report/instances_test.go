@@ -0,0 +1,148 @@
+package report_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeInstances(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "instances.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadInstances(t *testing.T) {
+	t.Run("reads the listed instances", func(t *testing.T) {
+		path := writeInstances(t, `
+- url: https://gitlab.acme.example
+  token: glpat-acme
+  label: acme
+- url: https://gitlab.globex.example
+  label: globex
+`)
+
+		instances, err := report.LoadInstances(path)
+		require.NoError(t, err)
+		assert.Equal(t, []report.Instance{
+			{URL: "https://gitlab.acme.example", Token: "glpat-acme", Label: "acme"},
+			{URL: "https://gitlab.globex.example", Label: "globex"},
+		}, instances)
+	})
+
+	invalid := []struct {
+		name    string
+		content string
+	}{
+		{name: "not a list", content: "url: https://gitlab.acme.example\n"},
+		{name: "no instances", content: "[]\n"},
+		{name: "missing url", content: "- label: acme\n"},
+		{name: "missing label", content: "- url: https://gitlab.acme.example\n"},
+		{
+			name: "duplicate label",
+			content: "- {url: https://gitlab.acme.example, label: acme}\n" +
+				"- {url: https://gitlab.globex.example, label: acme}\n",
+		},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := report.LoadInstances(writeInstances(t, tt.content))
+			require.ErrorIs(t, err, report.ErrInvalidInstances)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := report.LoadInstances(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+	})
+}
+
+func TestFetchInstances(t *testing.T) {
+	instances := []report.Instance{
+		{URL: "https://gitlab.acme.example", Label: "acme"},
+		{URL: "https://gitlab.globex.example", Label: "globex"},
+	}
+
+	t.Run("fetches every instance concurrently and tags the items", func(t *testing.T) {
+		var started sync.WaitGroup
+
+		started.Add(len(instances))
+
+		items, err := report.FetchInstances(t.Context(), instances,
+			func(_ context.Context, instance report.Instance) ([]*glclient.ProjectStorage, error) {
+				// each fetch waits for the other, which only finishes if they run concurrently
+				started.Done()
+				started.Wait()
+
+				return []*glclient.ProjectStorage{
+					{ProjectPath: "org/api", ProjectWebURL: instance.URL + "/org/api"},
+					{ProjectPath: "org/web", ProjectWebURL: instance.URL + "/org/web"},
+				}, nil
+			})
+		require.NoError(t, err)
+
+		require.Len(t, items, 4)
+
+		for i, want := range []string{"acme", "acme", "globex", "globex"} {
+			assert.Equal(t, want, items[i].Instance)
+		}
+
+		assert.Equal(t, "https://gitlab.globex.example/org/api", items[2].ProjectWebURL)
+	})
+
+	t.Run("fails with the failing instance", func(t *testing.T) {
+		errFetch := errors.New("401 Unauthorized")
+
+		_, err := report.FetchInstances(t.Context(), instances,
+			func(_ context.Context, instance report.Instance) ([]*glclient.ProjectStorage, error) {
+				if instance.Label == "globex" {
+					return nil, errFetch
+				}
+
+				return []*glclient.ProjectStorage{{ProjectPath: "org/api"}}, nil
+			})
+		require.ErrorIs(t, err, errFetch)
+		assert.Contains(t, err.Error(), "instance globex")
+	})
+}
+
+func TestTagInstance(t *testing.T) {
+	t.Run("sets the instance field", func(t *testing.T) {
+		items := []*glclient.BadgeWithSource{{Name: "coverage"}, {Name: "pipeline"}}
+
+		report.TagInstance(items, "acme")
+
+		assert.Equal(t, "acme", items[0].Instance)
+		assert.Equal(t, "acme", items[1].Instance)
+	})
+
+	t.Run("sets the instance field next to an embedded GitLab item", func(t *testing.T) {
+		tokens := []*glclient.GroupAccessTokenWithGroup{{GroupPath: "org"}}
+		variables := []*glclient.ProjectVariableWithProject{{ProjectPath: "org/api"}}
+
+		report.TagInstance(tokens, "acme")
+		report.TagInstance(variables, "globex")
+
+		assert.Equal(t, "acme", tokens[0].Instance)
+		assert.Equal(t, "globex", variables[0].Instance)
+	})
+
+	t.Run("ignores items without an instance field", func(t *testing.T) {
+		items := []*report.VariableDifference{{Key: "TOKEN"}}
+
+		assert.NotPanics(t, func() { report.TagInstance(items, "acme") })
+		assert.Equal(t, "TOKEN", items[0].Key)
+	})
+}
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+var ErrInvalidCIDR = errors.New("invalid CIDR")
+
+// ParseCIDR parses a network in CIDR notation, such as 203.0.113.0/24. A single address stands for
+// the network of just that address.
+func ParseCIDR(cidr string) (netip.Prefix, error) {
+	if !strings.Contains(cidr, "/") {
+		addr, err := netip.ParseAddr(cidr)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("%w: %w", ErrInvalidCIDR, err)
+		}
+
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %w", ErrInvalidCIDR, err)
+	}
+
+	return prefix.Masked(), nil
+}
+
+// FilterUsedFrom returns the items last used from an address within network, preserving their order.
+// Items without known addresses are left out; an invalid network, the zero Prefix, keeps all items.
+func FilterUsedFrom[T any](items []T, network netip.Prefix, ips func(T) []string) []T {
+	if !network.IsValid() {
+		return items
+	}
+
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		for _, ip := range ips(item) {
+			addr, err := netip.ParseAddr(ip)
+			if err == nil && network.Contains(addr.Unmap()) {
+				filtered = append(filtered, item)
+
+				break
+			}
+		}
+	}
+
+	return filtered
+}
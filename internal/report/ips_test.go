@@ -0,0 +1,61 @@
+package report_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCIDR(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{cidr: "203.0.113.0/24", want: "203.0.113.0/24"},
+		{cidr: "203.0.113.7/24", want: "203.0.113.0/24"},
+		{cidr: "198.51.100.7", want: "198.51.100.7/32"},
+		{cidr: "2001:db8::/32", want: "2001:db8::/32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			prefix, err := report.ParseCIDR(tt.cidr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, prefix.String())
+		})
+	}
+
+	for _, cidr := range []string{"", "203.0.113.0/33", "office"} {
+		_, err := report.ParseCIDR(cidr)
+		require.ErrorIs(t, err, report.ErrInvalidCIDR, cidr)
+	}
+}
+
+func TestFilterUsedFrom(t *testing.T) {
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		{ProjectPath: "org/api", LastUsedIPs: []string{"10.0.0.5", "203.0.113.9"}},
+		{ProjectPath: "org/web", LastUsedIPs: []string{"198.51.100.1"}},
+		{ProjectPath: "org/docs", LastUsedIPs: []string{}},
+		// GitLab did not report the addresses
+		{ProjectPath: "org/legacy"},
+		{ProjectPath: "org/ipv6", LastUsedIPs: []string{"2001:db8::1"}},
+		{ProjectPath: "org/mapped", LastUsedIPs: []string{"::ffff:203.0.113.20"}},
+		{ProjectPath: "org/garbled", LastUsedIPs: []string{"unknown"}},
+	}
+
+	ips := func(token *glclient.ProjectAccessTokenWithProject) []string { return token.LastUsedIPs }
+
+	network := netip.MustParsePrefix("203.0.113.0/24")
+	assert.Equal(t, []*glclient.ProjectAccessTokenWithProject{tokens[0], tokens[5]},
+		report.FilterUsedFrom(tokens, network, ips))
+
+	network = netip.MustParsePrefix("2001:db8::/32")
+	assert.Equal(t, []*glclient.ProjectAccessTokenWithProject{tokens[4]}, report.FilterUsedFrom(tokens, network, ips))
+
+	// no network keeps every token, including those without known addresses
+	assert.Equal(t, tokens, report.FilterUsedFrom(tokens, netip.Prefix{}, ips))
+}
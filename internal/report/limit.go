@@ -0,0 +1,10 @@
+package report
+
+// Limit returns the first n items, or all of them when n is not positive or there are no more.
+func Limit[T any](items []T, n int) []T {
+	if n <= 0 || len(items) <= n {
+		return items
+	}
+
+	return items[:n]
+}
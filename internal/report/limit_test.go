@@ -0,0 +1,18 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit(t *testing.T) {
+	items := []string{"a", "b", "c"}
+
+	assert.Equal(t, []string{"a", "b"}, report.Limit(items, 2))
+	assert.Equal(t, items, report.Limit(items, 3))
+	assert.Equal(t, items, report.Limit(items, 5))
+	assert.Equal(t, items, report.Limit(items, 0), "no limit")
+	assert.Empty(t, report.Limit([]string(nil), 2))
+}
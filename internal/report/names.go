@@ -0,0 +1,56 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidNameRegex = errors.New("invalid name regex")
+
+// NameMatcher reports whether the name of a token or trigger matches a search.
+type NameMatcher func(name string) bool
+
+// NameRegex returns a NameMatcher matching names that match the regular expression pattern. An empty
+// pattern matches every name.
+func NameRegex(pattern string) (NameMatcher, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidNameRegex, err)
+	}
+
+	return re.MatchString, nil
+}
+
+// FilterByName returns the items whose name matches, preserving their order.
+func FilterByName[T any](items []T, match NameMatcher, name func(T) string) []T {
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if match(name(item)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// FilterByTokenPrefix returns the items whose token value starts with prefix, such as glptt- for
+// pipeline trigger tokens, preserving their order. Tokens GitLab returns truncated only match on
+// the characters returned. An empty prefix keeps all items.
+func FilterByTokenPrefix[T any](items []T, prefix string, token func(T) string) []T {
+	if prefix == "" {
+		return items
+	}
+
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if strings.HasPrefix(token(item), prefix) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,86 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestFilterByName(t *testing.T) {
+	match, err := report.NameRegex(`^ci-deploy-`)
+	require.NoError(t, err)
+
+	t.Run("group access tokens", func(t *testing.T) {
+		tokens := []*glclient.GroupAccessTokenWithGroup{
+			{GroupAccessToken: &gitlab.GroupAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{
+				Name: "ci-deploy-prod",
+			}}},
+			{GroupAccessToken: &gitlab.GroupAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{
+				Name: "renovate",
+			}}},
+		}
+
+		filtered := report.FilterByName(tokens, match, func(token *glclient.GroupAccessTokenWithGroup) string {
+			return token.Name
+		})
+		assert.Equal(t, tokens[:1], filtered)
+	})
+
+	t.Run("project access tokens", func(t *testing.T) {
+		tokens := []*glclient.ProjectAccessTokenWithProject{
+			{ProjectAccessToken: &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{
+				Name: "old-ci-deploy-prod",
+			}}},
+			{ProjectAccessToken: &gitlab.ProjectAccessToken{PersonalAccessToken: gitlab.PersonalAccessToken{
+				Name: "ci-deploy-staging",
+			}}},
+		}
+
+		filtered := report.FilterByName(tokens, match, func(token *glclient.ProjectAccessTokenWithProject) string {
+			return token.Name
+		})
+		assert.Equal(t, tokens[1:], filtered)
+	})
+
+	t.Run("pipeline triggers", func(t *testing.T) {
+		triggers := []*glclient.PipelineTriggerWithProject{
+			{PipelineTrigger: &gitlab.PipelineTrigger{Description: "ci-deploy-prod"}},
+			{PipelineTrigger: &gitlab.PipelineTrigger{Description: "ci-deploy-docs"}},
+			{PipelineTrigger: &gitlab.PipelineTrigger{Description: "nightly"}},
+		}
+
+		filtered := report.FilterByName(triggers, match, func(trigger *glclient.PipelineTriggerWithProject) string {
+			return trigger.Description
+		})
+		assert.Equal(t, triggers[:2], filtered)
+	})
+
+	t.Run("an empty pattern matches every name", func(t *testing.T) {
+		matchAll, err := report.NameRegex("")
+		require.NoError(t, err)
+
+		names := []string{"ci-deploy-prod", "renovate", ""}
+		assert.Equal(t, names, report.FilterByName(names, matchAll, func(name string) string { return name }))
+	})
+
+	t.Run("rejects an invalid regex", func(t *testing.T) {
+		_, err := report.NameRegex(`ci-(`)
+		require.ErrorIs(t, err, report.ErrInvalidNameRegex)
+	})
+}
+
+func TestFilterByTokenPrefix(t *testing.T) {
+	triggers := []*glclient.PipelineTriggerWithProject{
+		{PipelineTrigger: &gitlab.PipelineTrigger{Token: "glptt-0123456789"}},
+		{PipelineTrigger: &gitlab.PipelineTrigger{Token: "a1b2c3d4e5"}},
+		{PipelineTrigger: &gitlab.PipelineTrigger{Token: "glpt"}},
+	}
+	token := func(trigger *glclient.PipelineTriggerWithProject) string { return trigger.Token }
+
+	assert.Equal(t, triggers[:1], report.FilterByTokenPrefix(triggers, "glptt-", token))
+	assert.Equal(t, triggers, report.FilterByTokenPrefix(triggers, "", token))
+}
@@ -0,0 +1,20 @@
+package report
+
+import "time"
+
+// FilterOverdue returns the items whose due date lies before the day of now, in UTC, preserving
+// their order. An item is due at the end of its due date, so it becomes overdue the following day.
+// Items without a due date are dropped; dueDate returns nil for items that cannot be overdue, such
+// as closed milestones.
+func FilterOverdue[T any](items []T, now time.Time, dueDate func(T) *time.Time) []T {
+	today := now.UTC().Truncate(24 * time.Hour)
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if due := dueDate(item); due != nil && due.Before(today) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
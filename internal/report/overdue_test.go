@@ -0,0 +1,64 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+type dueMilestone struct {
+	title string
+	open  bool
+	due   *time.Time
+}
+
+func TestFilterOverdue(t *testing.T) {
+	date := func(year int, month time.Month, day int) *time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+		return &d
+	}
+
+	milestones := []dueMilestone{
+		{title: "last month", open: true, due: date(2025, 5, 1)},
+		{title: "yesterday", open: true, due: date(2025, 5, 31)},
+		{title: "today", open: true, due: date(2025, 6, 1)},
+		{title: "next week", open: true, due: date(2025, 6, 8)},
+		{title: "closed", due: date(2025, 5, 1)},
+		{title: "undated", open: true},
+	}
+
+	dueDate := func(m dueMilestone) *time.Time {
+		if !m.open {
+			return nil
+		}
+
+		return m.due
+	}
+
+	titles := func(milestones []dueMilestone) []string {
+		result := make([]string, 0, len(milestones))
+		for _, m := range milestones {
+			result = append(result, m.title)
+		}
+
+		return result
+	}
+
+	t.Run("keeps open milestones due before today", func(t *testing.T) {
+		now := time.Date(2025, 6, 1, 23, 59, 0, 0, time.UTC)
+		assert.Equal(t, []string{"last month", "yesterday"}, titles(report.FilterOverdue(milestones, now, dueDate)))
+	})
+
+	t.Run("compares dates in UTC", func(t *testing.T) {
+		now := time.Date(2025, 6, 2, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+		assert.Equal(t, []string{"last month", "yesterday"}, titles(report.FilterOverdue(milestones, now, dueDate)))
+	})
+
+	t.Run("keeps nothing when no milestone is due", func(t *testing.T) {
+		now := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+		assert.Empty(t, report.FilterOverdue(milestones, now, dueDate))
+	})
+}
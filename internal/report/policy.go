@@ -0,0 +1,418 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidPolicy = errors.New("invalid policy file")
+
+// Operator compares a field of a report item with the value of a condition.
+type Operator string
+
+const (
+	// OpEquals matches fields equal to the value.
+	OpEquals Operator = "eq"
+	// OpNotEquals matches fields not equal to the value.
+	OpNotEquals Operator = "ne"
+	// OpContains matches strings containing the value and lists holding it.
+	OpContains Operator = "contains"
+	// OpNotContains matches strings not containing the value and lists not holding it.
+	OpNotContains Operator = "not_contains"
+	// OpMatches matches fields matching the value as a glob pattern, such as *SECRET*.
+	OpMatches Operator = "matches"
+	// OpRegex matches fields matching the value as a regular expression.
+	OpRegex Operator = "regex"
+	// OpGreaterThan matches numbers greater than the value.
+	OpGreaterThan Operator = "gt"
+	// OpLessThan matches numbers less than the value.
+	OpLessThan Operator = "lt"
+	// OpOlderThan matches times longer ago than the value, a duration such as 365d or 72h.
+	OpOlderThan Operator = "older_than"
+	// OpNewerThan matches times more recent than the value, a duration such as 365d or 72h.
+	OpNewerThan Operator = "newer_than"
+	// OpEmpty matches fields that are unset, zero, or empty.
+	OpEmpty Operator = "empty"
+	// OpNotEmpty matches fields that are set.
+	OpNotEmpty Operator = "not_empty"
+)
+
+// Policy is a set of rules evaluated against the items of a report.
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is violated by every item of its report type matching all of its conditions. A rule
+// without a report type applies to the items of any report that have its fields.
+type Rule struct {
+	Name   string      `yaml:"name"`
+	Report string      `yaml:"report"`
+	When   []Condition `yaml:"when"`
+}
+
+// Condition compares the field of a report item with the given JSON key to a value.
+type Condition struct {
+	Field    string   `yaml:"field"`
+	Operator Operator `yaml:"operator"`
+	Value    string   `yaml:"value"`
+
+	// match is compiled from the operator and value by LoadPolicy
+	match func(field reflect.Value, now time.Time) bool
+}
+
+// Violation is a report item breaking a rule of a policy.
+type Violation struct {
+	Rule string
+	Item string
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// LoadPolicy reads a YAML policy with a list of rules, each with a name, an optional report type,
+// and the conditions under which an item violates it.
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidPolicy, file, err)
+	}
+
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("%w: %s lists no rules", ErrInvalidPolicy, file)
+	}
+
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+
+		switch {
+		case rule.Name == "":
+			return nil, fmt.Errorf("%w: rule %d has no name", ErrInvalidPolicy, i+1)
+		case len(rule.When) == 0:
+			return nil, fmt.Errorf("%w: rule %q has no conditions", ErrInvalidPolicy, rule.Name)
+		}
+
+		for j := range rule.When {
+			if err := rule.When[j].compile(rule.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// compile sets the match func of a condition of the named rule.
+func (c *Condition) compile(rule string) error {
+	if c.Field == "" {
+		return fmt.Errorf("%w: rule %q has a condition without a field", ErrInvalidPolicy, rule)
+	}
+
+	switch c.Operator {
+	case OpEquals, OpNotEquals:
+		equal := c.Operator == OpEquals
+		c.match = func(field reflect.Value, _ time.Time) bool { return (scalarString(field) == c.Value) == equal }
+	case OpContains, OpNotContains:
+		contains := c.Operator == OpContains
+		c.match = func(field reflect.Value, _ time.Time) bool { return containsValue(field, c.Value) == contains }
+	case OpMatches:
+		if _, err := path.Match(c.Value, ""); err != nil {
+			return fmt.Errorf("%w: rule %q: invalid glob %q: %w", ErrInvalidPolicy, rule, c.Value, err)
+		}
+
+		c.match = func(field reflect.Value, _ time.Time) bool {
+			matched, _ := path.Match(c.Value, scalarString(field))
+
+			return matched
+		}
+	case OpRegex:
+		re, err := regexp.Compile(c.Value)
+		if err != nil {
+			return fmt.Errorf("%w: rule %q: invalid regex %q: %w", ErrInvalidPolicy, rule, c.Value, err)
+		}
+
+		c.match = func(field reflect.Value, _ time.Time) bool { return re.MatchString(scalarString(field)) }
+	case OpGreaterThan, OpLessThan:
+		limit, err := strconv.ParseFloat(c.Value, 64)
+		if err != nil {
+			return fmt.Errorf("%w: rule %q: invalid number %q for %s", ErrInvalidPolicy, rule, c.Value, c.Operator)
+		}
+
+		greater := c.Operator == OpGreaterThan
+		c.match = func(field reflect.Value, _ time.Time) bool {
+			number, ok := numberValue(field)
+
+			return ok && number != limit && (number > limit) == greater
+		}
+	case OpOlderThan, OpNewerThan:
+		age, ok := parsePolicyDuration(c.Value)
+		if !ok {
+			return fmt.Errorf("%w: rule %q: invalid duration %q for %s", ErrInvalidPolicy, rule, c.Value, c.Operator)
+		}
+
+		older := c.Operator == OpOlderThan
+		c.match = func(field reflect.Value, now time.Time) bool {
+			at, ok := timeValue(field)
+
+			return ok && at.Before(now.Add(-age)) == older
+		}
+	case OpEmpty, OpNotEmpty:
+		empty := c.Operator == OpEmpty
+		c.match = func(field reflect.Value, _ time.Time) bool { return isEmpty(field) == empty }
+	default:
+		return fmt.Errorf("%w: rule %q: unknown operator %q for field %s", ErrInvalidPolicy, rule, c.Operator, c.Field)
+	}
+
+	return nil
+}
+
+// parsePolicyDuration parses a Go duration, or a number of days such as 365d.
+func parsePolicyDuration(value string) (time.Duration, bool) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+
+		return time.Duration(n) * 24 * time.Hour, err == nil && n >= 0
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	return duration, err == nil && duration >= 0
+}
+
+// CheckPolicy returns the violations of the policy's rules by items, in the order of the rules and
+// then of the items. An item of a rule's report type without one of the rule's fields is an error,
+// which most likely comes from a misspelled field.
+func CheckPolicy[T any](policy *Policy, items []T, now time.Time) ([]Violation, error) {
+	var violations []Violation
+
+	for _, rule := range policy.Rules {
+		for _, item := range items {
+			violated, err := rule.violatedBy(reflect.ValueOf(item), now)
+			if err != nil {
+				return nil, err
+			}
+
+			if violated {
+				violations = append(violations, Violation{Rule: rule.Name, Item: describeItem(reflect.ValueOf(item))})
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+func (r *Rule) violatedBy(item reflect.Value, now time.Time) (bool, error) {
+	if r.Report != "" {
+		if reportType, _ := lookupField(item, "report_type"); scalarString(reportType) != r.Report {
+			return false, nil
+		}
+	}
+
+	for _, condition := range r.When {
+		field, ok := lookupField(item, condition.Field)
+
+		switch {
+		case !ok && r.Report != "":
+			return false, fmt.Errorf("%w: rule %q: %s items have no field %s",
+				ErrInvalidPolicy, r.Name, r.Report, condition.Field)
+		case !ok || !condition.match(field, now):
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// lookupField returns the field of the struct v points to encoded under the given JSON key,
+// including the fields of embedded structs. The value is invalid for the fields of a nil embedded
+// pointer.
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	return lookupStructField(v, name, true)
+}
+
+func lookupStructField(v reflect.Value, name string, present bool) (reflect.Value, bool) {
+	typ := v.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		if field.Anonymous {
+			embedded := v.Field(i)
+			embeddedPresent := present
+
+			if embedded.Kind() == reflect.Ptr {
+				if !present || embedded.IsNil() {
+					embedded, embeddedPresent = reflect.New(field.Type.Elem()).Elem(), false
+				} else {
+					embedded = embedded.Elem()
+				}
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				if value, ok := lookupStructField(embedded, name, embeddedPresent); ok {
+					return value, true
+				}
+			}
+
+			continue
+		}
+
+		if key, _, _ := strings.Cut(field.Tag.Get("json"), ","); key == name {
+			if !present {
+				return reflect.Value{}, true
+			}
+
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// deref follows pointers, returning an invalid value for nil ones.
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// scalarString returns the text of a string, boolean, or number field, and an empty string for
+// unset fields.
+func scalarString(v reflect.Value) string {
+	v = deref(v)
+
+	switch {
+	case !v.IsValid():
+		return ""
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Kind() == reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case v.CanInt():
+		return strconv.FormatInt(v.Int(), 10)
+	case v.CanUint():
+		return strconv.FormatUint(v.Uint(), 10)
+	case v.CanFloat():
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func containsValue(v reflect.Value, value string) bool {
+	v = deref(v)
+
+	switch {
+	case !v.IsValid():
+		return false
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := range v.Len() {
+			if scalarString(v.Index(i)) == value {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return strings.Contains(scalarString(v), value)
+	}
+}
+
+func numberValue(v reflect.Value) (float64, bool) {
+	v = deref(v)
+
+	switch {
+	case !v.IsValid():
+		return 0, false
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// timeValue returns the time of a time field, including dates such as gitlab.ISOTime.
+func timeValue(v reflect.Value) (time.Time, bool) {
+	v = deref(v)
+	if !v.IsValid() || !v.Type().ConvertibleTo(timeType) {
+		return time.Time{}, false
+	}
+
+	at, _ := v.Convert(timeType).Interface().(time.Time)
+
+	return at, !at.IsZero()
+}
+
+func isEmpty(v reflect.Value) bool {
+	v = deref(v)
+
+	return !v.IsValid() || v.IsZero() || ((v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0)
+}
+
+// itemPathFields and itemNameFields are the JSON keys describing a report item in a violation,
+// checked in order.
+var (
+	itemPathFields = []string{
+		"project_path", "path_with_namespace", "group_full_path", "full_path", "group_path", "source_path",
+		"username",
+	}
+	itemNameFields = []string{"key", "name", "title"}
+)
+
+// describeItem names a report item by the group or project it belongs to and its own name.
+func describeItem(item reflect.Value) string {
+	first := func(names []string) string {
+		for _, name := range names {
+			if field, ok := lookupField(item, name); ok && scalarString(field) != "" {
+				return scalarString(field)
+			}
+		}
+
+		return ""
+	}
+
+	location, name := first(itemPathFields), first(itemNameFields)
+
+	switch {
+	case location == "":
+		return name
+	case name == "" || strings.HasSuffix(location, "/"+name) || location == name:
+		return location
+	default:
+		return location + ": " + name
+	}
+}
@@ -0,0 +1,180 @@
+package report_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	return file
+}
+
+func loadPolicy(t *testing.T, content string) *report.Policy {
+	t.Helper()
+
+	policy, err := report.LoadPolicy(writePolicy(t, content))
+	require.NoError(t, err)
+
+	return policy
+}
+
+func TestCheckPolicy(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	t.Run("token age", func(t *testing.T) {
+		policy := loadPolicy(t, `
+rules:
+  - name: no token older than 365 days
+    report: project_access_token
+    when:
+      - field: created_at
+        operator: older_than
+        value: 365d
+`)
+		recent := now.AddDate(0, -2, 0)
+		old := now.AddDate(-2, 0, 0)
+		tokens := []*glclient.ProjectAccessTokenWithProject{
+			{
+				ProjectAccessToken: &gitlab.ProjectAccessToken{
+					PersonalAccessToken: gitlab.PersonalAccessToken{Name: "deploy", CreatedAt: &recent},
+				},
+				ReportType: glclient.ReportTypeProjectAccessToken, ProjectPath: "org/api",
+			},
+			{
+				ProjectAccessToken: &gitlab.ProjectAccessToken{
+					PersonalAccessToken: gitlab.PersonalAccessToken{Name: "legacy", CreatedAt: &old},
+				},
+				ReportType: glclient.ReportTypeProjectAccessToken, ProjectPath: "org/web",
+			},
+		}
+
+		violations, err := report.CheckPolicy(policy, tokens, now)
+		require.NoError(t, err)
+		assert.Equal(t, []report.Violation{{Rule: "no token older than 365 days", Item: "org/web: legacy"}}, violations)
+
+		violations, err = report.CheckPolicy(policy, tokens[:1], now)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("unmasked secret variables", func(t *testing.T) {
+		policy := loadPolicy(t, `
+rules:
+  - name: no unmasked variable named *SECRET*
+    when:
+      - field: key
+        operator: matches
+        value: "*SECRET*"
+      - field: masked
+        operator: eq
+        value: "false"
+`)
+		variables := []*glclient.VariableWithSource{
+			{Key: "DB_SECRET", Masked: true, Source: "project", SourcePath: "org/api"},
+			{Key: "API_SECRET_KEY", Source: "group", SourcePath: "org"},
+			{Key: "REGION", Source: "group", SourcePath: "org"},
+		}
+
+		violations, err := report.CheckPolicy(policy, variables, now)
+		require.NoError(t, err)
+		assert.Equal(t, []report.Violation{
+			{Rule: "no unmasked variable named *SECRET*", Item: "org: API_SECRET_KEY"},
+		}, violations)
+	})
+
+	t.Run("two-factor enforcement", func(t *testing.T) {
+		policy := loadPolicy(t, `
+rules:
+  - name: all groups require 2FA
+    report: group_two_factor
+    when:
+      - field: enforced_by
+        operator: empty
+`)
+		statuses := []*glclient.GroupTwoFactor{
+			{ReportType: glclient.ReportTypeGroupTwoFactor, GroupPath: "org", EnforcedBy: "org"},
+			{ReportType: glclient.ReportTypeGroupTwoFactor, GroupPath: "org/team", EnforcedBy: "org"},
+		}
+
+		violations, err := report.CheckPolicy(policy, statuses, now)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+
+		statuses[0].EnforcedBy, statuses[1].EnforcedBy = "", ""
+
+		violations, err = report.CheckPolicy(policy, statuses, now)
+		require.NoError(t, err)
+		assert.Len(t, violations, 2)
+	})
+
+	t.Run("skips the items of other reports", func(t *testing.T) {
+		policy := loadPolicy(t, `
+rules:
+  - name: all groups require 2FA
+    report: group_two_factor
+    when:
+      - field: enforced_by
+        operator: empty
+`)
+		violations, err := report.CheckPolicy(policy,
+			[]*glclient.VariableWithSource{{Key: "REGION", ReportType: glclient.ReportTypeGroupVariable}}, now)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("rejects a misspelled field", func(t *testing.T) {
+		policy := loadPolicy(t, `
+rules:
+  - name: all groups require 2FA
+    report: group_two_factor
+    when:
+      - field: enforced
+        operator: empty
+`)
+		_, err := report.CheckPolicy(policy,
+			[]*glclient.GroupTwoFactor{{ReportType: glclient.ReportTypeGroupTwoFactor, GroupPath: "org"}}, now)
+		require.ErrorIs(t, err, report.ErrInvalidPolicy)
+	})
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "no rules", content: "rules: []"},
+		{name: "unnamed rule", content: "rules:\n  - when:\n      - {field: key, operator: empty}"},
+		{name: "no conditions", content: "rules:\n  - name: empty"},
+		{name: "unknown operator", content: "rules:\n  - name: r\n    when:\n      - {field: key, operator: like}"},
+		{
+			name:    "invalid duration",
+			content: "rules:\n  - name: r\n    when:\n      - {field: created_at, operator: older_than, value: soon}",
+		},
+		{
+			name:    "invalid regex",
+			content: "rules:\n  - name: r\n    when:\n      - {field: key, operator: regex, value: \"(\"}",
+		},
+		{name: "invalid number", content: "rules:\n  - name: r\n    when:\n      - {field: tags, operator: gt, value: many}"},
+		{name: "not YAML", content: "rules: ["},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := report.LoadPolicy(writePolicy(t, tt.content))
+			require.ErrorIs(t, err, report.ErrInvalidPolicy)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package report
+
+import gitlab "gitlab.com/gitlab-org/api/client-go"
+
+// FilterCIDisabled returns the projects with CI/CD disabled entirely, preserving their order. A
+// project counts as disabled by its builds_access_level; the deprecated jobs_enabled mirrors it and
+// is only consulted when the access level is missing from the response.
+func FilterCIDisabled(projects []*gitlab.Project) []*gitlab.Project {
+	filtered := make([]*gitlab.Project, 0, len(projects))
+
+	for _, project := range projects {
+		if ciDisabled(project) {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
+
+func ciDisabled(project *gitlab.Project) bool {
+	if project.BuildsAccessLevel != "" {
+		return project.BuildsAccessLevel == gitlab.DisabledAccessControl
+	}
+
+	return !project.JobsEnabled
+}
@@ -0,0 +1,22 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func TestFilterCIDisabled(t *testing.T) {
+	projects := []*gitlab.Project{
+		{PathWithNamespace: "org/api", BuildsAccessLevel: gitlab.EnabledAccessControl, JobsEnabled: true},
+		{PathWithNamespace: "org/docs", BuildsAccessLevel: gitlab.DisabledAccessControl},
+		{PathWithNamespace: "org/web", BuildsAccessLevel: gitlab.PrivateAccessControl, JobsEnabled: true},
+		{PathWithNamespace: "org/legacy"},
+		{PathWithNamespace: "org/tools", JobsEnabled: true},
+	}
+
+	assert.Equal(t, []*gitlab.Project{projects[1], projects[3]}, report.FilterCIDisabled(projects))
+	assert.Empty(t, report.FilterCIDisabled(nil))
+}
@@ -0,0 +1,17 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// NonEmptyRegistries returns the projects holding container repositories or packages, preserving
+// their order.
+func NonEmptyRegistries(projects []*glclient.ProjectRegistry) []*glclient.ProjectRegistry {
+	filtered := make([]*glclient.ProjectRegistry, 0, len(projects))
+
+	for _, project := range projects {
+		if !project.Empty() {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
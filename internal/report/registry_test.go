@@ -0,0 +1,21 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonEmptyRegistries(t *testing.T) {
+	projects := []*glclient.ProjectRegistry{
+		{ProjectPath: "org/api", ContainerRegistryEnabled: true, Repositories: 2, Tags: 14},
+		{ProjectPath: "org/docs", ContainerRegistryEnabled: true, PackageRegistryEnabled: true},
+		{ProjectPath: "org/sdk", PackageRegistryEnabled: true, Packages: 3},
+		{ProjectPath: "org/wiki"},
+	}
+
+	assert.Equal(t, []*glclient.ProjectRegistry{projects[0], projects[2]}, report.NonEmptyRegistries(projects))
+	assert.Empty(t, report.NonEmptyRegistries(nil))
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+var ErrRollupUnsupported = errors.New("the report items carry no group or project path to roll up by")
+
+// SourceCount is the number of items of a report belonging to a group or project.
+type SourceCount struct {
+	ReportType string `json:"report_type" csv:"-"`
+	Instance   string `json:"instance,omitempty" csv:"omitempty"`
+	Source     string `json:"source"`
+	SourcePath string `json:"source_path"`
+	Count      int    `json:"count"`
+}
+
+// sourcePathFields are the JSON keys holding the full path of the project or group a report item
+// belongs to, checked in order.
+var sourcePathFields = []struct {
+	name   string
+	source string
+}{
+	{name: "project_path", source: "project"},
+	{name: "path_with_namespace", source: "project"},
+	{name: "group_full_path", source: "group"},
+	{name: "full_path", source: "group"},
+	{name: "group_path", source: "group"},
+}
+
+// itemSource returns whether the report item belongs to a group or a project, and its full path.
+// Items of mixed reports carry their source along with its path.
+func itemSource(item reflect.Value) (string, string, bool) {
+	for _, field := range sourcePathFields {
+		if value, ok := lookupField(item, field.name); ok && scalarString(value) != "" {
+			return field.source, scalarString(value), true
+		}
+	}
+
+	path, _ := lookupField(item, "source_path")
+	source, _ := lookupField(item, "source")
+
+	if scalarString(path) == "" || scalarString(source) == "" {
+		return "", "", false
+	}
+
+	return scalarString(source), scalarString(path), true
+}
+
+// RollupBySource counts the report items of each group or project they belong to, keeping the
+// items of different instances and report types apart. The counts are sorted by instance, path,
+// source, and report type. An item without a group or project path is an error.
+func RollupBySource[T any](items []T) ([]*SourceCount, error) {
+	type key struct {
+		instance, reportType, source, path string
+	}
+
+	counts := make(map[key]*SourceCount)
+
+	for i, item := range items {
+		v := reflect.ValueOf(item)
+
+		source, path, ok := itemSource(v)
+		if !ok {
+			return nil, fmt.Errorf("%w: item %d has no path", ErrRollupUnsupported, i+1)
+		}
+
+		reportType, _ := lookupField(v, "report_type")
+		instance, _ := lookupField(v, "instance")
+		k := key{
+			instance:   scalarString(instance),
+			reportType: scalarString(reportType),
+			source:     source,
+			path:       path,
+		}
+
+		if count, ok := counts[k]; ok {
+			count.Count++
+
+			continue
+		}
+
+		counts[k] = &SourceCount{
+			ReportType: k.reportType,
+			Instance:   k.instance,
+			Source:     source,
+			SourcePath: path,
+			Count:      1,
+		}
+	}
+
+	rollup := make([]*SourceCount, 0, len(counts))
+	for _, count := range counts {
+		rollup = append(rollup, count)
+	}
+
+	slices.SortFunc(rollup, func(a, b *SourceCount) int {
+		return cmp.Or(
+			cmp.Compare(a.Instance, b.Instance),
+			cmp.Compare(a.SourcePath, b.SourcePath),
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.ReportType, b.ReportType),
+		)
+	})
+
+	return rollup, nil
+}
@@ -0,0 +1,83 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupBySource(t *testing.T) {
+	t.Run("counts tokens per project", func(t *testing.T) {
+		tokens := []*glclient.ProjectAccessTokenWithProject{
+			{ReportType: glclient.ReportTypeProjectAccessToken, ProjectPath: "org/web"},
+			{ReportType: glclient.ReportTypeProjectAccessToken, ProjectPath: "org/api"},
+			{ReportType: glclient.ReportTypeProjectAccessToken, ProjectPath: "org/web"},
+			{ReportType: glclient.ReportTypeProjectAccessToken, ProjectPath: "org/web"},
+		}
+
+		counts, err := report.RollupBySource(tokens)
+		require.NoError(t, err)
+		assert.Equal(t, []*report.SourceCount{
+			{ReportType: glclient.ReportTypeProjectAccessToken, Source: "project", SourcePath: "org/api", Count: 1},
+			{ReportType: glclient.ReportTypeProjectAccessToken, Source: "project", SourcePath: "org/web", Count: 3},
+		}, counts)
+	})
+
+	t.Run("counts group tokens by group path", func(t *testing.T) {
+		tokens := []*glclient.GroupAccessTokenWithGroup{
+			{GroupPath: "org"}, {GroupPath: "org/team"}, {GroupPath: "org"},
+		}
+
+		counts, err := report.RollupBySource(tokens)
+		require.NoError(t, err)
+		assert.Equal(t, []*report.SourceCount{
+			{Source: "group", SourcePath: "org", Count: 2},
+			{Source: "group", SourcePath: "org/team", Count: 1},
+		}, counts)
+	})
+
+	t.Run("keeps the sources of mixed reports apart", func(t *testing.T) {
+		variables := []*glclient.VariableWithSource{
+			{ReportType: glclient.ReportTypeGroupVariable, Key: "REGION", Source: "group", SourcePath: "org"},
+			{ReportType: glclient.ReportTypeProjectVariable, Key: "PORT", Source: "project", SourcePath: "org/api"},
+			{ReportType: glclient.ReportTypeGroupVariable, Key: "TEAM", Source: "group", SourcePath: "org"},
+			{ReportType: glclient.ReportTypeProjectVariable, Key: "HOST", Source: "project", SourcePath: "org/api"},
+			{ReportType: glclient.ReportTypeProjectVariable, Key: "HOST", Source: "project", SourcePath: "org/web"},
+		}
+
+		counts, err := report.RollupBySource(variables)
+		require.NoError(t, err)
+		assert.Equal(t, []*report.SourceCount{
+			{ReportType: glclient.ReportTypeGroupVariable, Source: "group", SourcePath: "org", Count: 2},
+			{ReportType: glclient.ReportTypeProjectVariable, Source: "project", SourcePath: "org/api", Count: 2},
+			{ReportType: glclient.ReportTypeProjectVariable, Source: "project", SourcePath: "org/web", Count: 1},
+		}, counts)
+	})
+
+	t.Run("keeps instances apart", func(t *testing.T) {
+		usage := []*glclient.GroupComputeUsage{
+			{Instance: "self-managed", GroupPath: "org"},
+			{Instance: "gitlab.com", GroupPath: "org"},
+		}
+
+		counts, err := report.RollupBySource(usage)
+		require.NoError(t, err)
+		require.Len(t, counts, 2)
+		assert.Equal(t, "gitlab.com", counts[0].Instance)
+		assert.Equal(t, "self-managed", counts[1].Instance)
+	})
+
+	t.Run("rejects items without a path", func(t *testing.T) {
+		_, err := report.RollupBySource([]*glclient.ImpersonationTokenWithUser{{Username: "alice"}})
+		require.ErrorIs(t, err, report.ErrRollupUnsupported)
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		counts, err := report.RollupBySource[*glclient.PipelineTriggerWithProject](nil)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// FilterDeviatingRunnerSettings returns the projects whose runner settings differ from the default
+// of their group, preserving their order. Shared runners are expected to be enabled exactly when the
+// group's shared runners setting is enabled, and group runners, which GitLab enables for every new
+// project, to be enabled. Projects whose group setting is unknown, such as those in a personal
+// namespace, are left out.
+func FilterDeviatingRunnerSettings(settings []*glclient.ProjectRunnerSettings) []*glclient.ProjectRunnerSettings {
+	filtered := make([]*glclient.ProjectRunnerSettings, 0, len(settings))
+
+	for _, s := range settings {
+		if s.GroupSharedRunners == "" {
+			continue
+		}
+
+		sharedDefault := s.GroupSharedRunners == string(gitlab.EnabledSharedRunnersSettingValue)
+		if s.SharedRunnersEnabled != sharedDefault || !s.GroupRunnersEnabled {
+			filtered = append(filtered, s)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,35 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterDeviatingRunnerSettings(t *testing.T) {
+	settings := []*glclient.ProjectRunnerSettings{
+		// inherits an enabled group default
+		{ProjectPath: "org/api", SharedRunnersEnabled: true, GroupRunnersEnabled: true, GroupSharedRunners: "enabled"},
+		// opted out of shared runners the group enables
+		{ProjectPath: "org/build", GroupRunnersEnabled: true, GroupSharedRunners: "enabled"},
+		// inherits a disabled group default
+		{ProjectPath: "org/docs", GroupRunnersEnabled: true, GroupSharedRunners: "disabled_and_overridable"},
+		// overrode shared runners the group disables
+		{
+			ProjectPath:          "org/web",
+			SharedRunnersEnabled: true,
+			GroupRunnersEnabled:  true,
+			GroupSharedRunners:   "disabled_and_overridable",
+		},
+		// turned off group runners
+		{ProjectPath: "org/tools", SharedRunnersEnabled: true, GroupSharedRunners: "enabled"},
+		// personal project without a group default
+		{ProjectPath: "alice/sandbox", SharedRunnersEnabled: false},
+	}
+
+	assert.Equal(t, []*glclient.ProjectRunnerSettings{settings[1], settings[3], settings[4]},
+		report.FilterDeviatingRunnerSettings(settings))
+	assert.Empty(t, report.FilterDeviatingRunnerSettings(nil))
+}
@@ -0,0 +1,21 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// FilterByRunnerReliance returns the projects with the given runner reliance, preserving their order.
+// An empty reliance keeps all projects.
+func FilterByRunnerReliance(projects []*glclient.ProjectRunners, reliance string) []*glclient.ProjectRunners {
+	if reliance == "" {
+		return projects
+	}
+
+	filtered := make([]*glclient.ProjectRunners, 0, len(projects))
+
+	for _, project := range projects {
+		if project.Reliance == reliance {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered
+}
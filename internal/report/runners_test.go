@@ -0,0 +1,24 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterByRunnerReliance(t *testing.T) {
+	projects := []*glclient.ProjectRunners{
+		{ProjectPath: "org/api", Reliance: glclient.RunnerRelianceShared},
+		{ProjectPath: "org/build", Reliance: glclient.RunnerRelianceSpecific},
+		{ProjectPath: "org/docs", Reliance: glclient.RunnerRelianceNone},
+		{ProjectPath: "org/web", Reliance: glclient.RunnerRelianceShared},
+	}
+
+	assert.Equal(t, []*glclient.ProjectRunners{projects[0], projects[3]},
+		report.FilterByRunnerReliance(projects, glclient.RunnerRelianceShared))
+	assert.Equal(t, []*glclient.ProjectRunners{projects[1]},
+		report.FilterByRunnerReliance(projects, glclient.RunnerRelianceSpecific))
+	assert.Equal(t, projects, report.FilterByRunnerReliance(projects, ""))
+}
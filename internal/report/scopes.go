@@ -0,0 +1,64 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ScopeCount is the number of items granted a scope.
+type ScopeCount struct {
+	Scope string
+	Count int
+}
+
+// TallyScopes counts the items granted each scope, most frequent first and by name among equal counts.
+// A scope listed twice for the same item is counted once.
+func TallyScopes[T any](items []T, scopes func(T) []string) []ScopeCount {
+	counts := make(map[string]int)
+
+	for _, item := range items {
+		seen := make(map[string]bool)
+
+		for _, scope := range scopes(item) {
+			if seen[scope] {
+				continue
+			}
+
+			seen[scope] = true
+			counts[scope]++
+		}
+	}
+
+	tally := make([]ScopeCount, 0, len(counts))
+	for scope, count := range counts {
+		tally = append(tally, ScopeCount{Scope: scope, Count: count})
+	}
+
+	slices.SortFunc(tally, func(a, b ScopeCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Scope, b.Scope))
+	})
+
+	return tally
+}
+
+// FormatScopeSummary renders a tally of total tokens on one line, such as
+// "42 tokens: 30 api, 10 read_repository".
+func FormatScopeSummary(total int, tally []ScopeCount) string {
+	noun := "tokens"
+	if total == 1 {
+		noun = "token"
+	}
+
+	if len(tally) == 0 {
+		return fmt.Sprintf("%d %s: no scopes", total, noun)
+	}
+
+	parts := make([]string, 0, len(tally))
+	for _, count := range tally {
+		parts = append(parts, fmt.Sprintf("%d %s", count.Count, count.Scope))
+	}
+
+	return fmt.Sprintf("%d %s: %s", total, noun, strings.Join(parts, ", "))
+}
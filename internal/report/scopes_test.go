@@ -0,0 +1,71 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTallyScopes(t *testing.T) {
+	tokens := [][]string{
+		{"api", "read_repository"},
+		{"api", "write_registry", "read_repository"},
+		{"read_repository", "api"},
+		{"read_api"},
+		{"api", "api"},
+		{},
+	}
+
+	scopes := func(token []string) []string { return token }
+
+	t.Run("counts overlapping scope sets", func(t *testing.T) {
+		assert.Equal(t, []report.ScopeCount{
+			{Scope: "api", Count: 4},
+			{Scope: "read_repository", Count: 3},
+			{Scope: "read_api", Count: 1},
+			{Scope: "write_registry", Count: 1},
+		}, report.TallyScopes(tokens, scopes))
+	})
+
+	t.Run("counts nothing without tokens", func(t *testing.T) {
+		assert.Empty(t, report.TallyScopes(nil, scopes))
+	})
+}
+
+func TestFormatScopeSummary(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		tally []report.ScopeCount
+		want  string
+	}{
+		{
+			name:  "lists scopes in order",
+			total: 42,
+			tally: []report.ScopeCount{
+				{Scope: "api", Count: 30},
+				{Scope: "read_repository", Count: 10},
+				{Scope: "write_registry", Count: 2},
+			},
+			want: "42 tokens: 30 api, 10 read_repository, 2 write_registry",
+		},
+		{
+			name:  "uses the singular for one token",
+			total: 1,
+			tally: []report.ScopeCount{{Scope: "api", Count: 1}},
+			want:  "1 token: 1 api",
+		},
+		{
+			name:  "reports missing scopes",
+			total: 0,
+			want:  "0 tokens: no scopes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, report.FormatScopeSummary(tt.total, tt.tally))
+		})
+	}
+}
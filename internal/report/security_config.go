@@ -0,0 +1,17 @@
+package report
+
+import "github.com/andreygrechin/glreporter/internal/glclient"
+
+// FilterMissingSAST returns the projects whose default branch does not run SAST, including those
+// without pipelines, preserving their order.
+func FilterMissingSAST(configs []*glclient.ProjectSecurityConfig) []*glclient.ProjectSecurityConfig {
+	filtered := make([]*glclient.ProjectSecurityConfig, 0, len(configs))
+
+	for _, config := range configs {
+		if !config.SAST {
+			filtered = append(filtered, config)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,20 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMissingSAST(t *testing.T) {
+	configs := []*glclient.ProjectSecurityConfig{
+		{ProjectPath: "org/api", PipelineID: 100, SAST: true, DependencyScanning: true},
+		{ProjectPath: "org/web", PipelineID: 101, DAST: true},
+		{ProjectPath: "org/empty"},
+	}
+
+	assert.Equal(t, []*glclient.ProjectSecurityConfig{configs[1], configs[2]}, report.FilterMissingSAST(configs))
+	assert.Empty(t, report.FilterMissingSAST(nil))
+}
@@ -0,0 +1,89 @@
+package report
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+var ErrInvalidSize = errors.New("invalid size, use a number of bytes or a value like 500MB or 1.5GiB")
+
+// sizeUnits maps size suffixes to their number of bytes. KB, MB, GB, and TB are decimal units,
+// KiB, MiB, GiB, and TiB binary ones.
+var sizeUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// binaryUnits are the units FormatSize picks from, as used by the GitLab UI.
+var binaryUnits = []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+// ParseSize parses a human-readable size such as 1GB, 500 MiB, or 1048576 into a number of bytes.
+// Units are case-insensitive.
+func ParseSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	number := strings.TrimRightFunc(value, func(r rune) bool {
+		return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	})
+
+	multiplier, ok := sizeUnits[strings.ToLower(value[len(number):])]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, value)
+	}
+
+	size, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || size < 0 || math.IsInf(size, 0) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, value)
+	}
+
+	return int64(math.Round(size * multiplier)), nil
+}
+
+// FormatSize formats a number of bytes with the largest binary unit that keeps the value at least 1,
+// for example 1.5 GiB.
+func FormatSize(size int64) string {
+	value := float64(size)
+	unit := 0
+
+	for value >= 1024 && unit < len(binaryUnits)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	return fmt.Sprintf("%.1f %s", value, binaryUnits[unit])
+}
+
+// LargestProjects returns the projects using at least minSize bytes in total, largest first.
+func LargestProjects(storage []*glclient.ProjectStorage, minSize int64) []*glclient.ProjectStorage {
+	largest := make([]*glclient.ProjectStorage, 0, len(storage))
+
+	for _, project := range storage {
+		if project.StorageSize >= minSize {
+			largest = append(largest, project)
+		}
+	}
+
+	slices.SortStableFunc(largest, func(a, b *glclient.ProjectStorage) int {
+		return cmp.Or(cmp.Compare(b.StorageSize, a.StorageSize), cmp.Compare(a.ProjectPath, b.ProjectPath))
+	})
+
+	return largest
+}
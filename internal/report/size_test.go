@@ -0,0 +1,74 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{"0", 0},
+		{"1048576", 1048576},
+		{"512B", 512},
+		{"1KB", 1000},
+		{"1GB", 1_000_000_000},
+		{"1gb", 1_000_000_000},
+		{"1.5GiB", 1610612736},
+		{"500 MiB", 524288000},
+		{" 2TB ", 2_000_000_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := report.ParseSize(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, value := range []string{"", "GB", "1XB", "-1GB", "1.2.3MB", "large"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			_, err := report.ParseSize(value)
+			require.ErrorIs(t, err, report.ErrInvalidSize)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "0 B", report.FormatSize(0))
+	assert.Equal(t, "1023 B", report.FormatSize(1023))
+	assert.Equal(t, "1.0 KiB", report.FormatSize(1024))
+	assert.Equal(t, "1.5 GiB", report.FormatSize(1610612736))
+	assert.Equal(t, "2048.0 TiB", report.FormatSize(1<<51))
+}
+
+func TestLargestProjects(t *testing.T) {
+	storage := []*glclient.ProjectStorage{
+		{ProjectPath: "org/small", StorageSize: 10},
+		{ProjectPath: "org/b-large", StorageSize: 3000},
+		{ProjectPath: "org/a-large", StorageSize: 3000},
+		{ProjectPath: "org/medium", StorageSize: 1000},
+	}
+
+	paths := func(storage []*glclient.ProjectStorage) []string {
+		var paths []string
+		for _, project := range storage {
+			paths = append(paths, project.ProjectPath)
+		}
+
+		return paths
+	}
+
+	assert.Equal(t, []string{"org/a-large", "org/b-large", "org/medium", "org/small"},
+		paths(report.LargestProjects(storage, 0)))
+	assert.Equal(t, []string{"org/a-large", "org/b-large", "org/medium"},
+		paths(report.LargestProjects(storage, 1000)))
+	assert.Empty(t, report.LargestProjects(storage, 5000))
+}
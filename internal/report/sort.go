@@ -0,0 +1,135 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// TokenSortField names the token attribute a report is sorted by.
+type TokenSortField string
+
+const (
+	// SortByExpiresAt sorts tokens by expiry date, tokens that never expire last.
+	SortByExpiresAt TokenSortField = "expires-at"
+	// SortByCreatedAt sorts tokens by creation time.
+	SortByCreatedAt TokenSortField = "created-at"
+	// SortByName sorts tokens by name.
+	SortByName TokenSortField = "name"
+	// SortByPath sorts tokens by the path of the group or project they belong to.
+	SortByPath TokenSortField = "path"
+)
+
+// SortOrder is the direction of a sort.
+type SortOrder string
+
+const (
+	// Ascending sorts from the smallest value to the largest.
+	Ascending SortOrder = "asc"
+	// Descending sorts from the largest value to the smallest.
+	Descending SortOrder = "desc"
+)
+
+var (
+	ErrInvalidSortField = errors.New("invalid sort field, use expires-at, created-at, name, or path")
+	ErrInvalidSortOrder = errors.New("invalid sort order, use asc or desc")
+)
+
+// TokenSort selects how token reports are ordered. Natural compares names and paths naturally, see
+// glclient.NaturalCompare.
+type TokenSort struct {
+	By      TokenSortField
+	Order   SortOrder
+	Natural bool
+}
+
+// Validate reports whether the sort field and order are known.
+func (s TokenSort) Validate() error {
+	switch s.By {
+	case SortByExpiresAt, SortByCreatedAt, SortByName, SortByPath:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSortField, s.By)
+	}
+
+	switch s.Order {
+	case Ascending, Descending:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSortOrder, s.Order)
+	}
+
+	return nil
+}
+
+// SortTokens orders tokens in place. The sort is stable, so tokens with equal keys keep the order
+// they were fetched in. Missing dates sort after all others in ascending order, so tokens that never
+// expire come last, and first in descending order.
+// The token and path funcs return a token's common attributes and the path it belongs to.
+func SortTokens[T any](
+	tokens []T,
+	sort TokenSort,
+	token func(T) *gitlab.PersonalAccessToken,
+	path func(T) string,
+) {
+	compareStrings := strings.Compare
+	if sort.Natural {
+		compareStrings = glclient.NaturalCompare
+	}
+
+	compare := func(a, b T) int {
+		ta, tb := token(a), token(b)
+
+		switch sort.By {
+		case SortByCreatedAt:
+			return compareTimes(ta.CreatedAt, tb.CreatedAt)
+		case SortByName:
+			return compareStrings(ta.Name, tb.Name)
+		case SortByPath:
+			return compareStrings(path(a), path(b))
+		default:
+			return compareTimes((*time.Time)(ta.ExpiresAt), (*time.Time)(tb.ExpiresAt))
+		}
+	}
+
+	slices.SortStableFunc(tokens, func(a, b T) int {
+		if sort.Order == Descending {
+			return compare(b, a)
+		}
+
+		return compare(a, b)
+	})
+}
+
+// compareTimes orders times chronologically, with nil after every time.
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	default:
+		return a.Compare(*b)
+	}
+}
+
+// SortGroupAccessTokens orders group access tokens in place, see SortTokens.
+func SortGroupAccessTokens(tokens []*glclient.GroupAccessTokenWithGroup, sort TokenSort) {
+	SortTokens(tokens, sort,
+		func(t *glclient.GroupAccessTokenWithGroup) *gitlab.PersonalAccessToken { return &t.PersonalAccessToken },
+		func(t *glclient.GroupAccessTokenWithGroup) string { return t.GroupPath })
+}
+
+// SortProjectAccessTokens orders project access tokens in place, see SortTokens.
+func SortProjectAccessTokens(tokens []*glclient.ProjectAccessTokenWithProject, sort TokenSort) {
+	SortTokens(tokens, sort,
+		func(t *glclient.ProjectAccessTokenWithProject) *gitlab.PersonalAccessToken {
+			return &t.PersonalAccessToken
+		},
+		func(t *glclient.ProjectAccessTokenWithProject) string { return t.ProjectPath })
+}
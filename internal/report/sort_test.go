@@ -0,0 +1,112 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func projectToken(name, path string, expiresAt *gitlab.ISOTime) *glclient.ProjectAccessTokenWithProject {
+	return &glclient.ProjectAccessTokenWithProject{
+		ProjectAccessToken: &gitlab.ProjectAccessToken{
+			PersonalAccessToken: gitlab.PersonalAccessToken{Name: name, ExpiresAt: expiresAt},
+		},
+		ProjectPath: path,
+	}
+}
+
+func date(year int, month time.Month, day int) *gitlab.ISOTime {
+	d := gitlab.ISOTime(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+
+	return &d
+}
+
+func tokenNames(tokens []*glclient.ProjectAccessTokenWithProject) []string {
+	names := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		names = append(names, token.Name)
+	}
+
+	return names
+}
+
+func TestSortProjectAccessTokens(t *testing.T) {
+	fetched := func() []*glclient.ProjectAccessTokenWithProject {
+		return []*glclient.ProjectAccessTokenWithProject{
+			projectToken("never-a", "b/project", nil),
+			projectToken("late", "a/project", date(2026, 1, 1)),
+			projectToken("never-b", "a/project", nil),
+			projectToken("soon", "c/project", date(2025, 2, 1)),
+			projectToken("soon-too", "a/project", date(2025, 2, 1)),
+		}
+	}
+
+	tests := []struct {
+		name string
+		sort report.TokenSort
+		want []string
+	}{
+		{
+			name: "soonest expiry first and never-expiring last by default",
+			sort: report.TokenSort{By: report.SortByExpiresAt, Order: report.Ascending},
+			want: []string{"soon", "soon-too", "late", "never-a", "never-b"},
+		},
+		{
+			name: "never-expiring first in descending order",
+			sort: report.TokenSort{By: report.SortByExpiresAt, Order: report.Descending},
+			want: []string{"never-a", "never-b", "late", "soon", "soon-too"},
+		},
+		{
+			name: "by path keeps fetch order within a path",
+			sort: report.TokenSort{By: report.SortByPath, Order: report.Ascending},
+			want: []string{"late", "never-b", "soon-too", "never-a", "soon"},
+		},
+		{
+			name: "by name",
+			sort: report.TokenSort{By: report.SortByName, Order: report.Ascending},
+			want: []string{"late", "never-a", "never-b", "soon", "soon-too"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := fetched()
+			report.SortProjectAccessTokens(tokens, tt.sort)
+			assert.Equal(t, tt.want, tokenNames(tokens))
+		})
+	}
+}
+
+func TestSortProjectAccessTokens_natural(t *testing.T) {
+	tokens := []*glclient.ProjectAccessTokenWithProject{
+		projectToken("deploy-10", "org/sub-group-10/api", nil),
+		projectToken("deploy-2", "org/sub-group-2/api", nil),
+		projectToken("deploy-1", "org/sub-group-1/api", nil),
+	}
+
+	report.SortProjectAccessTokens(tokens, report.TokenSort{By: report.SortByName, Order: report.Ascending})
+	assert.Equal(t, []string{"deploy-1", "deploy-10", "deploy-2"}, tokenNames(tokens))
+
+	natural := report.TokenSort{By: report.SortByName, Order: report.Ascending, Natural: true}
+	report.SortProjectAccessTokens(tokens, natural)
+	assert.Equal(t, []string{"deploy-1", "deploy-2", "deploy-10"}, tokenNames(tokens))
+
+	natural = report.TokenSort{By: report.SortByPath, Order: report.Descending, Natural: true}
+	report.SortProjectAccessTokens(tokens, natural)
+	assert.Equal(t, []string{"deploy-10", "deploy-2", "deploy-1"}, tokenNames(tokens))
+}
+
+func TestTokenSort_Validate(t *testing.T) {
+	require.NoError(t, report.TokenSort{By: report.SortByCreatedAt, Order: report.Descending}.Validate())
+
+	err := report.TokenSort{By: "owner", Order: report.Ascending}.Validate()
+	require.ErrorIs(t, err, report.ErrInvalidSortField)
+
+	err = report.TokenSort{By: report.SortByName, Order: "up"}.Validate()
+	require.ErrorIs(t, err, report.ErrInvalidSortOrder)
+}
@@ -0,0 +1,50 @@
+package report
+
+import "time"
+
+// FilterUnused returns the items last used longer than unusedFor before now, preserving their order.
+// Items that were never used are kept only with includeNeverUsed. A non-positive unusedFor keeps
+// all items.
+func FilterUnused[T any](
+	items []T,
+	unusedFor time.Duration,
+	includeNeverUsed bool,
+	now time.Time,
+	lastUsed func(T) *time.Time,
+) []T {
+	if unusedFor <= 0 {
+		return items
+	}
+
+	cutoff := now.Add(-unusedFor)
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		used := lastUsed(item)
+		if (used == nil && includeNeverUsed) || (used != nil && used.Before(cutoff)) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// FilterExpiringWithin returns the items expiring within the given duration after now, preserving
+// their order. Items that already expired are kept; items that never expire are not. A non-positive
+// within keeps all items.
+func FilterExpiringWithin[T any](items []T, within time.Duration, now time.Time, expiresAt func(T) *time.Time) []T {
+	if within <= 0 {
+		return items
+	}
+
+	cutoff := now.Add(within)
+	filtered := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if expires := expiresAt(item); expires != nil && !expires.After(cutoff) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
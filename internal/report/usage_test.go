@@ -0,0 +1,83 @@
+package report_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+type usedToken struct {
+	name     string
+	lastUsed *time.Time
+}
+
+func TestFilterUnused(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recently := now.Add(-24 * time.Hour)
+	longAgo := now.Add(-200 * 24 * time.Hour)
+
+	tokens := []usedToken{
+		{name: "recent", lastUsed: &recently},
+		{name: "idle", lastUsed: &longAgo},
+		{name: "never"},
+	}
+
+	names := func(tokens []usedToken) []string {
+		result := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			result = append(result, token.name)
+		}
+
+		return result
+	}
+
+	lastUsed := func(token usedToken) *time.Time { return token.lastUsed }
+
+	t.Run("keeps long-idle tokens", func(t *testing.T) {
+		filtered := report.FilterUnused(tokens, 90*24*time.Hour, false, now, lastUsed)
+		assert.Equal(t, []string{"idle"}, names(filtered))
+	})
+
+	t.Run("includes never-used tokens on request", func(t *testing.T) {
+		filtered := report.FilterUnused(tokens, 90*24*time.Hour, true, now, lastUsed)
+		assert.Equal(t, []string{"idle", "never"}, names(filtered))
+	})
+
+	t.Run("keeps recently used tokens within a short window", func(t *testing.T) {
+		filtered := report.FilterUnused(tokens, time.Hour, false, now, lastUsed)
+		assert.Equal(t, []string{"recent", "idle"}, names(filtered))
+	})
+
+	t.Run("keeps all tokens without a window", func(t *testing.T) {
+		filtered := report.FilterUnused(tokens, 0, false, now, lastUsed)
+		assert.Equal(t, []string{"recent", "idle", "never"}, names(filtered))
+	})
+}
+
+type expiringToken struct {
+	name      string
+	expiresAt *time.Time
+}
+
+func TestFilterExpiringWithin(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-24 * time.Hour)
+	soon := now.Add(10 * 24 * time.Hour)
+	later := now.Add(90 * 24 * time.Hour)
+
+	tokens := []expiringToken{
+		{name: "expired", expiresAt: &expired},
+		{name: "soon", expiresAt: &soon},
+		{name: "later", expiresAt: &later},
+		{name: "never"},
+	}
+
+	expiresAt := func(token expiringToken) *time.Time { return token.expiresAt }
+
+	filtered := report.FilterExpiringWithin(tokens, 30*24*time.Hour, now, expiresAt)
+	assert.Equal(t, []expiringToken{tokens[0], tokens[1]}, filtered)
+
+	assert.Equal(t, tokens, report.FilterExpiringWithin(tokens, 0, now, expiresAt))
+}
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+)
+
+// Ways a variable can differ between two projects.
+const (
+	VariableOnlyLeft  = "only_left"
+	VariableOnlyRight = "only_right"
+	VariableValue     = "value"
+)
+
+// VariableDifference is a variable set differently in two projects, matched by key and environment
+// scope. The value of a side is empty when the variable is missing from it.
+type VariableDifference struct {
+	Key              string `json:"key"`
+	EnvironmentScope string `json:"environment_scope"`
+	Difference       string `json:"difference"` // only_left, only_right, or value
+	Left             bool   `json:"left"`       // set in the left project
+	Right            bool   `json:"right"`      // set in the right project
+	LeftValue        string `json:"left_value"`
+	RightValue       string `json:"right_value"`
+}
+
+type variableKey struct {
+	key, scope string
+}
+
+// DiffVariables compares the variables of two projects and returns those missing from one of them
+// or set to different values, sorted by key and environment scope. Hidden variables, whose values
+// GitLab never returns, are compared by presence only.
+func DiffVariables(left, right []*glclient.ProjectVariableWithProject) []*VariableDifference {
+	rightByKey := make(map[variableKey]*glclient.ProjectVariableWithProject, len(right))
+	for _, v := range right {
+		rightByKey[variableKey{v.Key, v.EnvironmentScope}] = v
+	}
+
+	var differences []*VariableDifference
+
+	seen := make(map[variableKey]bool, len(left))
+
+	for _, l := range left {
+		key := variableKey{l.Key, l.EnvironmentScope}
+		seen[key] = true
+
+		r, ok := rightByKey[key]
+		switch {
+		case !ok:
+			differences = append(differences, &VariableDifference{
+				Key:              l.Key,
+				EnvironmentScope: l.EnvironmentScope,
+				Difference:       VariableOnlyLeft,
+				Left:             true,
+				LeftValue:        l.Value,
+			})
+		case !l.Hidden && !r.Hidden && l.Value != r.Value:
+			differences = append(differences, &VariableDifference{
+				Key:              l.Key,
+				EnvironmentScope: l.EnvironmentScope,
+				Difference:       VariableValue,
+				Left:             true,
+				Right:            true,
+				LeftValue:        l.Value,
+				RightValue:       r.Value,
+			})
+		}
+	}
+
+	for _, r := range right {
+		if !seen[variableKey{r.Key, r.EnvironmentScope}] {
+			differences = append(differences, &VariableDifference{
+				Key:              r.Key,
+				EnvironmentScope: r.EnvironmentScope,
+				Difference:       VariableOnlyRight,
+				Right:            true,
+				RightValue:       r.Value,
+			})
+		}
+	}
+
+	slices.SortFunc(differences, func(a, b *VariableDifference) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.EnvironmentScope, b.EnvironmentScope))
+	})
+
+	return differences
+}
@@ -0,0 +1,62 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/andreygrechin/glreporter/internal/glclient"
+	"github.com/andreygrechin/glreporter/internal/report"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+func projectVariable(key, scope, value string, hidden bool) *glclient.ProjectVariableWithProject {
+	return &glclient.ProjectVariableWithProject{
+		ProjectVariable: &gitlab.ProjectVariable{Key: key, EnvironmentScope: scope, Value: value, Hidden: hidden},
+	}
+}
+
+func TestDiffVariables(t *testing.T) {
+	left := []*glclient.ProjectVariableWithProject{
+		projectVariable("SAME", "*", "1", false),
+		projectVariable("DB_URL", "*", "postgres://staging", false),
+		projectVariable("ONLY_STAGING", "*", "on", false),
+		projectVariable("TOKEN", "*", "", true),
+		projectVariable("REGION", "staging", "eu", false),
+	}
+	right := []*glclient.ProjectVariableWithProject{
+		projectVariable("SAME", "*", "1", false),
+		projectVariable("DB_URL", "*", "postgres://production", false),
+		projectVariable("TOKEN", "*", "", true),
+		projectVariable("REGION", "production", "eu", false),
+		projectVariable("ONLY_PRODUCTION", "*", "", false),
+	}
+
+	assert.Equal(t, []*report.VariableDifference{
+		{
+			Key:              "DB_URL",
+			EnvironmentScope: "*",
+			Difference:       report.VariableValue,
+			Left:             true,
+			Right:            true,
+			LeftValue:        "postgres://staging",
+			RightValue:       "postgres://production",
+		},
+		{Key: "ONLY_PRODUCTION", EnvironmentScope: "*", Difference: report.VariableOnlyRight, Right: true},
+		{Key: "ONLY_STAGING", EnvironmentScope: "*", Difference: report.VariableOnlyLeft, Left: true, LeftValue: "on"},
+		{
+			Key:              "REGION",
+			EnvironmentScope: "production",
+			Difference:       report.VariableOnlyRight,
+			Right:            true,
+			RightValue:       "eu",
+		},
+		{Key: "REGION", EnvironmentScope: "staging", Difference: report.VariableOnlyLeft, Left: true, LeftValue: "eu"},
+	}, report.DiffVariables(left, right))
+}
+
+func TestDiffVariables_identical(t *testing.T) {
+	variables := []*glclient.ProjectVariableWithProject{projectVariable("SAME", "*", "1", false)}
+
+	assert.Empty(t, report.DiffVariables(variables, variables))
+	assert.Empty(t, report.DiffVariables(nil, nil))
+}
@@ -22,9 +22,15 @@ func NewPool(workers int) *Pool {
 	return p
 }
 
-// Submit adds a task to the worker pool.
+// Submit adds a task to the worker pool. When the queue is full, the task runs in the calling
+// goroutine instead, so that tasks submitting further tasks never wait for a queue that only
+// the busy workers could drain. This bounds the queued work without risking a deadlock.
 func (p *Pool) Submit(task func()) {
-	p.taskQueue <- task
+	select {
+	case p.taskQueue <- task:
+	default:
+		task()
+	}
 }
 
 // Shutdown closes the task queue, signaling workers to exit after completing current tasks.
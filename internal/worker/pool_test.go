@@ -36,3 +36,64 @@ func TestWorkerPool(t *testing.T) {
 		t.Errorf("expected counter to be 10, got %d", counter)
 	}
 }
+
+func TestWorkerPool_nestedSubmissions(t *testing.T) {
+	const (
+		width = 6
+		depth = 5
+	)
+
+	pool := worker.NewPool(2)
+	defer pool.Shutdown()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		count int
+		visit func(level int)
+	)
+
+	// every task submits its children, far more than the workers and the queue can hold
+	visit = func(level int) {
+		defer wg.Done()
+
+		mu.Lock()
+		count++
+		mu.Unlock()
+
+		if level == depth {
+			return
+		}
+
+		for range width {
+			wg.Add(1)
+			pool.Submit(func() { visit(level + 1) })
+		}
+	}
+
+	wg.Add(1)
+	pool.Submit(func() { visit(0) })
+
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("nested submissions deadlocked the pool")
+	}
+
+	expected, level := 0, 1
+	for range depth + 1 {
+		expected += level
+		level *= width
+	}
+
+	if count != expected {
+		t.Errorf("expected %d tasks to run, got %d", expected, count)
+	}
+}
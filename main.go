@@ -1,6 +1,9 @@
 package main
 
 import (
+	// embedded so that --timezone works on systems without a time zone database
+	_ "time/tzdata"
+
 	"github.com/andreygrechin/glreporter/cmd"
 )
 